@@ -19,15 +19,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InteractiveService_IncrReadCnt_FullMethodName   = "/intr.v1.InteractiveService/IncrReadCnt"
-	InteractiveService_Like_FullMethodName          = "/intr.v1.InteractiveService/Like"
-	InteractiveService_CancelLike_FullMethodName    = "/intr.v1.InteractiveService/CancelLike"
-	InteractiveService_Collect_FullMethodName       = "/intr.v1.InteractiveService/Collect"
-	InteractiveService_CancelCollect_FullMethodName = "/intr.v1.InteractiveService/CancelCollect"
-	InteractiveService_Get_FullMethodName           = "/intr.v1.InteractiveService/Get"
-	InteractiveService_MustBatchGet_FullMethodName  = "/intr.v1.InteractiveService/MustBatchGet"
-	InteractiveService_GetByIDs_FullMethodName      = "/intr.v1.InteractiveService/GetByIDs"
-	InteractiveService_GetTopLike_FullMethodName    = "/intr.v1.InteractiveService/GetTopLike"
+	InteractiveService_IncrReadCnt_FullMethodName         = "/intr.v1.InteractiveService/IncrReadCnt"
+	InteractiveService_Like_FullMethodName                = "/intr.v1.InteractiveService/Like"
+	InteractiveService_CancelLike_FullMethodName          = "/intr.v1.InteractiveService/CancelLike"
+	InteractiveService_Collect_FullMethodName             = "/intr.v1.InteractiveService/Collect"
+	InteractiveService_CancelCollect_FullMethodName       = "/intr.v1.InteractiveService/CancelCollect"
+	InteractiveService_Get_FullMethodName                 = "/intr.v1.InteractiveService/Get"
+	InteractiveService_MustBatchGet_FullMethodName        = "/intr.v1.InteractiveService/MustBatchGet"
+	InteractiveService_GetByIDs_FullMethodName            = "/intr.v1.InteractiveService/GetByIDs"
+	InteractiveService_GetTopLike_FullMethodName          = "/intr.v1.InteractiveService/GetTopLike"
+	InteractiveService_BatchGet_FullMethodName            = "/intr.v1.InteractiveService/BatchGet"
+	InteractiveService_SubscribeCnts_FullMethodName       = "/intr.v1.InteractiveService/SubscribeCnts"
+	InteractiveService_Delete_FullMethodName              = "/intr.v1.InteractiveService/Delete"
+	InteractiveService_ListLikes_FullMethodName           = "/intr.v1.InteractiveService/ListLikes"
+	InteractiveService_ListCollects_FullMethodName        = "/intr.v1.InteractiveService/ListCollects"
+	InteractiveService_ListUserLikes_FullMethodName       = "/intr.v1.InteractiveService/ListUserLikes"
+	InteractiveService_ListUserCollects_FullMethodName    = "/intr.v1.InteractiveService/ListUserCollects"
+	InteractiveService_CreateCollection_FullMethodName    = "/intr.v1.InteractiveService/CreateCollection"
+	InteractiveService_UpdateCollection_FullMethodName    = "/intr.v1.InteractiveService/UpdateCollection"
+	InteractiveService_DeleteCollection_FullMethodName    = "/intr.v1.InteractiveService/DeleteCollection"
+	InteractiveService_ListCollections_FullMethodName     = "/intr.v1.InteractiveService/ListCollections"
+	InteractiveService_ListCollectionItems_FullMethodName = "/intr.v1.InteractiveService/ListCollectionItems"
+	InteractiveService_MoveCollectionItem_FullMethodName  = "/intr.v1.InteractiveService/MoveCollectionItem"
 )
 
 // InteractiveServiceClient is the client API for InteractiveService service.
@@ -43,6 +56,33 @@ type InteractiveServiceClient interface {
 	MustBatchGet(ctx context.Context, in *MustBatchGetRequest, opts ...grpc.CallOption) (*MustBatchGetResponse, error)
 	GetByIDs(ctx context.Context, in *GetByIDsRequest, opts ...grpc.CallOption) (*GetByIDsResponse, error)
 	GetTopLike(ctx context.Context, in *GetTopLikeRequest, opts ...grpc.CallOption) (*GetTopLikeResponse, error)
+	// BatchGet returns the counters of the resources, and whether uid liked
+	// and collected them. The missing resources have no interactions yet.
+	BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error)
+	// SubscribeCnts sends the counters of the resources, then the counters
+	// changed, until the client cancels.
+	SubscribeCnts(ctx context.Context, in *SubscribeCntsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeCntsResponse], error)
+	// Delete removes the counters, likes and collections of the resources.
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// ListLikes scans the likes in the order of their IDs, for the offline
+	// computations.
+	ListLikes(ctx context.Context, in *ListLikesRequest, opts ...grpc.CallOption) (*ListLikesResponse, error)
+	// ListCollects scans the collected resources the same way.
+	ListCollects(ctx context.Context, in *ListCollectsRequest, opts ...grpc.CallOption) (*ListCollectsResponse, error)
+	// ListUserLikes lists the resources liked by a user, the latest first.
+	ListUserLikes(ctx context.Context, in *ListUserLikesRequest, opts ...grpc.CallOption) (*ListUserLikesResponse, error)
+	// ListUserCollects lists the resources collected by a user, the latest
+	// first. A resource in several collections is listed once.
+	ListUserCollects(ctx context.Context, in *ListUserCollectsRequest, opts ...grpc.CallOption) (*ListUserCollectsResponse, error)
+	// The collections are named folders of the collected resources. The
+	// resources collected with cid 0 are in the default collection.
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error)
+	UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*UpdateCollectionResponse, error)
+	// DeleteCollection moves the items to the default collection.
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	ListCollectionItems(ctx context.Context, in *ListCollectionItemsRequest, opts ...grpc.CallOption) (*ListCollectionItemsResponse, error)
+	MoveCollectionItem(ctx context.Context, in *MoveCollectionItemRequest, opts ...grpc.CallOption) (*MoveCollectionItemResponse, error)
 }
 
 type interactiveServiceClient struct {
@@ -143,6 +183,145 @@ func (c *interactiveServiceClient) GetTopLike(ctx context.Context, in *GetTopLik
 	return out, nil
 }
 
+func (c *interactiveServiceClient) BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_BatchGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) SubscribeCnts(ctx context.Context, in *SubscribeCntsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeCntsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InteractiveService_ServiceDesc.Streams[0], InteractiveService_SubscribeCnts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeCntsRequest, SubscribeCntsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InteractiveService_SubscribeCntsClient = grpc.ServerStreamingClient[SubscribeCntsResponse]
+
+func (c *interactiveServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListLikes(ctx context.Context, in *ListLikesRequest, opts ...grpc.CallOption) (*ListLikesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikesResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListLikes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListCollects(ctx context.Context, in *ListCollectsRequest, opts ...grpc.CallOption) (*ListCollectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListCollects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListUserLikes(ctx context.Context, in *ListUserLikesRequest, opts ...grpc.CallOption) (*ListUserLikesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserLikesResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListUserLikes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListUserCollects(ctx context.Context, in *ListUserCollectsRequest, opts ...grpc.CallOption) (*ListUserCollectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserCollectsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListUserCollects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*UpdateCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_UpdateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListCollectionItems(ctx context.Context, in *ListCollectionItemsRequest, opts ...grpc.CallOption) (*ListCollectionItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionItemsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListCollectionItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) MoveCollectionItem(ctx context.Context, in *MoveCollectionItemRequest, opts ...grpc.CallOption) (*MoveCollectionItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveCollectionItemResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_MoveCollectionItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InteractiveServiceServer is the server API for InteractiveService service.
 // All implementations must embed UnimplementedInteractiveServiceServer
 // for forward compatibility.
@@ -156,6 +335,33 @@ type InteractiveServiceServer interface {
 	MustBatchGet(context.Context, *MustBatchGetRequest) (*MustBatchGetResponse, error)
 	GetByIDs(context.Context, *GetByIDsRequest) (*GetByIDsResponse, error)
 	GetTopLike(context.Context, *GetTopLikeRequest) (*GetTopLikeResponse, error)
+	// BatchGet returns the counters of the resources, and whether uid liked
+	// and collected them. The missing resources have no interactions yet.
+	BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error)
+	// SubscribeCnts sends the counters of the resources, then the counters
+	// changed, until the client cancels.
+	SubscribeCnts(*SubscribeCntsRequest, grpc.ServerStreamingServer[SubscribeCntsResponse]) error
+	// Delete removes the counters, likes and collections of the resources.
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// ListLikes scans the likes in the order of their IDs, for the offline
+	// computations.
+	ListLikes(context.Context, *ListLikesRequest) (*ListLikesResponse, error)
+	// ListCollects scans the collected resources the same way.
+	ListCollects(context.Context, *ListCollectsRequest) (*ListCollectsResponse, error)
+	// ListUserLikes lists the resources liked by a user, the latest first.
+	ListUserLikes(context.Context, *ListUserLikesRequest) (*ListUserLikesResponse, error)
+	// ListUserCollects lists the resources collected by a user, the latest
+	// first. A resource in several collections is listed once.
+	ListUserCollects(context.Context, *ListUserCollectsRequest) (*ListUserCollectsResponse, error)
+	// The collections are named folders of the collected resources. The
+	// resources collected with cid 0 are in the default collection.
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
+	UpdateCollection(context.Context, *UpdateCollectionRequest) (*UpdateCollectionResponse, error)
+	// DeleteCollection moves the items to the default collection.
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	ListCollectionItems(context.Context, *ListCollectionItemsRequest) (*ListCollectionItemsResponse, error)
+	MoveCollectionItem(context.Context, *MoveCollectionItemRequest) (*MoveCollectionItemResponse, error)
 	mustEmbedUnimplementedInteractiveServiceServer()
 }
 
@@ -193,6 +399,45 @@ func (UnimplementedInteractiveServiceServer) GetByIDs(context.Context, *GetByIDs
 func (UnimplementedInteractiveServiceServer) GetTopLike(context.Context, *GetTopLikeRequest) (*GetTopLikeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTopLike not implemented")
 }
+func (UnimplementedInteractiveServiceServer) BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGet not implemented")
+}
+func (UnimplementedInteractiveServiceServer) SubscribeCnts(*SubscribeCntsRequest, grpc.ServerStreamingServer[SubscribeCntsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeCnts not implemented")
+}
+func (UnimplementedInteractiveServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListLikes(context.Context, *ListLikesRequest) (*ListLikesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLikes not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListCollects(context.Context, *ListCollectsRequest) (*ListCollectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollects not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListUserLikes(context.Context, *ListUserLikesRequest) (*ListUserLikesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserLikes not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListUserCollects(context.Context, *ListUserCollectsRequest) (*ListUserCollectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserCollects not implemented")
+}
+func (UnimplementedInteractiveServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) UpdateCollection(context.Context, *UpdateCollectionRequest) (*UpdateCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListCollectionItems(context.Context, *ListCollectionItemsRequest) (*ListCollectionItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionItems not implemented")
+}
+func (UnimplementedInteractiveServiceServer) MoveCollectionItem(context.Context, *MoveCollectionItemRequest) (*MoveCollectionItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCollectionItem not implemented")
+}
 func (UnimplementedInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {}
 func (UnimplementedInteractiveServiceServer) testEmbeddedByValue()                            {}
 
@@ -376,6 +621,233 @@ func _InteractiveService_GetTopLike_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractiveService_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_BatchGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).BatchGet(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_SubscribeCnts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeCntsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InteractiveServiceServer).SubscribeCnts(m, &grpc.GenericServerStream[SubscribeCntsRequest, SubscribeCntsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InteractiveService_SubscribeCntsServer = grpc.ServerStreamingServer[SubscribeCntsResponse]
+
+func _InteractiveService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListLikes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListLikes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListLikes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListLikes(ctx, req.(*ListLikesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListCollects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListCollects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListCollects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListCollects(ctx, req.(*ListCollectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListUserLikes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserLikesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListUserLikes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListUserLikes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListUserLikes(ctx, req.(*ListUserLikesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListUserCollects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserCollectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListUserCollects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListUserCollects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListUserCollects(ctx, req.(*ListUserCollectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_UpdateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).UpdateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_UpdateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).UpdateCollection(ctx, req.(*UpdateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListCollectionItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListCollectionItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListCollectionItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListCollectionItems(ctx, req.(*ListCollectionItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_MoveCollectionItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCollectionItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).MoveCollectionItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_MoveCollectionItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).MoveCollectionItem(ctx, req.(*MoveCollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InteractiveService_ServiceDesc is the grpc.ServiceDesc for InteractiveService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -419,7 +891,61 @@ var InteractiveService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTopLike",
 			Handler:    _InteractiveService_GetTopLike_Handler,
 		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _InteractiveService_BatchGet_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _InteractiveService_Delete_Handler,
+		},
+		{
+			MethodName: "ListLikes",
+			Handler:    _InteractiveService_ListLikes_Handler,
+		},
+		{
+			MethodName: "ListCollects",
+			Handler:    _InteractiveService_ListCollects_Handler,
+		},
+		{
+			MethodName: "ListUserLikes",
+			Handler:    _InteractiveService_ListUserLikes_Handler,
+		},
+		{
+			MethodName: "ListUserCollects",
+			Handler:    _InteractiveService_ListUserCollects_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _InteractiveService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "UpdateCollection",
+			Handler:    _InteractiveService_UpdateCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _InteractiveService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _InteractiveService_ListCollections_Handler,
+		},
+		{
+			MethodName: "ListCollectionItems",
+			Handler:    _InteractiveService_ListCollectionItems_Handler,
+		},
+		{
+			MethodName: "MoveCollectionItem",
+			Handler:    _InteractiveService_MoveCollectionItem_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeCnts",
+			Handler:       _InteractiveService_SubscribeCnts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "intr/v1/interactive.proto",
 }
@@ -42,6 +42,26 @@ func (m *MockInteractiveServiceClient) EXPECT() *MockInteractiveServiceClientMoc
 	return m.recorder
 }
 
+// BatchGet mocks base method.
+func (m *MockInteractiveServiceClient) BatchGet(ctx context.Context, in *intrv1.BatchGetRequest, opts ...grpc.CallOption) (*intrv1.BatchGetResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchGet", varargs...)
+	ret0, _ := ret[0].(*intrv1.BatchGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockInteractiveServiceClientMockRecorder) BatchGet(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockInteractiveServiceClient)(nil).BatchGet), varargs...)
+}
+
 // CancelCollect mocks base method.
 func (m *MockInteractiveServiceClient) CancelCollect(ctx context.Context, in *intrv1.CancelCollectRequest, opts ...grpc.CallOption) (*intrv1.CancelCollectResponse, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +122,66 @@ func (mr *MockInteractiveServiceClientMockRecorder) Collect(ctx, in any, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveServiceClient)(nil).Collect), varargs...)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractiveServiceClient) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCollection", varargs...)
+	ret0, _ := ret[0].(*intrv1.CreateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractiveServiceClientMockRecorder) CreateCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractiveServiceClient)(nil).CreateCollection), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockInteractiveServiceClient) Delete(ctx context.Context, in *intrv1.DeleteRequest, opts ...grpc.CallOption) (*intrv1.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(*intrv1.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractiveServiceClientMockRecorder) Delete(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractiveServiceClient)(nil).Delete), varargs...)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractiveServiceClient) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCollection", varargs...)
+	ret0, _ := ret[0].(*intrv1.DeleteCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractiveServiceClientMockRecorder) DeleteCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractiveServiceClient)(nil).DeleteCollection), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveServiceClient) Get(ctx context.Context, in *intrv1.GetRequest, opts ...grpc.CallOption) (*intrv1.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -202,6 +282,146 @@ func (mr *MockInteractiveServiceClientMockRecorder) Like(ctx, in any, opts ...an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveServiceClient)(nil).Like), varargs...)
 }
 
+// ListCollectionItems mocks base method.
+func (m *MockInteractiveServiceClient) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCollectionItems", varargs...)
+	ret0, _ := ret[0].(*intrv1.ListCollectionItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractiveServiceClientMockRecorder) ListCollectionItems(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListCollectionItems), varargs...)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractiveServiceClient) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCollections", varargs...)
+	ret0, _ := ret[0].(*intrv1.ListCollectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractiveServiceClientMockRecorder) ListCollections(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListCollections), varargs...)
+}
+
+// ListCollects mocks base method.
+func (m *MockInteractiveServiceClient) ListCollects(ctx context.Context, in *intrv1.ListCollectsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCollects", varargs...)
+	ret0, _ := ret[0].(*intrv1.ListCollectsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollects indicates an expected call of ListCollects.
+func (mr *MockInteractiveServiceClientMockRecorder) ListCollects(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollects", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListCollects), varargs...)
+}
+
+// ListLikes mocks base method.
+func (m *MockInteractiveServiceClient) ListLikes(ctx context.Context, in *intrv1.ListLikesRequest, opts ...grpc.CallOption) (*intrv1.ListLikesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLikes", varargs...)
+	ret0, _ := ret[0].(*intrv1.ListLikesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikes indicates an expected call of ListLikes.
+func (mr *MockInteractiveServiceClientMockRecorder) ListLikes(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListLikes), varargs...)
+}
+
+// ListUserCollects mocks base method.
+func (m *MockInteractiveServiceClient) ListUserCollects(ctx context.Context, in *intrv1.ListUserCollectsRequest, opts ...grpc.CallOption) (*intrv1.ListUserCollectsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUserCollects", varargs...)
+	ret0, _ := ret[0].(*intrv1.ListUserCollectsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserCollects indicates an expected call of ListUserCollects.
+func (mr *MockInteractiveServiceClientMockRecorder) ListUserCollects(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCollects", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListUserCollects), varargs...)
+}
+
+// ListUserLikes mocks base method.
+func (m *MockInteractiveServiceClient) ListUserLikes(ctx context.Context, in *intrv1.ListUserLikesRequest, opts ...grpc.CallOption) (*intrv1.ListUserLikesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUserLikes", varargs...)
+	ret0, _ := ret[0].(*intrv1.ListUserLikesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLikes indicates an expected call of ListUserLikes.
+func (mr *MockInteractiveServiceClientMockRecorder) ListUserLikes(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLikes", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListUserLikes), varargs...)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractiveServiceClient) MoveCollectionItem(ctx context.Context, in *intrv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*intrv1.MoveCollectionItemResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveCollectionItem", varargs...)
+	ret0, _ := ret[0].(*intrv1.MoveCollectionItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractiveServiceClientMockRecorder) MoveCollectionItem(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractiveServiceClient)(nil).MoveCollectionItem), varargs...)
+}
+
 // MustBatchGet mocks base method.
 func (m *MockInteractiveServiceClient) MustBatchGet(ctx context.Context, in *intrv1.MustBatchGetRequest, opts ...grpc.CallOption) (*intrv1.MustBatchGetResponse, error) {
 	m.ctrl.T.Helper()
@@ -222,6 +442,46 @@ func (mr *MockInteractiveServiceClientMockRecorder) MustBatchGet(ctx, in any, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustBatchGet", reflect.TypeOf((*MockInteractiveServiceClient)(nil).MustBatchGet), varargs...)
 }
 
+// SubscribeCnts mocks base method.
+func (m *MockInteractiveServiceClient) SubscribeCnts(ctx context.Context, in *intrv1.SubscribeCntsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[intrv1.SubscribeCntsResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubscribeCnts", varargs...)
+	ret0, _ := ret[0].(grpc.ServerStreamingClient[intrv1.SubscribeCntsResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeCnts indicates an expected call of SubscribeCnts.
+func (mr *MockInteractiveServiceClientMockRecorder) SubscribeCnts(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeCnts", reflect.TypeOf((*MockInteractiveServiceClient)(nil).SubscribeCnts), varargs...)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockInteractiveServiceClient) UpdateCollection(ctx context.Context, in *intrv1.UpdateCollectionRequest, opts ...grpc.CallOption) (*intrv1.UpdateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateCollection", varargs...)
+	ret0, _ := ret[0].(*intrv1.UpdateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockInteractiveServiceClientMockRecorder) UpdateCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockInteractiveServiceClient)(nil).UpdateCollection), varargs...)
+}
+
 // MockInteractiveServiceServer is a mock of InteractiveServiceServer interface.
 type MockInteractiveServiceServer struct {
 	ctrl     *gomock.Controller
@@ -246,6 +506,21 @@ func (m *MockInteractiveServiceServer) EXPECT() *MockInteractiveServiceServerMoc
 	return m.recorder
 }
 
+// BatchGet mocks base method.
+func (m *MockInteractiveServiceServer) BatchGet(arg0 context.Context, arg1 *intrv1.BatchGetRequest) (*intrv1.BatchGetResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGet", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.BatchGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockInteractiveServiceServerMockRecorder) BatchGet(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockInteractiveServiceServer)(nil).BatchGet), arg0, arg1)
+}
+
 // CancelCollect mocks base method.
 func (m *MockInteractiveServiceServer) CancelCollect(arg0 context.Context, arg1 *intrv1.CancelCollectRequest) (*intrv1.CancelCollectResponse, error) {
 	m.ctrl.T.Helper()
@@ -291,6 +566,51 @@ func (mr *MockInteractiveServiceServerMockRecorder) Collect(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveServiceServer)(nil).Collect), arg0, arg1)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractiveServiceServer) CreateCollection(arg0 context.Context, arg1 *intrv1.CreateCollectionRequest) (*intrv1.CreateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.CreateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractiveServiceServerMockRecorder) CreateCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractiveServiceServer)(nil).CreateCollection), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockInteractiveServiceServer) Delete(arg0 context.Context, arg1 *intrv1.DeleteRequest) (*intrv1.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractiveServiceServerMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractiveServiceServer)(nil).Delete), arg0, arg1)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractiveServiceServer) DeleteCollection(arg0 context.Context, arg1 *intrv1.DeleteCollectionRequest) (*intrv1.DeleteCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.DeleteCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractiveServiceServerMockRecorder) DeleteCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractiveServiceServer)(nil).DeleteCollection), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveServiceServer) Get(arg0 context.Context, arg1 *intrv1.GetRequest) (*intrv1.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -366,6 +686,111 @@ func (mr *MockInteractiveServiceServerMockRecorder) Like(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveServiceServer)(nil).Like), arg0, arg1)
 }
 
+// ListCollectionItems mocks base method.
+func (m *MockInteractiveServiceServer) ListCollectionItems(arg0 context.Context, arg1 *intrv1.ListCollectionItemsRequest) (*intrv1.ListCollectionItemsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionItems", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.ListCollectionItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractiveServiceServerMockRecorder) ListCollectionItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListCollectionItems), arg0, arg1)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractiveServiceServer) ListCollections(arg0 context.Context, arg1 *intrv1.ListCollectionsRequest) (*intrv1.ListCollectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.ListCollectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractiveServiceServerMockRecorder) ListCollections(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListCollections), arg0, arg1)
+}
+
+// ListCollects mocks base method.
+func (m *MockInteractiveServiceServer) ListCollects(arg0 context.Context, arg1 *intrv1.ListCollectsRequest) (*intrv1.ListCollectsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollects", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.ListCollectsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollects indicates an expected call of ListCollects.
+func (mr *MockInteractiveServiceServerMockRecorder) ListCollects(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollects", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListCollects), arg0, arg1)
+}
+
+// ListLikes mocks base method.
+func (m *MockInteractiveServiceServer) ListLikes(arg0 context.Context, arg1 *intrv1.ListLikesRequest) (*intrv1.ListLikesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikes", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.ListLikesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikes indicates an expected call of ListLikes.
+func (mr *MockInteractiveServiceServerMockRecorder) ListLikes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListLikes), arg0, arg1)
+}
+
+// ListUserCollects mocks base method.
+func (m *MockInteractiveServiceServer) ListUserCollects(arg0 context.Context, arg1 *intrv1.ListUserCollectsRequest) (*intrv1.ListUserCollectsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserCollects", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.ListUserCollectsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserCollects indicates an expected call of ListUserCollects.
+func (mr *MockInteractiveServiceServerMockRecorder) ListUserCollects(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCollects", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListUserCollects), arg0, arg1)
+}
+
+// ListUserLikes mocks base method.
+func (m *MockInteractiveServiceServer) ListUserLikes(arg0 context.Context, arg1 *intrv1.ListUserLikesRequest) (*intrv1.ListUserLikesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLikes", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.ListUserLikesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLikes indicates an expected call of ListUserLikes.
+func (mr *MockInteractiveServiceServerMockRecorder) ListUserLikes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLikes", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListUserLikes), arg0, arg1)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractiveServiceServer) MoveCollectionItem(arg0 context.Context, arg1 *intrv1.MoveCollectionItemRequest) (*intrv1.MoveCollectionItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItem", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.MoveCollectionItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractiveServiceServerMockRecorder) MoveCollectionItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractiveServiceServer)(nil).MoveCollectionItem), arg0, arg1)
+}
+
 // MustBatchGet mocks base method.
 func (m *MockInteractiveServiceServer) MustBatchGet(arg0 context.Context, arg1 *intrv1.MustBatchGetRequest) (*intrv1.MustBatchGetResponse, error) {
 	m.ctrl.T.Helper()
@@ -381,6 +806,35 @@ func (mr *MockInteractiveServiceServerMockRecorder) MustBatchGet(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustBatchGet", reflect.TypeOf((*MockInteractiveServiceServer)(nil).MustBatchGet), arg0, arg1)
 }
 
+// SubscribeCnts mocks base method.
+func (m *MockInteractiveServiceServer) SubscribeCnts(arg0 *intrv1.SubscribeCntsRequest, arg1 grpc.ServerStreamingServer[intrv1.SubscribeCntsResponse]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeCnts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeCnts indicates an expected call of SubscribeCnts.
+func (mr *MockInteractiveServiceServerMockRecorder) SubscribeCnts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeCnts", reflect.TypeOf((*MockInteractiveServiceServer)(nil).SubscribeCnts), arg0, arg1)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockInteractiveServiceServer) UpdateCollection(arg0 context.Context, arg1 *intrv1.UpdateCollectionRequest) (*intrv1.UpdateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollection", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.UpdateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockInteractiveServiceServerMockRecorder) UpdateCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockInteractiveServiceServer)(nil).UpdateCollection), arg0, arg1)
+}
+
 // mustEmbedUnimplementedInteractiveServiceServer mocks base method.
 func (m *MockInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {
 	m.ctrl.T.Helper()
@@ -1,6 +1,7 @@
 package main
 
 import (
+	intrService "github.com/chenmuyao/go-bootcamp/interactive/service"
 	"github.com/chenmuyao/go-bootcamp/internal/events"
 	"github.com/gin-gonic/gin"
 	"github.com/robfig/cron/v3"
@@ -10,4 +11,5 @@ type App struct {
 	server    *gin.Engine
 	consumers []events.Consumer
 	cron      *cron.Cron
+	bizs      *intrService.BizRegistry
 }
@@ -7,6 +7,12 @@ type Config struct {
 	Redis  RedisConfig        `yaml:"redis"`
 	Sarama SaramaConfig       `yaml:"sarama"`
 	GRPC   GRPCConfig         `yaml:"grpc"`
+	Mongo  MongoConfig        `yaml:"mongo"`
+
+	Article ArticleConfig `yaml:"article"`
+	Feed    FeedConfig    `yaml:"feed"`
+	Payment PaymentConfig `yaml:"payment"`
+	Account AccountConfig `yaml:"account"`
 }
 
 type RemoteConfigCenter struct {
@@ -19,6 +25,12 @@ type DBConfig struct {
 	DSN string `yaml:"dsn"`
 }
 
+type MongoConfig struct {
+	URI string `yaml:"uri"`
+	// snowflake node to generate the ids
+	NodeID int64 `yaml:"nodeID"`
+}
+
 type RedisConfig struct {
 	Addr string `yaml:"addr"`
 }
@@ -42,3 +54,44 @@ type GRPCConfig struct {
 	Secure bool       `yaml:"secure"`
 	Intr   IntrConfig `yaml:"intr"`
 }
+
+type ArticleConfig struct {
+	// "mysql" (default), "mongodb" or "objstore"
+	Storage string `yaml:"storage"`
+	// also stores the images and the exports, whatever the storage
+	ObjStore ObjStoreConfig `yaml:"objstore"`
+	// days before the deleted articles are purged, 30 by default
+	TrashRetentionDays int `yaml:"trashRetentionDays"`
+}
+
+type ObjStoreConfig struct {
+	// only the local store for now
+	Dir string `yaml:"dir"`
+}
+
+type FeedConfig struct {
+	// public URL of the site, in the links of the feeds and the sitemaps
+	BaseURL string `yaml:"baseURL"`
+	Title   string `yaml:"title"`
+}
+
+type PaymentConfig struct {
+	// only the local stand-in for now
+	Local LocalPayConfig `yaml:"local"`
+}
+
+type LocalPayConfig struct {
+	// signs the callbacks
+	Secret string `yaml:"secret"`
+	// the reward callback of the web server
+	NotifyURL string `yaml:"notifyURL"`
+	// seconds before the orders are paid, never if 0
+	PayDelay int `yaml:"payDelay"`
+}
+
+type AccountConfig struct {
+	// basis points taken on the rewards, 1000 by default
+	FeeRate int64 `yaml:"feeRate"`
+	// the users who review the withdrawals
+	Admins []int64 `yaml:"admins"`
+}
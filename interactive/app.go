@@ -1,11 +1,17 @@
 package main
 
 import (
+	intrJob "github.com/chenmuyao/go-bootcamp/interactive/job"
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
 	"github.com/chenmuyao/go-bootcamp/internal/events"
 	"github.com/chenmuyao/go-bootcamp/pkg/grpcx"
+	"github.com/robfig/cron/v3"
 )
 
 type App struct {
 	consumers []events.Consumer
 	server    *grpcx.Server
+	cron      *cron.Cron
+	cntFlush  *intrJob.CntFlushJob
+	bizs      *service.BizRegistry
 }
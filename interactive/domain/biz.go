@@ -0,0 +1,66 @@
+package domain
+
+import "slices"
+
+const (
+	BizArticle   = "article"
+	BizItinerary = "itinerary"
+)
+
+// BizAction is an interaction of the users with a resource.
+type BizAction string
+
+const (
+	BizActionRead    BizAction = "read"
+	BizActionLike    BizAction = "like"
+	BizActionCollect BizAction = "collect"
+	BizActionTip     BizAction = "tip"
+)
+
+// Counter is a counter of the interactions with a resource.
+type Counter string
+
+const (
+	CounterRead       Counter = "read_cnt"
+	CounterLike       Counter = "like_cnt"
+	CounterCollect    Counter = "collect_cnt"
+	CounterTip        Counter = "tip_cnt"
+	CounterUniqueRead Counter = "unique_read_cnt"
+)
+
+// Biz is a type of resources the users interact with.
+type Biz struct {
+	Name     string
+	Actions  []BizAction
+	Counters []Counter
+	// TopLikeSize is the number of the most liked resources cached.
+	TopLikeSize int
+}
+
+func (b Biz) Allows(action BizAction) bool {
+	return slices.Contains(b.Actions, action)
+}
+
+func (b Biz) Counts(cnt Counter) bool {
+	return slices.Contains(b.Counters, cnt)
+}
+
+// Mask zeroes the counters the biz doesn't count.
+func (b Biz) Mask(intr Interactive) Interactive {
+	if !b.Counts(CounterRead) {
+		intr.ReadCnt = 0
+	}
+	if !b.Counts(CounterLike) {
+		intr.LikeCnt = 0
+	}
+	if !b.Counts(CounterCollect) {
+		intr.CollectCnt = 0
+	}
+	if !b.Counts(CounterTip) {
+		intr.TipCnt = 0
+	}
+	if !b.Counts(CounterUniqueRead) {
+		intr.UniqueReadCnt = 0
+	}
+	return intr
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type EventType string
+
+const (
+	EventTypeLike      EventType = "like"
+	EventTypeUnlike    EventType = "unlike"
+	EventTypeCollect   EventType = "collect"
+	EventTypeUncollect EventType = "uncollect"
+)
+
+// InteractiveEvent is a change of the likes or the collections of a user.
+// Collect and uncollect happen when the resource enters the first or leaves
+// the last collection of the user.
+type InteractiveEvent struct {
+	ID    int64
+	Type  EventType
+	Biz   string
+	BizID int64
+	UID   int64
+	Time  time.Time
+}
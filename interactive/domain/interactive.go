@@ -1,11 +1,111 @@
 package domain
 
+import "time"
+
 type Interactive struct {
 	Biz        string
 	BizID      int64
 	ReadCnt    int64
 	LikeCnt    int64
 	CollectCnt int64
-	Liked      bool
-	Collected  bool
+	// successful rewards
+	TipCnt int64
+	// distinct readers estimated with a HyperLogLog, snapshotted periodically
+	UniqueReadCnt int64
+	Liked         bool
+	Collected     bool
+}
+
+// CntDelta is the change of the counters of a resource.
+type CntDelta struct {
+	Biz        string
+	BizID      int64
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+	TipCnt     int64
+}
+
+func (d CntDelta) IsZero() bool {
+	return d.ReadCnt == 0 && d.LikeCnt == 0 && d.CollectCnt == 0 && d.TipCnt == 0
+}
+
+// CntBatch is a batch of the buffered deltas taken by a flush. FlushID and No
+// identify it, so that a batch taken again after a crash is saved once.
+type CntBatch struct {
+	FlushID int64
+	No      int64
+	Deltas  []CntDelta
+	// since when the oldest delta of the flush has been waiting
+	Since time.Time
+}
+
+// CntDrift is a cached counter different from the one saved, or a saved like
+// or collect counter different from the count of the rows.
+type CntDrift struct {
+	Biz      string
+	BizID    int64
+	Counter  string
+	Cached   int64
+	Expected int64
+}
+
+// CntReconciliation is the result of the check of a batch of counters.
+type CntReconciliation struct {
+	// Checked counts the resources checked, and the next batch starts after
+	// LastID.
+	Checked int
+	LastID  int64
+	// Compared counts the cached counters compared.
+	Compared int
+	Drifts   []CntDrift
+}
+
+// UserBiz is a resource liked or collected by a user.
+type UserBiz struct {
+	ID    int64
+	UID   int64
+	Biz   string
+	BizID int64
+	// collection of a collected resource, 0 for the default one
+	CID   int64
+	Utime time.Time
+}
+
+// Cursor is a position in a list of UserBiz sorted by (Utime, BizID) desc,
+// the next page starts right after it. The zero value is the beginning of the
+// list.
+type Cursor struct {
+	Utime time.Time
+	BizID int64
+}
+
+func (c Cursor) IsZero() bool {
+	return c.Utime.IsZero()
+}
+
+// UserBizCursor returns the cursor after the resource.
+func UserBizCursor(ub UserBiz) Cursor {
+	return Cursor{
+		Utime: ub.Utime,
+		BizID: ub.BizID,
+	}
+}
+
+// Collection is a named folder of the collected resources of a user. The
+// resources collected without a collection are in the default one, which has
+// the ID 0 and is always private.
+type Collection struct {
+	ID   int64
+	UID  int64
+	Name string
+	// Public collections can be listed by the other users.
+	Public bool
+	Ctime  time.Time
+	Utime  time.Time
+}
+
+// CanView tells if uid can list the items of the collection.
+func (c Collection) CanView(uid int64) bool {
+	return c.Public || c.UID == uid
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
 	"github.com/chenmuyao/go-bootcamp/interactive/repository"
 	"github.com/chenmuyao/go-bootcamp/pkg/logger"
 	"github.com/chenmuyao/go-bootcamp/pkg/saramax"
@@ -91,7 +92,7 @@ func (i *InteractiveReadEventConsumer) Consume(msg *sarama.ConsumerMessage, even
 
 	i.l.Debug("Consume")
 
-	return i.repo.IncrReadCnt(ctx, "article", event.Aid)
+	return i.repo.IncrReadCnt(ctx, domain.BizArticle, event.Aid)
 }
 
 func (i *InteractiveReadEventConsumer) BatchConsume(
@@ -103,12 +104,27 @@ func (i *InteractiveReadEventConsumer) BatchConsume(
 
 	bizs := make([]string, len(events))
 	bizIDs := make([]int64, len(events))
+	// the anonymous reads are not unique readers
+	readerBizs := make([]string, 0, len(events))
+	readerBizIDs := make([]int64, 0, len(events))
+	uids := make([]int64, 0, len(events))
 
 	for i, ev := range events {
-		bizs[i] = "article"
+		bizs[i] = domain.BizArticle
 		bizIDs[i] = ev.Aid
+		if ev.Uid > 0 {
+			readerBizs = append(readerBizs, domain.BizArticle)
+			readerBizIDs = append(readerBizIDs, ev.Aid)
+			uids = append(uids, ev.Uid)
+		}
 	}
 
+	// NOTE: adding the readers is idempotent, it goes first so that a failure
+	// does not count the reads twice when the batch is consumed again.
+	err := i.repo.BatchAddReaders(ctx, readerBizs, readerBizIDs, uids)
+	if err != nil {
+		return err
+	}
 	return i.repo.BatchIncrReadCnt(ctx, bizs, bizIDs)
 }
 
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	intrrepomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInteractiveReadEventConsumer_BatchConsume(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+
+		events  []ReadEvent
+		wantErr error
+	}{
+		{
+			name: "anonymous reads not unique readers",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				gomock.InOrder(
+					repo.EXPECT().BatchAddReaders(
+						gomock.Any(),
+						[]string{"article", "article"},
+						[]int64{1, 2},
+						[]int64{123, 456},
+					).Return(nil),
+					repo.EXPECT().BatchIncrReadCnt(
+						gomock.Any(),
+						[]string{"article", "article", "article"},
+						[]int64{1, 1, 2},
+					).Return(nil),
+				)
+				return repo
+			},
+			events: []ReadEvent{
+				{Aid: 1, Uid: 123},
+				{Aid: 1},
+				{Aid: 2, Uid: 456},
+			},
+		},
+		{
+			name: "readers not added",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().BatchAddReaders(
+					gomock.Any(),
+					[]string{"article"},
+					[]int64{1},
+					[]int64{123},
+				).Return(errors.New("mock error"))
+				return repo
+			},
+			events:  []ReadEvent{{Aid: 1, Uid: 123}},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := NewInteractiveReadEventConsumer(logger.NewNopLogger(), tc.mock(ctrl), nil)
+			err := c.BatchConsume(nil, tc.events)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
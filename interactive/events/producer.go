@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+// TopicInteractiveEvent carries the likes and collections of the users.
+//
+// The messages are keyed by "<biz>:<bizId>", so the events of a resource are
+// in order within a partition. The value is an InteractiveEvent in JSON:
+//
+//	{"id": 42, "type": "like", "biz": "article", "bizId": 1, "uid": 123, "time": 1700000000000}
+//
+// NOTE: the events are delivered at least once, the consumers must be
+// idempotent on the id.
+const TopicInteractiveEvent = "interactive_event"
+
+// InteractiveEvent is the message of TopicInteractiveEvent.
+type InteractiveEvent struct {
+	// unique, to deduplicate the events
+	ID int64 `json:"id"`
+	// "like", "unlike", "collect" or "uncollect". A resource is collected
+	// when it enters the first collection of the user, and uncollected when
+	// it leaves the last one.
+	Type  string `json:"type"`
+	Biz   string `json:"biz"`
+	BizID int64  `json:"bizId"`
+	UID   int64  `json:"uid"`
+	// unix milliseconds
+	Time int64 `json:"time"`
+}
+
+//go:generate mockgen -source=./producer.go -package=evtmocks -destination=./mocks/producer.mock.go
+type Producer interface {
+	// ProduceInteractiveEvents sends the events in order, it fails if any
+	// of them is not sent.
+	ProduceInteractiveEvents(ctx context.Context, evts []InteractiveEvent) error
+}
+
+type SaramaSyncProducer struct {
+	producer sarama.SyncProducer
+}
+
+// ProduceInteractiveEvents implements Producer.
+func (s *SaramaSyncProducer) ProduceInteractiveEvents(
+	ctx context.Context,
+	evts []InteractiveEvent,
+) error {
+	msgs := make([]*sarama.ProducerMessage, 0, len(evts))
+	for _, evt := range evts {
+		val, err := json.Marshal(evt)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, &sarama.ProducerMessage{
+			Topic: TopicInteractiveEvent,
+			Key:   sarama.StringEncoder(evt.Biz + ":" + strconv.FormatInt(evt.BizID, 10)),
+			Value: sarama.ByteEncoder(val),
+		})
+	}
+	return s.producer.SendMessages(msgs)
+}
+
+func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
+	return &SaramaSyncProducer{producer: producer}
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/sarama"
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/chenmuyao/go-bootcamp/pkg/saramax"
+)
+
+const (
+	TopicRewardPaid = "reward_paid"
+	rewardGroup     = "interactive_reward"
+)
+
+// RewardPaidEvent is produced once a reward has been paid.
+type RewardPaidEvent struct {
+	ID     int64
+	Biz    string
+	BizID  int64
+	UID    int64
+	Amount int64
+}
+
+// InteractiveRewardConsumer counts the paid rewards into the tip counter.
+type InteractiveRewardConsumer struct {
+	l      logger.Logger
+	svc    service.InteractiveService
+	client sarama.Client
+}
+
+func (i *InteractiveRewardConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient(rewardGroup, i.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(
+			context.Background(),
+			[]string{TopicRewardPaid},
+			saramax.NewBatchHandler[RewardPaidEvent](i.l, i.BatchConsume),
+		)
+		if er != nil {
+			i.l.Error("quit consuming", logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+func (i *InteractiveRewardConsumer) BatchConsume(
+	msgs []*sarama.ConsumerMessage,
+	events []RewardPaidEvent,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), consumeTimeout)
+	defer cancel()
+
+	// NOTE: the event is delivered at least once, a redelivered reward is
+	// only counted once.
+	for _, evt := range events {
+		err := i.svc.IncrTipCnt(ctx, evt.Biz, evt.BizID, evt.ID)
+		switch {
+		case errors.Is(err, service.ErrUnknownBiz), errors.Is(err, service.ErrActionNotAllowed):
+			// would fail again
+			i.l.Warn("reward not counted",
+				logger.Int64("id", evt.ID),
+				logger.String("biz", evt.Biz),
+				logger.Int64("bizID", evt.BizID),
+				logger.Error(err))
+		case err != nil:
+			return err
+		}
+	}
+	return nil
+}
+
+func NewInteractiveRewardConsumer(
+	l logger.Logger,
+	svc service.InteractiveService,
+	client sarama.Client,
+) *InteractiveRewardConsumer {
+	return &InteractiveRewardConsumer{
+		l:      l,
+		svc:    svc,
+		client: client,
+	}
+}
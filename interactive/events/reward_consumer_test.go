@@ -0,0 +1,82 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+	intrsvcmocks "github.com/chenmuyao/go-bootcamp/interactive/service/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInteractiveRewardConsumer_BatchConsume(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.InteractiveService
+
+		events  []RewardPaidEvent
+		wantErr error
+	}{
+		{
+			name: "counted",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := intrsvcmocks.NewMockInteractiveService(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().IncrTipCnt(gomock.Any(), "article", int64(1), int64(10)).Return(nil),
+					svc.EXPECT().IncrTipCnt(gomock.Any(), "article", int64(2), int64(11)).Return(nil),
+				)
+				return svc
+			},
+			events: []RewardPaidEvent{
+				{ID: 10, Biz: "article", BizID: 1},
+				{ID: 11, Biz: "article", BizID: 2},
+			},
+		},
+		{
+			name: "invalid biz skipped",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := intrsvcmocks.NewMockInteractiveService(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().IncrTipCnt(gomock.Any(), "video", int64(1), int64(10)).
+						Return(service.ErrUnknownBiz),
+					svc.EXPECT().IncrTipCnt(gomock.Any(), "itinerary", int64(1), int64(11)).
+						Return(service.ErrActionNotAllowed),
+					svc.EXPECT().IncrTipCnt(gomock.Any(), "article", int64(2), int64(12)).Return(nil),
+				)
+				return svc
+			},
+			events: []RewardPaidEvent{
+				{ID: 10, Biz: "video", BizID: 1},
+				{ID: 11, Biz: "itinerary", BizID: 1},
+				{ID: 12, Biz: "article", BizID: 2},
+			},
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := intrsvcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().IncrTipCnt(gomock.Any(), "article", int64(1), int64(10)).
+					Return(errors.New("mock error"))
+				return svc
+			},
+			events: []RewardPaidEvent{
+				{ID: 10, Biz: "article", BizID: 1},
+				{ID: 11, Biz: "article", BizID: 2},
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := NewInteractiveRewardConsumer(logger.NewNopLogger(), tc.mock(ctrl), nil)
+			err := c.BatchConsume(nil, tc.events)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
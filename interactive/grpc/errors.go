@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StatusError turns the errors of the service the clients must tell apart
+// into status errors, the others are returned as is.
+func StatusError(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case service.ErrCollectionNotFound, service.ErrCollectionItemNotFound,
+		service.ErrResourceNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.ErrDuplicatedCollection:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case service.ErrInvalidCollectionName, service.ErrUnknownBiz, service.ErrActionNotAllowed,
+		service.ErrTooManyIDs:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/chenmuyao/generique/gslice"
 	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
@@ -32,7 +33,7 @@ func (i *InteractiveServiceServer) CancelCollect(
 		request.GetCid(),
 		request.GetUid(),
 	)
-	return &intrv1.CancelCollectResponse{}, err
+	return &intrv1.CancelCollectResponse{}, StatusError(err)
 }
 
 // CancelLike implements intrv1.InteractiveServiceServer.
@@ -41,7 +42,7 @@ func (i *InteractiveServiceServer) CancelLike(
 	request *intrv1.CancelLikeRequest,
 ) (*intrv1.CancelLikeResponse, error) {
 	err := i.svc.CancelLike(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
-	return &intrv1.CancelLikeResponse{}, err
+	return &intrv1.CancelLikeResponse{}, StatusError(err)
 }
 
 // Collect implements intrv1.InteractiveServiceServer.
@@ -50,7 +51,16 @@ func (i *InteractiveServiceServer) Collect(
 	request *intrv1.CollectRequest,
 ) (*intrv1.CollectResponse, error) {
 	err := i.svc.Collect(ctx, request.GetBiz(), request.GetId(), request.GetCid(), request.GetUid())
-	return &intrv1.CollectResponse{}, err
+	return &intrv1.CollectResponse{}, StatusError(err)
+}
+
+// Delete implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) Delete(
+	ctx context.Context,
+	request *intrv1.DeleteRequest,
+) (*intrv1.DeleteResponse, error) {
+	err := i.svc.Delete(ctx, request.GetBiz(), request.GetBizIds())
+	return &intrv1.DeleteResponse{}, StatusError(err)
 }
 
 // Get implements intrv1.InteractiveServiceServer.
@@ -60,7 +70,7 @@ func (i *InteractiveServiceServer) Get(
 ) (*intrv1.GetResponse, error) {
 	intr, err := i.svc.Get(ctx, request.GetBiz(), request.GetId(), request.GetUid())
 	if err != nil {
-		return nil, err
+		return nil, StatusError(err)
 	}
 	return &intrv1.GetResponse{
 		Intr: i.toDTO(intr),
@@ -74,7 +84,7 @@ func (i *InteractiveServiceServer) GetByIDs(
 ) (*intrv1.GetByIDsResponse, error) {
 	intrs, err := i.svc.GetByIDs(ctx, request.GetBiz(), request.GetIds())
 	if err != nil {
-		return nil, err
+		return nil, StatusError(err)
 	}
 	res := make(map[int64]*intrv1.Interactive, len(intrs))
 	for key, val := range intrs {
@@ -85,6 +95,44 @@ func (i *InteractiveServiceServer) GetByIDs(
 	}, nil
 }
 
+// BatchGet implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) BatchGet(
+	ctx context.Context,
+	request *intrv1.BatchGetRequest,
+) (*intrv1.BatchGetResponse, error) {
+	intrs, err := i.svc.BatchGet(ctx, request.GetBiz(), request.GetIds(), request.GetUid())
+	if err != nil {
+		return nil, StatusError(err)
+	}
+	res := make(map[int64]*intrv1.Interactive, len(intrs))
+	for key, val := range intrs {
+		res[key] = i.toDTO(val)
+	}
+	return &intrv1.BatchGetResponse{
+		Intrs: res,
+	}, nil
+}
+
+// SubscribeCnts implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) SubscribeCnts(
+	request *intrv1.SubscribeCntsRequest,
+	stream grpc.ServerStreamingServer[intrv1.SubscribeCntsResponse],
+) error {
+	err := i.svc.SubscribeCnts(
+		stream.Context(),
+		request.GetBiz(),
+		request.GetIds(),
+		func(intrs []domain.Interactive) error {
+			return stream.Send(&intrv1.SubscribeCntsResponse{
+				Intrs: gslice.Map(intrs, func(id int, src domain.Interactive) *intrv1.Interactive {
+					return i.toDTO(src)
+				}),
+			})
+		},
+	)
+	return StatusError(err)
+}
+
 // GetTopLike implements intrv1.InteractiveServiceServer.
 func (i *InteractiveServiceServer) GetTopLike(
 	ctx context.Context,
@@ -92,7 +140,7 @@ func (i *InteractiveServiceServer) GetTopLike(
 ) (*intrv1.GetTopLikeResponse, error) {
 	likes, err := i.svc.GetTopLike(ctx, request.GetBiz(), int(request.GetLimit()))
 	if err != nil {
-		return nil, err
+		return nil, StatusError(err)
 	}
 	return &intrv1.GetTopLikeResponse{Ids: likes}, nil
 }
@@ -103,7 +151,7 @@ func (i *InteractiveServiceServer) IncrReadCnt(
 	request *intrv1.IncrReadCntRequest,
 ) (*intrv1.IncrReadCntResponse, error) {
 	err := i.svc.IncrReadCnt(ctx, request.GetBiz(), request.GetBizId())
-	return &intrv1.IncrReadCntResponse{}, err
+	return &intrv1.IncrReadCntResponse{}, StatusError(err)
 }
 
 // Like implements intrv1.InteractiveServiceServer.
@@ -112,7 +160,7 @@ func (i *InteractiveServiceServer) Like(
 	request *intrv1.LikeRequest,
 ) (*intrv1.LikeResponse, error) {
 	err := i.svc.Like(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
-	return &intrv1.LikeResponse{}, err
+	return &intrv1.LikeResponse{}, StatusError(err)
 }
 
 // MustBatchGet implements intrv1.InteractiveServiceServer.
@@ -122,7 +170,7 @@ func (i *InteractiveServiceServer) MustBatchGet(
 ) (*intrv1.MustBatchGetResponse, error) {
 	intrs, err := i.svc.MustBatchGet(ctx, request.GetBiz(), request.GetIds())
 	if err != nil {
-		return nil, err
+		return nil, StatusError(err)
 	}
 	res := gslice.Map(intrs, func(id int, src domain.Interactive) *intrv1.Interactive {
 		return i.toDTO(src)
@@ -131,15 +179,204 @@ func (i *InteractiveServiceServer) MustBatchGet(
 	return &intrv1.MustBatchGetResponse{Intrs: res}, nil
 }
 
+// ListLikes implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) ListLikes(
+	ctx context.Context,
+	request *intrv1.ListLikesRequest,
+) (*intrv1.ListLikesResponse, error) {
+	likes, err := i.svc.ListLikes(
+		ctx,
+		request.GetBiz(),
+		request.GetAfterId(),
+		int(request.GetLimit()),
+	)
+	if err != nil {
+		return nil, StatusError(err)
+	}
+	return &intrv1.ListLikesResponse{Likes: gslice.Map(likes, toUserBizDTO)}, nil
+}
+
+// ListCollects implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) ListCollects(
+	ctx context.Context,
+	request *intrv1.ListCollectsRequest,
+) (*intrv1.ListCollectsResponse, error) {
+	collects, err := i.svc.ListCollects(
+		ctx,
+		request.GetBiz(),
+		request.GetAfterId(),
+		int(request.GetLimit()),
+	)
+	if err != nil {
+		return nil, StatusError(err)
+	}
+	return &intrv1.ListCollectsResponse{Collects: gslice.Map(collects, toUserBizDTO)}, nil
+}
+
+// ListUserLikes implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) ListUserLikes(
+	ctx context.Context,
+	request *intrv1.ListUserLikesRequest,
+) (*intrv1.ListUserLikesResponse, error) {
+	likes, err := i.svc.ListUserLikes(
+		ctx,
+		request.GetUid(),
+		request.GetBiz(),
+		toDomainCursor(request.GetCursor()),
+		int(request.GetLimit()),
+	)
+	if err != nil {
+		return nil, StatusError(err)
+	}
+	return &intrv1.ListUserLikesResponse{Likes: gslice.Map(likes, toUserBizDTO)}, nil
+}
+
+// ListUserCollects implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) ListUserCollects(
+	ctx context.Context,
+	request *intrv1.ListUserCollectsRequest,
+) (*intrv1.ListUserCollectsResponse, error) {
+	collects, err := i.svc.ListUserCollects(
+		ctx,
+		request.GetUid(),
+		request.GetBiz(),
+		toDomainCursor(request.GetCursor()),
+		int(request.GetLimit()),
+	)
+	if err != nil {
+		return nil, StatusError(err)
+	}
+	return &intrv1.ListUserCollectsResponse{Collects: gslice.Map(collects, toUserBizDTO)}, nil
+}
+
+// CreateCollection implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) CreateCollection(
+	ctx context.Context,
+	request *intrv1.CreateCollectionRequest,
+) (*intrv1.CreateCollectionResponse, error) {
+	c, err := i.svc.CreateCollection(ctx, domain.Collection{
+		UID:    request.GetUid(),
+		Name:   request.GetName(),
+		Public: request.GetPublic(),
+	})
+	if err != nil {
+		return nil, StatusError(err)
+	}
+	return &intrv1.CreateCollectionResponse{Collection: toCollectionDTO(0, c)}, nil
+}
+
+// UpdateCollection implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) UpdateCollection(
+	ctx context.Context,
+	request *intrv1.UpdateCollectionRequest,
+) (*intrv1.UpdateCollectionResponse, error) {
+	err := i.svc.UpdateCollection(ctx, domain.Collection{
+		ID:     request.GetId(),
+		UID:    request.GetUid(),
+		Name:   request.GetName(),
+		Public: request.GetPublic(),
+	})
+	return &intrv1.UpdateCollectionResponse{}, StatusError(err)
+}
+
+// DeleteCollection implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) DeleteCollection(
+	ctx context.Context,
+	request *intrv1.DeleteCollectionRequest,
+) (*intrv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, request.GetUid(), request.GetId())
+	return &intrv1.DeleteCollectionResponse{}, StatusError(err)
+}
+
+// ListCollections implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) ListCollections(
+	ctx context.Context,
+	request *intrv1.ListCollectionsRequest,
+) (*intrv1.ListCollectionsResponse, error) {
+	cs, err := i.svc.ListCollections(ctx, request.GetUid(), request.GetViewerUid())
+	if err != nil {
+		return nil, StatusError(err)
+	}
+	return &intrv1.ListCollectionsResponse{Collections: gslice.Map(cs, toCollectionDTO)}, nil
+}
+
+// ListCollectionItems implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) ListCollectionItems(
+	ctx context.Context,
+	request *intrv1.ListCollectionItemsRequest,
+) (*intrv1.ListCollectionItemsResponse, error) {
+	items, err := i.svc.ListCollectionItems(
+		ctx,
+		request.GetCid(),
+		request.GetViewerUid(),
+		int(request.GetOffset()),
+		int(request.GetLimit()),
+	)
+	if err != nil {
+		return nil, StatusError(err)
+	}
+	return &intrv1.ListCollectionItemsResponse{Items: gslice.Map(items, toUserBizDTO)}, nil
+}
+
+// MoveCollectionItem implements intrv1.InteractiveServiceServer.
+func (i *InteractiveServiceServer) MoveCollectionItem(
+	ctx context.Context,
+	request *intrv1.MoveCollectionItemRequest,
+) (*intrv1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectionItem(
+		ctx,
+		request.GetBiz(),
+		request.GetId(),
+		request.GetUid(),
+		request.GetFromCid(),
+		request.GetToCid(),
+	)
+	return &intrv1.MoveCollectionItemResponse{}, StatusError(err)
+}
+
 func (i *InteractiveServiceServer) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
-		Biz:        intr.Biz,
-		BizId:      intr.BizID,
-		ReadCnt:    intr.ReadCnt,
-		LikeCnt:    intr.LikeCnt,
-		CollectCnt: intr.CollectCnt,
-		Liked:      intr.Liked,
-		Collected:  intr.Collected,
+		Biz:           intr.Biz,
+		BizId:         intr.BizID,
+		ReadCnt:       intr.ReadCnt,
+		LikeCnt:       intr.LikeCnt,
+		CollectCnt:    intr.CollectCnt,
+		TipCnt:        intr.TipCnt,
+		Liked:         intr.Liked,
+		Collected:     intr.Collected,
+		UniqueReadCnt: intr.UniqueReadCnt,
+	}
+}
+
+func toUserBizDTO(id int, src domain.UserBiz) *intrv1.UserBiz {
+	return &intrv1.UserBiz{
+		Id:    src.ID,
+		Uid:   src.UID,
+		Biz:   src.Biz,
+		BizId: src.BizID,
+		Utime: src.Utime.UnixMilli(),
+		Cid:   src.CID,
+	}
+}
+
+func toDomainCursor(src *intrv1.UserBizCursor) domain.Cursor {
+	if src.GetUtime() <= 0 {
+		return domain.Cursor{}
+	}
+	return domain.Cursor{
+		Utime: time.UnixMilli(src.GetUtime()),
+		BizID: src.GetBizId(),
+	}
+}
+
+func toCollectionDTO(id int, src domain.Collection) *intrv1.Collection {
+	return &intrv1.Collection{
+		Id:     src.ID,
+		Uid:    src.UID,
+		Name:   src.Name,
+		Public: src.Public,
+		Ctime:  src.Ctime.UnixMilli(),
+		Utime:  src.Utime.UnixMilli(),
 	}
 }
 
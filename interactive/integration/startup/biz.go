@@ -0,0 +1,19 @@
+package startup
+
+import (
+	"github.com/chenmuyao/go-bootcamp/interactive/ioc"
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+)
+
+// InitBizRegistry registers a biz per test case, with the interactions of the
+// articles. The resources of the tests are not checked.
+func InitBizRegistry() *service.BizRegistry {
+	bizs := []string{"read", "like", "cancel_like", "collect", "cancel_collect"}
+	cfgs := make([]service.BizConfig, 0, len(bizs))
+	for _, biz := range bizs {
+		cfg := ioc.ArticleBizConfig(nil)
+		cfg.Name = biz
+		cfgs = append(cfgs, cfg)
+	}
+	return service.NewBizRegistry(cfgs...)
+}
@@ -22,10 +22,14 @@ var thirdPartySet = wire.NewSet(
 
 var interactiveSvcSet = wire.NewSet(
 	intrDao.NewGORMInteractiveDAO,
+	intrDao.NewGORMCollectionDAO,
 	intrRediscache.NewInteractiveRedisCache,
 	ioc.InitTopArticlesCache,
+	intrRepository.NewWriteThroughCntWriter,
 	intrRepository.NewCachedInteractiveRepository,
+	intrRepository.NewGORMCollectionRepository,
 	intrService.NewInteractiveService,
+	InitBizRegistry,
 )
 
 func InitInteractiveService() *grpc.InteractiveServiceServer {
@@ -22,11 +22,15 @@ func InitInteractiveService() *grpc.InteractiveServiceServer {
 	logger := InitLogger()
 	db := InitDB()
 	interactiveDAO := dao.NewGORMInteractiveDAO(db)
+	cntWriter := repository.NewWriteThroughCntWriter(interactiveDAO)
 	cmdable := InitRedis()
 	interactiveCache := rediscache.NewInteractiveRedisCache(cmdable)
 	topArticlesCache := ioc.InitTopArticlesCache()
-	interactiveRepository := repository.NewCachedInteractiveRepository(logger, interactiveDAO, interactiveCache, topArticlesCache)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	interactiveRepository := repository.NewCachedInteractiveRepository(logger, interactiveDAO, cntWriter, interactiveCache, topArticlesCache)
+	collectionDAO := dao.NewGORMCollectionDAO(db)
+	collectionRepository := repository.NewGORMCollectionRepository(collectionDAO)
+	bizRegistry := InitBizRegistry()
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, bizRegistry)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	return interactiveServiceServer
 }
@@ -40,4 +44,4 @@ var thirdPartySet = wire.NewSet(
 	InitSaramaClient,
 )
 
-var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, rediscache.NewInteractiveRedisCache, ioc.InitTopArticlesCache, repository.NewCachedInteractiveRepository, service.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, dao.NewGORMCollectionDAO, rediscache.NewInteractiveRedisCache, ioc.InitTopArticlesCache, repository.NewWriteThroughCntWriter, repository.NewCachedInteractiveRepository, repository.NewGORMCollectionRepository, service.NewInteractiveService, InitBizRegistry)
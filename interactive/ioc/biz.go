@@ -0,0 +1,61 @@
+package ioc
+
+import (
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+)
+
+func InitBizRegistry(published repository.PublishedRepository) *service.BizRegistry {
+	return service.NewBizRegistry(
+		ArticleBizConfig(service.ExistCheckerFunc(published.ArticleExists)),
+		ItineraryBizConfig(service.ExistCheckerFunc(published.ItineraryExists)),
+	)
+}
+
+// ArticleBizConfig registers the interactions of the articles, only the
+// published ones are liked and collected.
+func ArticleBizConfig(exists service.ExistChecker) service.BizConfig {
+	return service.BizConfig{
+		Biz: domain.Biz{
+			Name: domain.BizArticle,
+			Actions: []domain.BizAction{
+				domain.BizActionRead,
+				domain.BizActionLike,
+				domain.BizActionCollect,
+				domain.BizActionTip,
+			},
+			Counters: []domain.Counter{
+				domain.CounterRead,
+				domain.CounterLike,
+				domain.CounterCollect,
+				domain.CounterTip,
+				domain.CounterUniqueRead,
+			},
+			TopLikeSize: 10,
+		},
+		Exists: exists,
+	}
+}
+
+// ItineraryBizConfig registers the interactions of the itineraries, only the
+// published ones are liked and collected.
+func ItineraryBizConfig(exists service.ExistChecker) service.BizConfig {
+	return service.BizConfig{
+		Biz: domain.Biz{
+			Name: domain.BizItinerary,
+			Actions: []domain.BizAction{
+				domain.BizActionRead,
+				domain.BizActionLike,
+				domain.BizActionCollect,
+			},
+			Counters: []domain.Counter{
+				domain.CounterRead,
+				domain.CounterLike,
+				domain.CounterCollect,
+			},
+			TopLikeSize: 10,
+		},
+		Exists: exists,
+	}
+}
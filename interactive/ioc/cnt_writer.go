@@ -0,0 +1,20 @@
+package ioc
+
+import (
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/cache"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+func InitWriteBehindCntWriter(
+	l logger.Logger,
+	dao dao.InteractiveDAO,
+	cache cache.CntBufferCache,
+) *repository.WriteBehindCntWriter {
+	return repository.NewWriteBehindCntWriter(l, dao, cache, 1000)
+}
+
+func InitCntWriter(w *repository.WriteBehindCntWriter) repository.CntWriter {
+	return w
+}
@@ -0,0 +1,109 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/events"
+	intrJob "github.com/chenmuyao/go-bootcamp/interactive/job"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+	"github.com/chenmuyao/go-bootcamp/internal/job"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/robfig/cron/v3"
+)
+
+func InitUniqueReadCntJob(l logger.Logger, svc service.InteractiveService) *intrJob.UniqueReadCntJob {
+	return intrJob.NewUniqueReadCntJob(svc, domain.BizArticle, 500, time.Second*50, l)
+}
+
+func InitOutboxRelayJob(
+	l logger.Logger,
+	repo repository.OutboxRepository,
+	producer events.Producer,
+	redis redis.Cmdable,
+) *intrJob.OutboxRelayJob {
+	return intrJob.NewOutboxRelayJob(repo, producer, redislock.New(redis), 100, time.Second*5, l)
+}
+
+func InitCntFlushJob(
+	l logger.Logger,
+	w *repository.WriteBehindCntWriter,
+	redis redis.Cmdable,
+) *intrJob.CntFlushJob {
+	return intrJob.NewCntFlushJob(w, redislock.New(redis), 500, time.Second*5, prometheus.SummaryOpts{
+		Namespace: "my_company",
+		Subsystem: "wetravel",
+		Name:      "interactive_cnt_flush_lag",
+		Help:      "interactive counters flush lag",
+		Objectives: map[float64]float64{
+			0.5:  0.01,
+			0.9:  0.01,
+			0.99: 0.001,
+		},
+	}, l)
+}
+
+func InitCntReconcileJob(
+	l logger.Logger,
+	svc service.InteractiveService,
+) *intrJob.CntReconcileJob {
+	return intrJob.NewCntReconcileJob(svc, domain.BizArticle, 500, 10, time.Second*10,
+		prometheus.CounterOpts{
+			Namespace: "my_company",
+			Subsystem: "wetravel",
+			Name:      "interactive_cnt_reconcile_compared",
+			Help:      "interactive cached counters compared to the saved ones",
+		},
+		prometheus.CounterOpts{
+			Namespace: "my_company",
+			Subsystem: "wetravel",
+			Name:      "interactive_cnt_reconcile_drift",
+			Help:      "interactive cached counters drifting from the saved ones",
+		}, l)
+}
+
+func InitJobs(
+	l logger.Logger,
+	uniqueReadCnt *intrJob.UniqueReadCntJob,
+	outboxRelay *intrJob.OutboxRelayJob,
+	cntFlush *intrJob.CntFlushJob,
+	cntReconcile *intrJob.CntReconcileJob,
+) *cron.Cron {
+	builder := job.NewCronJobBuilder(l, prometheus.SummaryOpts{
+		Namespace: "my_company",
+		Subsystem: "wetravel",
+		Name:      "interactive_cron_job",
+		Help:      "interactive cron job",
+		Objectives: map[float64]float64{
+			0.5:   0.01,
+			0.75:  0.01,
+			0.9:   0.01,
+			0.99:  0.001,
+			0.999: 0.0001,
+		},
+	})
+	expr := cron.New(cron.WithSeconds())
+	_, err := expr.AddJob("@every 1m", builder.Build(uniqueReadCnt))
+	if err != nil {
+		panic(err)
+	}
+	// NOTE: a run may last longer than the interval while Kafka is down.
+	skip := cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger))
+	_, err = expr.AddJob("@every 1s", skip.Then(builder.Build(outboxRelay)))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 1s", skip.Then(builder.Build(cntFlush)))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 30s", skip.Then(builder.Build(cntReconcile)))
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
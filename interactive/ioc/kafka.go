@@ -10,6 +10,8 @@ import (
 func InitSaramaClient() sarama.Client {
 	cfg := sarama.NewConfig()
 	cfg.Producer.Return.Successes = true
+	// the interactive events are deleted from the outbox once acknowledged
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
 	client, err := sarama.NewClient(config.Cfg.Sarama.Addr, cfg)
 	if err != nil {
 		panic(err)
@@ -17,6 +19,17 @@ func InitSaramaClient() sarama.Client {
 	return client
 }
 
-func InitConsumers(c1 *intrEvents.InteractiveReadEventConsumer) []events.Consumer {
-	return []events.Consumer{c1}
+func InitSyncProducer(c sarama.Client) sarama.SyncProducer {
+	p, err := sarama.NewSyncProducerFromClient(c)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func InitConsumers(
+	c1 *intrEvents.InteractiveReadEventConsumer,
+	c2 *intrEvents.InteractiveRewardConsumer,
+) []events.Consumer {
+	return []events.Consumer{c1, c2}
 }
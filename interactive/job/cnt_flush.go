@@ -0,0 +1,102 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CntFlushJob saves the counters buffered by the WriteBehindCntWriter. It
+// runs on schedule, and as soon as the buffer is large enough once started.
+// NOTE: two flushes at the same time would take the same batches, the
+// instances take turns with a distributed lock.
+type CntFlushJob struct {
+	l          logger.Logger
+	writer     *repository.WriteBehindCntWriter
+	batchSize  int
+	timeout    time.Duration
+	lag        prometheus.Summary
+	lockClient *redislock.Client
+}
+
+// Name implements job.Job.
+func (c *CntFlushJob) Name() string {
+	return "interactive_cnt_flush"
+}
+
+// Run implements job.Job.
+func (c *CntFlushJob) Run() error {
+	lockCtx, lockCancel := context.WithTimeout(context.Background(), time.Second)
+	defer lockCancel()
+	lock, err := c.lockClient.Obtain(lockCtx, "job:interactive_cnt_flush", c.timeout, nil)
+	if err != nil {
+		if errors.Is(err, redislock.ErrNotObtained) {
+			// another flush is running
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Release(ctx)
+		if er != nil {
+			c.l.Error("counters flush job failed to release distributed lock",
+				logger.Error(er))
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	for ctx.Err() == nil {
+		n, since, err := c.writer.Flush(ctx, c.batchSize)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			c.lag.Observe(float64(time.Since(since).Milliseconds()))
+		}
+		if n < c.batchSize {
+			break
+		}
+	}
+	return nil
+}
+
+// Start flushes in the background whenever the buffer reaches its threshold.
+func (c *CntFlushJob) Start() {
+	go func() {
+		for range c.writer.Notify() {
+			err := c.Run()
+			if err != nil {
+				c.l.Error("failed to flush the counters", logger.Error(err))
+			}
+		}
+	}()
+}
+
+// NewCntFlushJob observes in opts the lag in milliseconds between the first
+// buffered delta and its flush.
+func NewCntFlushJob(
+	writer *repository.WriteBehindCntWriter,
+	lock *redislock.Client,
+	batchSize int,
+	timeout time.Duration,
+	opts prometheus.SummaryOpts,
+	l logger.Logger,
+) *CntFlushJob {
+	return &CntFlushJob{
+		l:          l,
+		writer:     writer,
+		batchSize:  batchSize,
+		timeout:    timeout,
+		lag:        promauto.NewSummary(opts),
+		lockClient: lock,
+	}
+}
@@ -0,0 +1,202 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/cache"
+	intrcachemocks "github.com/chenmuyao/go-bootcamp/interactive/repository/cache/mocks"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
+	intrdaomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/dao/mocks"
+	redismock "github.com/chenmuyao/go-bootcamp/internal/repository/cache/rediscache/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCntFlushJob_Run(t *testing.T) {
+	since := time.Now()
+	batch := domain.CntBatch{
+		FlushID: 1,
+		Deltas: []domain.CntDelta{
+			{Biz: "article", BizID: 1, ReadCnt: 10},
+			{Biz: "article", BizID: 2, TipCnt: 1},
+		},
+		Since: since,
+	}
+	partial := domain.CntBatch{
+		FlushID: 1,
+		No:      1,
+		Deltas:  []domain.CntDelta{{Biz: "article", BizID: 3, ReadCnt: 1}},
+		Since:   since,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (
+			dao.InteractiveDAO,
+			cache.CntBufferCache,
+			redis.Cmdable,
+		)
+
+		wantErr error
+	}{
+		{
+			name: "until a partial batch",
+			mock: func(ctrl *gomock.Controller) (
+				dao.InteractiveDAO,
+				cache.CntBufferCache,
+				redis.Cmdable,
+			) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				cmd := redismock.NewMockCmdable(ctrl)
+				gomock.InOrder(
+					expectLock(cmd, "job:interactive_cnt_flush", "OK", nil),
+					c.EXPECT().Take(gomock.Any(), 2).Return(batch, nil),
+					d.EXPECT().IncrCntBatch(gomock.Any(), batch).Return(true, nil),
+					c.EXPECT().Ack(gomock.Any(), batch).Return(nil),
+					c.EXPECT().Take(gomock.Any(), 2).Return(partial, nil),
+					d.EXPECT().IncrCntBatch(gomock.Any(), partial).Return(true, nil),
+					c.EXPECT().Ack(gomock.Any(), partial).Return(nil),
+					expectLock(cmd, "job:interactive_cnt_flush", int64(1), nil),
+				)
+				return d, c, cmd
+			},
+		},
+		{
+			name: "flushed by another instance",
+			mock: func(ctrl *gomock.Controller) (
+				dao.InteractiveDAO,
+				cache.CntBufferCache,
+				redis.Cmdable,
+			) {
+				cmd := redismock.NewMockCmdable(ctrl)
+				expectLock(cmd, "job:interactive_cnt_flush", nil, redis.Nil)
+				return nil, nil, cmd
+			},
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (
+				dao.InteractiveDAO,
+				cache.CntBufferCache,
+				redis.Cmdable,
+			) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				cmd := redismock.NewMockCmdable(ctrl)
+				gomock.InOrder(
+					expectLock(cmd, "job:interactive_cnt_flush", "OK", nil),
+					c.EXPECT().Take(gomock.Any(), 2).Return(batch, nil),
+					d.EXPECT().IncrCntBatch(gomock.Any(), batch).
+						Return(false, errors.New("mock error")),
+					expectLock(cmd, "job:interactive_cnt_flush", int64(1), nil),
+				)
+				return d, c, cmd
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, c, cmd := tc.mock(ctrl)
+			job := newTestCntFlushJob(d, c, cmd)
+			err := job.Run()
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCntFlushJob_RunConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	batch := domain.CntBatch{
+		FlushID: 1,
+		Deltas:  []domain.CntDelta{{Biz: "article", BizID: 1, ReadCnt: 10}},
+		Since:   time.Now(),
+	}
+	d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+	c := intrcachemocks.NewMockCntBufferCache(ctrl)
+	cmd := redismock.NewMockCmdable(ctrl)
+
+	// the lock shared by the instances
+	var mu sync.Mutex
+	held := false
+	cmd.EXPECT().
+		EvalSha(gomock.Any(), gomock.Any(), []string{"job:interactive_cnt_flush"}, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, sha string, keys []string, args ...any) *redis.Cmd {
+			mu.Lock()
+			defer mu.Unlock()
+			res := redis.NewCmd(ctx)
+			switch {
+			case len(args) == 1:
+				// release
+				held = false
+				res.SetVal(int64(1))
+			case held:
+				res.SetErr(redis.Nil)
+			default:
+				held = true
+				res.SetVal("OK")
+			}
+			return res
+		}).AnyTimes()
+
+	flushing := make(chan struct{})
+	done := make(chan struct{})
+	var saved atomic.Int32
+	c.EXPECT().Take(gomock.Any(), 2).Return(batch, nil)
+	d.EXPECT().IncrCntBatch(gomock.Any(), batch).DoAndReturn(
+		func(ctx context.Context, batch domain.CntBatch) (bool, error) {
+			saved.Add(1)
+			close(flushing)
+			<-done
+			return true, nil
+		})
+	c.EXPECT().Ack(gomock.Any(), batch).Return(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := newTestCntFlushJob(d, c, cmd).Run()
+		assert.NoError(t, err)
+	}()
+	<-flushing
+	// another instance while the first one is saving
+	err := newTestCntFlushJob(d, c, cmd).Run()
+	assert.NoError(t, err)
+	close(done)
+	wg.Wait()
+	assert.Equal(t, int32(1), saved.Load())
+}
+
+var testCntFlushJobs atomic.Int32
+
+func newTestCntFlushJob(
+	d dao.InteractiveDAO,
+	c cache.CntBufferCache,
+	cmd redis.Cmdable,
+) *CntFlushJob {
+	w := repository.NewWriteBehindCntWriter(logger.NewNopLogger(), d, c, 1000)
+	// NOTE: the summaries are registered globally, their names must differ.
+	opts := prometheus.SummaryOpts{
+		Name: fmt.Sprintf("test_cnt_flush_lag_%d", testCntFlushJobs.Add(1)),
+	}
+	return NewCntFlushJob(w, redislock.New(cmd), 2, time.Second, opts, logger.NewNopLogger())
+}
@@ -0,0 +1,92 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CntReconcileJob scans the saved counters batch by batch, recounts the likes
+// and the collects, and repairs the counters drifting away. A run checks at
+// most batches batches, the next run continues after the last resource
+// checked, and the scan restarts from the beginning once the end is reached.
+type CntReconcileJob struct {
+	l         logger.Logger
+	svc       service.InteractiveService
+	biz       string
+	batchSize int
+	batches   int
+	timeout   time.Duration
+	afterID   int64
+
+	compared *prometheus.CounterVec
+	drift    *prometheus.CounterVec
+}
+
+// Name implements job.Job.
+func (c *CntReconcileJob) Name() string {
+	return "interactive_cnt_reconcile"
+}
+
+// Run implements job.Job.
+func (c *CntReconcileJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	checked, drifts := 0, 0
+	for i := 0; i < c.batches && ctx.Err() == nil; i++ {
+		res, err := c.svc.ReconcileCnts(ctx, c.biz, c.afterID, c.batchSize)
+		if err != nil {
+			return err
+		}
+		checked += res.Checked
+		drifts += len(res.Drifts)
+		c.compared.WithLabelValues(c.biz).Add(float64(res.Compared))
+		for _, d := range res.Drifts {
+			c.drift.WithLabelValues(c.biz, d.Counter).Inc()
+			c.l.Warn("interactive counter drifted",
+				logger.String("biz", d.Biz),
+				logger.Int64("bizID", d.BizID),
+				logger.String("counter", d.Counter),
+				logger.Int64("cached", d.Cached),
+				logger.Int64("expected", d.Expected))
+		}
+		c.afterID = res.LastID
+		if res.Checked < c.batchSize {
+			c.afterID = 0
+			break
+		}
+	}
+	c.l.Debug("interactive counters reconciled",
+		logger.String("biz", c.biz),
+		logger.Int("checked", checked),
+		logger.Int("drifts", drifts))
+	return nil
+}
+
+// NewCntReconcileJob counts the cached counters compared in comparedOpts, and
+// the drifting ones in driftOpts.
+func NewCntReconcileJob(
+	svc service.InteractiveService,
+	biz string,
+	batchSize int,
+	batches int,
+	timeout time.Duration,
+	comparedOpts prometheus.CounterOpts,
+	driftOpts prometheus.CounterOpts,
+	l logger.Logger,
+) *CntReconcileJob {
+	return &CntReconcileJob{
+		l:         l,
+		svc:       svc,
+		biz:       biz,
+		batchSize: batchSize,
+		batches:   batches,
+		timeout:   timeout,
+		compared:  promauto.NewCounterVec(comparedOpts, []string{"biz"}),
+		drift:     promauto.NewCounterVec(driftOpts, []string{"biz", "counter"}),
+	}
+}
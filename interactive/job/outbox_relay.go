@@ -0,0 +1,115 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/events"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+// OutboxRelayJob sends the interactive events of the outbox to Kafka. The
+// events stay in the outbox while Kafka is down and are sent by a later run.
+// NOTE: an event may still be sent twice if the relay fails after sending
+// it, the consumers must tolerate it.
+type OutboxRelayJob struct {
+	l          logger.Logger
+	repo       repository.OutboxRepository
+	producer   events.Producer
+	batchSize  int
+	timeout    time.Duration
+	lockClient *redislock.Client
+}
+
+// Name implements job.Job.
+func (o *OutboxRelayJob) Name() string {
+	return "interactive_outbox_relay"
+}
+
+// Run implements job.Job.
+func (o *OutboxRelayJob) Run() error {
+	lockCtx, lockCancel := context.WithTimeout(context.Background(), time.Second)
+	defer lockCancel()
+	lock, err := o.lockClient.Obtain(lockCtx, "job:interactive_outbox_relay", o.timeout, nil)
+	if err != nil {
+		if errors.Is(err, redislock.ErrNotObtained) {
+			// another instance is relaying
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Release(ctx)
+		if er != nil {
+			o.l.Error("outbox relay job failed to release distributed lock",
+				logger.Error(er))
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+	total := 0
+	for ctx.Err() == nil {
+		evts, err := o.repo.ListPending(ctx, o.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(evts) == 0 {
+			break
+		}
+		err = o.producer.ProduceInteractiveEvents(ctx, gslice.Map(evts, toEventDTO))
+		if err != nil {
+			return err
+		}
+		err = o.repo.Delete(ctx, gslice.Map(evts, func(id int, src domain.InteractiveEvent) int64 {
+			return src.ID
+		}))
+		if err != nil {
+			return err
+		}
+		total += len(evts)
+		if len(evts) < o.batchSize {
+			break
+		}
+	}
+	if total > 0 {
+		o.l.Debug("interactive events relayed", logger.Int("cnt", total))
+	}
+	return nil
+}
+
+func NewOutboxRelayJob(
+	repo repository.OutboxRepository,
+	producer events.Producer,
+	lock *redislock.Client,
+	batchSize int,
+	timeout time.Duration,
+	l logger.Logger,
+) *OutboxRelayJob {
+	return &OutboxRelayJob{
+		l:          l,
+		repo:       repo,
+		producer:   producer,
+		batchSize:  batchSize,
+		timeout:    timeout,
+		lockClient: lock,
+	}
+}
+
+func toEventDTO(id int, src domain.InteractiveEvent) events.InteractiveEvent {
+	return events.InteractiveEvent{
+		ID:    src.ID,
+		Type:  string(src.Type),
+		Biz:   src.Biz,
+		BizID: src.BizID,
+		UID:   src.UID,
+		Time:  src.Time.UnixMilli(),
+	}
+}
@@ -0,0 +1,122 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/events"
+	evtmocks "github.com/chenmuyao/go-bootcamp/interactive/events/mocks"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	intrrepomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/mocks"
+	redismock "github.com/chenmuyao/go-bootcamp/internal/repository/cache/rediscache/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxRelayJob_Run(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (
+			repository.OutboxRepository,
+			events.Producer,
+			redis.Cmdable,
+		)
+
+		wantErr error
+	}{
+		{
+			name: "relayed",
+			mock: func(ctrl *gomock.Controller) (
+				repository.OutboxRepository,
+				events.Producer,
+				redis.Cmdable,
+			) {
+				repo := intrrepomocks.NewMockOutboxRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				cmd := redismock.NewMockCmdable(ctrl)
+				gomock.InOrder(
+					expectLock(cmd, "job:interactive_outbox_relay", "OK", nil),
+					repo.EXPECT().ListPending(gomock.Any(), 2).Return([]domain.InteractiveEvent{
+						{ID: 1, Type: domain.EventTypeLike, Biz: "article", BizID: 1, UID: 123, Time: now},
+						{ID: 2, Type: domain.EventTypeUnlike, Biz: "article", BizID: 1, UID: 123, Time: now},
+					}, nil),
+					producer.EXPECT().ProduceInteractiveEvents(gomock.Any(), []events.InteractiveEvent{
+						{ID: 1, Type: "like", Biz: "article", BizID: 1, UID: 123, Time: now.UnixMilli()},
+						{ID: 2, Type: "unlike", Biz: "article", BizID: 1, UID: 123, Time: now.UnixMilli()},
+					}).Return(nil),
+					repo.EXPECT().Delete(gomock.Any(), []int64{1, 2}).Return(nil),
+					repo.EXPECT().ListPending(gomock.Any(), 2).Return(nil, nil),
+					expectLock(cmd, "job:interactive_outbox_relay", int64(1), nil),
+				)
+				return repo, producer, cmd
+			},
+		},
+		{
+			name: "relayed by another instance",
+			mock: func(ctrl *gomock.Controller) (
+				repository.OutboxRepository,
+				events.Producer,
+				redis.Cmdable,
+			) {
+				cmd := redismock.NewMockCmdable(ctrl)
+				expectLock(cmd, "job:interactive_outbox_relay", nil, redis.Nil)
+				return nil, nil, cmd
+			},
+		},
+		{
+			name: "kafka down",
+			mock: func(ctrl *gomock.Controller) (
+				repository.OutboxRepository,
+				events.Producer,
+				redis.Cmdable,
+			) {
+				repo := intrrepomocks.NewMockOutboxRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				cmd := redismock.NewMockCmdable(ctrl)
+				gomock.InOrder(
+					expectLock(cmd, "job:interactive_outbox_relay", "OK", nil),
+					repo.EXPECT().ListPending(gomock.Any(), 2).Return([]domain.InteractiveEvent{
+						{ID: 1, Type: domain.EventTypeLike, Biz: "article", BizID: 1, UID: 123, Time: now},
+					}, nil),
+					producer.EXPECT().
+						ProduceInteractiveEvents(gomock.Any(), gomock.Any()).
+						Return(errors.New("mock error")),
+					expectLock(cmd, "job:interactive_outbox_relay", int64(1), nil),
+				)
+				return repo, producer, cmd
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, producer, cmd := tc.mock(ctrl)
+			job := NewOutboxRelayJob(repo, producer, redislock.New(cmd), 2, time.Second,
+				logger.NewNopLogger())
+			err := job.Run()
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+// expectLock expects a script of redislock on the key, which obtains or
+// releases the lock.
+func expectLock(cmd *redismock.MockCmdable, key string, val any, err error) *gomock.Call {
+	res := redis.NewCmd(context.Background())
+	res.SetVal(val)
+	res.SetErr(err)
+	return cmd.EXPECT().
+		EvalSha(gomock.Any(), gomock.Any(), []string{key}, gomock.Any()).
+		Return(res)
+}
@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+// UniqueReadCntJob persists the unique reader counts estimated in Redis.
+// NOTE: no distributed lock, each counter to save is popped by only one
+// instance.
+type UniqueReadCntJob struct {
+	l         logger.Logger
+	svc       service.InteractiveService
+	biz       string
+	batchSize int
+	timeout   time.Duration
+}
+
+// Name implements job.Job.
+func (u *UniqueReadCntJob) Name() string {
+	return "unique_read_cnt"
+}
+
+// Run implements job.Job.
+func (u *UniqueReadCntJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), u.timeout)
+	defer cancel()
+	total := 0
+	for {
+		n, err := u.svc.SnapshotUniqueReadCnts(ctx, u.biz, u.batchSize)
+		total += n
+		if err != nil {
+			return err
+		}
+		if n < u.batchSize || ctx.Err() != nil {
+			break
+		}
+	}
+	u.l.Debug("unique read counts saved", logger.String("biz", u.biz), logger.Int("cnt", total))
+	return nil
+}
+
+func NewUniqueReadCntJob(
+	svc service.InteractiveService,
+	biz string,
+	batchSize int,
+	timeout time.Duration,
+	l logger.Logger,
+) *UniqueReadCntJob {
+	return &UniqueReadCntJob{
+		l:         l,
+		svc:       svc,
+		biz:       biz,
+		batchSize: batchSize,
+		timeout:   timeout,
+	}
+}
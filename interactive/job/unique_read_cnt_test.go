@@ -0,0 +1,68 @@
+package job
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/service"
+	intrsvcmocks "github.com/chenmuyao/go-bootcamp/interactive/service/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUniqueReadCntJob_Run(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.InteractiveService
+
+		wantErr error
+	}{
+		{
+			name: "until a partial batch",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := intrsvcmocks.NewMockInteractiveService(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().SnapshotUniqueReadCnts(gomock.Any(), "article", 2).Return(2, nil),
+					svc.EXPECT().SnapshotUniqueReadCnts(gomock.Any(), "article", 2).Return(2, nil),
+					svc.EXPECT().SnapshotUniqueReadCnts(gomock.Any(), "article", 2).Return(1, nil),
+				)
+				return svc
+			},
+		},
+		{
+			name: "nothing to save",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := intrsvcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().SnapshotUniqueReadCnts(gomock.Any(), "article", 2).Return(0, nil)
+				return svc
+			},
+		},
+		{
+			name: "error",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := intrsvcmocks.NewMockInteractiveService(ctrl)
+				gomock.InOrder(
+					svc.EXPECT().SnapshotUniqueReadCnts(gomock.Any(), "article", 2).Return(2, nil),
+					svc.EXPECT().
+						SnapshotUniqueReadCnts(gomock.Any(), "article", 2).
+						Return(0, errors.New("mock error")),
+				)
+				return svc
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			job := NewUniqueReadCntJob(tc.mock(ctrl), "article", 2, time.Second, logger.NewNopLogger())
+			err := job.Run()
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
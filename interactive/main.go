@@ -38,6 +38,11 @@ func main() {
 	config.InitConfig("config/dev.yaml")
 
 	app := InitApp()
+	app.cntFlush.Start()
+	app.cron.Start()
+	defer func() {
+		<-app.cron.Stop().Done()
+	}()
 
 	for _, c := range app.consumers {
 		err := c.Start()
@@ -48,11 +53,13 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	intrRepo := InitInteractiveRepo()
-	err := intrRepo.BatchSetTopLike(ctx, "article", 1000)
-	if err != nil {
-		panic(err)
+	for _, biz := range app.bizs.List() {
+		err := intrRepo.BatchSetTopLike(ctx, biz.Name, 1000)
+		if err != nil {
+			panic(err)
+		}
 	}
-	err = app.server.Serve()
+	err := app.server.Serve()
 	if err != nil {
 		panic(err)
 	}
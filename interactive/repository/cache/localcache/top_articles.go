@@ -15,8 +15,9 @@ type TopArticlesLocalCache struct {
 // GetTopLikedArticles implements cache.TopArticlesCache.
 func (t *TopArticlesLocalCache) GetTopLikedArticles(
 	ctx context.Context,
+	biz string,
 ) ([]int64, error) {
-	res := t.cache.Get(t.key())
+	res := t.cache.Get(t.key(biz))
 	if res == nil {
 		return nil, errors.New("no data")
 	}
@@ -26,14 +27,15 @@ func (t *TopArticlesLocalCache) GetTopLikedArticles(
 // SetTopLikedArticles implements cache.TopArticlesCache.
 func (t *TopArticlesLocalCache) SetTopLikedArticles(
 	ctx context.Context,
+	biz string,
 	articles []int64,
 ) error {
-	_ = t.cache.Set(t.key(), articles, ttlcache.DefaultTTL)
+	_ = t.cache.Set(t.key(biz), articles, ttlcache.DefaultTTL)
 	return nil
 }
 
-func (t *TopArticlesLocalCache) key() string {
-	return "top_liked_articles"
+func (t *TopArticlesLocalCache) key(biz string) string {
+	return "top_liked_" + biz
 }
 
 func NewTopArticlesLocalCache(
@@ -41,6 +41,35 @@ func (m *MockInteractiveCache) EXPECT() *MockInteractiveCacheMockRecorder {
 	return m.recorder
 }
 
+// AddReaders mocks base method.
+func (m *MockInteractiveCache) AddReaders(ctx context.Context, bizs []string, bizIDs, uids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaders", ctx, bizs, bizIDs, uids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReaders indicates an expected call of AddReaders.
+func (mr *MockInteractiveCacheMockRecorder) AddReaders(ctx, bizs, bizIDs, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaders", reflect.TypeOf((*MockInteractiveCache)(nil).AddReaders), ctx, bizs, bizIDs, uids)
+}
+
+// BatchGetIfPresent mocks base method.
+func (m *MockInteractiveCache) BatchGetIfPresent(ctx context.Context, biz string, bizIDs []int64) (map[int64]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetIfPresent", ctx, biz, bizIDs)
+	ret0, _ := ret[0].(map[int64]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetIfPresent indicates an expected call of BatchGetIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) BatchGetIfPresent(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).BatchGetIfPresent), ctx, biz, bizIDs)
+}
+
 // BatchSet mocks base method.
 func (m *MockInteractiveCache) BatchSet(ctx context.Context, biz string, bizIDs []int64, intr []domain.Interactive) error {
 	m.ctrl.T.Helper()
@@ -97,6 +126,34 @@ func (mr *MockInteractiveCacheMockRecorder) DecrLikeRank(ctx, biz, bizID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLikeRank", reflect.TypeOf((*MockInteractiveCache)(nil).DecrLikeRank), ctx, biz, bizID)
 }
 
+// Del mocks base method.
+func (m *MockInteractiveCache) Del(ctx context.Context, biz string, bizIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, biz, bizIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockInteractiveCacheMockRecorder) Del(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockInteractiveCache)(nil).Del), ctx, biz, bizIDs)
+}
+
+// DelCnts mocks base method.
+func (m *MockInteractiveCache) DelCnts(ctx context.Context, biz string, bizIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelCnts", ctx, biz, bizIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelCnts indicates an expected call of DelCnts.
+func (mr *MockInteractiveCacheMockRecorder) DelCnts(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelCnts", reflect.TypeOf((*MockInteractiveCache)(nil).DelCnts), ctx, biz, bizIDs)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveCache) Get(ctx context.Context, biz string, bizID int64) (domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -112,6 +169,21 @@ func (mr *MockInteractiveCacheMockRecorder) Get(ctx, biz, bizID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveCache)(nil).Get), ctx, biz, bizID)
 }
 
+// GetLikeRanks mocks base method.
+func (m *MockInteractiveCache) GetLikeRanks(ctx context.Context, biz string, bizIDs []int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikeRanks", ctx, biz, bizIDs)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikeRanks indicates an expected call of GetLikeRanks.
+func (mr *MockInteractiveCacheMockRecorder) GetLikeRanks(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeRanks", reflect.TypeOf((*MockInteractiveCache)(nil).GetLikeRanks), ctx, biz, bizIDs)
+}
+
 // GetTopLikedIDs mocks base method.
 func (m *MockInteractiveCache) GetTopLikedIDs(ctx context.Context, biz string, limit int64) ([]int64, error) {
 	m.ctrl.T.Helper()
@@ -183,6 +255,20 @@ func (mr *MockInteractiveCacheMockRecorder) IncrReadCntIfPresent(ctx, biz, bizID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrReadCntIfPresent), ctx, biz, bizID)
 }
 
+// IncrTipCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrTipCntIfPresent(ctx context.Context, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrTipCntIfPresent", ctx, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrTipCntIfPresent indicates an expected call of IncrTipCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrTipCntIfPresent(ctx, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrTipCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrTipCntIfPresent), ctx, biz, bizID)
+}
+
 // MustBatchGet mocks base method.
 func (m *MockInteractiveCache) MustBatchGet(ctx context.Context, biz string, bizIDs []int64) ([]domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +284,21 @@ func (mr *MockInteractiveCacheMockRecorder) MustBatchGet(ctx, biz, bizIDs any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustBatchGet", reflect.TypeOf((*MockInteractiveCache)(nil).MustBatchGet), ctx, biz, bizIDs)
 }
 
+// PopUniqueReadCnts mocks base method.
+func (m *MockInteractiveCache) PopUniqueReadCnts(ctx context.Context, biz string, limit int) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopUniqueReadCnts", ctx, biz, limit)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopUniqueReadCnts indicates an expected call of PopUniqueReadCnts.
+func (mr *MockInteractiveCacheMockRecorder) PopUniqueReadCnts(ctx, biz, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopUniqueReadCnts", reflect.TypeOf((*MockInteractiveCache)(nil).PopUniqueReadCnts), ctx, biz, limit)
+}
+
 // Set mocks base method.
 func (m *MockInteractiveCache) Set(ctx context.Context, biz string, bizID int64, intr domain.Interactive) error {
 	m.ctrl.T.Helper()
@@ -226,6 +327,89 @@ func (mr *MockInteractiveCacheMockRecorder) SetLikeToZSET(ctx, biz, bizId, likeC
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLikeToZSET", reflect.TypeOf((*MockInteractiveCache)(nil).SetLikeToZSET), ctx, biz, bizId, likeCnt)
 }
 
+// MockCntBufferCache is a mock of CntBufferCache interface.
+type MockCntBufferCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCntBufferCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockCntBufferCacheMockRecorder is the mock recorder for MockCntBufferCache.
+type MockCntBufferCacheMockRecorder struct {
+	mock *MockCntBufferCache
+}
+
+// NewMockCntBufferCache creates a new mock instance.
+func NewMockCntBufferCache(ctrl *gomock.Controller) *MockCntBufferCache {
+	mock := &MockCntBufferCache{ctrl: ctrl}
+	mock.recorder = &MockCntBufferCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCntBufferCache) EXPECT() *MockCntBufferCacheMockRecorder {
+	return m.recorder
+}
+
+// Ack mocks base method.
+func (m *MockCntBufferCache) Ack(ctx context.Context, batch domain.CntBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ack", ctx, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ack indicates an expected call of Ack.
+func (mr *MockCntBufferCacheMockRecorder) Ack(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ack", reflect.TypeOf((*MockCntBufferCache)(nil).Ack), ctx, batch)
+}
+
+// Add mocks base method.
+func (m *MockCntBufferCache) Add(ctx context.Context, deltas []domain.CntDelta) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, deltas)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCntBufferCacheMockRecorder) Add(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCntBufferCache)(nil).Add), ctx, deltas)
+}
+
+// Pending mocks base method.
+func (m *MockCntBufferCache) Pending(ctx context.Context, biz string, bizIDs []int64) ([]domain.CntDelta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", ctx, biz, bizIDs)
+	ret0, _ := ret[0].([]domain.CntDelta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockCntBufferCacheMockRecorder) Pending(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockCntBufferCache)(nil).Pending), ctx, biz, bizIDs)
+}
+
+// Take mocks base method.
+func (m *MockCntBufferCache) Take(ctx context.Context, limit int) (domain.CntBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, limit)
+	ret0, _ := ret[0].(domain.CntBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockCntBufferCacheMockRecorder) Take(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockCntBufferCache)(nil).Take), ctx, limit)
+}
+
 // MockTopArticlesCache is a mock of TopArticlesCache interface.
 type MockTopArticlesCache struct {
 	ctrl     *gomock.Controller
@@ -251,30 +435,30 @@ func (m *MockTopArticlesCache) EXPECT() *MockTopArticlesCacheMockRecorder {
 }
 
 // GetTopLikedArticles mocks base method.
-func (m *MockTopArticlesCache) GetTopLikedArticles(ctx context.Context) ([]int64, error) {
+func (m *MockTopArticlesCache) GetTopLikedArticles(ctx context.Context, biz string) ([]int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTopLikedArticles", ctx)
+	ret := m.ctrl.Call(m, "GetTopLikedArticles", ctx, biz)
 	ret0, _ := ret[0].([]int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTopLikedArticles indicates an expected call of GetTopLikedArticles.
-func (mr *MockTopArticlesCacheMockRecorder) GetTopLikedArticles(ctx any) *gomock.Call {
+func (mr *MockTopArticlesCacheMockRecorder) GetTopLikedArticles(ctx, biz any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopLikedArticles", reflect.TypeOf((*MockTopArticlesCache)(nil).GetTopLikedArticles), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopLikedArticles", reflect.TypeOf((*MockTopArticlesCache)(nil).GetTopLikedArticles), ctx, biz)
 }
 
 // SetTopLikedArticles mocks base method.
-func (m *MockTopArticlesCache) SetTopLikedArticles(ctx context.Context, articles []int64) error {
+func (m *MockTopArticlesCache) SetTopLikedArticles(ctx context.Context, biz string, articles []int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetTopLikedArticles", ctx, articles)
+	ret := m.ctrl.Call(m, "SetTopLikedArticles", ctx, biz, articles)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetTopLikedArticles indicates an expected call of SetTopLikedArticles.
-func (mr *MockTopArticlesCacheMockRecorder) SetTopLikedArticles(ctx, articles any) *gomock.Call {
+func (mr *MockTopArticlesCacheMockRecorder) SetTopLikedArticles(ctx, biz, articles any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopLikedArticles", reflect.TypeOf((*MockTopArticlesCache)(nil).SetTopLikedArticles), ctx, articles)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopLikedArticles", reflect.TypeOf((*MockTopArticlesCache)(nil).SetTopLikedArticles), ctx, biz, articles)
 }
@@ -0,0 +1,215 @@
+package rediscache
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "embed"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	cntBufferKey         = "interactive:cnt_buffer"
+	cntBufferFlushingKey = "interactive:cnt_buffer:flushing"
+	cntBufferFlushSeqKey = "interactive:cnt_buffer:flush_seq"
+	// unix milliseconds of the first delta of a buffer
+	fieldSince = "since"
+	// the flush of the buffer being flushed, the number and the size of its
+	// current batch
+	fieldFlushID   = "flush_id"
+	fieldBatchNo   = "batch_no"
+	fieldBatchSize = "batch_size"
+)
+
+// the buffered counters
+var cntFields = []string{fieldReadCnt, fieldLikeCnt, fieldCollectCnt, fieldTipCnt}
+
+//go:embed lua/take_cnt_buffer.lua
+var luaTakeCntBuffer string
+
+//go:embed lua/ack_cnt_buffer.lua
+var luaAckCntBuffer string
+
+// CntBufferRedisCache keeps the deltas in a hash, a field per counter named
+// "<counter>:<bizID>:<biz>". The buffer being flushed also keeps its flush
+// id, and the number and the size of the batch taken.
+type CntBufferRedisCache struct {
+	client redis.Cmdable
+}
+
+// Add implements cache.CntBufferCache.
+func (c *CntBufferRedisCache) Add(ctx context.Context, deltas []domain.CntDelta) (int64, error) {
+	pipe := c.client.TxPipeline()
+	for _, d := range deltas {
+		for field, delta := range c.cnts(d) {
+			if delta != 0 {
+				pipe.HIncrBy(ctx, cntBufferKey, c.field(field, d), delta)
+			}
+		}
+	}
+	pipe.HSetNX(ctx, cntBufferKey, fieldSince, time.Now().UnixMilli())
+	hlen := pipe.HLen(ctx, cntBufferKey)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hlen.Val() - 1, nil
+}
+
+// Take implements cache.CntBufferCache.
+func (c *CntBufferRedisCache) Take(ctx context.Context, limit int) (domain.CntBatch, error) {
+	vals, err := c.client.Eval(
+		ctx,
+		luaTakeCntBuffer,
+		[]string{cntBufferKey, cntBufferFlushingKey, cntBufferFlushSeqKey},
+		limit,
+	).StringSlice()
+	if err != nil {
+		return domain.CntBatch{}, err
+	}
+	var batch domain.CntBatch
+	deltas := make(map[domain.CntDelta]*domain.CntDelta)
+	for i := 0; i+1 < len(vals); i += 2 {
+		val, err := strconv.ParseInt(vals[i+1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch vals[i] {
+		case fieldSince:
+			batch.Since = time.UnixMilli(val)
+			continue
+		case fieldFlushID:
+			batch.FlushID = val
+			continue
+		case fieldBatchNo:
+			batch.No = val
+			continue
+		case fieldBatchSize:
+			limit = int(val)
+			continue
+		}
+		parts := strings.SplitN(vals[i], ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		bizID, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		res := domain.CntDelta{Biz: parts[2], BizID: bizID}
+		d, ok := deltas[res]
+		if !ok {
+			d = &res
+			deltas[res] = d
+		}
+		c.addDelta(d, parts[0], val)
+	}
+	res := make([]domain.CntDelta, 0, len(deltas))
+	for _, d := range deltas {
+		res = append(res, *d)
+	}
+	slices.SortFunc(res, func(a, b domain.CntDelta) int {
+		return cmp.Or(strings.Compare(a.Biz, b.Biz), cmp.Compare(a.BizID, b.BizID))
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	batch.Deltas = res
+	return batch, nil
+}
+
+// Ack implements cache.CntBufferCache.
+func (c *CntBufferRedisCache) Ack(ctx context.Context, batch domain.CntBatch) error {
+	if len(batch.Deltas) == 0 {
+		return nil
+	}
+	fields := make([]any, 0, len(cntFields)*len(batch.Deltas))
+	for _, d := range batch.Deltas {
+		for _, field := range cntFields {
+			fields = append(fields, c.field(field, d))
+		}
+	}
+	return c.client.Eval(ctx, luaAckCntBuffer, []string{cntBufferFlushingKey}, fields...).Err()
+}
+
+// Pending implements cache.CntBufferCache.
+func (c *CntBufferRedisCache) Pending(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) ([]domain.CntDelta, error) {
+	res := make([]domain.CntDelta, len(bizIDs))
+	if len(bizIDs) == 0 {
+		return res, nil
+	}
+	fields := make([]string, 0, len(cntFields)*len(bizIDs))
+	for i, bizID := range bizIDs {
+		res[i] = domain.CntDelta{Biz: biz, BizID: bizID}
+		for _, field := range cntFields {
+			fields = append(fields, c.field(field, res[i]))
+		}
+	}
+	pipe := c.client.Pipeline()
+	cmds := []*redis.SliceCmd{
+		pipe.HMGet(ctx, cntBufferKey, fields...),
+		pipe.HMGet(ctx, cntBufferFlushingKey, fields...),
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, cmd := range cmds {
+		for i, val := range cmd.Val() {
+			str, ok := val.(string)
+			if !ok {
+				continue
+			}
+			delta, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				continue
+			}
+			c.addDelta(&res[i/len(cntFields)], cntFields[i%len(cntFields)], delta)
+		}
+	}
+	return res, nil
+}
+
+func (c *CntBufferRedisCache) cnts(d domain.CntDelta) map[string]int64 {
+	return map[string]int64{
+		fieldReadCnt:    d.ReadCnt,
+		fieldLikeCnt:    d.LikeCnt,
+		fieldCollectCnt: d.CollectCnt,
+		fieldTipCnt:     d.TipCnt,
+	}
+}
+
+func (c *CntBufferRedisCache) addDelta(d *domain.CntDelta, field string, delta int64) {
+	switch field {
+	case fieldReadCnt:
+		d.ReadCnt += delta
+	case fieldLikeCnt:
+		d.LikeCnt += delta
+	case fieldCollectCnt:
+		d.CollectCnt += delta
+	case fieldTipCnt:
+		d.TipCnt += delta
+	}
+}
+
+func (c *CntBufferRedisCache) field(cnt string, d domain.CntDelta) string {
+	return fmt.Sprintf("%s:%d:%s", cnt, d.BizID, d.Biz)
+}
+
+func NewCntBufferRedisCache(client redis.Cmdable) cache.CntBufferCache {
+	return &CntBufferRedisCache{
+		client: client,
+	}
+}
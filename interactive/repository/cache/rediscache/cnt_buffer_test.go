@@ -0,0 +1,208 @@
+package rediscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	redismock "github.com/chenmuyao/go-bootcamp/internal/repository/cache/rediscache/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCntBufferRedisCache_Take(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) redis.Cmdable
+
+		limit   int
+		wantRes domain.CntBatch
+		wantErr error
+	}{
+		{
+			name: "aggregated per resource",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				res := redismock.NewMockCmdable(ctrl)
+				cmd := redis.NewCmd(context.Background())
+				cmd.SetVal([]any{
+					"read_cnt:2:article", "3",
+					"since", "1700000000000",
+					"flush_id", "7",
+					"batch_no", "2",
+					"batch_size", "10",
+					"tip_cnt:1:article", "1",
+					"read_cnt:1:article", "10",
+					"read_cnt:1:video", "4",
+					"bad", "1",
+				})
+				res.EXPECT().
+					Eval(gomock.Any(), luaTakeCntBuffer,
+						[]string{cntBufferKey, cntBufferFlushingKey, cntBufferFlushSeqKey}, 10).
+					Return(cmd)
+				return res
+			},
+			limit: 10,
+			wantRes: domain.CntBatch{
+				FlushID: 7,
+				No:      2,
+				Deltas: []domain.CntDelta{
+					{Biz: "article", BizID: 1, ReadCnt: 10, TipCnt: 1},
+					{Biz: "article", BizID: 2, ReadCnt: 3},
+					{Biz: "video", BizID: 1, ReadCnt: 4},
+				},
+				Since: time.UnixMilli(1700000000000),
+			},
+		},
+		{
+			name: "limited",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				res := redismock.NewMockCmdable(ctrl)
+				cmd := redis.NewCmd(context.Background())
+				cmd.SetVal([]any{
+					"read_cnt:2:article", "3",
+					"read_cnt:1:article", "10",
+				})
+				res.EXPECT().
+					Eval(gomock.Any(), luaTakeCntBuffer,
+						[]string{cntBufferKey, cntBufferFlushingKey, cntBufferFlushSeqKey}, 1).
+					Return(cmd)
+				return res
+			},
+			limit: 1,
+			wantRes: domain.CntBatch{
+				Deltas: []domain.CntDelta{{Biz: "article", BizID: 1, ReadCnt: 10}},
+			},
+		},
+		{
+			name: "taken again with its size",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				res := redismock.NewMockCmdable(ctrl)
+				cmd := redis.NewCmd(context.Background())
+				cmd.SetVal([]any{
+					"flush_id", "7",
+					"batch_no", "1",
+					"batch_size", "1",
+					"read_cnt:2:article", "3",
+					"read_cnt:1:article", "10",
+				})
+				res.EXPECT().
+					Eval(gomock.Any(), luaTakeCntBuffer,
+						[]string{cntBufferKey, cntBufferFlushingKey, cntBufferFlushSeqKey}, 10).
+					Return(cmd)
+				return res
+			},
+			limit: 10,
+			wantRes: domain.CntBatch{
+				FlushID: 7,
+				No:      1,
+				Deltas:  []domain.CntDelta{{Biz: "article", BizID: 1, ReadCnt: 10}},
+			},
+		},
+		{
+			name: "empty",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				res := redismock.NewMockCmdable(ctrl)
+				cmd := redis.NewCmd(context.Background())
+				cmd.SetVal([]any{})
+				res.EXPECT().
+					Eval(gomock.Any(), luaTakeCntBuffer,
+						[]string{cntBufferKey, cntBufferFlushingKey, cntBufferFlushSeqKey}, 10).
+					Return(cmd)
+				return res
+			},
+			limit:   10,
+			wantRes: domain.CntBatch{Deltas: []domain.CntDelta{}},
+		},
+		{
+			name: "redis error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				res := redismock.NewMockCmdable(ctrl)
+				cmd := redis.NewCmd(context.Background())
+				cmd.SetErr(redis.ErrClosed)
+				res.EXPECT().
+					Eval(gomock.Any(), luaTakeCntBuffer,
+						[]string{cntBufferKey, cntBufferFlushingKey, cntBufferFlushSeqKey}, 10).
+					Return(cmd)
+				return res
+			},
+			limit:   10,
+			wantErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := NewCntBufferRedisCache(tc.mock(ctrl))
+			res, err := c.Take(context.Background(), tc.limit)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestCntBufferRedisCache_Ack(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) redis.Cmdable
+
+		batch   domain.CntBatch
+		wantErr error
+	}{
+		{
+			name: "acknowledged",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				res := redismock.NewMockCmdable(ctrl)
+				cmd := redis.NewCmd(context.Background())
+				cmd.SetVal(int64(0))
+				res.EXPECT().
+					Eval(gomock.Any(), luaAckCntBuffer, []string{cntBufferFlushingKey},
+						"read_cnt:1:article", "like_cnt:1:article",
+						"collect_cnt:1:article", "tip_cnt:1:article").
+					Return(cmd)
+				return res
+			},
+			batch: domain.CntBatch{
+				Deltas: []domain.CntDelta{{Biz: "article", BizID: 1, ReadCnt: 10}},
+			},
+		},
+		{
+			name: "nothing to acknowledge",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				return redismock.NewMockCmdable(ctrl)
+			},
+		},
+		{
+			name: "redis error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				res := redismock.NewMockCmdable(ctrl)
+				cmd := redis.NewCmd(context.Background())
+				cmd.SetErr(redis.ErrClosed)
+				res.EXPECT().
+					Eval(gomock.Any(), luaAckCntBuffer, []string{cntBufferFlushingKey},
+						gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(cmd)
+				return res
+			},
+			batch: domain.CntBatch{
+				Deltas: []domain.CntDelta{{Biz: "article", BizID: 1, ReadCnt: 10}},
+			},
+			wantErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := NewCntBufferRedisCache(tc.mock(ctrl))
+			err := c.Ack(context.Background(), tc.batch)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -19,6 +19,8 @@ const (
 	fieldReadCnt    = "read_cnt"
 	fieldLikeCnt    = "like_cnt"
 	fieldCollectCnt = "collect_cnt"
+	fieldTipCnt     = "tip_cnt"
+	fieldUniqueRead = "unique_read_cnt"
 	intrExpiryTime  = time.Minute * 15
 )
 
@@ -52,6 +54,63 @@ func (i *InteractiveRedisCache) DecrLikeRank(
 		Err()
 }
 
+// Del implements cache.InteractiveCache.
+func (i *InteractiveRedisCache) Del(ctx context.Context, biz string, bizIDs []int64) error {
+	if len(bizIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, 2*len(bizIDs))
+	members := make([]any, 0, len(bizIDs))
+	for _, bizID := range bizIDs {
+		keys = append(keys, i.Key(biz, bizID), i.readersKey(biz, bizID))
+		members = append(members, strconv.FormatInt(bizID, 10))
+	}
+	pipe := i.client.TxPipeline()
+	pipe.Del(ctx, keys...)
+	pipe.ZRem(ctx, i.topLikedKey(biz), members...)
+	pipe.SRem(ctx, i.readersUpdatedKey(biz), members...)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetLikeRanks implements cache.InteractiveCache.
+func (i *InteractiveRedisCache) GetLikeRanks(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) (map[int64]int64, error) {
+	res := make(map[int64]int64, len(bizIDs))
+	if len(bizIDs) == 0 {
+		return res, nil
+	}
+	pipe := i.client.Pipeline()
+	cmds := make([]*redis.FloatCmd, 0, len(bizIDs))
+	for _, bizID := range bizIDs {
+		cmds = append(cmds, pipe.ZScore(ctx, i.topLikedKey(biz), strconv.FormatInt(bizID, 10)))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	for idx, cmd := range cmds {
+		if cmd.Err() == nil {
+			res[bizIDs[idx]] = int64(cmd.Val())
+		}
+	}
+	return res, nil
+}
+
+// DelCnts implements cache.InteractiveCache.
+func (i *InteractiveRedisCache) DelCnts(ctx context.Context, biz string, bizIDs []int64) error {
+	if len(bizIDs) == 0 {
+		return nil
+	}
+	keys := gslice.Map(bizIDs, func(id int, src int64) string {
+		return i.Key(biz, src)
+	})
+	return i.client.Del(ctx, keys...).Err()
+}
+
 // GetTopLikedIDs implements cache.InteractiveCache.
 func (i *InteractiveRedisCache) GetTopLikedIDs(
 	ctx context.Context,
@@ -87,6 +146,70 @@ func (i *InteractiveRedisCache) topLikedKey(biz string) string {
 	return fmt.Sprintf("top_liked_%s", biz)
 }
 
+// AddReaders implements cache.InteractiveCache.
+func (i *InteractiveRedisCache) AddReaders(
+	ctx context.Context,
+	bizs []string,
+	bizIDs []int64,
+	uids []int64,
+) error {
+	if len(bizIDs) == 0 {
+		return nil
+	}
+	pipe := i.client.Pipeline()
+	for idx, bizID := range bizIDs {
+		pipe.PFAdd(ctx, i.readersKey(bizs[idx], bizID), uids[idx])
+		pipe.SAdd(ctx, i.readersUpdatedKey(bizs[idx]), bizID)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// PopUniqueReadCnts implements cache.InteractiveCache.
+func (i *InteractiveRedisCache) PopUniqueReadCnts(
+	ctx context.Context,
+	biz string,
+	limit int,
+) (map[int64]int64, error) {
+	members, err := i.client.SPopN(ctx, i.readersUpdatedKey(biz), int64(limit)).Result()
+	if err != nil {
+		return nil, err
+	}
+	bizIDs := make([]int64, 0, len(members))
+	pipe := i.client.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, len(members))
+	for _, member := range members {
+		bizID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		bizIDs = append(bizIDs, bizID)
+		cmds = append(cmds, pipe.PFCount(ctx, i.readersKey(biz, bizID)))
+	}
+	res := make(map[int64]int64, len(bizIDs))
+	if len(bizIDs) == 0 {
+		return res, nil
+	}
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for idx, cmd := range cmds {
+		res[bizIDs[idx]] = cmd.Val()
+	}
+	return res, nil
+}
+
+func (i *InteractiveRedisCache) readersKey(biz string, bizID int64) string {
+	return fmt.Sprintf("interactive:readers:%s:%d", biz, bizID)
+}
+
+// readersUpdatedKey is the set of the resources having new readers since the
+// last snapshot.
+func (i *InteractiveRedisCache) readersUpdatedKey(biz string) string {
+	return fmt.Sprintf("interactive:readers_updated:%s", biz)
+}
+
 // BatchGet implements cache.InteractiveCache.
 func (i *InteractiveRedisCache) MustBatchGet(
 	ctx context.Context,
@@ -104,6 +227,33 @@ func (i *InteractiveRedisCache) MustBatchGet(
 	return res, nil
 }
 
+// BatchGetIfPresent implements cache.InteractiveCache.
+func (i *InteractiveRedisCache) BatchGetIfPresent(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) (map[int64]domain.Interactive, error) {
+	res := make(map[int64]domain.Interactive, len(bizIDs))
+	if len(bizIDs) == 0 {
+		return res, nil
+	}
+	pipe := i.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, 0, len(bizIDs))
+	for _, bizID := range bizIDs {
+		cmds = append(cmds, pipe.HGetAll(ctx, i.Key(biz, bizID)))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for idx, cmd := range cmds {
+		if len(cmd.Val()) > 0 {
+			res[bizIDs[idx]] = i.toDomain(biz, bizIDs[idx], cmd.Val())
+		}
+	}
+	return res, nil
+}
+
 // BatchSet implements cache.InteractiveCache.
 func (i *InteractiveRedisCache) BatchSet(
 	ctx context.Context,
@@ -135,10 +285,14 @@ func (i *InteractiveRedisCache) Set(
 		key,
 		fieldCollectCnt,
 		intr.CollectCnt,
+		fieldTipCnt,
+		intr.TipCnt,
 		fieldReadCnt,
 		intr.ReadCnt,
 		fieldLikeCnt,
 		intr.LikeCnt,
+		fieldUniqueRead,
+		intr.UniqueReadCnt,
 	).Err()
 	if err != nil {
 		return err
@@ -162,13 +316,32 @@ func (i *InteractiveRedisCache) Get(
 		// No data
 		return domain.Interactive{}, errors.New("no data")
 	}
+	return i.toDomain(biz, bizID, res), nil
+}
+
+func (i *InteractiveRedisCache) toDomain(
+	biz string,
+	bizID int64,
+	res map[string]string,
+) domain.Interactive {
 	var intr domain.Interactive
 	intr.CollectCnt, _ = strconv.ParseInt(res[fieldCollectCnt], 10, 64)
+	intr.TipCnt, _ = strconv.ParseInt(res[fieldTipCnt], 10, 64)
 	intr.LikeCnt, _ = strconv.ParseInt(res[fieldLikeCnt], 10, 64)
 	intr.ReadCnt, _ = strconv.ParseInt(res[fieldReadCnt], 10, 64)
+	intr.UniqueReadCnt, _ = strconv.ParseInt(res[fieldUniqueRead], 10, 64)
 	intr.Biz = biz
 	intr.BizID = bizID
-	return intr, nil
+	return intr
+}
+
+// IncrTipCntIfPresent implements cache.InteractiveCache.
+func (i *InteractiveRedisCache) IncrTipCntIfPresent(
+	ctx context.Context,
+	biz string,
+	bizID int64,
+) error {
+	return i.client.Eval(ctx, luaIncrCnt, []string{i.Key(biz, bizID)}, fieldTipCnt, 1).Err()
 }
 
 // DecrCollectCntIfPresent implements cache.InteractiveCache.
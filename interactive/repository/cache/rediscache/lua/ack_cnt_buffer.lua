@@ -0,0 +1,16 @@
+-- buffer being flushed
+local key = KEYS[1]
+
+redis.call("HDEL", key, "batch_size", unpack(ARGV))
+redis.call("HINCRBY", key, "batch_no", 1)
+
+-- only the meta fields are left
+local meta = 0
+for _, field in ipairs({"since", "flush_id", "batch_no"}) do
+	meta = meta + redis.call("HEXISTS", key, field)
+end
+if redis.call("HLEN", key) <= meta then
+	redis.call("DEL", key)
+end
+
+return 0
@@ -8,7 +8,7 @@ local delta = tonumber(ARGV[2])
 
 local zsetKey = ARGV[3]
 
-local exist = redis.call("EXISTS", key, cntKey, delta)
+local exist = redis.call("EXISTS", key)
 
 if exist == 1 then
 	redis.call("HINCRBY", key, cntKey, delta)
@@ -0,0 +1,21 @@
+-- buffer of the new deltas
+local key = KEYS[1]
+-- buffer being flushed
+local flushingKey = KEYS[2]
+-- sequence of the flush ids
+local seqKey = KEYS[3]
+local limit = ARGV[1]
+
+-- NOTE: a buffer left by a crashed flush is taken again before the new one.
+if redis.call("EXISTS", flushingKey) == 0 then
+	if redis.call("EXISTS", key) == 0 then
+		return {}
+	end
+	redis.call("RENAME", key, flushingKey)
+	redis.call("HSET", flushingKey, "flush_id", redis.call("INCR", seqKey), "batch_no", 0)
+end
+
+-- a batch taken again keeps its size, so that it has the same deltas
+redis.call("HSETNX", flushingKey, "batch_size", limit)
+
+return redis.call("HGETALL", flushingKey)
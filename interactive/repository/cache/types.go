@@ -51,7 +51,17 @@ type InteractiveCache interface {
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizID int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizID int64) error
 	DecrCollectCntIfPresent(ctx context.Context, biz string, bizID int64) error
+	IncrTipCntIfPresent(ctx context.Context, biz string, bizID int64) error
 	Get(ctx context.Context, biz string, bizID int64) (domain.Interactive, error)
+	// BatchGetIfPresent returns the cached counters of the resources, the
+	// missing ones are skipped.
+	BatchGetIfPresent(
+		ctx context.Context,
+		biz string,
+		bizIDs []int64,
+	) (map[int64]domain.Interactive, error)
+	// DelCnts removes the cached counters of the resources.
+	DelCnts(ctx context.Context, biz string, bizIDs []int64) error
 	Set(ctx context.Context, biz string, bizID int64, intr domain.Interactive) error
 	MustBatchGet(ctx context.Context, biz string, bizIDs []int64) ([]domain.Interactive, error)
 	BatchSet(ctx context.Context, biz string, bizIDs []int64, intr []domain.Interactive) error
@@ -59,11 +69,39 @@ type InteractiveCache interface {
 	SetLikeToZSET(ctx context.Context, biz string, bizId int64, likeCnt int64) error
 	IncrLikeRank(ctx context.Context, biz string, bizID int64) error
 	DecrLikeRank(ctx context.Context, biz string, bizID int64) error
+	// GetLikeRanks returns the like counts of the resources in the rank.
+	GetLikeRanks(ctx context.Context, biz string, bizIDs []int64) (map[int64]int64, error)
+	// Del removes the counters, the readers and the like rank of the
+	// resources.
+	Del(ctx context.Context, biz string, bizIDs []int64) error
+	// AddReaders adds the readers to the HyperLogLog of the resources, which
+	// are marked to be snapshotted.
+	AddReaders(ctx context.Context, bizs []string, bizIDs []int64, uids []int64) error
+	// PopUniqueReadCnts takes at most limit resources marked since the last
+	// call, and returns their estimated unique reader counts.
+	PopUniqueReadCnts(ctx context.Context, biz string, limit int) (map[int64]int64, error)
+}
+
+// CntBufferCache buffers the counter deltas written behind to the DB. The
+// buffer is swapped to be flushed, and new deltas go to a new buffer.
+type CntBufferCache interface {
+	// Add accumulates the deltas, and returns the number of counters in the
+	// buffer.
+	Add(ctx context.Context, deltas []domain.CntDelta) (int64, error)
+	// Take returns a batch of at most limit resources of the buffer being
+	// flushed. The current buffer is swapped once the one being flushed is
+	// done. A batch not acked is taken again with the same id and deltas.
+	Take(ctx context.Context, limit int) (domain.CntBatch, error)
+	// Ack removes the batch saved to the DB from the buffer being flushed.
+	Ack(ctx context.Context, batch domain.CntBatch) error
+	// Pending returns the deltas of the resources not saved yet, in the order
+	// of bizIDs.
+	Pending(ctx context.Context, biz string, bizIDs []int64) ([]domain.CntDelta, error)
 }
 
 type TopArticlesCache interface {
-	SetTopLikedArticles(ctx context.Context, articles []int64) error
-	GetTopLikedArticles(ctx context.Context) ([]int64, error)
+	SetTopLikedArticles(ctx context.Context, biz string, articles []int64) error
+	GetTopLikedArticles(ctx context.Context, biz string) ([]int64, error)
 }
 
 // type RankingCache interface {
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/cache"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+//go:generate mockgen -source=./cnt_writer.go -package=intrrepomocks -destination=./mocks/cnt_writer.mock.go
+
+// CntWriter saves the changes of the counters to the DB.
+type CntWriter interface {
+	Incr(ctx context.Context, deltas []domain.CntDelta) error
+	// Pending returns the deltas of the resources not saved yet, in the order
+	// of bizIDs.
+	Pending(ctx context.Context, biz string, bizIDs []int64) ([]domain.CntDelta, error)
+}
+
+// WriteThroughCntWriter saves the deltas immediately.
+type WriteThroughCntWriter struct {
+	dao dao.InteractiveDAO
+}
+
+// Incr implements CntWriter.
+func (w *WriteThroughCntWriter) Incr(ctx context.Context, deltas []domain.CntDelta) error {
+	return w.dao.IncrCnts(ctx, deltas)
+}
+
+// Pending implements CntWriter.
+func (w *WriteThroughCntWriter) Pending(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) ([]domain.CntDelta, error) {
+	res := make([]domain.CntDelta, len(bizIDs))
+	for i, bizID := range bizIDs {
+		res[i] = domain.CntDelta{Biz: biz, BizID: bizID}
+	}
+	return res, nil
+}
+
+func NewWriteThroughCntWriter(dao dao.InteractiveDAO) CntWriter {
+	return &WriteThroughCntWriter{
+		dao: dao,
+	}
+}
+
+// WriteBehindCntWriter accumulates the deltas in Redis, and Flush saves them
+// aggregated per resource, so that a hot resource is updated once per flush
+// instead of once per event.
+//
+// The deltas survive a crash of the service: they are only removed from
+// Redis once saved. If Redis cannot be written, the deltas are saved
+// immediately. The id of each batch is saved with its deltas, so that a
+// batch taken again after a flush interrupted before the acknowledgement is
+// not applied twice.
+type WriteBehindCntWriter struct {
+	l         logger.Logger
+	dao       dao.InteractiveDAO
+	cache     cache.CntBufferCache
+	threshold int64
+	notify    chan struct{}
+}
+
+// Incr implements CntWriter.
+func (w *WriteBehindCntWriter) Incr(ctx context.Context, deltas []domain.CntDelta) error {
+	n, err := w.cache.Add(ctx, deltas)
+	if err != nil {
+		w.l.Warn("failed to buffer the counters, write through", logger.Error(err))
+		return w.dao.IncrCnts(ctx, deltas)
+	}
+	if n >= w.threshold {
+		select {
+		case w.notify <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Pending implements CntWriter.
+func (w *WriteBehindCntWriter) Pending(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) ([]domain.CntDelta, error) {
+	return w.cache.Pending(ctx, biz, bizIDs)
+}
+
+// Notify is signaled when the buffer reaches the size threshold.
+func (w *WriteBehindCntWriter) Notify() <-chan struct{} {
+	return w.notify
+}
+
+// Flush saves at most limit buffered resources, and returns how many are
+// saved and since when the oldest delta was waiting.
+func (w *WriteBehindCntWriter) Flush(ctx context.Context, limit int) (int, time.Time, error) {
+	batch, err := w.cache.Take(ctx, limit)
+	if err != nil || len(batch.Deltas) == 0 {
+		return 0, batch.Since, err
+	}
+	applied, err := w.dao.IncrCntBatch(ctx, batch)
+	if err != nil {
+		return 0, batch.Since, err
+	}
+	if !applied {
+		w.l.Info("counter batch already saved",
+			logger.Int64("flush_id", batch.FlushID),
+			logger.Int64("batch_no", batch.No))
+	}
+	return len(batch.Deltas), batch.Since, w.cache.Ack(ctx, batch)
+}
+
+// NewWriteBehindCntWriter signals Notify when threshold counters are
+// buffered.
+func NewWriteBehindCntWriter(
+	l logger.Logger,
+	dao dao.InteractiveDAO,
+	cache cache.CntBufferCache,
+	threshold int64,
+) *WriteBehindCntWriter {
+	return &WriteBehindCntWriter{
+		l:         l,
+		dao:       dao,
+		cache:     cache,
+		threshold: threshold,
+		notify:    make(chan struct{}, 1),
+	}
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/cache"
+	intrcachemocks "github.com/chenmuyao/go-bootcamp/interactive/repository/cache/mocks"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
+	intrdaomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/dao/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWriteBehindCntWriter_Incr(t *testing.T) {
+	deltas := []domain.CntDelta{{Biz: "article", BizID: 1, ReadCnt: 1}}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache)
+
+		wantNotify bool
+		wantErr    error
+	}{
+		{
+			name: "buffered",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache) {
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				c.EXPECT().Add(gomock.Any(), deltas).Return(int64(1), nil)
+				return nil, c
+			},
+		},
+		{
+			name: "threshold reached",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache) {
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				c.EXPECT().Add(gomock.Any(), deltas).Return(int64(2), nil)
+				return nil, c
+			},
+			wantNotify: true,
+		},
+		{
+			name: "redis down, write through",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				c.EXPECT().Add(gomock.Any(), deltas).Return(int64(0), errors.New("mock error"))
+				d.EXPECT().IncrCnts(gomock.Any(), deltas).Return(nil)
+				return d, c
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, c := tc.mock(ctrl)
+			w := NewWriteBehindCntWriter(logger.NewNopLogger(), d, c, 2)
+			err := w.Incr(context.Background(), deltas)
+			assert.Equal(t, tc.wantErr, err)
+			select {
+			case <-w.Notify():
+				assert.True(t, tc.wantNotify)
+			default:
+				assert.False(t, tc.wantNotify)
+			}
+		})
+	}
+}
+
+func TestWriteBehindCntWriter_Flush(t *testing.T) {
+	since := time.UnixMilli(1700000000000)
+	batch := domain.CntBatch{
+		FlushID: 3,
+		No:      1,
+		Deltas: []domain.CntDelta{
+			{Biz: "article", BizID: 1, ReadCnt: 10},
+			{Biz: "article", BizID: 2, TipCnt: 1},
+		},
+		Since: since,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache)
+
+		wantN     int
+		wantSince time.Time
+		wantErr   error
+	}{
+		{
+			name: "saved",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				gomock.InOrder(
+					c.EXPECT().Take(gomock.Any(), 10).Return(batch, nil),
+					d.EXPECT().IncrCntBatch(gomock.Any(), batch).Return(true, nil),
+					c.EXPECT().Ack(gomock.Any(), batch).Return(nil),
+				)
+				return d, c
+			},
+			wantN:     2,
+			wantSince: since,
+		},
+		{
+			name: "taken again after a crash, already saved",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				gomock.InOrder(
+					c.EXPECT().Take(gomock.Any(), 10).Return(batch, nil),
+					d.EXPECT().IncrCntBatch(gomock.Any(), batch).Return(false, nil),
+					c.EXPECT().Ack(gomock.Any(), batch).Return(nil),
+				)
+				return d, c
+			},
+			wantN:     2,
+			wantSince: since,
+		},
+		{
+			name: "nothing to save",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache) {
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				c.EXPECT().Take(gomock.Any(), 10).Return(domain.CntBatch{}, nil)
+				return nil, c
+			},
+		},
+		{
+			name: "db error, kept in the buffer",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				c.EXPECT().Take(gomock.Any(), 10).Return(batch, nil)
+				d.EXPECT().IncrCntBatch(gomock.Any(), batch).
+					Return(false, errors.New("mock error"))
+				return d, c
+			},
+			wantSince: since,
+			wantErr:   errors.New("mock error"),
+		},
+		{
+			name: "redis error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntBufferCache) {
+				c := intrcachemocks.NewMockCntBufferCache(ctrl)
+				c.EXPECT().Take(gomock.Any(), 10).
+					Return(domain.CntBatch{}, errors.New("mock error"))
+				return nil, c
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, c := tc.mock(ctrl)
+			w := NewWriteBehindCntWriter(logger.NewNopLogger(), d, c, 1000)
+			n, since, err := w.Flush(context.Background(), 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantN, n)
+			assert.Equal(t, tc.wantSince, since)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
+)
+
+var (
+	ErrCollectionNotFound   = errors.New("collection not found")
+	ErrDuplicatedCollection = dao.ErrDuplicatedCollection
+)
+
+//go:generate mockgen -source=./collection.go -package=intrrepomocks -destination=./mocks/collection.mock.go
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Get(ctx context.Context, id int64) (domain.Collection, error)
+	List(ctx context.Context, uid int64) ([]domain.Collection, error)
+	// Update and Delete fail with ErrCollectionNotFound if the collection
+	// doesn't belong to c.UID.
+	Update(ctx context.Context, c domain.Collection) error
+	// Delete moves the items of the collection to the default one.
+	Delete(ctx context.Context, uid int64, id int64) error
+}
+
+// NOTE: no cache, the collections are only read by their owner and the
+// visitors of the profiles.
+type GORMCollectionRepository struct {
+	dao dao.CollectionDAO
+}
+
+// Create implements CollectionRepository.
+func (g *GORMCollectionRepository) Create(ctx context.Context, c domain.Collection) (int64, error) {
+	return g.dao.Insert(ctx, g.toEntity(c))
+}
+
+// Get implements CollectionRepository.
+func (g *GORMCollectionRepository) Get(ctx context.Context, id int64) (domain.Collection, error) {
+	c, err := g.dao.GetByID(ctx, id)
+	if err == dao.ErrRecordNotFound {
+		return domain.Collection{}, ErrCollectionNotFound
+	}
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return g.toDomain(c), nil
+}
+
+// List implements CollectionRepository.
+func (g *GORMCollectionRepository) List(
+	ctx context.Context,
+	uid int64,
+) ([]domain.Collection, error) {
+	cs, err := g.dao.ListByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(cs, func(id int, src dao.Collection) domain.Collection {
+		return g.toDomain(src)
+	}), nil
+}
+
+// Update implements CollectionRepository.
+func (g *GORMCollectionRepository) Update(ctx context.Context, c domain.Collection) error {
+	err := g.dao.Update(ctx, g.toEntity(c))
+	if err == dao.ErrRecordNotFound {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+// Delete implements CollectionRepository.
+func (g *GORMCollectionRepository) Delete(ctx context.Context, uid int64, id int64) error {
+	err := g.dao.Delete(ctx, uid, id)
+	if err == dao.ErrRecordNotFound {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (g *GORMCollectionRepository) toDomain(c dao.Collection) domain.Collection {
+	return domain.Collection{
+		ID:     c.ID,
+		UID:    c.UID,
+		Name:   c.Name,
+		Public: c.Public,
+		Ctime:  time.UnixMilli(c.Ctime),
+		Utime:  time.UnixMilli(c.Utime),
+	}
+}
+
+func (g *GORMCollectionRepository) toEntity(c domain.Collection) dao.Collection {
+	return dao.Collection{
+		ID:     c.ID,
+		UID:    c.UID,
+		Name:   c.Name,
+		Public: c.Public,
+	}
+}
+
+func NewGORMCollectionRepository(dao dao.CollectionDAO) CollectionRepository {
+	return &GORMCollectionRepository{
+		dao: dao,
+	}
+}
@@ -0,0 +1,141 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+var ErrDuplicatedCollection = errors.New("collection name already exists")
+
+//go:generate mockgen -source=./collection.go -package=intrdaomocks -destination=./mocks/collection.mock.go
+type CollectionDAO interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	GetByID(ctx context.Context, id int64) (Collection, error)
+	ListByUID(ctx context.Context, uid int64) ([]Collection, error)
+	// Update changes the name and the visibility of a collection of uid.
+	Update(ctx context.Context, c Collection) error
+	// Delete removes a collection of uid, its items are moved to the default
+	// collection.
+	Delete(ctx context.Context, uid int64, id int64) error
+}
+
+type Collection struct {
+	ID     int64  `gorm:"primaryKey,autoIncrement"`
+	UID    int64  `gorm:"uniqueIndex:uid_name"`
+	Name   string `gorm:"uniqueIndex:uid_name,length:128"`
+	Public bool
+	Utime  int64
+	Ctime  int64
+}
+
+type GORMCollectionDAO struct {
+	db *gorm.DB
+}
+
+// Insert implements CollectionDAO.
+func (g *GORMCollectionDAO) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	if isDuplicateErr(err) {
+		return 0, ErrDuplicatedCollection
+	}
+	return c.ID, err
+}
+
+// GetByID implements CollectionDAO.
+func (g *GORMCollectionDAO) GetByID(ctx context.Context, id int64) (Collection, error) {
+	var res Collection
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+// ListByUID implements CollectionDAO.
+func (g *GORMCollectionDAO) ListByUID(ctx context.Context, uid int64) ([]Collection, error) {
+	var res []Collection
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).Order("id").Find(&res).Error
+	return res, err
+}
+
+// Update implements CollectionDAO.
+func (g *GORMCollectionDAO) Update(ctx context.Context, c Collection) error {
+	res := g.db.WithContext(ctx).
+		Model(&Collection{}).
+		Where("id = ? AND uid = ?", c.ID, c.UID).
+		Updates(map[string]any{
+			"name":   c.Name,
+			"public": c.Public,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if isDuplicateErr(res.Error) {
+		return ErrDuplicatedCollection
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	// NOTE: utime always changes, no row affected means not found.
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete implements CollectionDAO.
+func (g *GORMCollectionDAO) Delete(ctx context.Context, uid int64, id int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		// the items already in the default collection are only removed,
+		// the resources stay collected.
+		var dups []int64
+		err := tx.Table("user_collection_bizs AS a").
+			Joins("JOIN user_collection_bizs AS d "+
+				"ON d.uid = a.uid AND d.biz = a.biz AND d.biz_id = a.biz_id AND d.cid = 0").
+			Where("a.uid = ? AND a.cid = ?", uid, id).
+			Pluck("a.id", &dups).
+			Error
+		if err != nil {
+			return err
+		}
+		if len(dups) > 0 {
+			err = tx.Where("id IN ?", dups).Delete(&UserCollectionBiz{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? AND cid = ?", uid, id).
+			Updates(map[string]any{
+				"cid":   0,
+				"utime": time.Now().UnixMilli(),
+			}).Error
+	})
+}
+
+func NewGORMCollectionDAO(db *gorm.DB) CollectionDAO {
+	return &GORMCollectionDAO{
+		db: db,
+	}
+}
+
+func isDuplicateErr(err error) bool {
+	const duplicateErr = 1062
+	var me *mysql.MySQLError
+	return errors.As(err, &me) && me.Number == duplicateErr
+}
+
+func isDeadlockErr(err error) bool {
+	const deadlockErr = 1213
+	var me *mysql.MySQLError
+	return errors.As(err, &me) && me.Number == deadlockErr
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
 	"github.com/chenmuyao/go-bootcamp/pkg/logger"
 	"go.uber.org/atomic"
 )
@@ -112,8 +113,47 @@ func (d *DoubleWriteDAO) BatchIncrReadCnt(
 	panic("unimplemented")
 }
 
+// DeleteByBizIDs implements InteractiveDAO.
+func (d *DoubleWriteDAO) DeleteByBizIDs(ctx context.Context, biz string, bizIDs []int64) error {
+	pattern := d.pattern.Load()
+	switch pattern {
+	case PatternSrcOnly:
+		return d.src.DeleteByBizIDs(ctx, biz, bizIDs)
+	case PatternSrcFirst:
+		err := d.src.DeleteByBizIDs(ctx, biz, bizIDs)
+		if err != nil {
+			return err
+		}
+		err = d.dst.DeleteByBizIDs(ctx, biz, bizIDs)
+		if err != nil {
+			d.l.Error(
+				"failed to write to dst db",
+				logger.Error(err),
+				logger.String("biz", biz),
+			)
+		}
+		return nil
+	case PatternDstFirst:
+		err := d.dst.DeleteByBizIDs(ctx, biz, bizIDs)
+		if err == nil {
+			er := d.src.DeleteByBizIDs(ctx, biz, bizIDs)
+			if er != nil {
+				d.l.Error("failed to write to src db", logger.Error(er))
+			}
+		}
+		return err
+	case PatternDstOnly:
+		return d.dst.DeleteByBizIDs(ctx, biz, bizIDs)
+	default:
+		return errUnknownPattern
+	}
+}
+
 // DeleteCollectionBiz implements InteractiveDAO.
-func (d *DoubleWriteDAO) DeleteCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
+func (d *DoubleWriteDAO) DeleteCollectionBiz(
+	ctx context.Context,
+	cb UserCollectionBiz,
+) (bool, error) {
 	panic("unimplemented")
 }
 
@@ -123,7 +163,7 @@ func (d *DoubleWriteDAO) DeleteLikeInfo(
 	biz string,
 	bizID int64,
 	uid int64,
-) error {
+) (bool, error) {
 	panic("unimplemented")
 }
 
@@ -146,6 +186,26 @@ func (d *DoubleWriteDAO) GetByIDs(
 	panic("unimplemented")
 }
 
+// GetLikedBizIDs implements InteractiveDAO.
+func (d *DoubleWriteDAO) GetLikedBizIDs(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+	uid int64,
+) ([]int64, error) {
+	panic("unimplemented")
+}
+
+// GetCollectedBizIDs implements InteractiveDAO.
+func (d *DoubleWriteDAO) GetCollectedBizIDs(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+	uid int64,
+) ([]int64, error) {
+	panic("unimplemented")
+}
+
 // GetCollectInfo implements InteractiveDAO.
 func (d *DoubleWriteDAO) GetCollectInfo(
 	ctx context.Context,
@@ -167,7 +227,43 @@ func (d *DoubleWriteDAO) GetLikeInfo(
 }
 
 // InsertCollectionBiz implements InteractiveDAO.
-func (d *DoubleWriteDAO) InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
+func (d *DoubleWriteDAO) InsertCollectionBiz(
+	ctx context.Context,
+	cb UserCollectionBiz,
+) (bool, error) {
+	panic("unimplemented")
+}
+
+// MoveCollectionBiz implements InteractiveDAO.
+func (d *DoubleWriteDAO) MoveCollectionBiz(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	bizID int64,
+	fromCID int64,
+	toCID int64,
+) error {
+	panic("unimplemented")
+}
+
+// ListCollectionBiz implements InteractiveDAO.
+func (d *DoubleWriteDAO) ListCollectionBiz(
+	ctx context.Context,
+	uid int64,
+	cid int64,
+	offset int,
+	limit int,
+) ([]UserCollectionBiz, error) {
+	panic("unimplemented")
+}
+
+// InsertTip implements InteractiveDAO.
+func (d *DoubleWriteDAO) InsertTip(
+	ctx context.Context,
+	biz string,
+	bizID int64,
+	rewardID int64,
+) (bool, error) {
 	panic("unimplemented")
 }
 
@@ -177,7 +273,7 @@ func (d *DoubleWriteDAO) InsertLikeInfo(
 	biz string,
 	bizID int64,
 	uid int64,
-) error {
+) (bool, error) {
 	panic("unimplemented")
 }
 
@@ -190,6 +286,86 @@ func (d *DoubleWriteDAO) MustBatchGet(
 	panic("unimplemented")
 }
 
+// SetUniqueReadCnts implements InteractiveDAO.
+func (d *DoubleWriteDAO) SetUniqueReadCnts(
+	ctx context.Context,
+	biz string,
+	cnts map[int64]int64,
+) error {
+	panic("unimplemented")
+}
+
+// ListCnts implements InteractiveDAO.
+func (d *DoubleWriteDAO) ListCnts(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]Interactive, error) {
+	panic("unimplemented")
+}
+
+// RecountCnts implements InteractiveDAO.
+func (d *DoubleWriteDAO) RecountCnts(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) ([]RecountedCnts, error) {
+	panic("unimplemented")
+}
+
+// ListLikes implements InteractiveDAO.
+func (d *DoubleWriteDAO) ListLikes(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]UserLikeBiz, error) {
+	panic("unimplemented")
+}
+
+// ListCollects implements InteractiveDAO.
+func (d *DoubleWriteDAO) ListCollects(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]UserCollectionBiz, error) {
+	panic("unimplemented")
+}
+
+// IncrCnts implements InteractiveDAO.
+func (d *DoubleWriteDAO) IncrCnts(ctx context.Context, deltas []domain.CntDelta) error {
+	panic("unimplemented")
+}
+
+// IncrCntBatch implements InteractiveDAO.
+func (d *DoubleWriteDAO) IncrCntBatch(ctx context.Context, batch domain.CntBatch) (bool, error) {
+	panic("unimplemented")
+}
+
+// ListUserLikes implements InteractiveDAO.
+func (d *DoubleWriteDAO) ListUserLikes(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	cursor domain.Cursor,
+	limit int,
+) ([]UserLikeBiz, error) {
+	panic("unimplemented")
+}
+
+// ListUserCollects implements InteractiveDAO.
+func (d *DoubleWriteDAO) ListUserCollects(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	cursor domain.Cursor,
+	limit int,
+) ([]UserCollectionBiz, error) {
+	panic("unimplemented")
+}
+
 func NewDoubleWriteDAO(src InteractiveDAO, dst InteractiveDAO, l logger.Logger) InteractiveDAO {
 	return &DoubleWriteDAO{
 		src:     src,
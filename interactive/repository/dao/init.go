@@ -5,10 +5,29 @@ import (
 )
 
 func InitTable(db *gorm.DB) error {
+	// the resources were collected once, before the named collections
+	err := dropIndex(db, &UserCollectionBiz{}, "uid_biz_type_id")
+	if err != nil {
+		return err
+	}
 	// NOTE: Not the best practice. Too risky. Strong dependency
 	return db.AutoMigrate(
 		&Interactive{},
 		&UserLikeBiz{},
 		&UserCollectionBiz{},
+		&Collection{},
+		&OutboxEvent{},
+		&RewardTip{},
+		&CntBatch{},
 	)
 }
+
+// dropIndex drops a former index of the table if it is still there,
+// AutoMigrate doesn't.
+func dropIndex(db *gorm.DB, model any, name string) error {
+	m := db.Migrator()
+	if !m.HasIndex(model, name) {
+		return nil
+	}
+	return m.DropIndex(model, name)
+}
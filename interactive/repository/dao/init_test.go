@@ -0,0 +1,70 @@
+package dao
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestDropIndex(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "legacy index",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT DATABASE()").
+					WillReturnRows(sqlmock.NewRows([]string{"database()"}).AddRow("webook"))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM information_schema.statistics").
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				mock.ExpectExec(regexp.QuoteMeta(
+					"DROP INDEX `uid_biz_type_id` ON `user_collection_bizs`",
+				)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "already dropped",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT DATABASE()").
+					WillReturnRows(sqlmock.NewRows([]string{"database()"}).AddRow("webook"))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM information_schema.statistics").
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			err = dropIndex(newMockGORM(t, sqlDB), &UserCollectionBiz{}, "uid_biz_type_id")
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func newMockGORM(t *testing.T, sqlDB *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn: sqlDB,
+		// mock has no version
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		// mock doesn't respond to ping
+		DisableAutomaticPing: true,
+		// use plein commands, don't add bigin-commit automatically
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
@@ -1,9 +1,13 @@
 package dao
 
 import (
+	"cmp"
 	"context"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -14,12 +18,51 @@ var ErrRecordNotFound = gorm.ErrRecordNotFound
 type InteractiveDAO interface {
 	IncrReadCnt(ctx context.Context, biz string, bizID int64) error
 	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIDs []int64) error
-	InsertLikeInfo(ctx context.Context, biz string, bizID int64, uid int64) error
-	DeleteLikeInfo(ctx context.Context, biz string, bizID int64, uid int64) error
-	InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error
-	DeleteCollectionBiz(ctx context.Context, cb UserCollectionBiz) error
+	// IncrCnts applies the counter deltas in a transaction. The missing
+	// counters are created, unless all their deltas are negative.
+	IncrCnts(ctx context.Context, deltas []domain.CntDelta) error
+	// IncrCntBatch applies the deltas of a flushed batch in a transaction
+	// with its id, and returns false if the batch was already applied.
+	IncrCntBatch(ctx context.Context, batch domain.CntBatch) (bool, error)
+	// NOTE: the like and collect methods update the counters in the same
+	// transaction, a crash can't lose them.
+	// InsertTip counts a paid reward into the tip counter, and returns false
+	// if it was already counted.
+	InsertTip(ctx context.Context, biz string, bizID int64, rewardID int64) (bool, error)
+	// InsertLikeInfo returns true if the user had not liked the resource yet.
+	InsertLikeInfo(ctx context.Context, biz string, bizID int64, uid int64) (bool, error)
+	// DeleteLikeInfo returns true if the user had liked the resource.
+	DeleteLikeInfo(ctx context.Context, biz string, bizID int64, uid int64) (bool, error)
+	// InsertCollectionBiz adds the resource to the collection cb.CID, and
+	// returns true if the user had not collected it yet.
+	InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) (bool, error)
+	// DeleteCollectionBiz removes the resource from the collection cb.CID,
+	// and returns true if the user no longer collects it.
+	DeleteCollectionBiz(ctx context.Context, cb UserCollectionBiz) (bool, error)
+	MoveCollectionBiz(
+		ctx context.Context,
+		uid int64,
+		biz string,
+		bizID int64,
+		fromCID int64,
+		toCID int64,
+	) error
+	// ListCollectionBiz lists the items of a collection of uid, the latest
+	// collected first.
+	ListCollectionBiz(
+		ctx context.Context,
+		uid int64,
+		cid int64,
+		offset int,
+		limit int,
+	) ([]UserCollectionBiz, error)
 	Get(ctx context.Context, biz string, bizID int64) (Interactive, error)
 	GetAll(ctx context.Context, biz string, limit int, offset int) ([]Interactive, error)
+	// ListCnts returns the counters with an ID greater than afterID.
+	ListCnts(ctx context.Context, biz string, afterID int64, limit int) ([]Interactive, error)
+	// RecountCnts counts the likes and the collects of the resources from
+	// their rows, saves the counters drifting away, and returns them.
+	RecountCnts(ctx context.Context, biz string, bizIDs []int64) ([]RecountedCnts, error)
 	MustBatchGet(ctx context.Context, biz string, bizIDs []int64) ([]Interactive, error)
 	GetLikeInfo(ctx context.Context, biz string, bizID int64, uid int64) (UserLikeBiz, error)
 	GetCollectInfo(
@@ -29,6 +72,48 @@ type InteractiveDAO interface {
 		uid int64,
 	) (UserCollectionBiz, error)
 	GetByIDs(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
+	// GetLikedBizIDs returns the resources among bizIDs liked by uid.
+	GetLikedBizIDs(ctx context.Context, biz string, bizIDs []int64, uid int64) ([]int64, error)
+	// GetCollectedBizIDs returns the resources among bizIDs collected by uid.
+	GetCollectedBizIDs(
+		ctx context.Context,
+		biz string,
+		bizIDs []int64,
+		uid int64,
+	) ([]int64, error)
+	// DeleteByBizIDs removes the counters, likes and collections.
+	DeleteByBizIDs(ctx context.Context, biz string, bizIDs []int64) error
+	// SetUniqueReadCnts saves the snapshots of the unique reader counts.
+	SetUniqueReadCnts(ctx context.Context, biz string, cnts map[int64]int64) error
+	// ListLikes returns the active likes with an ID greater than afterID.
+	ListLikes(ctx context.Context, biz string, afterID int64, limit int) ([]UserLikeBiz, error)
+	// ListCollects returns the collected resources with an ID greater than
+	// afterID. A resource is listed once per collection it is in.
+	ListCollects(
+		ctx context.Context,
+		biz string,
+		afterID int64,
+		limit int,
+	) ([]UserCollectionBiz, error)
+	// ListUserLikes returns the active likes of uid after the cursor, the
+	// latest first.
+	ListUserLikes(
+		ctx context.Context,
+		uid int64,
+		biz string,
+		cursor domain.Cursor,
+		limit int,
+	) ([]UserLikeBiz, error)
+	// ListUserCollects returns the resources collected by uid after the
+	// cursor, the latest first. A resource in several collections is listed
+	// once, with the time it was last collected.
+	ListUserCollects(
+		ctx context.Context,
+		uid int64,
+		biz string,
+		cursor domain.Cursor,
+		limit int,
+	) ([]UserCollectionBiz, error)
 }
 
 type GORMInteractiveDAO struct {
@@ -44,30 +129,62 @@ type Interactive struct {
 	ReadCnt    int64
 	LikeCnt    int64
 	CollectCnt int64
-	Utime      int64
-	Ctime      int64
+	TipCnt     int64
+	// snapshot of the HyperLogLog in Redis
+	UniqueReadCnt int64
+	Utime         int64
+	Ctime         int64
+}
+
+// RewardTip is a paid reward counted into the tip counter, so that a
+// redelivered reward is counted once.
+type RewardTip struct {
+	ID       int64 `gorm:"primaryKey,autoIncrement"`
+	RewardID int64 `gorm:"uniqueIndex"`
+	Biz      string
+	BizID    int64
+	Ctime    int64
+}
+
+// CntBatch is a batch of buffered deltas applied to the counters, so that a
+// batch taken again is applied once. Only the batches of the last flush are
+// kept.
+type CntBatch struct {
+	ID      int64 `gorm:"primaryKey,autoIncrement"`
+	FlushID int64 `gorm:"uniqueIndex:flush_id_no"`
+	No      int64 `gorm:"uniqueIndex:flush_id_no"`
+	Ctime   int64
+}
+
+// RecountedCnts are the saved like and collect counters of a resource, and
+// the ones counted from the rows.
+type RecountedCnts struct {
+	BizID           int64
+	SavedLikeCnt    int64
+	LikeCnt         int64
+	SavedCollectCnt int64
+	CollectCnt      int64
 }
 
 type UserLikeBiz struct {
 	ID     int64  `gorm:"primaryKey,autoIncrement"`
-	UID    int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	BizID  int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	Biz    string `gorm:"uniqueIndex:uid_biz_type_id,length:128"`
+	UID    int64  `gorm:"uniqueIndex:uid_biz_type_id;index:uid_biz_utime"`
+	BizID  int64  `gorm:"uniqueIndex:uid_biz_type_id;index:biz_type_id"`
+	Biz    string `gorm:"uniqueIndex:uid_biz_type_id,length:128;index:uid_biz_utime,length:128;index:biz_type_id,length:128"`
 	Status int
-	Utime  int64
+	Utime  int64 `gorm:"index:uid_biz_utime"`
 	Ctime  int64
 }
 
 type UserCollectionBiz struct {
 	ID int64 `gorm:"primaryKey,autoIncrement"`
-	// One ressource can only be put into one collection.
-	// Otherwise the composite index should include CID too
-	UID   int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	BizID int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	Biz   string `gorm:"uniqueIndex:uid_biz_type_id,length:128"`
-	// collection ID
-	CID   int64 `gorm:"index"`
-	Utime int64
+	// A ressource can be put into several collections of the user.
+	UID   int64  `gorm:"uniqueIndex:uid_biz_type_id_cid;index:uid_cid_utime"`
+	BizID int64  `gorm:"uniqueIndex:uid_biz_type_id_cid;index:biz_type_id"`
+	Biz   string `gorm:"uniqueIndex:uid_biz_type_id_cid,length:128;index:biz_type_id,length:128"`
+	// collection ID, 0 for the default collection
+	CID   int64 `gorm:"uniqueIndex:uid_biz_type_id_cid;index:uid_cid_utime"`
+	Utime int64 `gorm:"index:uid_cid_utime"`
 	Ctime int64
 }
 
@@ -85,6 +202,23 @@ func (g *GORMInteractiveDAO) GetByIDs(
 	return intrs, err
 }
 
+// DeleteByBizIDs implements InteractiveDAO.
+func (g *GORMInteractiveDAO) DeleteByBizIDs(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&Interactive{}, &UserLikeBiz{}, &UserCollectionBiz{}} {
+			err := tx.Where("biz = ? AND biz_id IN ?", biz, bizIDs).Delete(model).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // GetAll implements InteractiveDAO.
 func (g *GORMInteractiveDAO) GetAll(
 	ctx context.Context,
@@ -166,81 +300,213 @@ func (g *GORMInteractiveDAO) GetCollectInfo(
 	return res, err
 }
 
+// GetLikedBizIDs implements InteractiveDAO.
+func (g *GORMInteractiveDAO) GetLikedBizIDs(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+	uid int64,
+) ([]int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).
+		Model(&UserLikeBiz{}).
+		Where("uid = ? AND biz = ? AND biz_id IN ? AND status = ?", uid, biz, bizIDs, 1).
+		Pluck("biz_id", &res).
+		Error
+	return res, err
+}
+
+// GetCollectedBizIDs implements InteractiveDAO.
+func (g *GORMInteractiveDAO) GetCollectedBizIDs(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+	uid int64,
+) ([]int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).
+		Model(&UserCollectionBiz{}).
+		Distinct("biz_id").
+		Where("uid = ? AND biz = ? AND biz_id IN ?", uid, biz, bizIDs).
+		Pluck("biz_id", &res).
+		Error
+	return res, err
+}
+
 // DeleteCollectionBiz implements InteractiveDAO.
-func (g *GORMInteractiveDAO) DeleteCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
+func (g *GORMInteractiveDAO) DeleteCollectionBiz(
+	ctx context.Context,
+	cb UserCollectionBiz,
+) (bool, error) {
 	now := time.Now().UnixMilli()
-	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.WithContext(ctx).Delete(&UserCollectionBiz{}, map[string]interface{}{
-			"uid":    cb.UID,
-			"biz_id": cb.BizID,
-			"biz":    cb.Biz,
-		}).Error
+	var uncollected bool
+	err := g.retryDeadlock(ctx, func(tx *gorm.DB) error {
+		uncollected = false
+		existing, err := lockCollections(tx, cb.UID, cb.Biz, cb.BizID)
 		if err != nil {
 			return err
 		}
+		res := tx.Where("uid = ? AND biz_id = ? AND biz = ? AND cid = ?",
+			cb.UID, cb.BizID, cb.Biz, cb.CID).
+			Delete(&UserCollectionBiz{})
+		if res.Error != nil || res.RowsAffected == 0 || existing > res.RowsAffected {
+			return res.Error
+		}
 
-		return tx.WithContext(ctx).
-			Model(&Interactive{}).
-			Where("biz_id = ? AND biz = ?", cb.BizID, cb.Biz).
-			Updates(map[string]interface{}{
-				"collect_cnt": gorm.Expr("`collect_cnt` - 1"), // NOTE: don't forget ``
-				"utime":       now,
-			}).
-			Error
+		uncollected = true
+		err = incrCnt(tx, domain.CntDelta{Biz: cb.Biz, BizID: cb.BizID, CollectCnt: -1}, now)
+		if err != nil {
+			return err
+		}
+		return insertOutboxEvent(tx, domain.EventTypeUncollect, cb.Biz, cb.BizID, cb.UID, now)
 	})
+	return uncollected, err
 }
 
 // InsertCollectionBiz implements InteractiveDAO.
-func (g *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
+func (g *GORMInteractiveDAO) InsertCollectionBiz(
+	ctx context.Context,
+	cb UserCollectionBiz,
+) (bool, error) {
 	now := time.Now().UnixMilli()
+	var collected bool
+	err := g.retryDeadlock(ctx, func(tx *gorm.DB) error {
+		collected = false
+		existing, err := lockCollections(tx, cb.UID, cb.Biz, cb.BizID)
+		if err != nil {
+			return err
+		}
+		// nothing inserted if already in the collection
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&cb)
+		if res.Error != nil || res.RowsAffected == 0 || existing > 0 {
+			return res.Error
+		}
+
+		collected = true
+		err = incrCnt(tx, domain.CntDelta{Biz: cb.Biz, BizID: cb.BizID, CollectCnt: 1}, now)
+		if err != nil {
+			return err
+		}
+		return insertOutboxEvent(tx, domain.EventTypeCollect, cb.Biz, cb.BizID, cb.UID, now)
+	})
+	return collected, err
+}
+
+// lockCollections locks the collection items of a resource of uid, so that
+// only one transaction at a time decides whether it is collected, and
+// returns how many there are.
+// NOTE: if there is none, only the gap is locked, the first collections at
+// the same time may deadlock, see retryDeadlock.
+func lockCollections(tx *gorm.DB, uid int64, biz string, bizID int64) (int64, error) {
+	var res int64
+	err := tx.Model(&UserCollectionBiz{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uid = ? AND biz_id = ? AND biz = ?", uid, bizID, biz).
+		Count(&res).
+		Error
+	return res, err
+}
+
+// retryDeadlock runs fn in a transaction, again if it was rolled back by a
+// deadlock.
+func (g *GORMInteractiveDAO) retryDeadlock(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	const maxRetries = 3
+	var err error
+	for range maxRetries {
+		err = g.db.WithContext(ctx).Transaction(fn)
+		if !isDeadlockErr(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// MoveCollectionBiz implements InteractiveDAO.
+func (g *GORMInteractiveDAO) MoveCollectionBiz(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	bizID int64,
+	fromCID int64,
+	toCID int64,
+) error {
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Create(&cb).Error
+		var dst int64
+		err := tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? AND biz_id = ? AND biz = ? AND cid = ?", uid, bizID, biz, toCID).
+			Count(&dst).
+			Error
 		if err != nil {
 			return err
 		}
-		return tx.WithContext(ctx).Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"collect_cnt": gorm.Expr("`collect_cnt` + 1"), // NOTE: don't forget ``
-				"utime":       now,
-			}),
-		}).Create(&Interactive{
-			Biz:        cb.Biz,
-			BizID:      cb.BizID,
-			CollectCnt: 1,
-			Ctime:      now,
-			Utime:      now,
-		}).Error
+		src := tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? AND biz_id = ? AND biz = ? AND cid = ?", uid, bizID, biz, fromCID)
+		var res *gorm.DB
+		if dst > 0 {
+			// already in the destination
+			res = src.Delete(&UserCollectionBiz{})
+		} else {
+			res = src.Updates(map[string]any{
+				"cid":   toCID,
+				"utime": time.Now().UnixMilli(),
+			})
+		}
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
 	})
 }
 
+// ListCollectionBiz implements InteractiveDAO.
+func (g *GORMInteractiveDAO) ListCollectionBiz(
+	ctx context.Context,
+	uid int64,
+	cid int64,
+	offset int,
+	limit int,
+) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND cid = ?", uid, cid).
+		Order("utime DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).
+		Error
+	return res, err
+}
+
 // DeleteLikeInfo implements InteractiveDAO.
 func (g *GORMInteractiveDAO) DeleteLikeInfo(
 	ctx context.Context,
 	biz string,
 	bizID int64,
 	uid int64,
-) error {
-	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+) (bool, error) {
+	unliked := false
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now().UnixMilli()
 
-		err := tx.WithContext(ctx).
+		res := tx.WithContext(ctx).
 			Model(&UserLikeBiz{}).
-			Where("uid = ? AND biz_id = ? AND biz = ?", uid, bizID, biz).
-			Updates(map[string]interface{}{"status": 0, "utime": now}).
-			Error
+			Where("uid = ? AND biz_id = ? AND biz = ? AND status = ?", uid, bizID, biz, 1).
+			Updates(map[string]interface{}{"status": 0, "utime": now})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+
+		unliked = true
+		err := incrCnt(tx, domain.CntDelta{Biz: biz, BizID: bizID, LikeCnt: -1}, now)
 		if err != nil {
 			return err
 		}
-
-		return tx.WithContext(ctx).
-			Model(&Interactive{}).
-			Where("biz_id = ? AND biz = ?", bizID, biz).
-			Updates(map[string]interface{}{
-				"like_cnt": gorm.Expr("`like_cnt` - 1"), // NOTE: don't forget ``
-				"utime":    now,
-			}).
-			Error
+		return insertOutboxEvent(tx, domain.EventTypeUnlike, biz, bizID, uid, now)
 	})
+	return unliked, err
 }
 
 // InsertLikeInfo implements InteractiveDAO.
@@ -249,40 +515,43 @@ func (g *GORMInteractiveDAO) InsertLikeInfo(
 	biz string,
 	bizID int64,
 	uid int64,
-) error {
-	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+) (bool, error) {
+	liked := false
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now().UnixMilli()
 
-		err := tx.WithContext(ctx).Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"status": 1,
-				"utime":  now,
-			}),
-		}).Create(&UserLikeBiz{
-			UID:    uid,
-			BizID:  bizID,
-			Biz:    biz,
-			Status: 1,
-			Ctime:  now,
-			Utime:  now,
-		}).Error
+		// liked again
+		res := tx.WithContext(ctx).
+			Model(&UserLikeBiz{}).
+			Where("uid = ? AND biz_id = ? AND biz = ? AND status = ?", uid, bizID, biz, 0).
+			Updates(map[string]interface{}{"status": 1, "utime": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// nothing inserted if already liked
+			res = tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&UserLikeBiz{
+					UID:    uid,
+					BizID:  bizID,
+					Biz:    biz,
+					Status: 1,
+					Ctime:  now,
+					Utime:  now,
+				})
+			if res.Error != nil || res.RowsAffected == 0 {
+				return res.Error
+			}
+		}
+
+		liked = true
+		err := incrCnt(tx, domain.CntDelta{Biz: biz, BizID: bizID, LikeCnt: 1}, now)
 		if err != nil {
 			return err
 		}
-
-		return tx.WithContext(ctx).Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"like_cnt": gorm.Expr("`like_cnt` + 1"), // NOTE: don't forget ``
-				"utime":    now,
-			}),
-		}).Create(&Interactive{
-			Biz:     biz,
-			BizID:   bizID,
-			LikeCnt: 1,
-			Ctime:   now,
-			Utime:   now,
-		}).Error
+		return insertOutboxEvent(tx, domain.EventTypeLike, biz, bizID, uid, now)
 	})
+	return liked, err
 }
 
 // IncrReadCnt implements InteractiveDAO.
@@ -304,6 +573,107 @@ func (g *GORMInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizID
 	}).Error
 }
 
+// IncrCnts implements InteractiveDAO.
+func (g *GORMInteractiveDAO) IncrCnts(ctx context.Context, deltas []domain.CntDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return incrCnts(tx, deltas, now)
+	})
+}
+
+// IncrCntBatch implements InteractiveDAO.
+func (g *GORMInteractiveDAO) IncrCntBatch(
+	ctx context.Context,
+	batch domain.CntBatch,
+) (bool, error) {
+	if len(batch.Deltas) == 0 {
+		return true, nil
+	}
+	now := time.Now().UnixMilli()
+	applied := false
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&CntBatch{
+			FlushID: batch.FlushID,
+			No:      batch.No,
+			Ctime:   now,
+		})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		applied = true
+		// only a batch of the current flush can be taken again
+		err := tx.Where("flush_id < ?", batch.FlushID).Delete(&CntBatch{}).Error
+		if err != nil {
+			return err
+		}
+		return incrCnts(tx, batch.Deltas, now)
+	})
+	return applied && err == nil, err
+}
+
+// incrCnts applies the deltas in the transaction tx.
+func incrCnts(tx *gorm.DB, deltas []domain.CntDelta, now int64) error {
+	// NOTE: always lock the rows in the same order to avoid the deadlocks
+	// between the concurrent flushes.
+	sorted := slices.Clone(deltas)
+	slices.SortFunc(sorted, func(a, b domain.CntDelta) int {
+		return cmp.Or(strings.Compare(a.Biz, b.Biz), cmp.Compare(a.BizID, b.BizID))
+	})
+	for _, d := range sorted {
+		err := incrCnt(tx, d, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// incrCnt applies the deltas of a resource in the transaction tx.
+func incrCnt(tx *gorm.DB, d domain.CntDelta, now int64) error {
+	if d.IsZero() {
+		return nil
+	}
+	res := tx.Model(&Interactive{}).
+		Where("biz_id = ? AND biz = ?", d.BizID, d.Biz).
+		Updates(map[string]any{
+			"read_cnt":    gorm.Expr("`read_cnt` + ?", d.ReadCnt),
+			"like_cnt":    gorm.Expr("`like_cnt` + ?", d.LikeCnt),
+			"collect_cnt": gorm.Expr("`collect_cnt` + ?", d.CollectCnt),
+			"tip_cnt":     gorm.Expr("`tip_cnt` + ?", d.TipCnt),
+			"utime":       now,
+		})
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.Error
+	}
+	created := Interactive{
+		Biz:        d.Biz,
+		BizID:      d.BizID,
+		ReadCnt:    max(d.ReadCnt, 0),
+		LikeCnt:    max(d.LikeCnt, 0),
+		CollectCnt: max(d.CollectCnt, 0),
+		TipCnt:     max(d.TipCnt, 0),
+		Ctime:      now,
+		Utime:      now,
+	}
+	if created.ReadCnt == 0 && created.LikeCnt == 0 && created.CollectCnt == 0 &&
+		created.TipCnt == 0 {
+		return nil
+	}
+	// created by someone else in the meantime
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"read_cnt":    gorm.Expr("`read_cnt` + ?", d.ReadCnt),
+			"like_cnt":    gorm.Expr("`like_cnt` + ?", d.LikeCnt),
+			"collect_cnt": gorm.Expr("`collect_cnt` + ?", d.CollectCnt),
+			"tip_cnt":     gorm.Expr("`tip_cnt` + ?", d.TipCnt),
+			"utime":       now,
+		}),
+	}).Create(&created).Error
+}
+
 // BatchIncrReadCnt implements InteractiveDAO.
 func (g *GORMInteractiveDAO) BatchIncrReadCnt(
 	ctx context.Context,
@@ -323,6 +693,237 @@ func (g *GORMInteractiveDAO) BatchIncrReadCnt(
 	})
 }
 
+// SetUniqueReadCnts implements InteractiveDAO.
+func (g *GORMInteractiveDAO) SetUniqueReadCnts(
+	ctx context.Context,
+	biz string,
+	cnts map[int64]int64,
+) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for bizID, cnt := range cnts {
+			err := tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"unique_read_cnt": cnt,
+					"utime":           now,
+				}),
+			}).Create(&Interactive{
+				Biz:           biz,
+				BizID:         bizID,
+				UniqueReadCnt: cnt,
+				Ctime:         now,
+				Utime:         now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListCnts implements InteractiveDAO.
+func (g *GORMInteractiveDAO) ListCnts(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]Interactive, error) {
+	var res []Interactive
+	err := g.db.WithContext(ctx).
+		Where("biz = ? AND id > ?", biz, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&res).
+		Error
+	return res, err
+}
+
+// InsertTip implements InteractiveDAO.
+func (g *GORMInteractiveDAO) InsertTip(
+	ctx context.Context,
+	biz string,
+	bizID int64,
+	rewardID int64,
+) (bool, error) {
+	now := time.Now().UnixMilli()
+	tipped := false
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&RewardTip{
+			RewardID: rewardID,
+			Biz:      biz,
+			BizID:    bizID,
+			Ctime:    now,
+		})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		tipped = true
+		return incrCnt(tx, domain.CntDelta{Biz: biz, BizID: bizID, TipCnt: 1}, now)
+	})
+	return tipped && err == nil, err
+}
+
+// RecountCnts implements InteractiveDAO.
+func (g *GORMInteractiveDAO) RecountCnts(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) ([]RecountedCnts, error) {
+	if len(bizIDs) == 0 {
+		return nil, nil
+	}
+	var res []RecountedCnts
+	now := time.Now().UnixMilli()
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// NOTE: the counters are locked before the rows are counted, a like or
+		// a collect committed during the recount then waits for it and is
+		// applied after.
+		var saved []Interactive
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("biz = ? AND biz_id IN ?", biz, bizIDs).
+			Order("biz_id").
+			Find(&saved).
+			Error
+		if err != nil {
+			return err
+		}
+		likes, err := countByBizID(tx.Model(&UserLikeBiz{}).
+			Select("biz_id, COUNT(*) AS cnt").
+			Where("biz = ? AND biz_id IN ? AND status = ?", biz, bizIDs, 1))
+		if err != nil {
+			return err
+		}
+		// a resource in several collections of a user is collected once
+		collects, err := countByBizID(tx.Model(&UserCollectionBiz{}).
+			Select("biz_id, COUNT(DISTINCT uid) AS cnt").
+			Where("biz = ? AND biz_id IN ?", biz, bizIDs))
+		if err != nil {
+			return err
+		}
+		for _, intr := range saved {
+			r := RecountedCnts{
+				BizID:           intr.BizID,
+				SavedLikeCnt:    intr.LikeCnt,
+				LikeCnt:         likes[intr.BizID],
+				SavedCollectCnt: intr.CollectCnt,
+				CollectCnt:      collects[intr.BizID],
+			}
+			if r.LikeCnt == r.SavedLikeCnt && r.CollectCnt == r.SavedCollectCnt {
+				continue
+			}
+			err = tx.Model(&Interactive{}).
+				Where("id = ?", intr.ID).
+				Updates(map[string]any{
+					"like_cnt":    r.LikeCnt,
+					"collect_cnt": r.CollectCnt,
+					"utime":       now,
+				}).Error
+			if err != nil {
+				return err
+			}
+			res = append(res, r)
+		}
+		return nil
+	})
+	return res, err
+}
+
+func countByBizID(query *gorm.DB) (map[int64]int64, error) {
+	var cnts []struct {
+		BizID int64
+		Cnt   int64
+	}
+	err := query.Group("biz_id").Scan(&cnts).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(cnts))
+	for _, c := range cnts {
+		res[c.BizID] = c.Cnt
+	}
+	return res, nil
+}
+
+// ListLikes implements InteractiveDAO.
+func (g *GORMInteractiveDAO) ListLikes(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := g.db.WithContext(ctx).
+		Where("biz = ? AND id > ? AND status = ?", biz, afterID, 1).
+		Order("id").
+		Limit(limit).
+		Find(&res).
+		Error
+	return res, err
+}
+
+// ListCollects implements InteractiveDAO.
+func (g *GORMInteractiveDAO) ListCollects(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := g.db.WithContext(ctx).
+		Where("biz = ? AND id > ?", biz, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&res).
+		Error
+	return res, err
+}
+
+// ListUserLikes implements InteractiveDAO.
+func (g *GORMInteractiveDAO) ListUserLikes(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	cursor domain.Cursor,
+	limit int,
+) ([]UserLikeBiz, error) {
+	db := g.db.WithContext(ctx).Where("uid = ? AND biz = ? AND status = ?", uid, biz, 1)
+	if !cursor.IsZero() {
+		utime := cursor.Utime.UnixMilli()
+		db = db.Where("utime < ? OR (utime = ? AND biz_id < ?)", utime, utime, cursor.BizID)
+	}
+	var res []UserLikeBiz
+	err := db.Order("utime DESC, biz_id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+// ListUserCollects implements InteractiveDAO.
+func (g *GORMInteractiveDAO) ListUserCollects(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	cursor domain.Cursor,
+	limit int,
+) ([]UserCollectionBiz, error) {
+	db := g.db.WithContext(ctx).
+		Model(&UserCollectionBiz{}).
+		Select("uid, biz, biz_id, MAX(utime) AS utime").
+		Where("uid = ? AND biz = ?", uid, biz).
+		Group("uid, biz, biz_id")
+	if !cursor.IsZero() {
+		utime := cursor.Utime.UnixMilli()
+		db = db.Having(
+			"MAX(utime) < ? OR (MAX(utime) = ? AND biz_id < ?)",
+			utime,
+			utime,
+			cursor.BizID,
+		)
+	}
+	var res []UserCollectionBiz
+	err := db.Order("MAX(utime) DESC, biz_id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
 func NewGORMInteractiveDAO(db *gorm.DB) InteractiveDAO {
 	return &GORMInteractiveDAO{
 		db: db,
@@ -0,0 +1,577 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lockCollectionsSQL = "SELECT count\\(\\*\\) FROM `user_collection_bizs` " +
+	"WHERE uid = \\? AND biz_id = \\? AND biz = \\? FOR UPDATE"
+
+func TestGORMInteractiveDAO_InsertCollectionBiz(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantCollected bool
+		wantErr       error
+	}{
+		{
+			name: "first collection",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WithArgs(123, 1, "article").
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `interactives`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `interactives`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `outbox_events`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantCollected: true,
+		},
+		{
+			name: "in another collection",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "already in the collection",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "deadlock retried",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").
+					WillReturnError(&mysql.MySQLError{Number: 1213})
+				mock.ExpectRollback()
+				// collected by the other transaction
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "db error",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewGORMInteractiveDAO(newMockGORM(t, tc.mock(t)))
+
+			collected, err := dao.InsertCollectionBiz(context.Background(), UserCollectionBiz{
+				UID:   123,
+				Biz:   "article",
+				BizID: 1,
+				CID:   2,
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCollected, collected)
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_DeleteCollectionBiz(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantUncollected bool
+		wantErr         error
+	}{
+		{
+			name: "last collection",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WithArgs(123, 1, "article").
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				mock.ExpectExec("DELETE FROM `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `outbox_events`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantUncollected: true,
+		},
+		{
+			name: "still in another collection",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(2))
+				mock.ExpectExec("DELETE FROM `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "not in the collection",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCollectionsSQL).
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				mock.ExpectExec("DELETE FROM `user_collection_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewGORMInteractiveDAO(newMockGORM(t, tc.mock(t)))
+
+			uncollected, err := dao.DeleteCollectionBiz(context.Background(), UserCollectionBiz{
+				UID:   123,
+				Biz:   "article",
+				BizID: 1,
+				CID:   2,
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantUncollected, uncollected)
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_InsertLikeInfo(t *testing.T) {
+	const likeAgainSQL = "UPDATE `user_like_bizs` SET `status`=\\?,`utime`=\\? " +
+		"WHERE uid = \\? AND biz_id = \\? AND biz = \\? AND status = \\?"
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantLiked bool
+		wantErr   error
+	}{
+		{
+			name: "first like",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec(likeAgainSQL).
+					WithArgs(1, sqlmock.AnyArg(), 123, 1, "article", 0).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `user_like_bizs`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `interactives`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `outbox_events`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantLiked: true,
+		},
+		{
+			name: "liked again",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec(likeAgainSQL).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `outbox_events`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantLiked: true,
+		},
+		{
+			name: "already liked",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec(likeAgainSQL).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `user_like_bizs`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "db error",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec(likeAgainSQL).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewGORMInteractiveDAO(newMockGORM(t, tc.mock(t)))
+
+			liked, err := dao.InsertLikeInfo(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLiked, liked)
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_InsertTip(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantTipped bool
+		wantErr    error
+	}{
+		{
+			name: "first tip",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `reward_tips`").
+					WithArgs(42, "article", 1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `interactives`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantTipped: true,
+		},
+		{
+			name: "already counted",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `reward_tips`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "db error",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `reward_tips`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `interactives`").
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewGORMInteractiveDAO(newMockGORM(t, tc.mock(t)))
+
+			tipped, err := dao.InsertTip(context.Background(), "article", 1, 42)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantTipped, tipped)
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_IncrCntBatch(t *testing.T) {
+	batch := domain.CntBatch{
+		FlushID: 7,
+		No:      2,
+		Deltas: []domain.CntDelta{
+			{Biz: "article", BizID: 2, ReadCnt: 3},
+			{Biz: "article", BizID: 1, ReadCnt: 10},
+		},
+	}
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantApplied bool
+		wantErr     error
+	}{
+		{
+			name: "applied",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `cnt_batches`").
+					WithArgs(7, 2, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM `cnt_batches` WHERE flush_id < ").
+					WithArgs(7).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE `interactives`").
+					WithArgs(0, 0, 10, 0, sqlmock.AnyArg(), 1, "article").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives`").
+					WithArgs(0, 0, 3, 0, sqlmock.AnyArg(), 2, "article").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantApplied: true,
+		},
+		{
+			name: "already applied",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `cnt_batches`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "db error",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `cnt_batches`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM `cnt_batches`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE `interactives`").
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewGORMInteractiveDAO(newMockGORM(t, tc.mock(t)))
+
+			applied, err := dao.IncrCntBatch(context.Background(), batch)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantApplied, applied)
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_DeleteLikeInfo(t *testing.T) {
+	const unlikeSQL = "UPDATE `user_like_bizs` SET `status`=\\?,`utime`=\\? " +
+		"WHERE uid = \\? AND biz_id = \\? AND biz = \\? AND status = \\?"
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantUnliked bool
+		wantErr     error
+	}{
+		{
+			name: "unliked",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec(unlikeSQL).
+					WithArgs(0, sqlmock.AnyArg(), 123, 1, "article", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `outbox_events`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantUnliked: true,
+		},
+		{
+			name: "not liked",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec(unlikeSQL).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewGORMInteractiveDAO(newMockGORM(t, tc.mock(t)))
+
+			unliked, err := dao.DeleteLikeInfo(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantUnliked, unliked)
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_RecountCnts(t *testing.T) {
+	const (
+		lockCntsSQL = "SELECT \\* FROM `interactives` WHERE biz = \\? AND biz_id IN \\(\\?,\\?\\) " +
+			"ORDER BY biz_id FOR UPDATE"
+		countLikesSQL = "SELECT biz_id, COUNT\\(\\*\\) AS cnt FROM `user_like_bizs` " +
+			"WHERE biz = \\? AND biz_id IN \\(\\?,\\?\\) AND status = \\? GROUP BY `biz_id`"
+		countCollectsSQL = "SELECT biz_id, COUNT\\(DISTINCT uid\\) AS cnt FROM `user_collection_bizs` " +
+			"WHERE biz = \\? AND biz_id IN \\(\\?,\\?\\) GROUP BY `biz_id`"
+	)
+	cntCols := []string{"id", "biz_id", "biz", "like_cnt", "collect_cnt"}
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantRes []RecountedCnts
+		wantErr error
+	}{
+		{
+			name: "drifting counters repaired",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCntsSQL).
+					WithArgs("article", 1, 2).
+					WillReturnRows(sqlmock.NewRows(cntCols).
+						AddRow(10, 1, "article", 3, 1).
+						AddRow(11, 2, "article", 5, 2))
+				mock.ExpectQuery(countLikesSQL).
+					WithArgs("article", 1, 2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"biz_id", "cnt"}).
+						AddRow(1, 3).
+						AddRow(2, 4))
+				mock.ExpectQuery(countCollectsSQL).
+					WithArgs("article", 1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"biz_id", "cnt"}).
+						AddRow(1, 1))
+				mock.ExpectExec("UPDATE `interactives` SET `collect_cnt`=\\?,`like_cnt`=\\?,`utime`=\\? "+
+					"WHERE id = \\?").
+					WithArgs(0, 4, sqlmock.AnyArg(), 11).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return db
+			},
+			wantRes: []RecountedCnts{
+				{BizID: 2, SavedLikeCnt: 5, LikeCnt: 4, SavedCollectCnt: 2, CollectCnt: 0},
+			},
+		},
+		{
+			name: "no drift",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCntsSQL).
+					WillReturnRows(sqlmock.NewRows(cntCols).
+						AddRow(10, 1, "article", 3, 1))
+				mock.ExpectQuery(countLikesSQL).
+					WillReturnRows(sqlmock.NewRows([]string{"biz_id", "cnt"}).AddRow(1, 3))
+				mock.ExpectQuery(countCollectsSQL).
+					WillReturnRows(sqlmock.NewRows([]string{"biz_id", "cnt"}).AddRow(1, 1))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "db error",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockCntsSQL).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewGORMInteractiveDAO(newMockGORM(t, tc.mock(t)))
+
+			res, err := dao.RecountCnts(context.Background(), "article", []int64{1, 2})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	domain "github.com/chenmuyao/go-bootcamp/interactive/domain"
 	dao "github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -55,14 +56,29 @@ func (mr *MockInteractiveDAOMockRecorder) BatchIncrReadCnt(ctx, bizs, bizIDs any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchIncrReadCnt), ctx, bizs, bizIDs)
 }
 
-// DeleteCollectionBiz mocks base method.
-func (m *MockInteractiveDAO) DeleteCollectionBiz(ctx context.Context, cb dao.UserCollectionBiz) error {
+// DeleteByBizIDs mocks base method.
+func (m *MockInteractiveDAO) DeleteByBizIDs(ctx context.Context, biz string, bizIDs []int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCollectionBiz", ctx, cb)
+	ret := m.ctrl.Call(m, "DeleteByBizIDs", ctx, biz, bizIDs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// DeleteByBizIDs indicates an expected call of DeleteByBizIDs.
+func (mr *MockInteractiveDAOMockRecorder) DeleteByBizIDs(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBizIDs", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteByBizIDs), ctx, biz, bizIDs)
+}
+
+// DeleteCollectionBiz mocks base method.
+func (m *MockInteractiveDAO) DeleteCollectionBiz(ctx context.Context, cb dao.UserCollectionBiz) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionBiz", ctx, cb)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DeleteCollectionBiz indicates an expected call of DeleteCollectionBiz.
 func (mr *MockInteractiveDAOMockRecorder) DeleteCollectionBiz(ctx, cb any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -70,11 +86,12 @@ func (mr *MockInteractiveDAOMockRecorder) DeleteCollectionBiz(ctx, cb any) *gomo
 }
 
 // DeleteLikeInfo mocks base method.
-func (m *MockInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizID, uid int64) error {
+func (m *MockInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizID, uid int64) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteLikeInfo", ctx, biz, bizID, uid)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteLikeInfo indicates an expected call of DeleteLikeInfo.
@@ -143,6 +160,21 @@ func (mr *MockInteractiveDAOMockRecorder) GetCollectInfo(ctx, biz, bizID, uid an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectInfo), ctx, biz, bizID, uid)
 }
 
+// GetCollectedBizIDs mocks base method.
+func (m *MockInteractiveDAO) GetCollectedBizIDs(ctx context.Context, biz string, bizIDs []int64, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectedBizIDs", ctx, biz, bizIDs, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectedBizIDs indicates an expected call of GetCollectedBizIDs.
+func (mr *MockInteractiveDAOMockRecorder) GetCollectedBizIDs(ctx, biz, bizIDs, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectedBizIDs", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectedBizIDs), ctx, biz, bizIDs, uid)
+}
+
 // GetLikeInfo mocks base method.
 func (m *MockInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizID, uid int64) (dao.UserLikeBiz, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +190,50 @@ func (mr *MockInteractiveDAOMockRecorder) GetLikeInfo(ctx, biz, bizID, uid any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetLikeInfo), ctx, biz, bizID, uid)
 }
 
+// GetLikedBizIDs mocks base method.
+func (m *MockInteractiveDAO) GetLikedBizIDs(ctx context.Context, biz string, bizIDs []int64, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikedBizIDs", ctx, biz, bizIDs, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikedBizIDs indicates an expected call of GetLikedBizIDs.
+func (mr *MockInteractiveDAOMockRecorder) GetLikedBizIDs(ctx, biz, bizIDs, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedBizIDs", reflect.TypeOf((*MockInteractiveDAO)(nil).GetLikedBizIDs), ctx, biz, bizIDs, uid)
+}
+
+// IncrCntBatch mocks base method.
+func (m *MockInteractiveDAO) IncrCntBatch(ctx context.Context, batch domain.CntBatch) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCntBatch", ctx, batch)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrCntBatch indicates an expected call of IncrCntBatch.
+func (mr *MockInteractiveDAOMockRecorder) IncrCntBatch(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCntBatch", reflect.TypeOf((*MockInteractiveDAO)(nil).IncrCntBatch), ctx, batch)
+}
+
+// IncrCnts mocks base method.
+func (m *MockInteractiveDAO) IncrCnts(ctx context.Context, deltas []domain.CntDelta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCnts", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCnts indicates an expected call of IncrCnts.
+func (mr *MockInteractiveDAOMockRecorder) IncrCnts(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCnts", reflect.TypeOf((*MockInteractiveDAO)(nil).IncrCnts), ctx, deltas)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizID int64) error {
 	m.ctrl.T.Helper()
@@ -173,11 +249,12 @@ func (mr *MockInteractiveDAOMockRecorder) IncrReadCnt(ctx, biz, bizID any) *gomo
 }
 
 // InsertCollectionBiz mocks base method.
-func (m *MockInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb dao.UserCollectionBiz) error {
+func (m *MockInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb dao.UserCollectionBiz) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InsertCollectionBiz", ctx, cb)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // InsertCollectionBiz indicates an expected call of InsertCollectionBiz.
@@ -187,11 +264,12 @@ func (mr *MockInteractiveDAOMockRecorder) InsertCollectionBiz(ctx, cb any) *gomo
 }
 
 // InsertLikeInfo mocks base method.
-func (m *MockInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizID, uid int64) error {
+func (m *MockInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizID, uid int64) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InsertLikeInfo", ctx, biz, bizID, uid)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // InsertLikeInfo indicates an expected call of InsertLikeInfo.
@@ -200,6 +278,125 @@ func (mr *MockInteractiveDAOMockRecorder) InsertLikeInfo(ctx, biz, bizID, uid an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertLikeInfo), ctx, biz, bizID, uid)
 }
 
+// InsertTip mocks base method.
+func (m *MockInteractiveDAO) InsertTip(ctx context.Context, biz string, bizID, rewardID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTip", ctx, biz, bizID, rewardID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertTip indicates an expected call of InsertTip.
+func (mr *MockInteractiveDAOMockRecorder) InsertTip(ctx, biz, bizID, rewardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTip", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertTip), ctx, biz, bizID, rewardID)
+}
+
+// ListCnts mocks base method.
+func (m *MockInteractiveDAO) ListCnts(ctx context.Context, biz string, afterID int64, limit int) ([]dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCnts", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].([]dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCnts indicates an expected call of ListCnts.
+func (mr *MockInteractiveDAOMockRecorder) ListCnts(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCnts", reflect.TypeOf((*MockInteractiveDAO)(nil).ListCnts), ctx, biz, afterID, limit)
+}
+
+// ListCollectionBiz mocks base method.
+func (m *MockInteractiveDAO) ListCollectionBiz(ctx context.Context, uid, cid int64, offset, limit int) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionBiz", ctx, uid, cid, offset, limit)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionBiz indicates an expected call of ListCollectionBiz.
+func (mr *MockInteractiveDAOMockRecorder) ListCollectionBiz(ctx, uid, cid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).ListCollectionBiz), ctx, uid, cid, offset, limit)
+}
+
+// ListCollects mocks base method.
+func (m *MockInteractiveDAO) ListCollects(ctx context.Context, biz string, afterID int64, limit int) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollects", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollects indicates an expected call of ListCollects.
+func (mr *MockInteractiveDAOMockRecorder) ListCollects(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollects", reflect.TypeOf((*MockInteractiveDAO)(nil).ListCollects), ctx, biz, afterID, limit)
+}
+
+// ListLikes mocks base method.
+func (m *MockInteractiveDAO) ListLikes(ctx context.Context, biz string, afterID int64, limit int) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikes", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikes indicates an expected call of ListLikes.
+func (mr *MockInteractiveDAOMockRecorder) ListLikes(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockInteractiveDAO)(nil).ListLikes), ctx, biz, afterID, limit)
+}
+
+// ListUserCollects mocks base method.
+func (m *MockInteractiveDAO) ListUserCollects(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserCollects", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserCollects indicates an expected call of ListUserCollects.
+func (mr *MockInteractiveDAOMockRecorder) ListUserCollects(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCollects", reflect.TypeOf((*MockInteractiveDAO)(nil).ListUserCollects), ctx, uid, biz, cursor, limit)
+}
+
+// ListUserLikes mocks base method.
+func (m *MockInteractiveDAO) ListUserLikes(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLikes", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLikes indicates an expected call of ListUserLikes.
+func (mr *MockInteractiveDAOMockRecorder) ListUserLikes(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLikes", reflect.TypeOf((*MockInteractiveDAO)(nil).ListUserLikes), ctx, uid, biz, cursor, limit)
+}
+
+// MoveCollectionBiz mocks base method.
+func (m *MockInteractiveDAO) MoveCollectionBiz(ctx context.Context, uid int64, biz string, bizID, fromCID, toCID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionBiz", ctx, uid, biz, bizID, fromCID, toCID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCollectionBiz indicates an expected call of MoveCollectionBiz.
+func (mr *MockInteractiveDAOMockRecorder) MoveCollectionBiz(ctx, uid, biz, bizID, fromCID, toCID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).MoveCollectionBiz), ctx, uid, biz, bizID, fromCID, toCID)
+}
+
 // MustBatchGet mocks base method.
 func (m *MockInteractiveDAO) MustBatchGet(ctx context.Context, biz string, bizIDs []int64) ([]dao.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -214,3 +411,32 @@ func (mr *MockInteractiveDAOMockRecorder) MustBatchGet(ctx, biz, bizIDs any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustBatchGet", reflect.TypeOf((*MockInteractiveDAO)(nil).MustBatchGet), ctx, biz, bizIDs)
 }
+
+// RecountCnts mocks base method.
+func (m *MockInteractiveDAO) RecountCnts(ctx context.Context, biz string, bizIDs []int64) ([]dao.RecountedCnts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecountCnts", ctx, biz, bizIDs)
+	ret0, _ := ret[0].([]dao.RecountedCnts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecountCnts indicates an expected call of RecountCnts.
+func (mr *MockInteractiveDAOMockRecorder) RecountCnts(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecountCnts", reflect.TypeOf((*MockInteractiveDAO)(nil).RecountCnts), ctx, biz, bizIDs)
+}
+
+// SetUniqueReadCnts mocks base method.
+func (m *MockInteractiveDAO) SetUniqueReadCnts(ctx context.Context, biz string, cnts map[int64]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUniqueReadCnts", ctx, biz, cnts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUniqueReadCnts indicates an expected call of SetUniqueReadCnts.
+func (mr *MockInteractiveDAOMockRecorder) SetUniqueReadCnts(ctx, biz, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUniqueReadCnts", reflect.TypeOf((*MockInteractiveDAO)(nil).SetUniqueReadCnts), ctx, biz, cnts)
+}
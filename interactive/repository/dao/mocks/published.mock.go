@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./published.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./published.go -package=intrdaomocks -destination=./mocks/published.mock.go
+//
+
+// Package intrdaomocks is a generated GoMock package.
+package intrdaomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublishedDAO is a mock of PublishedDAO interface.
+type MockPublishedDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublishedDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockPublishedDAOMockRecorder is the mock recorder for MockPublishedDAO.
+type MockPublishedDAOMockRecorder struct {
+	mock *MockPublishedDAO
+}
+
+// NewMockPublishedDAO creates a new mock instance.
+func NewMockPublishedDAO(ctrl *gomock.Controller) *MockPublishedDAO {
+	mock := &MockPublishedDAO{ctrl: ctrl}
+	mock.recorder = &MockPublishedDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublishedDAO) EXPECT() *MockPublishedDAOMockRecorder {
+	return m.recorder
+}
+
+// ArticlePublished mocks base method.
+func (m *MockPublishedDAO) ArticlePublished(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArticlePublished", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArticlePublished indicates an expected call of ArticlePublished.
+func (mr *MockPublishedDAOMockRecorder) ArticlePublished(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArticlePublished", reflect.TypeOf((*MockPublishedDAO)(nil).ArticlePublished), ctx, id)
+}
+
+// ItineraryPublished mocks base method.
+func (m *MockPublishedDAO) ItineraryPublished(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ItineraryPublished", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ItineraryPublished indicates an expected call of ItineraryPublished.
+func (mr *MockPublishedDAOMockRecorder) ItineraryPublished(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItineraryPublished", reflect.TypeOf((*MockPublishedDAO)(nil).ItineraryPublished), ctx, id)
+}
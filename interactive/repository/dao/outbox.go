@@ -0,0 +1,69 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=./outbox.go -package=intrdaomocks -destination=./mocks/outbox.mock.go
+type OutboxDAO interface {
+	// ListPending returns the oldest events not relayed yet.
+	ListPending(ctx context.Context, limit int) ([]OutboxEvent, error)
+	Delete(ctx context.Context, ids []int64) error
+}
+
+// OutboxEvent is an interactive event written in the transaction of the
+// change, it is deleted once relayed to Kafka.
+type OutboxEvent struct {
+	ID    int64 `gorm:"primaryKey,autoIncrement"`
+	Type  string
+	Biz   string
+	BizID int64
+	UID   int64
+	Ctime int64
+}
+
+type GORMOutboxDAO struct {
+	db *gorm.DB
+}
+
+// ListPending implements OutboxDAO.
+func (g *GORMOutboxDAO) ListPending(ctx context.Context, limit int) ([]OutboxEvent, error) {
+	var res []OutboxEvent
+	err := g.db.WithContext(ctx).Order("id").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+// Delete implements OutboxDAO.
+func (g *GORMOutboxDAO) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Where("id IN ?", ids).Delete(&OutboxEvent{}).Error
+}
+
+func NewGORMOutboxDAO(db *gorm.DB) OutboxDAO {
+	return &GORMOutboxDAO{
+		db: db,
+	}
+}
+
+// insertOutboxEvent must be called in the transaction of the change.
+func insertOutboxEvent(
+	tx *gorm.DB,
+	typ domain.EventType,
+	biz string,
+	bizID int64,
+	uid int64,
+	now int64,
+) error {
+	return tx.Create(&OutboxEvent{
+		Type:  string(typ),
+		Biz:   biz,
+		BizID: bizID,
+		UID:   uid,
+		Ctime: now,
+	}).Error
+}
@@ -0,0 +1,65 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// status of a published resource visible to all, ArticleStatusPublished and
+// ItineraryStatusPublished of the services owning them
+const statusPublished uint8 = 2
+
+//go:generate mockgen -source=./published.go -package=intrdaomocks -destination=./mocks/published.mock.go
+
+// PublishedDAO reads the resources published by the other services, which
+// share the DB.
+type PublishedDAO interface {
+	// ArticlePublished returns true if the article is published and public.
+	ArticlePublished(ctx context.Context, id int64) (bool, error)
+	// ItineraryPublished returns true if the itinerary is published and
+	// public.
+	ItineraryPublished(ctx context.Context, id int64) (bool, error)
+}
+
+// PublishedArticle and PublishedItinerary are the parts of the published
+// resources read here, their tables belong to the services owning them and
+// are never migrated here.
+type PublishedArticle struct {
+	ID     int64
+	Status uint8
+}
+
+type PublishedItinerary struct {
+	ID     int64
+	Status uint8
+}
+
+type GORMPublishedDAO struct {
+	db *gorm.DB
+}
+
+// ArticlePublished implements PublishedDAO.
+func (g *GORMPublishedDAO) ArticlePublished(ctx context.Context, id int64) (bool, error) {
+	return g.published(ctx, &PublishedArticle{}, id)
+}
+
+// ItineraryPublished implements PublishedDAO.
+func (g *GORMPublishedDAO) ItineraryPublished(ctx context.Context, id int64) (bool, error) {
+	return g.published(ctx, &PublishedItinerary{}, id)
+}
+
+func (g *GORMPublishedDAO) published(ctx context.Context, model any, id int64) (bool, error) {
+	var cnt int64
+	err := g.db.WithContext(ctx).
+		Model(model).
+		Where("id = ? AND status = ?", id, statusPublished).
+		Count(&cnt).Error
+	return cnt > 0, err
+}
+
+func NewGORMPublishedDAO(db *gorm.DB) PublishedDAO {
+	return &GORMPublishedDAO{
+		db: db,
+	}
+}
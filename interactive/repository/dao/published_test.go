@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMPublishedDAO_Published(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+		get  func(dao PublishedDAO) (bool, error)
+
+		wantPublished bool
+		wantErr       error
+	}{
+		{
+			name: "published article",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `published_articles` "+
+					"WHERE id = \\? AND status = \\?").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				return db
+			},
+			get: func(dao PublishedDAO) (bool, error) {
+				return dao.ArticlePublished(context.Background(), 1)
+			},
+			wantPublished: true,
+		},
+		{
+			name: "private or missing itinerary",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `published_itineraries` "+
+					"WHERE id = \\? AND status = \\?").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+				return db
+			},
+			get: func(dao PublishedDAO) (bool, error) {
+				return dao.ItineraryPublished(context.Background(), 1)
+			},
+		},
+		{
+			name: "db error",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `published_articles`").
+					WillReturnError(errors.New("db error"))
+				return db
+			},
+			get: func(dao PublishedDAO) (bool, error) {
+				return dao.ArticlePublished(context.Background(), 1)
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewGORMPublishedDAO(newMockGORM(t, tc.mock(t)))
+
+			published, err := tc.get(dao)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantPublished, published)
+		})
+	}
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/chenmuyao/generique/gslice"
@@ -14,29 +16,89 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+var ErrCollectionItemNotFound = errors.New("collection item not found")
+
 //go:generate mockgen -source=./interactive.go -package=intrrepomocks -destination=./mocks/interactive.mock.go
 type InteractiveRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, bizID int64) error
+	// IncrTipCnt counts the paid reward rewardID once.
+	IncrTipCnt(ctx context.Context, biz string, bizID int64, rewardID int64) error
 	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIDs []int64) error
+	// BatchAddReaders counts the unique readers, uids[i] has read bizIDs[i].
+	BatchAddReaders(ctx context.Context, bizs []string, bizIDs []int64, uids []int64) error
+	// SnapshotUniqueReadCnts saves at most limit unique reader counts updated
+	// since the last snapshot, and returns how many are saved.
+	SnapshotUniqueReadCnts(ctx context.Context, biz string, limit int) (int, error)
+	// ReconcileCnts recounts the saved likes and collects of at most limit
+	// resources with an ID greater than afterID from their rows and repairs
+	// them, then compares the cached counters and the like rank to the saved
+	// ones. The drifting counters are removed from the cache, and the
+	// drifting ranks are overwritten.
+	ReconcileCnts(
+		ctx context.Context,
+		biz string,
+		afterID int64,
+		limit int,
+	) (domain.CntReconciliation, error)
 	IncrLike(ctx context.Context, biz string, id int64, uid int64) error
 	DecrLike(ctx context.Context, biz string, id int64, uid int64) error
 	AddCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) error
 	DeleteCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) error
+	// MoveCollectionItem fails with ErrCollectionItemNotFound if the resource
+	// is not in the collection fromCID.
+	MoveCollectionItem(
+		ctx context.Context,
+		biz string,
+		id int64,
+		uid int64,
+		fromCID int64,
+		toCID int64,
+	) error
+	ListCollectionItems(
+		ctx context.Context,
+		uid int64,
+		cid int64,
+		offset int,
+		limit int,
+	) ([]domain.UserBiz, error)
 	MustBatchGet(ctx context.Context, biz string, bizIDs []int64) ([]domain.Interactive, error)
+	// GetByIDs reads the cached counters, and the DB for the missing ones. The
+	// resources not saved yet only have their pending counters.
 	GetByIDs(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
 	Get(ctx context.Context, biz string, bizID int64) (domain.Interactive, error)
 	Liked(ctx context.Context, biz string, bizID int64, uid int64) (bool, error)
 	Collected(ctx context.Context, biz string, bizID int64, uid int64) (bool, error)
+	// BatchLiked returns the resources among bizIDs liked by uid.
+	BatchLiked(ctx context.Context, biz string, bizIDs []int64, uid int64) ([]int64, error)
+	// BatchCollected returns the resources among bizIDs collected by uid.
+	BatchCollected(ctx context.Context, biz string, bizIDs []int64, uid int64) ([]int64, error)
 	GetTopLike(ctx context.Context, biz string, limit int) ([]int64, error)
 	BatchSetTopLike(ctx context.Context, biz string, batchSize int) error
+	Delete(ctx context.Context, biz string, bizIDs []int64) error
+	ListLikes(ctx context.Context, biz string, afterID int64, limit int) ([]domain.UserBiz, error)
+	ListCollects(ctx context.Context, biz string, afterID int64, limit int) ([]domain.UserBiz, error)
+	ListUserLikes(
+		ctx context.Context,
+		uid int64,
+		biz string,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.UserBiz, error)
+	ListUserCollects(
+		ctx context.Context,
+		uid int64,
+		biz string,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.UserBiz, error)
 }
 
 type CachedInteractiveRepository struct {
-	l                    logger.Logger
-	dao                  dao.InteractiveDAO
-	cache                cache.InteractiveCache
-	topCache             cache.TopArticlesCache
-	defaultTopLikedLimit int64
+	l        logger.Logger
+	dao      dao.InteractiveDAO
+	cnts     CntWriter
+	cache    cache.InteractiveCache
+	topCache cache.TopArticlesCache
 }
 
 // GetByIDs implements InteractiveRepository.
@@ -45,13 +107,76 @@ func (c *CachedInteractiveRepository) GetByIDs(
 	biz string,
 	ids []int64,
 ) ([]domain.Interactive, error) {
-	intrDAOs, err := c.dao.GetByIDs(ctx, biz, ids)
+	cached, err := c.cache.BatchGetIfPresent(ctx, biz, ids)
+	if err != nil {
+		c.l.Warn("failed to get the cached counters",
+			logger.String("biz", biz), logger.Error(err))
+		cached = map[int64]domain.Interactive{}
+	}
+	missed := slices.DeleteFunc(slices.Clone(ids), func(id int64) bool {
+		_, ok := cached[id]
+		return ok
+	})
+	loaded, err := c.loadMissed(ctx, biz, missed)
 	if err != nil {
 		return []domain.Interactive{}, err
 	}
-	res := gslice.Map(intrDAOs, func(id int, src dao.Interactive) domain.Interactive {
-		return c.toDomain(src)
+	res := make([]domain.Interactive, 0, len(ids))
+	for _, id := range ids {
+		intr, ok := cached[id]
+		if !ok {
+			intr = loaded[id]
+		}
+		res = append(res, intr)
+	}
+	return res, nil
+}
+
+// loadMissed reads the counters missing in the cache from the DB, adds the
+// pending deltas and caches them. The resources not saved yet only have
+// their pending counters.
+func (c *CachedInteractiveRepository) loadMissed(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) (map[int64]domain.Interactive, error) {
+	res := make(map[int64]domain.Interactive, len(bizIDs))
+	if len(bizIDs) == 0 {
+		return res, nil
+	}
+	intrDAOs, err := c.dao.GetByIDs(ctx, biz, bizIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, intrDAO := range intrDAOs {
+		res[intrDAO.BizID] = c.toDomain(intrDAO)
+	}
+	intrs := gslice.Map(bizIDs, func(idx int, bizID int64) domain.Interactive {
+		intr, ok := res[bizID]
+		if !ok {
+			intr = domain.Interactive{Biz: biz, BizID: bizID}
+		}
+		return intr
 	})
+	err = c.addPending(ctx, biz, intrs)
+	if err != nil {
+		// not cached behind the pending counters
+		c.l.Warn("failed to get the pending counters",
+			logger.String("biz", biz), logger.Error(err))
+	} else {
+		err = c.cache.BatchSet(ctx, biz, bizIDs, intrs)
+		if err != nil {
+			c.l.Error(
+				"failed to set interactive cache",
+				logger.String("biz", biz),
+				logger.Any("bizID", bizIDs),
+				logger.Error(err),
+			)
+		}
+	}
+	for _, intr := range intrs {
+		res[intr.BizID] = intr
+	}
 	return res, nil
 }
 
@@ -110,7 +235,7 @@ func (c *CachedInteractiveRepository) GetTopLike(
 	limit int,
 ) ([]int64, error) {
 	// Get top like articles' IDs from local cache
-	res, err := c.topCache.GetTopLikedArticles(ctx)
+	res, err := c.topCache.GetTopLikedArticles(ctx, biz)
 	if err == nil && len(res) > 0 {
 		if len(res) > limit {
 			return res[:limit], nil
@@ -119,7 +244,7 @@ func (c *CachedInteractiveRepository) GetTopLike(
 	}
 
 	// If not found, compute from redis
-	ids, err := c.cache.GetTopLikedIDs(ctx, biz, c.defaultTopLikedLimit)
+	ids, err := c.cache.GetTopLikedIDs(ctx, biz, int64(limit))
 	if err != nil {
 		// XXX: The data should be prepared, if not found,
 		// just return error
@@ -131,7 +256,7 @@ func (c *CachedInteractiveRepository) GetTopLike(
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		er := c.topCache.SetTopLikedArticles(ctx, ids)
+		er := c.topCache.SetTopLikedArticles(ctx, biz, ids)
 		if er != nil {
 			c.l.Error("failed to write back to local cache", logger.Error(err))
 		}
@@ -176,6 +301,13 @@ func (c *CachedInteractiveRepository) MustBatchGet(
 	res := gslice.Map(intrDAOs, func(id int, src dao.Interactive) domain.Interactive {
 		return c.toDomain(src)
 	})
+	err = c.addPending(ctx, biz, res)
+	if err != nil {
+		// not cached behind the pending counters
+		c.l.Warn("failed to get the pending counters",
+			logger.String("biz", biz), logger.Error(err))
+		return res, nil
+	}
 	err = c.cache.BatchSet(ctx, biz, bizIDs, res)
 	if err != nil {
 		c.l.Error(
@@ -200,11 +332,22 @@ func (c *CachedInteractiveRepository) Get(
 		return intr, nil
 	}
 	intrDAO, err := c.dao.Get(ctx, biz, bizID)
-	if err != nil {
+	switch {
+	case errors.Is(err, dao.ErrRecordNotFound):
+		// the reads are only saved by the next flush
+		intrDAO = dao.Interactive{Biz: biz, BizID: bizID}
+	case err != nil:
 		return domain.Interactive{}, nil
 	}
-	res := c.toDomain(intrDAO)
-	err = c.cache.Set(ctx, biz, bizID, res)
+	res := []domain.Interactive{c.toDomain(intrDAO)}
+	err = c.addPending(ctx, biz, res)
+	if err != nil {
+		// not cached behind the pending counters
+		c.l.Warn("failed to get the pending counters",
+			logger.String("biz", biz), logger.Int64("bizID", bizID), logger.Error(err))
+		return res[0], nil
+	}
+	err = c.cache.Set(ctx, biz, bizID, res[0])
 	if err != nil {
 		c.l.Error(
 			"failed to set interactive cache",
@@ -213,7 +356,34 @@ func (c *CachedInteractiveRepository) Get(
 			logger.Error(err),
 		)
 	}
-	return res, nil
+	return res[0], nil
+}
+
+// addPending adds the deltas not flushed yet to the saved counters.
+// NOTE: a flush between the read of the DB and the one of the buffer counts
+// its deltas twice or not at all, the reconciliation repairs the cache then.
+func (c *CachedInteractiveRepository) addPending(
+	ctx context.Context,
+	biz string,
+	intrs []domain.Interactive,
+) error {
+	if len(intrs) == 0 {
+		return nil
+	}
+	bizIDs := gslice.Map(intrs, func(id int, src domain.Interactive) int64 {
+		return src.BizID
+	})
+	pending, err := c.cnts.Pending(ctx, biz, bizIDs)
+	if err != nil {
+		return err
+	}
+	for i, d := range pending {
+		intrs[i].ReadCnt += d.ReadCnt
+		intrs[i].LikeCnt += d.LikeCnt
+		intrs[i].CollectCnt += d.CollectCnt
+		intrs[i].TipCnt += d.TipCnt
+	}
+	return nil
 }
 
 // Liked implements InteractiveRepository.
@@ -234,6 +404,39 @@ func (c *CachedInteractiveRepository) Liked(
 	}
 }
 
+// BatchLiked implements InteractiveRepository.
+func (c *CachedInteractiveRepository) BatchLiked(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+	uid int64,
+) ([]int64, error) {
+	return c.dao.GetLikedBizIDs(ctx, biz, bizIDs, uid)
+}
+
+// BatchCollected implements InteractiveRepository.
+func (c *CachedInteractiveRepository) BatchCollected(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+	uid int64,
+) ([]int64, error) {
+	return c.dao.GetCollectedBizIDs(ctx, biz, bizIDs, uid)
+}
+
+// Delete implements InteractiveRepository.
+func (c *CachedInteractiveRepository) Delete(
+	ctx context.Context,
+	biz string,
+	bizIDs []int64,
+) error {
+	err := c.dao.DeleteByBizIDs(ctx, biz, bizIDs)
+	if err != nil {
+		return err
+	}
+	return c.cache.Del(ctx, biz, bizIDs)
+}
+
 // DeleteCollectionItem implements InteractiveRepository.
 func (c *CachedInteractiveRepository) DeleteCollectionItem(
 	ctx context.Context,
@@ -242,18 +445,49 @@ func (c *CachedInteractiveRepository) DeleteCollectionItem(
 	cid int64,
 	uid int64,
 ) error {
-	err := c.dao.DeleteCollectionBiz(ctx, dao.UserCollectionBiz{
+	uncollected, err := c.dao.DeleteCollectionBiz(ctx, dao.UserCollectionBiz{
 		UID:   uid,
 		BizID: id,
 		Biz:   biz,
+		CID:   cid,
 	})
-	if err != nil {
+	if err != nil || !uncollected {
 		return err
 	}
-
 	return c.cache.DecrCollectCntIfPresent(ctx, biz, id)
 }
 
+// MoveCollectionItem implements InteractiveRepository.
+func (c *CachedInteractiveRepository) MoveCollectionItem(
+	ctx context.Context,
+	biz string,
+	id int64,
+	uid int64,
+	fromCID int64,
+	toCID int64,
+) error {
+	err := c.dao.MoveCollectionBiz(ctx, uid, biz, id, fromCID, toCID)
+	if err == dao.ErrRecordNotFound {
+		return ErrCollectionItemNotFound
+	}
+	return err
+}
+
+// ListCollectionItems implements InteractiveRepository.
+func (c *CachedInteractiveRepository) ListCollectionItems(
+	ctx context.Context,
+	uid int64,
+	cid int64,
+	offset int,
+	limit int,
+) ([]domain.UserBiz, error) {
+	items, err := c.dao.ListCollectionBiz(ctx, uid, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(items, c.collectToDomain), nil
+}
+
 // AddCollectionItem implements InteractiveRepository.
 func (c *CachedInteractiveRepository) AddCollectionItem(
 	ctx context.Context,
@@ -263,7 +497,7 @@ func (c *CachedInteractiveRepository) AddCollectionItem(
 	uid int64,
 ) error {
 	now := time.Now().UnixMilli()
-	err := c.dao.InsertCollectionBiz(ctx, dao.UserCollectionBiz{
+	collected, err := c.dao.InsertCollectionBiz(ctx, dao.UserCollectionBiz{
 		UID:   uid,
 		BizID: id,
 		Biz:   biz,
@@ -271,10 +505,9 @@ func (c *CachedInteractiveRepository) AddCollectionItem(
 		Utime: now,
 		Ctime: now,
 	})
-	if err != nil {
+	if err != nil || !collected {
 		return err
 	}
-
 	return c.cache.IncrCollectCntIfPresent(ctx, biz, id)
 }
 
@@ -284,11 +517,10 @@ func (c *CachedInteractiveRepository) DecrLike(
 	id int64,
 	uid int64,
 ) error {
-	err := c.dao.DeleteLikeInfo(ctx, biz, id, uid)
-	if err != nil {
+	unliked, err := c.dao.DeleteLikeInfo(ctx, biz, id, uid)
+	if err != nil || !unliked {
 		return err
 	}
-
 	err = c.cache.DecrLikeCntIfPresent(ctx, biz, id)
 	if err != nil {
 		return err
@@ -302,11 +534,10 @@ func (c *CachedInteractiveRepository) IncrLike(
 	id int64,
 	uid int64,
 ) error {
-	err := c.dao.InsertLikeInfo(ctx, biz, id, uid)
-	if err != nil {
+	liked, err := c.dao.InsertLikeInfo(ctx, biz, id, uid)
+	if err != nil || !liked {
 		return err
 	}
-
 	err = c.cache.IncrLikeCntIfPresent(ctx, biz, id)
 	if err != nil {
 		return err
@@ -320,7 +551,7 @@ func (c *CachedInteractiveRepository) IncrReadCnt(
 	biz string,
 	bizID int64,
 ) error {
-	err := c.dao.IncrReadCnt(ctx, biz, bizID)
+	err := c.cnts.Incr(ctx, []domain.CntDelta{{Biz: biz, BizID: bizID, ReadCnt: 1}})
 	if err != nil {
 		return err
 	}
@@ -330,13 +561,40 @@ func (c *CachedInteractiveRepository) IncrReadCnt(
 	return c.cache.IncrReadCntIfPresent(ctx, biz, bizID)
 }
 
+// IncrTipCnt implements InteractiveRepository.
+func (c *CachedInteractiveRepository) IncrTipCnt(
+	ctx context.Context,
+	biz string,
+	bizID int64,
+	rewardID int64,
+) error {
+	tipped, err := c.dao.InsertTip(ctx, biz, bizID, rewardID)
+	if err != nil || !tipped {
+		return err
+	}
+	return c.cache.IncrTipCntIfPresent(ctx, biz, bizID)
+}
+
 // BatchIncrReadCnt implements InteractiveRepository.
 func (c *CachedInteractiveRepository) BatchIncrReadCnt(
 	ctx context.Context,
 	bizs []string,
 	bizIDs []int64,
 ) error {
-	err := c.dao.BatchIncrReadCnt(ctx, bizs, bizIDs)
+	// aggregated per resource
+	deltas := make([]domain.CntDelta, 0, len(bizIDs))
+	idx := make(map[domain.CntDelta]int, len(bizIDs))
+	for i, bizID := range bizIDs {
+		res := domain.CntDelta{Biz: bizs[i], BizID: bizID}
+		j, ok := idx[res]
+		if !ok {
+			j = len(deltas)
+			idx[res] = j
+			deltas = append(deltas, res)
+		}
+		deltas[j].ReadCnt++
+	}
+	err := c.cnts.Incr(ctx, deltas)
 	if err != nil {
 		return err
 	}
@@ -359,27 +617,285 @@ func (c *CachedInteractiveRepository) BatchIncrReadCnt(
 	return nil
 }
 
+// BatchAddReaders implements InteractiveRepository.
+func (c *CachedInteractiveRepository) BatchAddReaders(
+	ctx context.Context,
+	bizs []string,
+	bizIDs []int64,
+	uids []int64,
+) error {
+	return c.cache.AddReaders(ctx, bizs, bizIDs, uids)
+}
+
+// SnapshotUniqueReadCnts implements InteractiveRepository.
+// NOTE: the counts popped are lost if they cannot be saved, until the next
+// reader of the resource.
+func (c *CachedInteractiveRepository) SnapshotUniqueReadCnts(
+	ctx context.Context,
+	biz string,
+	limit int,
+) (int, error) {
+	cnts, err := c.cache.PopUniqueReadCnts(ctx, biz, limit)
+	if err != nil {
+		return 0, err
+	}
+	if len(cnts) == 0 {
+		return 0, nil
+	}
+	err = c.dao.SetUniqueReadCnts(ctx, biz, cnts)
+	if err != nil {
+		return 0, err
+	}
+	return len(cnts), nil
+}
+
+// ListLikes implements InteractiveRepository.
+func (c *CachedInteractiveRepository) ListLikes(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]domain.UserBiz, error) {
+	likes, err := c.dao.ListLikes(ctx, biz, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(likes, c.likeToDomain), nil
+}
+
+// ListCollects implements InteractiveRepository.
+func (c *CachedInteractiveRepository) ListCollects(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]domain.UserBiz, error) {
+	collects, err := c.dao.ListCollects(ctx, biz, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(collects, c.collectToDomain), nil
+}
+
+// ListUserLikes implements InteractiveRepository.
+func (c *CachedInteractiveRepository) ListUserLikes(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.UserBiz, error) {
+	likes, err := c.dao.ListUserLikes(ctx, uid, biz, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(likes, c.likeToDomain), nil
+}
+
+// ListUserCollects implements InteractiveRepository.
+func (c *CachedInteractiveRepository) ListUserCollects(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.UserBiz, error) {
+	collects, err := c.dao.ListUserCollects(ctx, uid, biz, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(collects, c.collectToDomain), nil
+}
+
+func (c *CachedInteractiveRepository) likeToDomain(id int, src dao.UserLikeBiz) domain.UserBiz {
+	return domain.UserBiz{
+		ID:    src.ID,
+		UID:   src.UID,
+		Biz:   src.Biz,
+		BizID: src.BizID,
+		Utime: time.UnixMilli(src.Utime),
+	}
+}
+
+func (c *CachedInteractiveRepository) collectToDomain(
+	id int,
+	src dao.UserCollectionBiz,
+) domain.UserBiz {
+	return domain.UserBiz{
+		ID:    src.ID,
+		UID:   src.UID,
+		Biz:   src.Biz,
+		BizID: src.BizID,
+		CID:   src.CID,
+		Utime: time.UnixMilli(src.Utime),
+	}
+}
+
+// ReconcileCnts implements InteractiveRepository.
+func (c *CachedInteractiveRepository) ReconcileCnts(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) (domain.CntReconciliation, error) {
+	intrs, err := c.dao.ListCnts(ctx, biz, afterID, limit)
+	if err != nil || len(intrs) == 0 {
+		return domain.CntReconciliation{LastID: afterID}, err
+	}
+	bizIDs := gslice.Map(intrs, func(id int, src dao.Interactive) int64 {
+		return src.BizID
+	})
+	recounted, err := c.dao.RecountCnts(ctx, biz, bizIDs)
+	if err != nil {
+		return domain.CntReconciliation{}, err
+	}
+	res := domain.CntReconciliation{
+		Checked: len(intrs),
+		LastID:  intrs[len(intrs)-1].ID,
+	}
+	repaired := make(map[int64]dao.RecountedCnts, len(recounted))
+	for _, r := range recounted {
+		repaired[r.BizID] = r
+		res.Drifts = append(res.Drifts, savedCntDrifts(biz, r)...)
+	}
+	// NOTE: the counters are saved before being cached, so the cache is read
+	// first. A counter changed during the check may still be reported, the
+	// next check repairs it if needed.
+	cached, err := c.cache.BatchGetIfPresent(ctx, biz, bizIDs)
+	if err != nil {
+		return domain.CntReconciliation{}, err
+	}
+	ranks, err := c.cache.GetLikeRanks(ctx, biz, bizIDs)
+	if err != nil {
+		return domain.CntReconciliation{}, err
+	}
+	pending, err := c.cnts.Pending(ctx, biz, bizIDs)
+	if err != nil {
+		return domain.CntReconciliation{}, err
+	}
+
+	var stale []int64
+	for i, intr := range intrs {
+		expected := c.toDomain(intr)
+		if r, ok := repaired[intr.BizID]; ok {
+			expected.LikeCnt = r.LikeCnt
+			expected.CollectCnt = r.CollectCnt
+		}
+		// the likes and the collects are not written behind
+		expected.ReadCnt += pending[i].ReadCnt
+		expected.TipCnt += pending[i].TipCnt
+
+		if got, ok := cached[intr.BizID]; ok {
+			drifts := cntDrifts(got, expected)
+			if len(drifts) > 0 {
+				stale = append(stale, intr.BizID)
+				res.Drifts = append(res.Drifts, drifts...)
+			}
+			res.Compared += 4
+		}
+		if rank, ok := ranks[intr.BizID]; ok {
+			if rank != expected.LikeCnt {
+				res.Drifts = append(res.Drifts, domain.CntDrift{
+					Biz:      biz,
+					BizID:    intr.BizID,
+					Counter:  cntLikeRank,
+					Cached:   rank,
+					Expected: expected.LikeCnt,
+				})
+				err = c.cache.SetLikeToZSET(ctx, biz, intr.BizID, expected.LikeCnt)
+				if err != nil {
+					return domain.CntReconciliation{}, err
+				}
+			}
+			res.Compared++
+		}
+	}
+	return res, c.cache.DelCnts(ctx, biz, stale)
+}
+
 func (c *CachedInteractiveRepository) toDomain(dao dao.Interactive) domain.Interactive {
 	return domain.Interactive{
-		Biz:        dao.Biz,
-		BizID:      dao.BizID,
-		ReadCnt:    dao.ReadCnt,
-		LikeCnt:    dao.LikeCnt,
-		CollectCnt: dao.CollectCnt,
+		Biz:           dao.Biz,
+		BizID:         dao.BizID,
+		ReadCnt:       dao.ReadCnt,
+		LikeCnt:       dao.LikeCnt,
+		CollectCnt:    dao.CollectCnt,
+		TipCnt:        dao.TipCnt,
+		UniqueReadCnt: dao.UniqueReadCnt,
 	}
 }
 
 func NewCachedInteractiveRepository(
 	l logger.Logger,
 	dao dao.InteractiveDAO,
+	cnts CntWriter,
 	cache cache.InteractiveCache,
 	topCache cache.TopArticlesCache,
 ) InteractiveRepository {
 	return &CachedInteractiveRepository{
-		l:                    l,
-		dao:                  dao,
-		cache:                cache,
-		topCache:             topCache,
-		defaultTopLikedLimit: 10,
+		l:        l,
+		dao:      dao,
+		cnts:     cnts,
+		cache:    cache,
+		topCache: topCache,
+	}
+}
+
+// names of the reconciled counters
+const (
+	cntRead         = "read_cnt"
+	cntLike         = "like_cnt"
+	cntCollect      = "collect_cnt"
+	cntTip          = "tip_cnt"
+	cntLikeRank     = "like_rank"
+	cntSavedLike    = "saved_like_cnt"
+	cntSavedCollect = "saved_collect_cnt"
+)
+
+func savedCntDrifts(biz string, r dao.RecountedCnts) []domain.CntDrift {
+	var res []domain.CntDrift
+	if r.SavedLikeCnt != r.LikeCnt {
+		res = append(res, domain.CntDrift{
+			Biz:      biz,
+			BizID:    r.BizID,
+			Counter:  cntSavedLike,
+			Cached:   r.SavedLikeCnt,
+			Expected: r.LikeCnt,
+		})
+	}
+	if r.SavedCollectCnt != r.CollectCnt {
+		res = append(res, domain.CntDrift{
+			Biz:      biz,
+			BizID:    r.BizID,
+			Counter:  cntSavedCollect,
+			Cached:   r.SavedCollectCnt,
+			Expected: r.CollectCnt,
+		})
+	}
+	return res
+}
+
+func cntDrifts(cached domain.Interactive, expected domain.Interactive) []domain.CntDrift {
+	var res []domain.CntDrift
+	for _, cnt := range []struct {
+		name     string
+		cached   int64
+		expected int64
+	}{
+		{cntRead, cached.ReadCnt, expected.ReadCnt},
+		{cntLike, cached.LikeCnt, expected.LikeCnt},
+		{cntCollect, cached.CollectCnt, expected.CollectCnt},
+		{cntTip, cached.TipCnt, expected.TipCnt},
+	} {
+		if cnt.cached != cnt.expected {
+			res = append(res, domain.CntDrift{
+				Biz:      expected.Biz,
+				BizID:    expected.BizID,
+				Counter:  cnt.name,
+				Cached:   cnt.cached,
+				Expected: cnt.expected,
+			})
+		}
 	}
+	return res
 }
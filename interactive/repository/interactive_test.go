@@ -0,0 +1,609 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/cache"
+	intrcachemocks "github.com/chenmuyao/go-bootcamp/interactive/repository/cache/mocks"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
+	intrdaomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/dao/mocks"
+	intrrepomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedInteractiveRepository_SnapshotUniqueReadCnts(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+
+		wantN   int
+		wantErr error
+	}{
+		{
+			name: "saved",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				cnts := map[int64]int64{1: 10, 2: 3}
+				c.EXPECT().PopUniqueReadCnts(gomock.Any(), "article", 2).Return(cnts, nil)
+				d.EXPECT().SetUniqueReadCnts(gomock.Any(), "article", cnts).Return(nil)
+				return d, c
+			},
+			wantN: 2,
+		},
+		{
+			name: "nothing to save",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().
+					PopUniqueReadCnts(gomock.Any(), "article", 2).
+					Return(map[int64]int64{}, nil)
+				return d, c
+			},
+		},
+		{
+			name: "cache error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().
+					PopUniqueReadCnts(gomock.Any(), "article", 2).
+					Return(nil, errors.New("mock error"))
+				return d, c
+			},
+			wantErr: errors.New("mock error"),
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				cnts := map[int64]int64{1: 10}
+				c.EXPECT().PopUniqueReadCnts(gomock.Any(), "article", 2).Return(cnts, nil)
+				d.EXPECT().
+					SetUniqueReadCnts(gomock.Any(), "article", cnts).
+					Return(errors.New("mock error"))
+				return d, c
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(logger.NewNopLogger(), d, nil, c, nil)
+			n, err := repo.SnapshotUniqueReadCnts(context.Background(), "article", 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantN, n)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_IncrTipCnt(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+
+		wantErr error
+	}{
+		{
+			name: "tipped",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().InsertTip(gomock.Any(), "article", int64(1), int64(42)).Return(true, nil)
+				c.EXPECT().IncrTipCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "already counted",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().InsertTip(gomock.Any(), "article", int64(1), int64(42)).Return(false, nil)
+				return d, nil
+			},
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().InsertTip(gomock.Any(), "article", int64(1), int64(42)).
+					Return(false, errors.New("mock error"))
+				return d, nil
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(logger.NewNopLogger(), d, nil, c, nil)
+			err := repo.IncrTipCnt(context.Background(), "article", 1, 42)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_Get(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache)
+
+		wantRes domain.Interactive
+	}{
+		{
+			name: "cached",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(domain.Interactive{Biz: "article", BizID: 1, ReadCnt: 12}, nil)
+				return nil, nil, c
+			},
+			wantRes: domain.Interactive{Biz: "article", BizID: 1, ReadCnt: 12},
+		},
+		{
+			name: "pending counters added and cached",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				w := intrrepomocks.NewMockCntWriter(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				want := domain.Interactive{Biz: "article", BizID: 1, ReadCnt: 12, LikeCnt: 3}
+				c.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(domain.Interactive{}, errors.New("cache miss"))
+				d.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(dao.Interactive{Biz: "article", BizID: 1, ReadCnt: 10, LikeCnt: 3}, nil)
+				w.EXPECT().Pending(gomock.Any(), "article", []int64{1}).
+					Return([]domain.CntDelta{{Biz: "article", BizID: 1, ReadCnt: 2}}, nil)
+				c.EXPECT().Set(gomock.Any(), "article", int64(1), want).Return(nil)
+				return d, w, c
+			},
+			wantRes: domain.Interactive{Biz: "article", BizID: 1, ReadCnt: 12, LikeCnt: 3},
+		},
+		{
+			name: "not saved yet",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				w := intrrepomocks.NewMockCntWriter(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				want := domain.Interactive{Biz: "article", BizID: 1, ReadCnt: 2}
+				c.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(domain.Interactive{}, errors.New("cache miss"))
+				d.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(dao.Interactive{}, dao.ErrRecordNotFound)
+				w.EXPECT().Pending(gomock.Any(), "article", []int64{1}).
+					Return([]domain.CntDelta{{Biz: "article", BizID: 1, ReadCnt: 2}}, nil)
+				c.EXPECT().Set(gomock.Any(), "article", int64(1), want).Return(nil)
+				return d, w, c
+			},
+			wantRes: domain.Interactive{Biz: "article", BizID: 1, ReadCnt: 2},
+		},
+		{
+			name: "pending error, not cached",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				w := intrrepomocks.NewMockCntWriter(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(domain.Interactive{}, errors.New("cache miss"))
+				d.EXPECT().Get(gomock.Any(), "article", int64(1)).
+					Return(dao.Interactive{Biz: "article", BizID: 1, ReadCnt: 10}, nil)
+				w.EXPECT().Pending(gomock.Any(), "article", []int64{1}).
+					Return(nil, errors.New("mock error"))
+				return d, w, c
+			},
+			wantRes: domain.Interactive{Biz: "article", BizID: 1, ReadCnt: 10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, w, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(logger.NewNopLogger(), d, w, c, nil)
+			res, err := repo.Get(context.Background(), "article", 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_GetByIDs(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache)
+
+		ids     []int64
+		wantRes []domain.Interactive
+		wantErr error
+	}{
+		{
+			name: "all cached",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().BatchGetIfPresent(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{
+						1: {Biz: "article", BizID: 1, ReadCnt: 12},
+						2: {Biz: "article", BizID: 2, LikeCnt: 1},
+					}, nil)
+				return nil, nil, c
+			},
+			ids: []int64{1, 2},
+			wantRes: []domain.Interactive{
+				{Biz: "article", BizID: 1, ReadCnt: 12},
+				{Biz: "article", BizID: 2, LikeCnt: 1},
+			},
+		},
+		{
+			name: "missed ones loaded with the pending counters",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				w := intrrepomocks.NewMockCntWriter(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				loaded := []domain.Interactive{
+					{Biz: "article", BizID: 1, ReadCnt: 12},
+					{Biz: "article", BizID: 3, ReadCnt: 1},
+				}
+				c.EXPECT().BatchGetIfPresent(gomock.Any(), "article", []int64{1, 2, 3}).
+					Return(map[int64]domain.Interactive{
+						2: {Biz: "article", BizID: 2, LikeCnt: 1},
+					}, nil)
+				d.EXPECT().GetByIDs(gomock.Any(), "article", []int64{1, 3}).
+					Return([]dao.Interactive{{Biz: "article", BizID: 1, ReadCnt: 10}}, nil)
+				w.EXPECT().Pending(gomock.Any(), "article", []int64{1, 3}).
+					Return([]domain.CntDelta{
+						{Biz: "article", BizID: 1, ReadCnt: 2},
+						{Biz: "article", BizID: 3, ReadCnt: 1},
+					}, nil)
+				c.EXPECT().BatchSet(gomock.Any(), "article", []int64{1, 3}, loaded).Return(nil)
+				return d, w, c
+			},
+			ids: []int64{1, 2, 3},
+			wantRes: []domain.Interactive{
+				{Biz: "article", BizID: 1, ReadCnt: 12},
+				{Biz: "article", BizID: 2, LikeCnt: 1},
+				{Biz: "article", BizID: 3, ReadCnt: 1},
+			},
+		},
+		{
+			name: "cache error, pending error, not cached",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				w := intrrepomocks.NewMockCntWriter(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().BatchGetIfPresent(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("mock error"))
+				d.EXPECT().GetByIDs(gomock.Any(), "article", []int64{1, 2}).
+					Return([]dao.Interactive{{Biz: "article", BizID: 1, ReadCnt: 10}}, nil)
+				w.EXPECT().Pending(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("mock error"))
+				return d, w, c
+			},
+			ids: []int64{1, 2},
+			wantRes: []domain.Interactive{
+				{Biz: "article", BizID: 1, ReadCnt: 10},
+				{Biz: "article", BizID: 2},
+			},
+		},
+		{
+			name: "db error",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().BatchGetIfPresent(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{}, nil)
+				d.EXPECT().GetByIDs(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("mock error"))
+				return d, nil, c
+			},
+			ids:     []int64{1, 2},
+			wantRes: []domain.Interactive{},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, w, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(logger.NewNopLogger(), d, w, c, nil)
+			res, err := repo.GetByIDs(context.Background(), "article", tc.ids)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_MustBatchGet(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache)
+
+		wantRes []domain.Interactive
+	}{
+		{
+			name: "pending counters added and cached",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				w := intrrepomocks.NewMockCntWriter(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				want := []domain.Interactive{
+					{Biz: "article", BizID: 1, ReadCnt: 12},
+					{Biz: "article", BizID: 2, TipCnt: 1},
+				}
+				c.EXPECT().MustBatchGet(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("cache miss"))
+				d.EXPECT().MustBatchGet(gomock.Any(), "article", []int64{1, 2}).
+					Return([]dao.Interactive{
+						{Biz: "article", BizID: 1, ReadCnt: 10},
+						{Biz: "article", BizID: 2},
+					}, nil)
+				w.EXPECT().Pending(gomock.Any(), "article", []int64{1, 2}).
+					Return([]domain.CntDelta{
+						{Biz: "article", BizID: 1, ReadCnt: 2},
+						{Biz: "article", BizID: 2, TipCnt: 1},
+					}, nil)
+				c.EXPECT().BatchSet(gomock.Any(), "article", []int64{1, 2}, want).Return(nil)
+				return d, w, c
+			},
+			wantRes: []domain.Interactive{
+				{Biz: "article", BizID: 1, ReadCnt: 12},
+				{Biz: "article", BizID: 2, TipCnt: 1},
+			},
+		},
+		{
+			name: "pending error, not cached",
+			mock: func(
+				ctrl *gomock.Controller,
+			) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				w := intrrepomocks.NewMockCntWriter(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().MustBatchGet(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("cache miss"))
+				d.EXPECT().MustBatchGet(gomock.Any(), "article", []int64{1, 2}).
+					Return([]dao.Interactive{
+						{Biz: "article", BizID: 1, ReadCnt: 10},
+						{Biz: "article", BizID: 2},
+					}, nil)
+				w.EXPECT().Pending(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("mock error"))
+				return d, w, c
+			},
+			wantRes: []domain.Interactive{
+				{Biz: "article", BizID: 1, ReadCnt: 10},
+				{Biz: "article", BizID: 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, w, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(logger.NewNopLogger(), d, w, c, nil)
+			res, err := repo.MustBatchGet(context.Background(), "article", []int64{1, 2})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_IncrLike(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache)
+
+		wantErr error
+	}{
+		{
+			name: "liked",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123)).Return(true, nil)
+				c.EXPECT().IncrLikeCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				c.EXPECT().IncrLikeRank(gomock.Any(), "article", int64(1)).Return(nil)
+				return d, nil, c
+			},
+		},
+		{
+			name: "already liked",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123)).Return(false, nil)
+				return d, nil, nil
+			},
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().
+					InsertLikeInfo(gomock.Any(), "article", int64(1), int64(123)).
+					Return(false, errors.New("mock error"))
+				return d, nil, nil
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, cnts, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(logger.NewNopLogger(), d, cnts, c, nil)
+			err := repo.IncrLike(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_DecrLike(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache)
+
+		wantErr error
+	}{
+		{
+			name: "unliked",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().DeleteLikeInfo(gomock.Any(), "article", int64(1), int64(123)).Return(true, nil)
+				c.EXPECT().DecrLikeCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				c.EXPECT().DecrLikeRank(gomock.Any(), "article", int64(1)).Return(nil)
+				return d, nil, c
+			},
+		},
+		{
+			name: "not liked",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().DeleteLikeInfo(gomock.Any(), "article", int64(1), int64(123)).Return(false, nil)
+				return d, nil, nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, cnts, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(logger.NewNopLogger(), d, cnts, c, nil)
+			err := repo.DecrLike(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_ReconcileCnts(t *testing.T) {
+	intrs := []dao.Interactive{
+		{ID: 10, Biz: "article", BizID: 1, ReadCnt: 7, LikeCnt: 3, CollectCnt: 1},
+		{ID: 11, Biz: "article", BizID: 2, ReadCnt: 2, LikeCnt: 5, CollectCnt: 2},
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache)
+
+		wantRes domain.CntReconciliation
+		wantErr error
+	}{
+		{
+			name: "saved counters repaired, then the cache",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				cnts := intrrepomocks.NewMockCntWriter(ctrl)
+				c := intrcachemocks.NewMockInteractiveCache(ctrl)
+				bizIDs := []int64{1, 2}
+				gomock.InOrder(
+					d.EXPECT().ListCnts(gomock.Any(), "article", int64(0), 2).Return(intrs, nil),
+					d.EXPECT().RecountCnts(gomock.Any(), "article", bizIDs).
+						Return([]dao.RecountedCnts{
+							{BizID: 2, SavedLikeCnt: 5, LikeCnt: 4, SavedCollectCnt: 2, CollectCnt: 2},
+						}, nil),
+					c.EXPECT().BatchGetIfPresent(gomock.Any(), "article", bizIDs).
+						Return(map[int64]domain.Interactive{
+							1: {Biz: "article", BizID: 1, ReadCnt: 8, LikeCnt: 3, CollectCnt: 1},
+							2: {Biz: "article", BizID: 2, ReadCnt: 2, LikeCnt: 5, CollectCnt: 2},
+						}, nil),
+					c.EXPECT().GetLikeRanks(gomock.Any(), "article", bizIDs).
+						Return(map[int64]int64{2: 5}, nil),
+					cnts.EXPECT().Pending(gomock.Any(), "article", bizIDs).
+						Return([]domain.CntDelta{
+							{Biz: "article", BizID: 1, ReadCnt: 1},
+							{Biz: "article", BizID: 2},
+						}, nil),
+					c.EXPECT().SetLikeToZSET(gomock.Any(), "article", int64(2), int64(4)).Return(nil),
+					c.EXPECT().DelCnts(gomock.Any(), "article", []int64{2}).Return(nil),
+				)
+				return d, cnts, c
+			},
+			wantRes: domain.CntReconciliation{
+				Checked:  2,
+				LastID:   11,
+				Compared: 9,
+				Drifts: []domain.CntDrift{
+					{Biz: "article", BizID: 2, Counter: "saved_like_cnt", Cached: 5, Expected: 4},
+					{Biz: "article", BizID: 2, Counter: "like_cnt", Cached: 5, Expected: 4},
+					{Biz: "article", BizID: 2, Counter: "like_rank", Cached: 5, Expected: 4},
+				},
+			},
+		},
+		{
+			name: "recount error",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().ListCnts(gomock.Any(), "article", int64(0), 2).Return(intrs, nil)
+				d.EXPECT().RecountCnts(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("mock error"))
+				return d, nil, nil
+			},
+			wantErr: errors.New("mock error"),
+		},
+		{
+			name: "end of the scan",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, CntWriter, cache.InteractiveCache) {
+				d := intrdaomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().ListCnts(gomock.Any(), "article", int64(0), 2).Return(nil, nil)
+				return d, nil, nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, cnts, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(logger.NewNopLogger(), d, cnts, c, nil)
+			res, err := repo.ReconcileCnts(context.Background(), "article", 0, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
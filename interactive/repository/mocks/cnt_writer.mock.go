@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cnt_writer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./cnt_writer.go -package=intrrepomocks -destination=./mocks/cnt_writer.mock.go
+//
+
+// Package intrrepomocks is a generated GoMock package.
+package intrrepomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/chenmuyao/go-bootcamp/interactive/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCntWriter is a mock of CntWriter interface.
+type MockCntWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCntWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockCntWriterMockRecorder is the mock recorder for MockCntWriter.
+type MockCntWriterMockRecorder struct {
+	mock *MockCntWriter
+}
+
+// NewMockCntWriter creates a new mock instance.
+func NewMockCntWriter(ctrl *gomock.Controller) *MockCntWriter {
+	mock := &MockCntWriter{ctrl: ctrl}
+	mock.recorder = &MockCntWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCntWriter) EXPECT() *MockCntWriterMockRecorder {
+	return m.recorder
+}
+
+// Incr mocks base method.
+func (m *MockCntWriter) Incr(ctx context.Context, deltas []domain.CntDelta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockCntWriterMockRecorder) Incr(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockCntWriter)(nil).Incr), ctx, deltas)
+}
+
+// Pending mocks base method.
+func (m *MockCntWriter) Pending(ctx context.Context, biz string, bizIDs []int64) ([]domain.CntDelta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", ctx, biz, bizIDs)
+	ret0, _ := ret[0].([]domain.CntDelta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockCntWriterMockRecorder) Pending(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockCntWriter)(nil).Pending), ctx, biz, bizIDs)
+}
@@ -55,6 +55,35 @@ func (mr *MockInteractiveRepositoryMockRecorder) AddCollectionItem(ctx, biz, id,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).AddCollectionItem), ctx, biz, id, cid, uid)
 }
 
+// BatchAddReaders mocks base method.
+func (m *MockInteractiveRepository) BatchAddReaders(ctx context.Context, bizs []string, bizIDs, uids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchAddReaders", ctx, bizs, bizIDs, uids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchAddReaders indicates an expected call of BatchAddReaders.
+func (mr *MockInteractiveRepositoryMockRecorder) BatchAddReaders(ctx, bizs, bizIDs, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchAddReaders", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchAddReaders), ctx, bizs, bizIDs, uids)
+}
+
+// BatchCollected mocks base method.
+func (m *MockInteractiveRepository) BatchCollected(ctx context.Context, biz string, bizIDs []int64, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCollected", ctx, biz, bizIDs, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCollected indicates an expected call of BatchCollected.
+func (mr *MockInteractiveRepositoryMockRecorder) BatchCollected(ctx, biz, bizIDs, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCollected", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchCollected), ctx, biz, bizIDs, uid)
+}
+
 // BatchIncrReadCnt mocks base method.
 func (m *MockInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIDs []int64) error {
 	m.ctrl.T.Helper()
@@ -69,6 +98,21 @@ func (mr *MockInteractiveRepositoryMockRecorder) BatchIncrReadCnt(ctx, bizs, biz
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchIncrReadCnt), ctx, bizs, bizIDs)
 }
 
+// BatchLiked mocks base method.
+func (m *MockInteractiveRepository) BatchLiked(ctx context.Context, biz string, bizIDs []int64, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchLiked", ctx, biz, bizIDs, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchLiked indicates an expected call of BatchLiked.
+func (mr *MockInteractiveRepositoryMockRecorder) BatchLiked(ctx, biz, bizIDs, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchLiked", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchLiked), ctx, biz, bizIDs, uid)
+}
+
 // BatchSetTopLike mocks base method.
 func (m *MockInteractiveRepository) BatchSetTopLike(ctx context.Context, biz string, batchSize int) error {
 	m.ctrl.T.Helper()
@@ -112,6 +156,20 @@ func (mr *MockInteractiveRepositoryMockRecorder) DecrLike(ctx, biz, id, uid any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLike", reflect.TypeOf((*MockInteractiveRepository)(nil).DecrLike), ctx, biz, id, uid)
 }
 
+// Delete mocks base method.
+func (m *MockInteractiveRepository) Delete(ctx context.Context, biz string, bizIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, biz, bizIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractiveRepositoryMockRecorder) Delete(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractiveRepository)(nil).Delete), ctx, biz, bizIDs)
+}
+
 // DeleteCollectionItem mocks base method.
 func (m *MockInteractiveRepository) DeleteCollectionItem(ctx context.Context, biz string, id, cid, uid int64) error {
 	m.ctrl.T.Helper()
@@ -199,6 +257,20 @@ func (mr *MockInteractiveRepositoryMockRecorder) IncrReadCnt(ctx, biz, bizID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrReadCnt), ctx, biz, bizID)
 }
 
+// IncrTipCnt mocks base method.
+func (m *MockInteractiveRepository) IncrTipCnt(ctx context.Context, biz string, bizID, rewardID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrTipCnt", ctx, biz, bizID, rewardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrTipCnt indicates an expected call of IncrTipCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) IncrTipCnt(ctx, biz, bizID, rewardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrTipCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrTipCnt), ctx, biz, bizID, rewardID)
+}
+
 // Liked mocks base method.
 func (m *MockInteractiveRepository) Liked(ctx context.Context, biz string, bizID, uid int64) (bool, error) {
 	m.ctrl.T.Helper()
@@ -214,6 +286,95 @@ func (mr *MockInteractiveRepositoryMockRecorder) Liked(ctx, biz, bizID, uid any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liked", reflect.TypeOf((*MockInteractiveRepository)(nil).Liked), ctx, biz, bizID, uid)
 }
 
+// ListCollectionItems mocks base method.
+func (m *MockInteractiveRepository) ListCollectionItems(ctx context.Context, uid, cid int64, offset, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionItems", ctx, uid, cid, offset, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractiveRepositoryMockRecorder) ListCollectionItems(ctx, uid, cid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractiveRepository)(nil).ListCollectionItems), ctx, uid, cid, offset, limit)
+}
+
+// ListCollects mocks base method.
+func (m *MockInteractiveRepository) ListCollects(ctx context.Context, biz string, afterID int64, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollects", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollects indicates an expected call of ListCollects.
+func (mr *MockInteractiveRepositoryMockRecorder) ListCollects(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollects", reflect.TypeOf((*MockInteractiveRepository)(nil).ListCollects), ctx, biz, afterID, limit)
+}
+
+// ListLikes mocks base method.
+func (m *MockInteractiveRepository) ListLikes(ctx context.Context, biz string, afterID int64, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikes", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikes indicates an expected call of ListLikes.
+func (mr *MockInteractiveRepositoryMockRecorder) ListLikes(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockInteractiveRepository)(nil).ListLikes), ctx, biz, afterID, limit)
+}
+
+// ListUserCollects mocks base method.
+func (m *MockInteractiveRepository) ListUserCollects(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserCollects", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserCollects indicates an expected call of ListUserCollects.
+func (mr *MockInteractiveRepositoryMockRecorder) ListUserCollects(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCollects", reflect.TypeOf((*MockInteractiveRepository)(nil).ListUserCollects), ctx, uid, biz, cursor, limit)
+}
+
+// ListUserLikes mocks base method.
+func (m *MockInteractiveRepository) ListUserLikes(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLikes", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLikes indicates an expected call of ListUserLikes.
+func (mr *MockInteractiveRepositoryMockRecorder) ListUserLikes(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLikes", reflect.TypeOf((*MockInteractiveRepository)(nil).ListUserLikes), ctx, uid, biz, cursor, limit)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractiveRepository) MoveCollectionItem(ctx context.Context, biz string, id, uid, fromCID, toCID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItem", ctx, biz, id, uid, fromCID, toCID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractiveRepositoryMockRecorder) MoveCollectionItem(ctx, biz, id, uid, fromCID, toCID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).MoveCollectionItem), ctx, biz, id, uid, fromCID, toCID)
+}
+
 // MustBatchGet mocks base method.
 func (m *MockInteractiveRepository) MustBatchGet(ctx context.Context, biz string, bizIDs []int64) ([]domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -228,3 +389,33 @@ func (mr *MockInteractiveRepositoryMockRecorder) MustBatchGet(ctx, biz, bizIDs a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustBatchGet", reflect.TypeOf((*MockInteractiveRepository)(nil).MustBatchGet), ctx, biz, bizIDs)
 }
+
+// ReconcileCnts mocks base method.
+func (m *MockInteractiveRepository) ReconcileCnts(ctx context.Context, biz string, afterID int64, limit int) (domain.CntReconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileCnts", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].(domain.CntReconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileCnts indicates an expected call of ReconcileCnts.
+func (mr *MockInteractiveRepositoryMockRecorder) ReconcileCnts(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileCnts", reflect.TypeOf((*MockInteractiveRepository)(nil).ReconcileCnts), ctx, biz, afterID, limit)
+}
+
+// SnapshotUniqueReadCnts mocks base method.
+func (m *MockInteractiveRepository) SnapshotUniqueReadCnts(ctx context.Context, biz string, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotUniqueReadCnts", ctx, biz, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotUniqueReadCnts indicates an expected call of SnapshotUniqueReadCnts.
+func (mr *MockInteractiveRepositoryMockRecorder) SnapshotUniqueReadCnts(ctx, biz, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotUniqueReadCnts", reflect.TypeOf((*MockInteractiveRepository)(nil).SnapshotUniqueReadCnts), ctx, biz, limit)
+}
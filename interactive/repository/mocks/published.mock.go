@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./published.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./published.go -package=intrrepomocks -destination=./mocks/published.mock.go
+//
+
+// Package intrrepomocks is a generated GoMock package.
+package intrrepomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublishedRepository is a mock of PublishedRepository interface.
+type MockPublishedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublishedRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPublishedRepositoryMockRecorder is the mock recorder for MockPublishedRepository.
+type MockPublishedRepositoryMockRecorder struct {
+	mock *MockPublishedRepository
+}
+
+// NewMockPublishedRepository creates a new mock instance.
+func NewMockPublishedRepository(ctrl *gomock.Controller) *MockPublishedRepository {
+	mock := &MockPublishedRepository{ctrl: ctrl}
+	mock.recorder = &MockPublishedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublishedRepository) EXPECT() *MockPublishedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ArticleExists mocks base method.
+func (m *MockPublishedRepository) ArticleExists(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArticleExists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArticleExists indicates an expected call of ArticleExists.
+func (mr *MockPublishedRepositoryMockRecorder) ArticleExists(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArticleExists", reflect.TypeOf((*MockPublishedRepository)(nil).ArticleExists), ctx, id)
+}
+
+// ItineraryExists mocks base method.
+func (m *MockPublishedRepository) ItineraryExists(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ItineraryExists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ItineraryExists indicates an expected call of ItineraryExists.
+func (mr *MockPublishedRepositoryMockRecorder) ItineraryExists(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItineraryExists", reflect.TypeOf((*MockPublishedRepository)(nil).ItineraryExists), ctx, id)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
+)
+
+//go:generate mockgen -source=./outbox.go -package=intrrepomocks -destination=./mocks/outbox.mock.go
+type OutboxRepository interface {
+	// ListPending returns the oldest events recorded with the changes and not
+	// relayed yet.
+	ListPending(ctx context.Context, limit int) ([]domain.InteractiveEvent, error)
+	// Delete removes the relayed events.
+	Delete(ctx context.Context, ids []int64) error
+}
+
+type GORMOutboxRepository struct {
+	dao dao.OutboxDAO
+}
+
+// ListPending implements OutboxRepository.
+func (g *GORMOutboxRepository) ListPending(
+	ctx context.Context,
+	limit int,
+) ([]domain.InteractiveEvent, error) {
+	evts, err := g.dao.ListPending(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(evts, func(id int, src dao.OutboxEvent) domain.InteractiveEvent {
+		return domain.InteractiveEvent{
+			ID:    src.ID,
+			Type:  domain.EventType(src.Type),
+			Biz:   src.Biz,
+			BizID: src.BizID,
+			UID:   src.UID,
+			Time:  time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
+
+// Delete implements OutboxRepository.
+func (g *GORMOutboxRepository) Delete(ctx context.Context, ids []int64) error {
+	return g.dao.Delete(ctx, ids)
+}
+
+func NewGORMOutboxRepository(dao dao.OutboxDAO) OutboxRepository {
+	return &GORMOutboxRepository{
+		dao: dao,
+	}
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
+)
+
+//go:generate mockgen -source=./published.go -package=intrrepomocks -destination=./mocks/published.mock.go
+
+// PublishedRepository checks the resources interacted with.
+type PublishedRepository interface {
+	// ArticleExists returns true if the article is published and public.
+	ArticleExists(ctx context.Context, id int64) (bool, error)
+	// ItineraryExists returns true if the itinerary is published and public.
+	ItineraryExists(ctx context.Context, id int64) (bool, error)
+}
+
+// NOTE: no cache, only the likes and the collects check the resources.
+type GORMPublishedRepository struct {
+	dao dao.PublishedDAO
+}
+
+// ArticleExists implements PublishedRepository.
+func (g *GORMPublishedRepository) ArticleExists(ctx context.Context, id int64) (bool, error) {
+	return g.dao.ArticlePublished(ctx, id)
+}
+
+// ItineraryExists implements PublishedRepository.
+func (g *GORMPublishedRepository) ItineraryExists(ctx context.Context, id int64) (bool, error) {
+	return g.dao.ItineraryPublished(ctx, id)
+}
+
+func NewGORMPublishedRepository(dao dao.PublishedDAO) PublishedRepository {
+	return &GORMPublishedRepository{
+		dao: dao,
+	}
+}
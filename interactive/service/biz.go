@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+)
+
+var (
+	ErrUnknownBiz       = errors.New("unknown biz")
+	ErrActionNotAllowed = errors.New("action not allowed for the biz")
+	ErrResourceNotFound = errors.New("resource not found")
+)
+
+// ExistChecker tells if a resource exists, usually by asking the service
+// owning it.
+type ExistChecker interface {
+	Exists(ctx context.Context, bizID int64) (bool, error)
+}
+
+type ExistCheckerFunc func(ctx context.Context, bizID int64) (bool, error)
+
+// Exists implements ExistChecker.
+func (f ExistCheckerFunc) Exists(ctx context.Context, bizID int64) (bool, error) {
+	return f(ctx, bizID)
+}
+
+// BizConfig registers a biz. The resources are not checked if Exists is nil.
+type BizConfig struct {
+	domain.Biz
+	Exists ExistChecker
+}
+
+// BizRegistry holds the bizs the service accepts.
+type BizRegistry struct {
+	bizs  map[string]BizConfig
+	names []string
+}
+
+// Get fails with ErrUnknownBiz if biz is not registered.
+func (r *BizRegistry) Get(biz string) (domain.Biz, error) {
+	cfg, ok := r.bizs[biz]
+	if !ok {
+		return domain.Biz{}, ErrUnknownBiz
+	}
+	return cfg.Biz, nil
+}
+
+// List returns the bizs in the order of registration.
+func (r *BizRegistry) List() []domain.Biz {
+	res := make([]domain.Biz, 0, len(r.names))
+	for _, name := range r.names {
+		res = append(res, r.bizs[name].Biz)
+	}
+	return res
+}
+
+// Check fails if biz is unknown or doesn't allow action.
+func (r *BizRegistry) Check(biz string, action domain.BizAction) error {
+	b, err := r.Get(biz)
+	if err != nil {
+		return err
+	}
+	if !b.Allows(action) {
+		return ErrActionNotAllowed
+	}
+	return nil
+}
+
+// CheckExists fails with ErrResourceNotFound if the resource doesn't exist.
+func (r *BizRegistry) CheckExists(ctx context.Context, biz string, bizID int64) error {
+	cfg, ok := r.bizs[biz]
+	if !ok {
+		return ErrUnknownBiz
+	}
+	if cfg.Exists == nil {
+		return nil
+	}
+	ok, err := cfg.Exists.Exists(ctx, bizID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrResourceNotFound
+	}
+	return nil
+}
+
+// NewBizRegistry panics if a biz is registered twice.
+func NewBizRegistry(cfgs ...BizConfig) *BizRegistry {
+	r := &BizRegistry{
+		bizs:  make(map[string]BizConfig, len(cfgs)),
+		names: make([]string, 0, len(cfgs)),
+	}
+	for _, cfg := range cfgs {
+		if _, ok := r.bizs[cfg.Name]; ok {
+			panic("biz registered twice: " + cfg.Name)
+		}
+		r.bizs[cfg.Name] = cfg
+		r.names = append(r.names, cfg.Name)
+	}
+	return r
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	intrrepomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBizRegistry_Check(t *testing.T) {
+	testCases := []struct {
+		name   string
+		biz    string
+		action domain.BizAction
+
+		wantErr error
+	}{
+		{
+			name:   "allowed",
+			biz:    "article",
+			action: domain.BizActionCollect,
+		},
+		{
+			name:    "unknown biz",
+			biz:     "video",
+			action:  domain.BizActionRead,
+			wantErr: ErrUnknownBiz,
+		},
+		{
+			name:    "action not allowed",
+			biz:     "article",
+			action:  domain.BizActionLike,
+			wantErr: ErrActionNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := newTestBizRegistry().Check(tc.biz, tc.action)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestBizRegistry_CheckExists(t *testing.T) {
+	exists := ExistCheckerFunc(func(ctx context.Context, bizID int64) (bool, error) {
+		switch bizID {
+		case 1:
+			return true, nil
+		case 2:
+			return false, nil
+		}
+		return false, errors.New("mock error")
+	})
+	r := NewBizRegistry(
+		BizConfig{Biz: domain.Biz{Name: "article"}, Exists: exists},
+		BizConfig{Biz: domain.Biz{Name: "itinerary"}},
+	)
+	testCases := []struct {
+		name  string
+		biz   string
+		bizID int64
+
+		wantErr error
+	}{
+		{
+			name:  "exists",
+			biz:   "article",
+			bizID: 1,
+		},
+		{
+			name:    "not found",
+			biz:     "article",
+			bizID:   2,
+			wantErr: ErrResourceNotFound,
+		},
+		{
+			name:    "check error",
+			biz:     "article",
+			bizID:   3,
+			wantErr: errors.New("mock error"),
+		},
+		{
+			name:  "not checked",
+			biz:   "itinerary",
+			bizID: 2,
+		},
+		{
+			name:    "unknown biz",
+			biz:     "video",
+			bizID:   1,
+			wantErr: ErrUnknownBiz,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := r.CheckExists(context.Background(), tc.biz, tc.bizID)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestNewBizRegistry(t *testing.T) {
+	r := NewBizRegistry(
+		BizConfig{Biz: domain.Biz{Name: "itinerary"}},
+		BizConfig{Biz: domain.Biz{Name: "article"}},
+	)
+	assert.Equal(t, []domain.Biz{{Name: "itinerary"}, {Name: "article"}}, r.List())
+	_, err := r.Get("video")
+	assert.Equal(t, ErrUnknownBiz, err)
+
+	assert.Panics(t, func() {
+		NewBizRegistry(
+			BizConfig{Biz: domain.Biz{Name: "article"}},
+			BizConfig{Biz: domain.Biz{Name: "article"}},
+		)
+	})
+}
+
+func Test_interactiveService_Like(t *testing.T) {
+	testCases := []struct {
+		name string
+		biz  string
+
+		wantErr error
+	}{
+		{
+			name:    "unknown biz",
+			biz:     "video",
+			wantErr: ErrUnknownBiz,
+		},
+		{
+			name:    "action not allowed",
+			biz:     "article",
+			wantErr: ErrActionNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// the repository is never called
+			repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+			svc := NewInteractiveService(repo, nil, newTestBizRegistry())
+			err := svc.Like(context.Background(), tc.biz, 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_interactiveService_IncrTipCnt(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+		bizs *BizRegistry
+		biz  string
+
+		wantErr error
+	}{
+		{
+			name: "counted",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().IncrTipCnt(gomock.Any(), "article", int64(1), int64(42)).Return(nil)
+				return repo
+			},
+			bizs: NewBizRegistry(BizConfig{Biz: domain.Biz{
+				Name:    "article",
+				Actions: []domain.BizAction{domain.BizActionTip},
+			}}),
+			biz: "article",
+		},
+		{
+			name: "unknown biz",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				return intrrepomocks.NewMockInteractiveRepository(ctrl)
+			},
+			bizs:    newTestBizRegistry(),
+			biz:     "video",
+			wantErr: ErrUnknownBiz,
+		},
+		{
+			name: "action not allowed",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				return intrrepomocks.NewMockInteractiveRepository(ctrl)
+			},
+			bizs:    newTestBizRegistry(),
+			biz:     "article",
+			wantErr: ErrActionNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewInteractiveService(tc.mock(ctrl), nil, tc.bizs)
+			err := svc.IncrTipCnt(context.Background(), tc.biz, 1, 42)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
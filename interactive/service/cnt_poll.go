@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+)
+
+// cntPoll polls the counters of a set of resources for all its subscribers,
+// and broadcasts each result by closing updated.
+type cntPoll struct {
+	key    string
+	subs   int
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	intrs   map[int64]domain.Interactive
+	err     error
+	updated chan struct{}
+}
+
+func (p *cntPoll) run(
+	ctx context.Context,
+	interval time.Duration,
+	get func(ctx context.Context) (map[int64]domain.Interactive, error),
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for ctx.Err() == nil {
+		intrs, err := get(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		p.mu.Lock()
+		p.intrs, p.err = intrs, err
+		close(p.updated)
+		p.updated = make(chan struct{})
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// latest returns the last polled counters, nil before the first poll, and a
+// channel closed by the next poll.
+func (p *cntPoll) latest() (map[int64]domain.Interactive, <-chan struct{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.intrs, p.updated, p.err
+}
+
+// cntPolls shares a poll between the subscriptions to the same resources.
+type cntPolls struct {
+	mu    sync.Mutex
+	polls map[string]*cntPoll
+}
+
+// subscribe starts polling the resources if they have no subscriber yet.
+func (c *cntPolls) subscribe(
+	biz string,
+	ids []int64,
+	interval time.Duration,
+	get func(ctx context.Context, biz string, ids []int64) (map[int64]domain.Interactive, error),
+) *cntPoll {
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	key := cntPollKey(biz, ids)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.polls[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		p = &cntPoll{
+			key:     key,
+			cancel:  cancel,
+			updated: make(chan struct{}),
+		}
+		c.polls[key] = p
+		go p.run(ctx, interval, func(ctx context.Context) (map[int64]domain.Interactive, error) {
+			return get(ctx, biz, ids)
+		})
+	}
+	p.subs++
+	return p
+}
+
+// unsubscribe stops the poll after its last subscriber.
+func (c *cntPolls) unsubscribe(p *cntPoll) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p.subs--
+	if p.subs == 0 {
+		p.cancel()
+		delete(c.polls, p.key)
+	}
+}
+
+func cntPollKey(biz string, ids []int64) string {
+	var sb strings.Builder
+	sb.WriteString(biz)
+	for _, id := range ids {
+		fmt.Fprintf(&sb, ":%d", id)
+	}
+	return sb.String()
+}
+
+func newCntPolls() *cntPolls {
+	return &cntPolls{
+		polls: make(map[string]*cntPoll),
+	}
+}
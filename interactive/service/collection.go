@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+)
+
+const maxCollectionNameLen = 64
+
+var (
+	ErrCollectionNotFound     = repository.ErrCollectionNotFound
+	ErrCollectionItemNotFound = repository.ErrCollectionItemNotFound
+	ErrDuplicatedCollection   = repository.ErrDuplicatedCollection
+	ErrInvalidCollectionName  = errors.New("invalid collection name")
+)
+
+// MoveCollectionItem implements InteractiveService.
+func (i *interactiveService) MoveCollectionItem(
+	ctx context.Context,
+	biz string,
+	id int64,
+	uid int64,
+	fromCID int64,
+	toCID int64,
+) error {
+	err := i.bizs.Check(biz, domain.BizActionCollect)
+	if err != nil {
+		return err
+	}
+	if fromCID == toCID {
+		return nil
+	}
+	for _, cid := range []int64{fromCID, toCID} {
+		err := i.checkCollection(ctx, uid, cid)
+		if err != nil {
+			return err
+		}
+	}
+	return i.repo.MoveCollectionItem(ctx, biz, id, uid, fromCID, toCID)
+}
+
+// CreateCollection implements InteractiveService.
+func (i *interactiveService) CreateCollection(
+	ctx context.Context,
+	c domain.Collection,
+) (domain.Collection, error) {
+	var err error
+	c.Name, err = normalizeCollectionName(c.Name)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	id, err := i.collectionRepo.Create(ctx, c)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return i.collectionRepo.Get(ctx, id)
+}
+
+// UpdateCollection implements InteractiveService.
+func (i *interactiveService) UpdateCollection(ctx context.Context, c domain.Collection) error {
+	var err error
+	c.Name, err = normalizeCollectionName(c.Name)
+	if err != nil {
+		return err
+	}
+	return i.collectionRepo.Update(ctx, c)
+}
+
+// DeleteCollection implements InteractiveService.
+func (i *interactiveService) DeleteCollection(ctx context.Context, uid int64, id int64) error {
+	return i.collectionRepo.Delete(ctx, uid, id)
+}
+
+// ListCollections implements InteractiveService.
+func (i *interactiveService) ListCollections(
+	ctx context.Context,
+	uid int64,
+	viewerUID int64,
+) ([]domain.Collection, error) {
+	cs, err := i.collectionRepo.List(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := cs[:0]
+	for _, c := range cs {
+		if c.CanView(viewerUID) {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+// ListCollectionItems implements InteractiveService.
+func (i *interactiveService) ListCollectionItems(
+	ctx context.Context,
+	cid int64,
+	viewerUID int64,
+	offset int,
+	limit int,
+) ([]domain.UserBiz, error) {
+	uid := viewerUID
+	if cid != 0 {
+		c, err := i.collectionRepo.Get(ctx, cid)
+		if err != nil {
+			return nil, err
+		}
+		// NOTE: don't tell the others that a private collection exists
+		if !c.CanView(viewerUID) {
+			return nil, ErrCollectionNotFound
+		}
+		uid = c.UID
+	}
+	return i.repo.ListCollectionItems(ctx, uid, cid, offset, limit)
+}
+
+// checkCollection returns ErrCollectionNotFound if cid is not a collection
+// of uid.
+func (i *interactiveService) checkCollection(ctx context.Context, uid int64, cid int64) error {
+	if cid == 0 {
+		return nil
+	}
+	c, err := i.collectionRepo.Get(ctx, cid)
+	if err != nil {
+		return err
+	}
+	if c.UID != uid {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+func normalizeCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCollectionNameLen {
+		return "", ErrInvalidCollectionName
+	}
+	return name, nil
+}
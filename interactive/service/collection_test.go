@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	intrrepomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestBizRegistry() *BizRegistry {
+	return NewBizRegistry(BizConfig{
+		Biz: domain.Biz{
+			Name:     domain.BizArticle,
+			Actions:  []domain.BizAction{domain.BizActionRead, domain.BizActionCollect},
+			Counters: []domain.Counter{domain.CounterRead, domain.CounterCollect},
+		},
+	})
+}
+
+func Test_interactiveService_Collect(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (
+			repository.InteractiveRepository,
+			repository.CollectionRepository,
+		)
+
+		biz     string
+		cid     int64
+		wantErr error
+	}{
+		{
+			name: "default collection",
+			mock: func(ctrl *gomock.Controller) (
+				repository.InteractiveRepository,
+				repository.CollectionRepository,
+			) {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(0), int64(123)).
+					Return(nil)
+				return repo, nil
+			},
+			biz: "article",
+		},
+		{
+			name: "own collection",
+			mock: func(ctrl *gomock.Controller) (
+				repository.InteractiveRepository,
+				repository.CollectionRepository,
+			) {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				collectionRepo := intrrepomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().Get(gomock.Any(), int64(2)).
+					Return(domain.Collection{ID: 2, UID: 123}, nil)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(2), int64(123)).
+					Return(nil)
+				return repo, collectionRepo
+			},
+			biz: "article",
+			cid: 2,
+		},
+		{
+			name: "collection of another user",
+			mock: func(ctrl *gomock.Controller) (
+				repository.InteractiveRepository,
+				repository.CollectionRepository,
+			) {
+				collectionRepo := intrrepomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().Get(gomock.Any(), int64(2)).
+					Return(domain.Collection{ID: 2, UID: 456, Public: true}, nil)
+				return nil, collectionRepo
+			},
+			biz:     "article",
+			cid:     2,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "unknown collection",
+			mock: func(ctrl *gomock.Controller) (
+				repository.InteractiveRepository,
+				repository.CollectionRepository,
+			) {
+				collectionRepo := intrrepomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().Get(gomock.Any(), int64(2)).
+					Return(domain.Collection{}, repository.ErrCollectionNotFound)
+				return nil, collectionRepo
+			},
+			biz:     "article",
+			cid:     2,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "unknown biz",
+			mock: func(ctrl *gomock.Controller) (
+				repository.InteractiveRepository,
+				repository.CollectionRepository,
+			) {
+				return nil, nil
+			},
+			biz:     "video",
+			wantErr: ErrUnknownBiz,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, collectionRepo := tc.mock(ctrl)
+			svc := NewInteractiveService(repo, collectionRepo, newTestBizRegistry())
+			err := svc.Collect(context.Background(), tc.biz, 1, tc.cid, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_interactiveService_CreateCollection(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.CollectionRepository
+
+		collection domain.Collection
+		wantRes    domain.Collection
+		wantErr    error
+	}{
+		{
+			name: "created",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				collectionRepo := intrrepomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().Create(gomock.Any(), domain.Collection{
+					UID:  123,
+					Name: "travel",
+				}).Return(int64(2), nil)
+				collectionRepo.EXPECT().Get(gomock.Any(), int64(2)).
+					Return(domain.Collection{ID: 2, UID: 123, Name: "travel"}, nil)
+				return collectionRepo
+			},
+			collection: domain.Collection{UID: 123, Name: "  travel "},
+			wantRes:    domain.Collection{ID: 2, UID: 123, Name: "travel"},
+		},
+		{
+			name: "duplicated",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				collectionRepo := intrrepomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().Create(gomock.Any(), domain.Collection{
+					UID:  123,
+					Name: "travel",
+				}).Return(int64(0), repository.ErrDuplicatedCollection)
+				return collectionRepo
+			},
+			collection: domain.Collection{UID: 123, Name: "travel"},
+			wantErr:    ErrDuplicatedCollection,
+		},
+		{
+			name: "empty name",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				return nil
+			},
+			collection: domain.Collection{UID: 123, Name: "  "},
+			wantErr:    ErrInvalidCollectionName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewInteractiveService(nil, tc.mock(ctrl), newTestBizRegistry())
+			res, err := svc.CreateCollection(context.Background(), tc.collection)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func Test_interactiveService_ListCollectionItems(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (
+			repository.InteractiveRepository,
+			repository.CollectionRepository,
+		)
+
+		cid     int64
+		wantRes []domain.UserBiz
+		wantErr error
+	}{
+		{
+			name: "default collection of the viewer",
+			mock: func(ctrl *gomock.Controller) (
+				repository.InteractiveRepository,
+				repository.CollectionRepository,
+			) {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().ListCollectionItems(gomock.Any(), int64(123), int64(0), 0, 10).
+					Return([]domain.UserBiz{{UID: 123, Biz: "article", BizID: 1}}, nil)
+				return repo, nil
+			},
+			wantRes: []domain.UserBiz{{UID: 123, Biz: "article", BizID: 1}},
+		},
+		{
+			name: "public collection of another user",
+			mock: func(ctrl *gomock.Controller) (
+				repository.InteractiveRepository,
+				repository.CollectionRepository,
+			) {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				collectionRepo := intrrepomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().Get(gomock.Any(), int64(2)).
+					Return(domain.Collection{ID: 2, UID: 456, Public: true}, nil)
+				repo.EXPECT().ListCollectionItems(gomock.Any(), int64(456), int64(2), 0, 10).
+					Return([]domain.UserBiz{{UID: 456, Biz: "article", BizID: 1, CID: 2}}, nil)
+				return repo, collectionRepo
+			},
+			cid:     2,
+			wantRes: []domain.UserBiz{{UID: 456, Biz: "article", BizID: 1, CID: 2}},
+		},
+		{
+			name: "private collection of another user",
+			mock: func(ctrl *gomock.Controller) (
+				repository.InteractiveRepository,
+				repository.CollectionRepository,
+			) {
+				collectionRepo := intrrepomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().Get(gomock.Any(), int64(2)).
+					Return(domain.Collection{ID: 2, UID: 456}, nil)
+				return nil, collectionRepo
+			},
+			cid:     2,
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, collectionRepo := tc.mock(ctrl)
+			svc := NewInteractiveService(repo, collectionRepo, newTestBizRegistry())
+			res, err := svc.ListCollectionItems(context.Background(), tc.cid, 123, 0, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -2,30 +2,119 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/chenmuyao/go-bootcamp/interactive/domain"
 	"github.com/chenmuyao/go-bootcamp/interactive/repository"
 	"golang.org/x/sync/errgroup"
 )
 
+// maxBatchIDs is the maximum number of resources of BatchGet and
+// SubscribeCnts.
+const maxBatchIDs = 100
+
+var ErrTooManyIDs = errors.New("too many ids")
+
+// NOTE: the methods fail with ErrUnknownBiz if biz is not registered.
+//
 //go:generate mockgen -source=./interactive.go -package=intrsvcmocks -destination=./mocks/interactive.mock.go
 type InteractiveService interface {
 	IncrReadCnt(ctx context.Context, biz string, bizID int64) error
+	// IncrTipCnt counts the paid reward rewardID once.
+	IncrTipCnt(ctx context.Context, biz string, bizID int64, rewardID int64) error
 	Like(ctx context.Context, biz string, id int64, uid int64) error
 	CancelLike(ctx context.Context, biz string, id int64, uid int64) error
+	// Collect and CancelCollect fail with ErrCollectionNotFound if cid is
+	// not a collection of uid, 0 is the default collection.
 	Collect(ctx context.Context, biz string, id int64, cid int64, uid int64) error
 	CancelCollect(ctx context.Context, biz string, id int64, cid int64, uid int64) error
+	MoveCollectionItem(
+		ctx context.Context,
+		biz string,
+		id int64,
+		uid int64,
+		fromCID int64,
+		toCID int64,
+	) error
+	CreateCollection(ctx context.Context, c domain.Collection) (domain.Collection, error)
+	// UpdateCollection renames the collection and changes its visibility.
+	UpdateCollection(ctx context.Context, c domain.Collection) error
+	// DeleteCollection moves the items to the default collection.
+	DeleteCollection(ctx context.Context, uid int64, id int64) error
+	// ListCollections returns the collections of uid visible by viewerUID.
+	ListCollections(ctx context.Context, uid int64, viewerUID int64) ([]domain.Collection, error)
+	// ListCollectionItems lists a collection visible by viewerUID, the
+	// latest collected first. cid 0 is the default collection of viewerUID.
+	ListCollectionItems(
+		ctx context.Context,
+		cid int64,
+		viewerUID int64,
+		offset int,
+		limit int,
+	) ([]domain.UserBiz, error)
 	Get(ctx context.Context, biz string, id int64, uid int64) (domain.Interactive, error)
 	// NOTE: Intr must exist
 	MustBatchGet(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
 	GetByIDs(ctx context.Context, biz string, ids []int64) (map[int64]domain.Interactive, error)
+	// BatchGet returns the counters of the resources, and whether uid liked
+	// and collected them. The missing resources have no interactions yet.
+	BatchGet(
+		ctx context.Context,
+		biz string,
+		ids []int64,
+		uid int64,
+	) (map[int64]domain.Interactive, error)
+	// SubscribeCnts calls send with the counters of the resources, then with
+	// the changed ones, until ctx is done or send fails.
+	SubscribeCnts(
+		ctx context.Context,
+		biz string,
+		ids []int64,
+		send func(intrs []domain.Interactive) error,
+	) error
 	GetTopLike(ctx context.Context, biz string, limit int) ([]int64, error)
+	// Delete cleans up the interactive data of deleted resources.
+	Delete(ctx context.Context, biz string, ids []int64) error
+	// SnapshotUniqueReadCnts persists at most limit unique reader counts,
+	// and returns how many are saved.
+	SnapshotUniqueReadCnts(ctx context.Context, biz string, limit int) (int, error)
+	// ReconcileCnts repairs the saved likes and collects, then the cached
+	// counters, of at most limit resources with an ID greater than afterID.
+	ReconcileCnts(
+		ctx context.Context,
+		biz string,
+		afterID int64,
+		limit int,
+	) (domain.CntReconciliation, error)
+	ListLikes(ctx context.Context, biz string, afterID int64, limit int) ([]domain.UserBiz, error)
+	ListCollects(ctx context.Context, biz string, afterID int64, limit int) ([]domain.UserBiz, error)
+	// ListUserLikes lists the resources liked by uid, the latest first.
+	ListUserLikes(
+		ctx context.Context,
+		uid int64,
+		biz string,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.UserBiz, error)
+	// ListUserCollects lists the resources collected by uid in any of the
+	// collections, the latest first.
+	ListUserCollects(
+		ctx context.Context,
+		uid int64,
+		biz string,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.UserBiz, error)
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
+	repo           repository.InteractiveRepository
+	collectionRepo repository.CollectionRepository
+	bizs           *BizRegistry
 
-	defaultTopLikeLimit int
+	cntPollInterval time.Duration
+	cntPolls        *cntPolls
 }
 
 // GetByIDs implements InteractiveService.
@@ -34,6 +123,10 @@ func (i *interactiveService) GetByIDs(
 	biz string,
 	ids []int64,
 ) (map[int64]domain.Interactive, error) {
+	b, err := i.bizs.Get(biz)
+	if err != nil {
+		return nil, err
+	}
 	intrs, err := i.repo.GetByIDs(ctx, biz, ids)
 	if err != nil {
 		return nil, err
@@ -41,21 +134,151 @@ func (i *interactiveService) GetByIDs(
 
 	res := make(map[int64]domain.Interactive)
 	for _, intr := range intrs {
-		res[intr.BizID] = intr
+		res[intr.BizID] = b.Mask(intr)
+	}
+	return res, nil
+}
+
+// BatchGet implements InteractiveService.
+func (i *interactiveService) BatchGet(
+	ctx context.Context,
+	biz string,
+	ids []int64,
+	uid int64,
+) (map[int64]domain.Interactive, error) {
+	if len(ids) > maxBatchIDs {
+		return nil, ErrTooManyIDs
+	}
+	b, err := i.bizs.Get(biz)
+	if err != nil {
+		return nil, err
+	}
+	intrs, err := i.repo.GetByIDs(ctx, biz, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.Interactive, len(ids))
+	for _, id := range ids {
+		res[id] = domain.Interactive{Biz: biz, BizID: id}
+	}
+	for _, intr := range intrs {
+		res[intr.BizID] = b.Mask(intr)
+	}
+	// anonymous users
+	if uid <= 0 {
+		return res, nil
+	}
+
+	var liked, collected []int64
+	var eg errgroup.Group
+	eg.Go(func() error {
+		var er error
+		liked, er = i.repo.BatchLiked(ctx, biz, ids, uid)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		collected, er = i.repo.BatchCollected(ctx, biz, ids, uid)
+		return er
+	})
+	err = eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range liked {
+		intr := res[id]
+		intr.Liked = true
+		res[id] = intr
+	}
+	for _, id := range collected {
+		intr := res[id]
+		intr.Collected = true
+		res[id] = intr
 	}
 	return res, nil
 }
 
+// SubscribeCnts implements InteractiveService.
+// NOTE: the counters are polled, so that the changes made through any
+// instance are seen, once per interval for all the subscribers to the same
+// resources. They are saved behind, and may be seen a flush late.
+func (i *interactiveService) SubscribeCnts(
+	ctx context.Context,
+	biz string,
+	ids []int64,
+	send func(intrs []domain.Interactive) error,
+) error {
+	if len(ids) > maxBatchIDs {
+		return ErrTooManyIDs
+	}
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return err
+	}
+	p := i.cntPolls.subscribe(biz, ids, i.cntPollInterval,
+		func(ctx context.Context, biz string, ids []int64) (map[int64]domain.Interactive, error) {
+			return i.BatchGet(ctx, biz, ids, 0)
+		})
+	defer i.cntPolls.unsubscribe(p)
+	sent := make(map[int64]domain.Interactive, len(ids))
+	for {
+		intrs, updated, err := p.latest()
+		if err != nil {
+			return err
+		}
+		changed := make([]domain.Interactive, 0, len(ids))
+		for _, id := range ids {
+			intr, ok := intrs[id]
+			if !ok {
+				// not polled yet
+				continue
+			}
+			prev, ok := sent[id]
+			if !ok || prev != intr {
+				sent[id] = intr
+				changed = append(changed, intr)
+			}
+		}
+		if len(changed) > 0 {
+			err = send(changed)
+			if err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
 // GetTopLike implements InteractiveService.
 func (i *interactiveService) GetTopLike(
 	ctx context.Context,
 	biz string,
 	limit int,
 ) ([]int64, error) {
-	if limit <= 0 || limit > i.defaultTopLikeLimit {
-		limit = i.defaultTopLikeLimit
+	b, err := i.bizs.Get(biz)
+	if err != nil {
+		return nil, err
+	}
+	if !b.Allows(domain.BizActionLike) {
+		return nil, ErrActionNotAllowed
 	}
-	return i.repo.GetTopLike(ctx, biz, limit)
+	if limit <= 0 || limit > b.TopLikeSize {
+		limit = b.TopLikeSize
+	}
+	// NOTE: the whole cached rank is asked, so that it is the same for any
+	// limit.
+	ids, err := i.repo.GetTopLike(ctx, biz, b.TopLikeSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
 }
 
 // BatchGet implements InteractiveService.
@@ -64,7 +287,18 @@ func (i *interactiveService) MustBatchGet(
 	biz string,
 	ids []int64,
 ) ([]domain.Interactive, error) {
-	return i.repo.MustBatchGet(ctx, biz, ids)
+	b, err := i.bizs.Get(biz)
+	if err != nil {
+		return nil, err
+	}
+	intrs, err := i.repo.MustBatchGet(ctx, biz, ids)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range intrs {
+		intrs[idx] = b.Mask(intrs[idx])
+	}
+	return intrs, nil
 }
 
 // Get implements InteractiveService.
@@ -74,10 +308,15 @@ func (i *interactiveService) Get(
 	id int64,
 	uid int64,
 ) (domain.Interactive, error) {
+	b, err := i.bizs.Get(biz)
+	if err != nil {
+		return domain.Interactive{}, err
+	}
 	intr, err := i.repo.Get(ctx, biz, id)
 	if err != nil {
 		return domain.Interactive{}, err
 	}
+	intr = b.Mask(intr)
 	// NOTE: can consider degrading
 	var eg errgroup.Group
 	eg.Go(func() error {
@@ -93,6 +332,103 @@ func (i *interactiveService) Get(
 	return intr, eg.Wait()
 }
 
+// Delete implements InteractiveService.
+func (i *interactiveService) Delete(ctx context.Context, biz string, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return err
+	}
+	return i.repo.Delete(ctx, biz, ids)
+}
+
+// SnapshotUniqueReadCnts implements InteractiveService.
+func (i *interactiveService) SnapshotUniqueReadCnts(
+	ctx context.Context,
+	biz string,
+	limit int,
+) (int, error) {
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return 0, err
+	}
+	return i.repo.SnapshotUniqueReadCnts(ctx, biz, limit)
+}
+
+// ReconcileCnts implements InteractiveService.
+func (i *interactiveService) ReconcileCnts(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) (domain.CntReconciliation, error) {
+	_, err := i.bizs.Get(biz)
+	if err != nil {
+		return domain.CntReconciliation{}, err
+	}
+	return i.repo.ReconcileCnts(ctx, biz, afterID, limit)
+}
+
+// ListLikes implements InteractiveService.
+func (i *interactiveService) ListLikes(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]domain.UserBiz, error) {
+	err := i.bizs.Check(biz, domain.BizActionLike)
+	if err != nil {
+		return nil, err
+	}
+	return i.repo.ListLikes(ctx, biz, afterID, limit)
+}
+
+// ListCollects implements InteractiveService.
+func (i *interactiveService) ListCollects(
+	ctx context.Context,
+	biz string,
+	afterID int64,
+	limit int,
+) ([]domain.UserBiz, error) {
+	err := i.bizs.Check(biz, domain.BizActionCollect)
+	if err != nil {
+		return nil, err
+	}
+	return i.repo.ListCollects(ctx, biz, afterID, limit)
+}
+
+// ListUserLikes implements InteractiveService.
+func (i *interactiveService) ListUserLikes(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.UserBiz, error) {
+	err := i.bizs.Check(biz, domain.BizActionLike)
+	if err != nil {
+		return nil, err
+	}
+	return i.repo.ListUserLikes(ctx, uid, biz, cursor, limit)
+}
+
+// ListUserCollects implements InteractiveService.
+func (i *interactiveService) ListUserCollects(
+	ctx context.Context,
+	uid int64,
+	biz string,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.UserBiz, error) {
+	err := i.bizs.Check(biz, domain.BizActionCollect)
+	if err != nil {
+		return nil, err
+	}
+	return i.repo.ListUserCollects(ctx, uid, biz, cursor, limit)
+}
+
 // CancelCollect implements InteractiveService.
 func (i *interactiveService) CancelCollect(
 	ctx context.Context,
@@ -101,6 +437,14 @@ func (i *interactiveService) CancelCollect(
 	cid int64,
 	uid int64,
 ) error {
+	err := i.bizs.Check(biz, domain.BizActionCollect)
+	if err != nil {
+		return err
+	}
+	err = i.checkCollection(ctx, uid, cid)
+	if err != nil {
+		return err
+	}
 	return i.repo.DeleteCollectionItem(ctx, biz, id, cid, uid)
 }
 
@@ -112,6 +456,18 @@ func (i *interactiveService) Collect(
 	cid int64,
 	uid int64,
 ) error {
+	err := i.bizs.Check(biz, domain.BizActionCollect)
+	if err != nil {
+		return err
+	}
+	err = i.checkCollection(ctx, uid, cid)
+	if err != nil {
+		return err
+	}
+	err = i.bizs.CheckExists(ctx, biz, id)
+	if err != nil {
+		return err
+	}
 	return i.repo.AddCollectionItem(ctx, biz, id, cid, uid)
 }
 
@@ -122,22 +478,64 @@ func (i *interactiveService) CancelLike(
 	id int64,
 	uid int64,
 ) error {
+	err := i.bizs.Check(biz, domain.BizActionLike)
+	if err != nil {
+		return err
+	}
 	return i.repo.DecrLike(ctx, biz, id, uid)
 }
 
 // Like implements InteractiveService.
 func (i *interactiveService) Like(ctx context.Context, biz string, id int64, uid int64) error {
+	err := i.bizs.Check(biz, domain.BizActionLike)
+	if err != nil {
+		return err
+	}
+	err = i.bizs.CheckExists(ctx, biz, id)
+	if err != nil {
+		return err
+	}
 	return i.repo.IncrLike(ctx, biz, id, uid)
 }
 
 // IncrReadCnt implements InteractiveService.
+// NOTE: the resources are not checked, the reads are reported by the service
+// owning them.
 func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizID int64) error {
+	err := i.bizs.Check(biz, domain.BizActionRead)
+	if err != nil {
+		return err
+	}
 	return i.repo.IncrReadCnt(ctx, biz, bizID)
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository) InteractiveService {
+// IncrTipCnt implements InteractiveService.
+// NOTE: the resources are not checked, the rewards are paid by the reward
+// service.
+func (i *interactiveService) IncrTipCnt(
+	ctx context.Context,
+	biz string,
+	bizID int64,
+	rewardID int64,
+) error {
+	err := i.bizs.Check(biz, domain.BizActionTip)
+	if err != nil {
+		return err
+	}
+	return i.repo.IncrTipCnt(ctx, biz, bizID, rewardID)
+}
+
+func NewInteractiveService(
+	repo repository.InteractiveRepository,
+	collectionRepo repository.CollectionRepository,
+	bizs *BizRegistry,
+) InteractiveService {
 	return &interactiveService{
-		repo:                repo,
-		defaultTopLikeLimit: 10,
+		repo:           repo,
+		collectionRepo: collectionRepo,
+		bizs:           bizs,
+
+		cntPollInterval: time.Second,
+		cntPolls:        newCntPolls(),
 	}
 }
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	"github.com/chenmuyao/go-bootcamp/interactive/repository"
+	intrrepomocks "github.com/chenmuyao/go-bootcamp/interactive/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_interactiveService_BatchGet(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+
+		biz     string
+		ids     []int64
+		uid     int64
+		wantRes map[int64]domain.Interactive
+		wantErr error
+	}{
+		{
+			name: "with the state of the user",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), "article", []int64{1, 2, 3}).
+					Return([]domain.Interactive{
+						{Biz: "article", BizID: 1, ReadCnt: 10, LikeCnt: 2, CollectCnt: 1},
+						{Biz: "article", BizID: 2, ReadCnt: 3},
+					}, nil)
+				repo.EXPECT().BatchLiked(gomock.Any(), "article", []int64{1, 2, 3}, int64(123)).
+					Return([]int64{1}, nil)
+				repo.EXPECT().BatchCollected(gomock.Any(), "article", []int64{1, 2, 3}, int64(123)).
+					Return([]int64{1, 3}, nil)
+				return repo
+			},
+			biz: "article",
+			ids: []int64{1, 2, 3},
+			uid: 123,
+			// the likes are not counted for the articles
+			wantRes: map[int64]domain.Interactive{
+				1: {
+					Biz:        "article",
+					BizID:      1,
+					ReadCnt:    10,
+					CollectCnt: 1,
+					Liked:      true,
+					Collected:  true,
+				},
+				2: {Biz: "article", BizID: 2, ReadCnt: 3},
+				3: {Biz: "article", BizID: 3, Collected: true},
+			},
+		},
+		{
+			name: "anonymous user",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), "article", []int64{1}).
+					Return([]domain.Interactive{{Biz: "article", BizID: 1, ReadCnt: 10}}, nil)
+				return repo
+			},
+			biz:     "article",
+			ids:     []int64{1},
+			wantRes: map[int64]domain.Interactive{1: {Biz: "article", BizID: 1, ReadCnt: 10}},
+		},
+		{
+			name: "too many ids",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				return nil
+			},
+			biz:     "article",
+			ids:     make([]int64, maxBatchIDs+1),
+			wantErr: ErrTooManyIDs,
+		},
+		{
+			name: "unknown biz",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				return nil
+			},
+			biz:     "video",
+			ids:     []int64{1},
+			wantErr: ErrUnknownBiz,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewInteractiveService(tc.mock(ctrl), nil, newTestBizRegistry())
+			res, err := svc.BatchGet(context.Background(), tc.biz, tc.ids, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func Test_interactiveService_SubscribeCnts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().GetByIDs(gomock.Any(), "article", []int64{1, 2}).
+			Return([]domain.Interactive{
+				{Biz: "article", BizID: 1, ReadCnt: 1},
+				{Biz: "article", BizID: 2, ReadCnt: 1},
+			}, nil),
+		repo.EXPECT().GetByIDs(gomock.Any(), "article", []int64{1, 2}).
+			Return([]domain.Interactive{
+				{Biz: "article", BizID: 1, ReadCnt: 1},
+				{Biz: "article", BizID: 2, ReadCnt: 2},
+			}, nil).
+			AnyTimes(),
+	)
+	svc := NewInteractiveService(repo, nil, newTestBizRegistry()).(*interactiveService)
+	svc.cntPollInterval = 10 * time.Millisecond
+
+	errDone := errors.New("done")
+	var sent []domain.Interactive
+	err := svc.SubscribeCnts(context.Background(), "article", []int64{1, 2},
+		func(intrs []domain.Interactive) error {
+			sent = append(sent, intrs...)
+			if intrs[len(intrs)-1].ReadCnt == 2 {
+				return errDone
+			}
+			return nil
+		})
+	assert.Equal(t, errDone, err)
+	// the unchanged counters are sent once
+	assert.Equal(t, domain.Interactive{Biz: "article", BizID: 1, ReadCnt: 1}, sent[0])
+	for _, intr := range sent[1:] {
+		assert.Equal(t, int64(2), intr.BizID)
+	}
+	assert.Empty(t, svc.cntPolls.polls)
+}
+
+func Test_interactiveService_SubscribeCntsShared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	polled := make(chan struct{})
+	repo := intrrepomocks.NewMockInteractiveRepository(ctrl)
+	// a single poll for both subscribers
+	repo.EXPECT().GetByIDs(gomock.Any(), "article", []int64{1, 2}).
+		DoAndReturn(func(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
+			<-polled
+			return []domain.Interactive{
+				{Biz: "article", BizID: 1, ReadCnt: 1},
+				{Biz: "article", BizID: 2, ReadCnt: 3},
+			}, nil
+		})
+	svc := NewInteractiveService(repo, nil, newTestBizRegistry()).(*interactiveService)
+	svc.cntPollInterval = time.Hour
+
+	errDone := errors.New("done")
+	subscribe := func(ids []int64) []domain.Interactive {
+		var sent []domain.Interactive
+		err := svc.SubscribeCnts(context.Background(), "article", ids,
+			func(intrs []domain.Interactive) error {
+				sent = intrs
+				return errDone
+			})
+		assert.Equal(t, errDone, err)
+		return sent
+	}
+
+	var wg sync.WaitGroup
+	var sent1, sent2 []domain.Interactive
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		sent1 = subscribe([]int64{1, 2})
+	}()
+	go func() {
+		defer wg.Done()
+		sent2 = subscribe([]int64{2, 1, 2})
+	}()
+	assert.Eventually(t, func() bool {
+		svc.cntPolls.mu.Lock()
+		defer svc.cntPolls.mu.Unlock()
+		p, ok := svc.cntPolls.polls["article:1:2"]
+		return ok && p.subs == 2
+	}, time.Second, time.Millisecond)
+	close(polled)
+	wg.Wait()
+
+	assert.Equal(t, []domain.Interactive{
+		{Biz: "article", BizID: 1, ReadCnt: 1},
+		{Biz: "article", BizID: 2, ReadCnt: 3},
+	}, sent1)
+	assert.Equal(t, []domain.Interactive{
+		{Biz: "article", BizID: 2, ReadCnt: 3},
+		{Biz: "article", BizID: 1, ReadCnt: 1},
+	}, sent2)
+	assert.Empty(t, svc.cntPolls.polls)
+}
+
+func Test_interactiveService_SubscribeCntsInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		biz  string
+		ids  []int64
+
+		wantErr error
+	}{
+		{
+			name:    "too many ids",
+			biz:     "article",
+			ids:     make([]int64, maxBatchIDs+1),
+			wantErr: ErrTooManyIDs,
+		},
+		{
+			name:    "unknown biz",
+			biz:     "video",
+			ids:     []int64{1},
+			wantErr: ErrUnknownBiz,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewInteractiveService(nil, nil, newTestBizRegistry())
+			err := svc.SubscribeCnts(context.Background(), tc.biz, tc.ids,
+				func(intrs []domain.Interactive) error {
+					return nil
+				})
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
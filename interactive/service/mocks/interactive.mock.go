@@ -41,6 +41,21 @@ func (m *MockInteractiveService) EXPECT() *MockInteractiveServiceMockRecorder {
 	return m.recorder
 }
 
+// BatchGet mocks base method.
+func (m *MockInteractiveService) BatchGet(ctx context.Context, biz string, ids []int64, uid int64) (map[int64]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGet", ctx, biz, ids, uid)
+	ret0, _ := ret[0].(map[int64]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet.
+func (mr *MockInteractiveServiceMockRecorder) BatchGet(ctx, biz, ids, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockInteractiveService)(nil).BatchGet), ctx, biz, ids, uid)
+}
+
 // CancelCollect mocks base method.
 func (m *MockInteractiveService) CancelCollect(ctx context.Context, biz string, id, cid, uid int64) error {
 	m.ctrl.T.Helper()
@@ -83,6 +98,49 @@ func (mr *MockInteractiveServiceMockRecorder) Collect(ctx, biz, id, cid, uid any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveService)(nil).Collect), ctx, biz, id, cid, uid)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractiveService) CreateCollection(ctx context.Context, c domain.Collection) (domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, c)
+	ret0, _ := ret[0].(domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractiveServiceMockRecorder) CreateCollection(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractiveService)(nil).CreateCollection), ctx, c)
+}
+
+// Delete mocks base method.
+func (m *MockInteractiveService) Delete(ctx context.Context, biz string, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, biz, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractiveServiceMockRecorder) Delete(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractiveService)(nil).Delete), ctx, biz, ids)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractiveService) DeleteCollection(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractiveServiceMockRecorder) DeleteCollection(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractiveService)(nil).DeleteCollection), ctx, uid, id)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveService) Get(ctx context.Context, biz string, id, uid int64) (domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +200,20 @@ func (mr *MockInteractiveServiceMockRecorder) IncrReadCnt(ctx, biz, bizID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveService)(nil).IncrReadCnt), ctx, biz, bizID)
 }
 
+// IncrTipCnt mocks base method.
+func (m *MockInteractiveService) IncrTipCnt(ctx context.Context, biz string, bizID, rewardID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrTipCnt", ctx, biz, bizID, rewardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrTipCnt indicates an expected call of IncrTipCnt.
+func (mr *MockInteractiveServiceMockRecorder) IncrTipCnt(ctx, biz, bizID, rewardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrTipCnt", reflect.TypeOf((*MockInteractiveService)(nil).IncrTipCnt), ctx, biz, bizID, rewardID)
+}
+
 // Like mocks base method.
 func (m *MockInteractiveService) Like(ctx context.Context, biz string, id, uid int64) error {
 	m.ctrl.T.Helper()
@@ -156,6 +228,110 @@ func (mr *MockInteractiveServiceMockRecorder) Like(ctx, biz, id, uid any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, biz, id, uid)
 }
 
+// ListCollectionItems mocks base method.
+func (m *MockInteractiveService) ListCollectionItems(ctx context.Context, cid, viewerUID int64, offset, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionItems", ctx, cid, viewerUID, offset, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractiveServiceMockRecorder) ListCollectionItems(ctx, cid, viewerUID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractiveService)(nil).ListCollectionItems), ctx, cid, viewerUID, offset, limit)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractiveService) ListCollections(ctx context.Context, uid, viewerUID int64) ([]domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", ctx, uid, viewerUID)
+	ret0, _ := ret[0].([]domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractiveServiceMockRecorder) ListCollections(ctx, uid, viewerUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractiveService)(nil).ListCollections), ctx, uid, viewerUID)
+}
+
+// ListCollects mocks base method.
+func (m *MockInteractiveService) ListCollects(ctx context.Context, biz string, afterID int64, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollects", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollects indicates an expected call of ListCollects.
+func (mr *MockInteractiveServiceMockRecorder) ListCollects(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollects", reflect.TypeOf((*MockInteractiveService)(nil).ListCollects), ctx, biz, afterID, limit)
+}
+
+// ListLikes mocks base method.
+func (m *MockInteractiveService) ListLikes(ctx context.Context, biz string, afterID int64, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikes", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikes indicates an expected call of ListLikes.
+func (mr *MockInteractiveServiceMockRecorder) ListLikes(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockInteractiveService)(nil).ListLikes), ctx, biz, afterID, limit)
+}
+
+// ListUserCollects mocks base method.
+func (m *MockInteractiveService) ListUserCollects(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserCollects", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserCollects indicates an expected call of ListUserCollects.
+func (mr *MockInteractiveServiceMockRecorder) ListUserCollects(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCollects", reflect.TypeOf((*MockInteractiveService)(nil).ListUserCollects), ctx, uid, biz, cursor, limit)
+}
+
+// ListUserLikes mocks base method.
+func (m *MockInteractiveService) ListUserLikes(ctx context.Context, uid int64, biz string, cursor domain.Cursor, limit int) ([]domain.UserBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLikes", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]domain.UserBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLikes indicates an expected call of ListUserLikes.
+func (mr *MockInteractiveServiceMockRecorder) ListUserLikes(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLikes", reflect.TypeOf((*MockInteractiveService)(nil).ListUserLikes), ctx, uid, biz, cursor, limit)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractiveService) MoveCollectionItem(ctx context.Context, biz string, id, uid, fromCID, toCID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItem", ctx, biz, id, uid, fromCID, toCID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractiveServiceMockRecorder) MoveCollectionItem(ctx, biz, id, uid, fromCID, toCID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractiveService)(nil).MoveCollectionItem), ctx, biz, id, uid, fromCID, toCID)
+}
+
 // MustBatchGet mocks base method.
 func (m *MockInteractiveService) MustBatchGet(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -170,3 +346,61 @@ func (mr *MockInteractiveServiceMockRecorder) MustBatchGet(ctx, biz, ids any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustBatchGet", reflect.TypeOf((*MockInteractiveService)(nil).MustBatchGet), ctx, biz, ids)
 }
+
+// ReconcileCnts mocks base method.
+func (m *MockInteractiveService) ReconcileCnts(ctx context.Context, biz string, afterID int64, limit int) (domain.CntReconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileCnts", ctx, biz, afterID, limit)
+	ret0, _ := ret[0].(domain.CntReconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileCnts indicates an expected call of ReconcileCnts.
+func (mr *MockInteractiveServiceMockRecorder) ReconcileCnts(ctx, biz, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileCnts", reflect.TypeOf((*MockInteractiveService)(nil).ReconcileCnts), ctx, biz, afterID, limit)
+}
+
+// SnapshotUniqueReadCnts mocks base method.
+func (m *MockInteractiveService) SnapshotUniqueReadCnts(ctx context.Context, biz string, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotUniqueReadCnts", ctx, biz, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotUniqueReadCnts indicates an expected call of SnapshotUniqueReadCnts.
+func (mr *MockInteractiveServiceMockRecorder) SnapshotUniqueReadCnts(ctx, biz, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotUniqueReadCnts", reflect.TypeOf((*MockInteractiveService)(nil).SnapshotUniqueReadCnts), ctx, biz, limit)
+}
+
+// SubscribeCnts mocks base method.
+func (m *MockInteractiveService) SubscribeCnts(ctx context.Context, biz string, ids []int64, send func([]domain.Interactive) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeCnts", ctx, biz, ids, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeCnts indicates an expected call of SubscribeCnts.
+func (mr *MockInteractiveServiceMockRecorder) SubscribeCnts(ctx, biz, ids, send any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeCnts", reflect.TypeOf((*MockInteractiveService)(nil).SubscribeCnts), ctx, biz, ids, send)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockInteractiveService) UpdateCollection(ctx context.Context, c domain.Collection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollection", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockInteractiveServiceMockRecorder) UpdateCollection(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockInteractiveService)(nil).UpdateCollection), ctx, c)
+}
@@ -23,10 +23,18 @@ var thirdPartySet = wire.NewSet(
 
 var interactiveSvcSet = wire.NewSet(
 	intrDao.NewGORMInteractiveDAO,
+	intrDao.NewGORMCollectionDAO,
+	intrDao.NewGORMPublishedDAO,
 	intrRediscache.NewInteractiveRedisCache,
+	intrRediscache.NewCntBufferRedisCache,
 	ioc.InitTopArticlesCache,
+	ioc.InitWriteBehindCntWriter,
+	ioc.InitCntWriter,
 	intrRepository.NewCachedInteractiveRepository,
+	intrRepository.NewGORMCollectionRepository,
+	intrRepository.NewGORMPublishedRepository,
 	intrService.NewInteractiveService,
+	ioc.InitBizRegistry,
 )
 
 func InitApp() *App {
@@ -35,7 +43,17 @@ func InitApp() *App {
 		interactiveSvcSet,
 		grpc.NewInteractiveServiceServer,
 		events.NewInteractiveReadEventConsumer,
+		events.NewInteractiveRewardConsumer,
 		ioc.InitConsumers,
+		ioc.InitUniqueReadCntJob,
+		intrDao.NewGORMOutboxDAO,
+		intrRepository.NewGORMOutboxRepository,
+		ioc.InitSyncProducer,
+		events.NewSaramaSyncProducer,
+		ioc.InitOutboxRelayJob,
+		ioc.InitCntFlushJob,
+		ioc.InitCntReconcileJob,
+		ioc.InitJobs,
 		ioc.NewGrpcxServer,
 		wire.Struct(new(App), "*"),
 	)
@@ -49,6 +67,7 @@ func InitInteractiveRepo() intrRepository.InteractiveRepository {
 		ioc.InitTopArticlesCache,
 
 		intrDao.NewGORMInteractiveDAO,
+		intrRepository.NewWriteThroughCntWriter,
 
 		intrRediscache.NewInteractiveRedisCache,
 
@@ -24,18 +24,39 @@ func InitApp() *App {
 	db := ioc.InitDB(logger)
 	interactiveDAO := dao.NewGORMInteractiveDAO(db)
 	cmdable := ioc.InitRedis()
+	cntBufferCache := rediscache.NewCntBufferRedisCache(cmdable)
+	writeBehindCntWriter := ioc.InitWriteBehindCntWriter(logger, interactiveDAO, cntBufferCache)
+	cntWriter := ioc.InitCntWriter(writeBehindCntWriter)
 	interactiveCache := rediscache.NewInteractiveRedisCache(cmdable)
 	topArticlesCache := ioc.InitTopArticlesCache()
-	interactiveRepository := repository.NewCachedInteractiveRepository(logger, interactiveDAO, interactiveCache, topArticlesCache)
+	interactiveRepository := repository.NewCachedInteractiveRepository(logger, interactiveDAO, cntWriter, interactiveCache, topArticlesCache)
 	client := ioc.InitSaramaClient()
 	interactiveReadEventConsumer := events.NewInteractiveReadEventConsumer(logger, interactiveRepository, client)
-	v := ioc.InitConsumers(interactiveReadEventConsumer)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	collectionDAO := dao.NewGORMCollectionDAO(db)
+	collectionRepository := repository.NewGORMCollectionRepository(collectionDAO)
+	publishedDAO := dao.NewGORMPublishedDAO(db)
+	publishedRepository := repository.NewGORMPublishedRepository(publishedDAO)
+	bizRegistry := ioc.InitBizRegistry(publishedRepository)
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, bizRegistry)
+	interactiveRewardConsumer := events.NewInteractiveRewardConsumer(logger, interactiveService, client)
+	v := ioc.InitConsumers(interactiveReadEventConsumer, interactiveRewardConsumer)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	server := ioc.NewGrpcxServer(interactiveServiceServer)
+	uniqueReadCntJob := ioc.InitUniqueReadCntJob(logger, interactiveService)
+	outboxDAO := dao.NewGORMOutboxDAO(db)
+	outboxRepository := repository.NewGORMOutboxRepository(outboxDAO)
+	syncProducer := ioc.InitSyncProducer(client)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	outboxRelayJob := ioc.InitOutboxRelayJob(logger, outboxRepository, producer, cmdable)
+	cntFlushJob := ioc.InitCntFlushJob(logger, writeBehindCntWriter, cmdable)
+	cntReconcileJob := ioc.InitCntReconcileJob(logger, interactiveService)
+	cron := ioc.InitJobs(logger, uniqueReadCntJob, outboxRelayJob, cntFlushJob, cntReconcileJob)
 	app := &App{
 		consumers: v,
 		server:    server,
+		cron:      cron,
+		cntFlush:  cntFlushJob,
+		bizs:      bizRegistry,
 	}
 	return app
 }
@@ -44,10 +65,11 @@ func InitInteractiveRepo() repository.InteractiveRepository {
 	logger := ioc.InitLogger()
 	db := ioc.InitDB(logger)
 	interactiveDAO := dao.NewGORMInteractiveDAO(db)
+	cntWriter := repository.NewWriteThroughCntWriter(interactiveDAO)
 	cmdable := ioc.InitRedis()
 	interactiveCache := rediscache.NewInteractiveRedisCache(cmdable)
 	topArticlesCache := ioc.InitTopArticlesCache()
-	interactiveRepository := repository.NewCachedInteractiveRepository(logger, interactiveDAO, interactiveCache, topArticlesCache)
+	interactiveRepository := repository.NewCachedInteractiveRepository(logger, interactiveDAO, cntWriter, interactiveCache, topArticlesCache)
 	return interactiveRepository
 }
 
@@ -55,4 +77,4 @@ func InitInteractiveRepo() repository.InteractiveRepository {
 
 var thirdPartySet = wire.NewSet(ioc.InitRedis, ioc.InitDB, ioc.InitLogger, ioc.InitSaramaClient)
 
-var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, rediscache.NewInteractiveRedisCache, ioc.InitTopArticlesCache, repository.NewCachedInteractiveRepository, service.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, dao.NewGORMCollectionDAO, dao.NewGORMPublishedDAO, rediscache.NewInteractiveRedisCache, rediscache.NewCntBufferRedisCache, ioc.InitTopArticlesCache, ioc.InitWriteBehindCntWriter, ioc.InitCntWriter, repository.NewCachedInteractiveRepository, repository.NewGORMCollectionRepository, repository.NewGORMPublishedRepository, service.NewInteractiveService, ioc.InitBizRegistry)
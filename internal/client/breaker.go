@@ -0,0 +1,83 @@
+package client
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/chenmuyao/go-bootcamp/pkg/breaker"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// methodBreakers keeps a circuit breaker per remote method.
+type methodBreakers struct {
+	l    logger.Logger
+	opts breaker.Options
+
+	mu       sync.Mutex
+	breakers map[string]*breaker.Breaker
+
+	state    *prometheus.GaugeVec
+	changes  *prometheus.CounterVec
+	fallback *prometheus.CounterVec
+}
+
+func (m *methodBreakers) get(method string) *breaker.Breaker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.breakers[method]
+	if ok {
+		return b
+	}
+	opts := m.opts
+	opts.OnStateChange = func(from, to breaker.State) {
+		m.state.WithLabelValues(method).Set(float64(to))
+		m.changes.WithLabelValues(method, to.String()).Inc()
+		m.l.Warn("interactive circuit breaker changed",
+			logger.String("method", method),
+			logger.String("from", from.String()),
+			logger.String("to", to.String()))
+	}
+	b = breaker.NewBreaker(&opts)
+	m.breakers[method] = b
+	m.state.WithLabelValues(method).Set(float64(breaker.StateClosed))
+	return b
+}
+
+func newMethodBreakers(l logger.Logger, opts breaker.Options) *methodBreakers {
+	return &methodBreakers{
+		l:        l,
+		opts:     opts,
+		breakers: make(map[string]*breaker.Breaker),
+		state: register(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "my_company",
+			Subsystem: "wetravel",
+			Name:      "intr_client_breaker_state",
+			Help:      "0 closed, 1 open, 2 half open",
+		}, []string{"method"})),
+		changes: register(prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "my_company",
+			Subsystem: "wetravel",
+			Name:      "intr_client_breaker_changes",
+			Help:      "state changes of the circuit breakers",
+		}, []string{"method", "to"})),
+		fallback: register(prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "my_company",
+			Subsystem: "wetravel",
+			Name:      "intr_client_fallback",
+			Help:      "calls sent to the local service by an open circuit",
+		}, []string{"method"})),
+	}
+}
+
+// register reuses the collector registered by another client.
+func register[T prometheus.Collector](c T) T {
+	err := prometheus.Register(c)
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		if existing, ok := are.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+	return c
+}
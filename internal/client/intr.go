@@ -5,16 +5,26 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"sync/atomic"
+	"time"
 
 	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	"github.com/chenmuyao/go-bootcamp/pkg/breaker"
+	"github.com/chenmuyao/go-bootcamp/pkg/grpcx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
 	"google.golang.org/grpc"
 )
 
+type intrClient = intrv1.InteractiveServiceClient
+
+// InteractiveClient sends a share of the calls, set by the threshold, to the
+// remote service and the others to the local one. A call of a method whose
+// circuit is open goes to the local service too.
 type InteractiveClient struct {
 	remote intrv1.InteractiveServiceClient
 	local  intrv1.InteractiveServiceClient
 
 	threshold atomic.Int32
+	breakers  *methodBreakers
 }
 
 // CancelCollect implements intrv1.InteractiveServiceClient.
@@ -23,7 +33,7 @@ func (i *InteractiveClient) CancelCollect(
 	in *intrv1.CancelCollectRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.CancelCollectResponse, error) {
-	return i.selectClient().CancelCollect(ctx, in, opts...)
+	return call(ctx, i, "CancelCollect", intrClient.CancelCollect, in, opts...)
 }
 
 // CancelLike implements intrv1.InteractiveServiceClient.
@@ -32,7 +42,7 @@ func (i *InteractiveClient) CancelLike(
 	in *intrv1.CancelLikeRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.CancelLikeResponse, error) {
-	return i.selectClient().CancelLike(ctx, in, opts...)
+	return call(ctx, i, "CancelLike", intrClient.CancelLike, in, opts...)
 }
 
 // Collect implements intrv1.InteractiveServiceClient.
@@ -41,7 +51,16 @@ func (i *InteractiveClient) Collect(
 	in *intrv1.CollectRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.CollectResponse, error) {
-	return i.selectClient().Collect(ctx, in, opts...)
+	return call(ctx, i, "Collect", intrClient.Collect, in, opts...)
+}
+
+// Delete implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) Delete(
+	ctx context.Context,
+	in *intrv1.DeleteRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.DeleteResponse, error) {
+	return call(ctx, i, "Delete", intrClient.Delete, in, opts...)
 }
 
 // Get implements intrv1.InteractiveServiceClient.
@@ -50,7 +69,7 @@ func (i *InteractiveClient) Get(
 	in *intrv1.GetRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.GetResponse, error) {
-	return i.selectClient().Get(ctx, in, opts...)
+	return call(ctx, i, "Get", intrClient.Get, in, opts...)
 }
 
 // GetByIDs implements intrv1.InteractiveServiceClient.
@@ -59,7 +78,7 @@ func (i *InteractiveClient) GetByIDs(
 	in *intrv1.GetByIDsRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.GetByIDsResponse, error) {
-	return i.selectClient().GetByIDs(ctx, in, opts...)
+	return call(ctx, i, "GetByIDs", intrClient.GetByIDs, in, opts...)
 }
 
 // GetTopLike implements intrv1.InteractiveServiceClient.
@@ -68,7 +87,7 @@ func (i *InteractiveClient) GetTopLike(
 	in *intrv1.GetTopLikeRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.GetTopLikeResponse, error) {
-	return i.selectClient().GetTopLike(ctx, in, opts...)
+	return call(ctx, i, "GetTopLike", intrClient.GetTopLike, in, opts...)
 }
 
 // IncrReadCnt implements intrv1.InteractiveServiceClient.
@@ -77,7 +96,7 @@ func (i *InteractiveClient) IncrReadCnt(
 	in *intrv1.IncrReadCntRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.IncrReadCntResponse, error) {
-	return i.selectClient().IncrReadCnt(ctx, in, opts...)
+	return call(ctx, i, "IncrReadCnt", intrClient.IncrReadCnt, in, opts...)
 }
 
 // Like implements intrv1.InteractiveServiceClient.
@@ -86,7 +105,7 @@ func (i *InteractiveClient) Like(
 	in *intrv1.LikeRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.LikeResponse, error) {
-	return i.selectClient().Like(ctx, in, opts...)
+	return call(ctx, i, "Like", intrClient.Like, in, opts...)
 }
 
 // MustBatchGet implements intrv1.InteractiveServiceClient.
@@ -95,7 +114,116 @@ func (i *InteractiveClient) MustBatchGet(
 	in *intrv1.MustBatchGetRequest,
 	opts ...grpc.CallOption,
 ) (*intrv1.MustBatchGetResponse, error) {
-	return i.selectClient().MustBatchGet(ctx, in, opts...)
+	return call(ctx, i, "MustBatchGet", intrClient.MustBatchGet, in, opts...)
+}
+
+// ListLikes implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) ListLikes(
+	ctx context.Context,
+	in *intrv1.ListLikesRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListLikesResponse, error) {
+	return call(ctx, i, "ListLikes", intrClient.ListLikes, in, opts...)
+}
+
+// ListCollects implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) ListCollects(
+	ctx context.Context,
+	in *intrv1.ListCollectsRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListCollectsResponse, error) {
+	return call(ctx, i, "ListCollects", intrClient.ListCollects, in, opts...)
+}
+
+// CreateCollection implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) CreateCollection(
+	ctx context.Context,
+	in *intrv1.CreateCollectionRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.CreateCollectionResponse, error) {
+	return call(ctx, i, "CreateCollection", intrClient.CreateCollection, in, opts...)
+}
+
+// UpdateCollection implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) UpdateCollection(
+	ctx context.Context,
+	in *intrv1.UpdateCollectionRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.UpdateCollectionResponse, error) {
+	return call(ctx, i, "UpdateCollection", intrClient.UpdateCollection, in, opts...)
+}
+
+// DeleteCollection implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) DeleteCollection(
+	ctx context.Context,
+	in *intrv1.DeleteCollectionRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.DeleteCollectionResponse, error) {
+	return call(ctx, i, "DeleteCollection", intrClient.DeleteCollection, in, opts...)
+}
+
+// ListCollections implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) ListCollections(
+	ctx context.Context,
+	in *intrv1.ListCollectionsRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListCollectionsResponse, error) {
+	return call(ctx, i, "ListCollections", intrClient.ListCollections, in, opts...)
+}
+
+// ListCollectionItems implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) ListCollectionItems(
+	ctx context.Context,
+	in *intrv1.ListCollectionItemsRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListCollectionItemsResponse, error) {
+	return call(ctx, i, "ListCollectionItems", intrClient.ListCollectionItems, in, opts...)
+}
+
+// MoveCollectionItem implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) MoveCollectionItem(
+	ctx context.Context,
+	in *intrv1.MoveCollectionItemRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.MoveCollectionItemResponse, error) {
+	return call(ctx, i, "MoveCollectionItem", intrClient.MoveCollectionItem, in, opts...)
+}
+
+// ListUserLikes implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) ListUserLikes(
+	ctx context.Context,
+	in *intrv1.ListUserLikesRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListUserLikesResponse, error) {
+	return call(ctx, i, "ListUserLikes", intrClient.ListUserLikes, in, opts...)
+}
+
+// ListUserCollects implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) ListUserCollects(
+	ctx context.Context,
+	in *intrv1.ListUserCollectsRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListUserCollectsResponse, error) {
+	return call(ctx, i, "ListUserCollects", intrClient.ListUserCollects, in, opts...)
+}
+
+// BatchGet implements intrv1.InteractiveServiceClient.
+func (i *InteractiveClient) BatchGet(
+	ctx context.Context,
+	in *intrv1.BatchGetRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.BatchGetResponse, error) {
+	return call(ctx, i, "BatchGet", intrClient.BatchGet, in, opts...)
+}
+
+// SubscribeCnts implements intrv1.InteractiveServiceClient. The whole
+// subscription goes through the client selected when it starts.
+func (i *InteractiveClient) SubscribeCnts(
+	ctx context.Context,
+	in *intrv1.SubscribeCntsRequest,
+	opts ...grpc.CallOption,
+) (grpc.ServerStreamingClient[intrv1.SubscribeCntsResponse], error) {
+	return call(ctx, i, "SubscribeCnts", intrClient.SubscribeCnts, in, opts...)
 }
 
 func (i *InteractiveClient) selectClient() intrv1.InteractiveServiceClient {
@@ -116,12 +244,39 @@ func (i *InteractiveClient) UpdateThreshold(val int32) {
 func NewInteractiveClient(
 	remote intrv1.InteractiveServiceClient,
 	local intrv1.InteractiveServiceClient,
+	l logger.Logger,
+	opts breaker.Options,
 ) *InteractiveClient {
 	return &InteractiveClient{
 		remote:    remote,
 		local:     local,
 		threshold: atomic.Int32{},
+		breakers:  newMethodBreakers(l, opts),
+	}
+}
+
+func call[Req, Resp any](
+	ctx context.Context,
+	i *InteractiveClient,
+	method string,
+	fn func(intrClient, context.Context, Req, ...grpc.CallOption) (Resp, error),
+	in Req,
+	opts ...grpc.CallOption,
+) (Resp, error) {
+	if i.selectClient() == i.local {
+		return fn(i.local, ctx, in, opts...)
+	}
+	b := i.breakers.get(method)
+	if !b.Allow() {
+		i.breakers.fallback.WithLabelValues(method).Inc()
+		return fn(i.local, ctx, in, opts...)
 	}
+	start := time.Now()
+	resp, err := fn(i.remote, ctx, in, opts...)
+	b.Record(grpcx.IsServerFailure(err), time.Since(start))
+	// NOTE: a failed call is not retried on the local service, the remote one
+	// may have applied it already.
+	return resp, err
 }
 
 var _ intrv1.InteractiveServiceClient = &InteractiveClient{}
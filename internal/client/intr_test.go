@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	intrv1mock "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1/mock"
+	"github.com/chenmuyao/go-bootcamp/pkg/breaker"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestClient(
+	remote intrv1.InteractiveServiceClient,
+	local intrv1.InteractiveServiceClient,
+	clock *fakeClock,
+) *InteractiveClient {
+	c := NewInteractiveClient(remote, local, logger.NewNopLogger(), breaker.Options{
+		Window:         10 * time.Second,
+		Buckets:        10,
+		MinCalls:       2,
+		ErrorRate:      0.5,
+		OpenTimeout:    5 * time.Second,
+		HalfOpenProbes: 1,
+		Now:            clock.Now,
+	})
+	// all the calls to the remote service
+	c.UpdateThreshold(100)
+	return c
+}
+
+func TestInteractiveClient_Breaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	like := &intrv1.LikeRequest{Biz: "article", BizId: 1, Uid: 123}
+	get := &intrv1.GetRequest{Biz: "article", Id: 1, Uid: 123}
+	testCases := []struct {
+		name string
+		// run the calls, the expectations are set in order
+		run func(
+			t *testing.T,
+			c *InteractiveClient,
+			remote *intrv1mock.MockInteractiveServiceClient,
+			local *intrv1mock.MockInteractiveServiceClient,
+			clock *fakeClock,
+		)
+	}{
+		{
+			name: "open circuit falls back to local",
+			run: func(
+				t *testing.T,
+				c *InteractiveClient,
+				remote *intrv1mock.MockInteractiveServiceClient,
+				local *intrv1mock.MockInteractiveServiceClient,
+				clock *fakeClock,
+			) {
+				remote.EXPECT().Like(gomock.Any(), like).Return(nil, unavailable).Times(2)
+				local.EXPECT().Like(gomock.Any(), like).Return(&intrv1.LikeResponse{}, nil)
+
+				fallback := c.breakers.fallback.WithLabelValues("Like")
+				fallbacks := testutil.ToFloat64(fallback)
+				for range 2 {
+					// not retried on the local service
+					_, err := c.Like(context.Background(), like)
+					assert.Equal(t, unavailable, err)
+				}
+				state := c.breakers.state.WithLabelValues("Like")
+				assert.Equal(t, float64(breaker.StateOpen), testutil.ToFloat64(state))
+				_, err := c.Like(context.Background(), like)
+				assert.NoError(t, err)
+				assert.Equal(t, fallbacks+1, testutil.ToFloat64(fallback))
+			},
+		},
+		{
+			name: "half-open probe recovers",
+			run: func(
+				t *testing.T,
+				c *InteractiveClient,
+				remote *intrv1mock.MockInteractiveServiceClient,
+				local *intrv1mock.MockInteractiveServiceClient,
+				clock *fakeClock,
+			) {
+				gomock.InOrder(
+					remote.EXPECT().Like(gomock.Any(), like).Return(nil, unavailable).Times(2),
+					remote.EXPECT().Like(gomock.Any(), like).
+						Return(&intrv1.LikeResponse{}, nil).Times(2),
+				)
+
+				for range 2 {
+					_, _ = c.Like(context.Background(), like)
+				}
+				clock.now = clock.now.Add(5 * time.Second)
+				// the probe, then closed
+				for range 2 {
+					_, err := c.Like(context.Background(), like)
+					assert.NoError(t, err)
+				}
+			},
+		},
+		{
+			name: "failed probe opens again",
+			run: func(
+				t *testing.T,
+				c *InteractiveClient,
+				remote *intrv1mock.MockInteractiveServiceClient,
+				local *intrv1mock.MockInteractiveServiceClient,
+				clock *fakeClock,
+			) {
+				remote.EXPECT().Like(gomock.Any(), like).Return(nil, unavailable).Times(3)
+				local.EXPECT().Like(gomock.Any(), like).Return(&intrv1.LikeResponse{}, nil)
+
+				for range 2 {
+					_, _ = c.Like(context.Background(), like)
+				}
+				clock.now = clock.now.Add(5 * time.Second)
+				_, err := c.Like(context.Background(), like)
+				assert.Equal(t, unavailable, err)
+				_, err = c.Like(context.Background(), like)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "breaker per method",
+			run: func(
+				t *testing.T,
+				c *InteractiveClient,
+				remote *intrv1mock.MockInteractiveServiceClient,
+				local *intrv1mock.MockInteractiveServiceClient,
+				clock *fakeClock,
+			) {
+				remote.EXPECT().Like(gomock.Any(), like).Return(nil, unavailable).Times(2)
+				local.EXPECT().Like(gomock.Any(), like).Return(&intrv1.LikeResponse{}, nil)
+				remote.EXPECT().Get(gomock.Any(), get).Return(&intrv1.GetResponse{}, nil)
+
+				for range 2 {
+					_, _ = c.Like(context.Background(), like)
+				}
+				_, err := c.Like(context.Background(), like)
+				assert.NoError(t, err)
+				_, err = c.Get(context.Background(), get)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "client errors keep the circuit closed",
+			run: func(
+				t *testing.T,
+				c *InteractiveClient,
+				remote *intrv1mock.MockInteractiveServiceClient,
+				local *intrv1mock.MockInteractiveServiceClient,
+				clock *fakeClock,
+			) {
+				invalid := status.Error(codes.InvalidArgument, "invalid")
+				remote.EXPECT().Like(gomock.Any(), like).Return(nil, invalid).Times(3)
+
+				for range 3 {
+					_, err := c.Like(context.Background(), like)
+					assert.Equal(t, invalid, err)
+				}
+			},
+		},
+		{
+			name: "local share skips the breaker",
+			run: func(
+				t *testing.T,
+				c *InteractiveClient,
+				remote *intrv1mock.MockInteractiveServiceClient,
+				local *intrv1mock.MockInteractiveServiceClient,
+				clock *fakeClock,
+			) {
+				c.UpdateThreshold(0)
+				local.EXPECT().Like(gomock.Any(), like).Return(nil, unavailable).Times(3)
+
+				for range 3 {
+					_, err := c.Like(context.Background(), like)
+					assert.Equal(t, unavailable, err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			remote := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+			local := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+			clock := &fakeClock{now: time.UnixMilli(1700000000000)}
+			c := newTestClient(remote, local, clock)
+			tc.run(t, c, remote, local, clock)
+		})
+	}
+}
@@ -2,10 +2,12 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/chenmuyao/generique/gslice"
 	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
 	"github.com/chenmuyao/go-bootcamp/interactive/domain"
+	intrGrpc "github.com/chenmuyao/go-bootcamp/interactive/grpc"
 	"github.com/chenmuyao/go-bootcamp/interactive/service"
 	"google.golang.org/grpc"
 )
@@ -21,7 +23,7 @@ func (l *LocalInteractiveAdapter) CancelCollect(
 	opts ...grpc.CallOption,
 ) (*intrv1.CancelCollectResponse, error) {
 	err := l.svc.CancelCollect(ctx, in.GetBiz(), in.GetId(), in.GetCid(), in.GetUid())
-	return &intrv1.CancelCollectResponse{}, err
+	return &intrv1.CancelCollectResponse{}, intrGrpc.StatusError(err)
 }
 
 // CancelLike implements intrv1.InteractiveServiceClient.
@@ -31,7 +33,7 @@ func (l *LocalInteractiveAdapter) CancelLike(
 	opts ...grpc.CallOption,
 ) (*intrv1.CancelLikeResponse, error) {
 	err := l.svc.CancelLike(ctx, in.GetBiz(), in.GetBizId(), in.GetUid())
-	return &intrv1.CancelLikeResponse{}, err
+	return &intrv1.CancelLikeResponse{}, intrGrpc.StatusError(err)
 }
 
 // Collect implements intrv1.InteractiveServiceClient.
@@ -41,7 +43,17 @@ func (l *LocalInteractiveAdapter) Collect(
 	opts ...grpc.CallOption,
 ) (*intrv1.CollectResponse, error) {
 	err := l.svc.Collect(ctx, in.GetBiz(), in.GetId(), in.GetCid(), in.GetUid())
-	return &intrv1.CollectResponse{}, err
+	return &intrv1.CollectResponse{}, intrGrpc.StatusError(err)
+}
+
+// Delete implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) Delete(
+	ctx context.Context,
+	in *intrv1.DeleteRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.DeleteResponse, error) {
+	err := l.svc.Delete(ctx, in.GetBiz(), in.GetBizIds())
+	return &intrv1.DeleteResponse{}, intrGrpc.StatusError(err)
 }
 
 // Get implements intrv1.InteractiveServiceClient.
@@ -51,7 +63,7 @@ func (l *LocalInteractiveAdapter) Get(
 	opts ...grpc.CallOption,
 ) (*intrv1.GetResponse, error) {
 	res, err := l.svc.Get(ctx, in.GetBiz(), in.GetId(), in.GetUid())
-	return &intrv1.GetResponse{Intr: l.toDTO(res)}, err
+	return &intrv1.GetResponse{Intr: l.toDTO(res)}, intrGrpc.StatusError(err)
 }
 
 // GetByIDs implements intrv1.InteractiveServiceClient.
@@ -62,7 +74,7 @@ func (l *LocalInteractiveAdapter) GetByIDs(
 ) (*intrv1.GetByIDsResponse, error) {
 	intrs, err := l.svc.GetByIDs(ctx, in.GetBiz(), in.GetIds())
 	if err != nil {
-		return nil, err
+		return nil, intrGrpc.StatusError(err)
 	}
 	res := make(map[int64]*intrv1.Interactive, len(intrs))
 	for key, val := range intrs {
@@ -73,6 +85,61 @@ func (l *LocalInteractiveAdapter) GetByIDs(
 	}, nil
 }
 
+// BatchGet implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) BatchGet(
+	ctx context.Context,
+	in *intrv1.BatchGetRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.BatchGetResponse, error) {
+	intrs, err := l.svc.BatchGet(ctx, in.GetBiz(), in.GetIds(), in.GetUid())
+	if err != nil {
+		return nil, intrGrpc.StatusError(err)
+	}
+	res := make(map[int64]*intrv1.Interactive, len(intrs))
+	for key, val := range intrs {
+		res[key] = l.toDTO(val)
+	}
+	return &intrv1.BatchGetResponse{
+		Intrs: res,
+	}, nil
+}
+
+// SubscribeCnts implements intrv1.InteractiveServiceClient. The
+// subscription runs until ctx is done, like a gRPC stream.
+func (l *LocalInteractiveAdapter) SubscribeCnts(
+	ctx context.Context,
+	in *intrv1.SubscribeCntsRequest,
+	opts ...grpc.CallOption,
+) (grpc.ServerStreamingClient[intrv1.SubscribeCntsResponse], error) {
+	stream := &localCntStream{
+		ctx:  ctx,
+		msgs: make(chan *intrv1.SubscribeCntsResponse),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(stream.done)
+		stream.err = l.svc.SubscribeCnts(
+			ctx,
+			in.GetBiz(),
+			in.GetIds(),
+			func(intrs []domain.Interactive) error {
+				msg := &intrv1.SubscribeCntsResponse{
+					Intrs: gslice.Map(intrs, func(id int, src domain.Interactive) *intrv1.Interactive {
+						return l.toDTO(src)
+					}),
+				}
+				select {
+				case stream.msgs <- msg:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+		)
+	}()
+	return stream, nil
+}
+
 // GetTopLike implements intrv1.InteractiveServiceClient.
 func (l *LocalInteractiveAdapter) GetTopLike(
 	ctx context.Context,
@@ -81,7 +148,7 @@ func (l *LocalInteractiveAdapter) GetTopLike(
 ) (*intrv1.GetTopLikeResponse, error) {
 	likes, err := l.svc.GetTopLike(ctx, in.GetBiz(), int(in.GetLimit()))
 	if err != nil {
-		return nil, err
+		return nil, intrGrpc.StatusError(err)
 	}
 	return &intrv1.GetTopLikeResponse{Ids: likes}, nil
 }
@@ -93,7 +160,7 @@ func (l *LocalInteractiveAdapter) IncrReadCnt(
 	opts ...grpc.CallOption,
 ) (*intrv1.IncrReadCntResponse, error) {
 	err := l.svc.IncrReadCnt(ctx, in.GetBiz(), in.GetBizId())
-	return &intrv1.IncrReadCntResponse{}, err
+	return &intrv1.IncrReadCntResponse{}, intrGrpc.StatusError(err)
 }
 
 // Like implements intrv1.InteractiveServiceClient.
@@ -103,7 +170,7 @@ func (l *LocalInteractiveAdapter) Like(
 	opts ...grpc.CallOption,
 ) (*intrv1.LikeResponse, error) {
 	err := l.svc.Like(ctx, in.GetBiz(), in.GetBizId(), in.GetUid())
-	return &intrv1.LikeResponse{}, err
+	return &intrv1.LikeResponse{}, intrGrpc.StatusError(err)
 }
 
 // MustBatchGet implements intrv1.InteractiveServiceClient.
@@ -114,7 +181,7 @@ func (l *LocalInteractiveAdapter) MustBatchGet(
 ) (*intrv1.MustBatchGetResponse, error) {
 	intrs, err := l.svc.MustBatchGet(ctx, in.GetBiz(), in.GetIds())
 	if err != nil {
-		return nil, err
+		return nil, intrGrpc.StatusError(err)
 	}
 	res := gslice.Map(intrs, func(id int, src domain.Interactive) *intrv1.Interactive {
 		return l.toDTO(src)
@@ -123,15 +190,204 @@ func (l *LocalInteractiveAdapter) MustBatchGet(
 	return &intrv1.MustBatchGetResponse{Intrs: res}, nil
 }
 
+// ListLikes implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) ListLikes(
+	ctx context.Context,
+	in *intrv1.ListLikesRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListLikesResponse, error) {
+	likes, err := l.svc.ListLikes(ctx, in.GetBiz(), in.GetAfterId(), int(in.GetLimit()))
+	if err != nil {
+		return nil, intrGrpc.StatusError(err)
+	}
+	return &intrv1.ListLikesResponse{Likes: gslice.Map(likes, toUserBizDTO)}, nil
+}
+
+// ListCollects implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) ListCollects(
+	ctx context.Context,
+	in *intrv1.ListCollectsRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListCollectsResponse, error) {
+	collects, err := l.svc.ListCollects(ctx, in.GetBiz(), in.GetAfterId(), int(in.GetLimit()))
+	if err != nil {
+		return nil, intrGrpc.StatusError(err)
+	}
+	return &intrv1.ListCollectsResponse{Collects: gslice.Map(collects, toUserBizDTO)}, nil
+}
+
+// ListUserLikes implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) ListUserLikes(
+	ctx context.Context,
+	in *intrv1.ListUserLikesRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListUserLikesResponse, error) {
+	likes, err := l.svc.ListUserLikes(
+		ctx,
+		in.GetUid(),
+		in.GetBiz(),
+		toDomainCursor(in.GetCursor()),
+		int(in.GetLimit()),
+	)
+	if err != nil {
+		return nil, intrGrpc.StatusError(err)
+	}
+	return &intrv1.ListUserLikesResponse{Likes: gslice.Map(likes, toUserBizDTO)}, nil
+}
+
+// ListUserCollects implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) ListUserCollects(
+	ctx context.Context,
+	in *intrv1.ListUserCollectsRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListUserCollectsResponse, error) {
+	collects, err := l.svc.ListUserCollects(
+		ctx,
+		in.GetUid(),
+		in.GetBiz(),
+		toDomainCursor(in.GetCursor()),
+		int(in.GetLimit()),
+	)
+	if err != nil {
+		return nil, intrGrpc.StatusError(err)
+	}
+	return &intrv1.ListUserCollectsResponse{Collects: gslice.Map(collects, toUserBizDTO)}, nil
+}
+
+// CreateCollection implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) CreateCollection(
+	ctx context.Context,
+	in *intrv1.CreateCollectionRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.CreateCollectionResponse, error) {
+	c, err := l.svc.CreateCollection(ctx, domain.Collection{
+		UID:    in.GetUid(),
+		Name:   in.GetName(),
+		Public: in.GetPublic(),
+	})
+	if err != nil {
+		return nil, intrGrpc.StatusError(err)
+	}
+	return &intrv1.CreateCollectionResponse{Collection: toCollectionDTO(0, c)}, nil
+}
+
+// UpdateCollection implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) UpdateCollection(
+	ctx context.Context,
+	in *intrv1.UpdateCollectionRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.UpdateCollectionResponse, error) {
+	err := l.svc.UpdateCollection(ctx, domain.Collection{
+		ID:     in.GetId(),
+		UID:    in.GetUid(),
+		Name:   in.GetName(),
+		Public: in.GetPublic(),
+	})
+	return &intrv1.UpdateCollectionResponse{}, intrGrpc.StatusError(err)
+}
+
+// DeleteCollection implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) DeleteCollection(
+	ctx context.Context,
+	in *intrv1.DeleteCollectionRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.DeleteCollectionResponse, error) {
+	err := l.svc.DeleteCollection(ctx, in.GetUid(), in.GetId())
+	return &intrv1.DeleteCollectionResponse{}, intrGrpc.StatusError(err)
+}
+
+// ListCollections implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) ListCollections(
+	ctx context.Context,
+	in *intrv1.ListCollectionsRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListCollectionsResponse, error) {
+	cs, err := l.svc.ListCollections(ctx, in.GetUid(), in.GetViewerUid())
+	if err != nil {
+		return nil, intrGrpc.StatusError(err)
+	}
+	return &intrv1.ListCollectionsResponse{Collections: gslice.Map(cs, toCollectionDTO)}, nil
+}
+
+// ListCollectionItems implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) ListCollectionItems(
+	ctx context.Context,
+	in *intrv1.ListCollectionItemsRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.ListCollectionItemsResponse, error) {
+	items, err := l.svc.ListCollectionItems(
+		ctx,
+		in.GetCid(),
+		in.GetViewerUid(),
+		int(in.GetOffset()),
+		int(in.GetLimit()),
+	)
+	if err != nil {
+		return nil, intrGrpc.StatusError(err)
+	}
+	return &intrv1.ListCollectionItemsResponse{Items: gslice.Map(items, toUserBizDTO)}, nil
+}
+
+// MoveCollectionItem implements intrv1.InteractiveServiceClient.
+func (l *LocalInteractiveAdapter) MoveCollectionItem(
+	ctx context.Context,
+	in *intrv1.MoveCollectionItemRequest,
+	opts ...grpc.CallOption,
+) (*intrv1.MoveCollectionItemResponse, error) {
+	err := l.svc.MoveCollectionItem(
+		ctx,
+		in.GetBiz(),
+		in.GetId(),
+		in.GetUid(),
+		in.GetFromCid(),
+		in.GetToCid(),
+	)
+	return &intrv1.MoveCollectionItemResponse{}, intrGrpc.StatusError(err)
+}
+
 func (i *LocalInteractiveAdapter) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
-		Biz:        intr.Biz,
-		BizId:      intr.BizID,
-		ReadCnt:    intr.ReadCnt,
-		LikeCnt:    intr.LikeCnt,
-		CollectCnt: intr.CollectCnt,
-		Liked:      intr.Liked,
-		Collected:  intr.Collected,
+		Biz:           intr.Biz,
+		BizId:         intr.BizID,
+		ReadCnt:       intr.ReadCnt,
+		LikeCnt:       intr.LikeCnt,
+		CollectCnt:    intr.CollectCnt,
+		TipCnt:        intr.TipCnt,
+		Liked:         intr.Liked,
+		Collected:     intr.Collected,
+		UniqueReadCnt: intr.UniqueReadCnt,
+	}
+}
+
+func toUserBizDTO(id int, src domain.UserBiz) *intrv1.UserBiz {
+	return &intrv1.UserBiz{
+		Id:    src.ID,
+		Uid:   src.UID,
+		Biz:   src.Biz,
+		BizId: src.BizID,
+		Utime: src.Utime.UnixMilli(),
+		Cid:   src.CID,
+	}
+}
+
+func toDomainCursor(src *intrv1.UserBizCursor) domain.Cursor {
+	if src.GetUtime() <= 0 {
+		return domain.Cursor{}
+	}
+	return domain.Cursor{
+		Utime: time.UnixMilli(src.GetUtime()),
+		BizID: src.GetBizId(),
+	}
+}
+
+func toCollectionDTO(id int, src domain.Collection) *intrv1.Collection {
+	return &intrv1.Collection{
+		Id:     src.ID,
+		Uid:    src.UID,
+		Name:   src.Name,
+		Public: src.Public,
+		Ctime:  src.Ctime.UnixMilli(),
+		Utime:  src.Utime.UnixMilli(),
 	}
 }
 
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	intrGrpc "github.com/chenmuyao/go-bootcamp/interactive/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// localCntStream passes the messages of a local subscription the way a gRPC
+// client stream does.
+type localCntStream struct {
+	ctx  context.Context
+	msgs chan *intrv1.SubscribeCntsResponse
+	// done is closed once the subscription ended with err.
+	done chan struct{}
+	err  error
+}
+
+// Recv implements grpc.ServerStreamingClient.
+func (s *localCntStream) Recv() (*intrv1.SubscribeCntsResponse, error) {
+	select {
+	case msg := <-s.msgs:
+		return msg, nil
+	case <-s.done:
+		switch {
+		case s.err == nil:
+			return nil, io.EOF
+		case errors.Is(s.err, context.Canceled), errors.Is(s.err, context.DeadlineExceeded):
+			return nil, status.FromContextError(s.err).Err()
+		default:
+			return nil, intrGrpc.StatusError(s.err)
+		}
+	}
+}
+
+// RecvMsg implements grpc.ClientStream.
+func (s *localCntStream) RecvMsg(m any) error {
+	msg, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	dst, ok := m.(proto.Message)
+	if !ok {
+		return errors.New("not a proto message")
+	}
+	proto.Reset(dst)
+	proto.Merge(dst, msg)
+	return nil
+}
+
+// SendMsg implements grpc.ClientStream.
+func (s *localCntStream) SendMsg(m any) error {
+	return errors.New("server streaming only")
+}
+
+// CloseSend implements grpc.ClientStream.
+func (s *localCntStream) CloseSend() error {
+	return nil
+}
+
+// Context implements grpc.ClientStream.
+func (s *localCntStream) Context() context.Context {
+	return s.ctx
+}
+
+// Header implements grpc.ClientStream.
+func (s *localCntStream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+// Trailer implements grpc.ClientStream.
+func (s *localCntStream) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+var _ grpc.ServerStreamingClient[intrv1.SubscribeCntsResponse] = &localCntStream{}
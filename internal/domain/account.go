@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+type AccountType uint8
+
+const (
+	AccountTypeUnknown AccountType = iota
+	// the revenue of an author
+	AccountTypeUser
+	// the fees taken on the rewards
+	AccountTypePlatform
+	// the money paid in by the readers, its balance is negative
+	AccountTypePayin
+	// the withdrawals waiting for their approval
+	AccountTypeWithdrawing
+	// the money paid out to the authors
+	AccountTypePayout
+)
+
+// Account is a balance of the ledger. The system accounts have no UID.
+type Account struct {
+	UID  int64
+	Type AccountType
+	// in cents
+	Balance int64
+	Utime   time.Time
+}
+
+// AccountEntry is a line of the ledger. The entries of a transaction sum to
+// zero, and are never changed once written.
+type AccountEntry struct {
+	ID    int64
+	TxnID int64
+	// what caused the transaction
+	Biz     string
+	BizID   int64
+	Account Account
+	// signed, in cents
+	Amount int64
+	Ctime  time.Time
+}
+
+type WithdrawalStatus uint8
+
+const (
+	WithdrawalStatusUnknown WithdrawalStatus = iota
+	WithdrawalStatusPending
+	WithdrawalStatusApproved
+	WithdrawalStatusRejected
+)
+
+// Withdrawal is asked by an author and approved by an admin. The amount is
+// held out of the balance of the author meanwhile.
+type Withdrawal struct {
+	ID  int64
+	UID int64
+	// in cents
+	Amount int64
+	Status WithdrawalStatus
+	// the admin who approved or rejected it
+	Reviewer int64
+	Ctime    time.Time
+	Utime    time.Time
+}
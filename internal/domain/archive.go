@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type ExportStatus uint8
+
+const (
+	ExportStatusUnknown ExportStatus = iota
+	ExportStatusPending
+	ExportStatusDone
+	ExportStatusFailed
+)
+
+// ArticleExport is the latest background export of the articles of a user.
+type ArticleExport struct {
+	UID    int64
+	Status ExportStatus
+	// why the export failed
+	Err   string
+	Ctime time.Time
+	Utime time.Time
+}
+
+// ImportResult is the outcome of a Markdown file of an imported archive.
+type ImportResult struct {
+	File string
+	// ID of the draft created, 0 if the file failed
+	ArticleID int64
+	Err       string
+}
@@ -1,6 +1,19 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MaxArticleTags = 10
+	MaxTagLen      = 32
+)
+
+var ErrInvalidTags = errors.New("invalid tags")
 
 type Article struct {
 	ID      int64
@@ -8,8 +21,31 @@ type Article struct {
 	Content string
 	Author  Author
 	Status  ArticleStatus
+	Places  []Place
+	Tags    []string
 	Ctime   time.Time
 	Utime   time.Time
+	// Dtime is when the article was moved to the trash, zero if it is not.
+	Dtime time.Time
+	// only filled for the drafts
+	Collaborators []Collaborator
+	// Version of the draft, an edit must send the version it is based on.
+	Version int64
+}
+
+// Place is a geo-tagged location attached to an article.
+type Place struct {
+	Name string
+	Lat  float64
+	Lng  float64
+}
+
+// NearbyArticle is a published article found by a radius search, with its
+// place closest to the search center.
+type NearbyArticle struct {
+	Article  Article
+	Place    Place
+	Distance float64 // in meters
 }
 
 type Author struct {
@@ -35,3 +71,33 @@ func (a Article) Abstract() string {
 	}
 	return string(str)
 }
+
+// SameContent tells if the published fields of the articles are the same.
+func (a Article) SameContent(b Article) bool {
+	return a.Title == b.Title && a.Content == b.Content &&
+		slices.Equal(a.Places, b.Places) && slices.Equal(a.Tags, b.Tags)
+}
+
+// NormalizeTags turns the tags to lower case and removes the duplicates.
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	if len(tags) > MaxArticleTags {
+		return nil, ErrInvalidTags
+	}
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || utf8.RuneCountInString(t) > MaxTagLen {
+			return nil, ErrInvalidTags
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		res = append(res, t)
+	}
+	return res, nil
+}
@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// Collaborator is a user invited by the author to work on an article.
+type Collaborator struct {
+	UID  int64
+	Role CollaboratorRole
+	// Approved is set when a reviewer approves the draft of ApprovedVersion,
+	// the approval is stale once the draft is saved again.
+	Approved        bool
+	ApprovedVersion int64
+	Ctime           time.Time
+}
+
+type CollaboratorRole uint8
+
+const (
+	CollaboratorRoleUnknown = iota
+	// can edit, publish and withdraw the article
+	CollaboratorRoleEditor
+	// can approve the draft before it is published
+	CollaboratorRoleReviewer
+)
+
+// ApprovedDraft tells if the reviewer has approved the current version of the
+// draft.
+func (c Collaborator) ApprovedDraft(draft Article) bool {
+	return c.Role == CollaboratorRoleReviewer && c.Approved && c.ApprovedVersion == draft.Version
+}
+
+func (r CollaboratorRole) Valid() bool {
+	return r == CollaboratorRoleEditor || r == CollaboratorRoleReviewer
+}
+
+// Collaborator returns the collaborator uid, if any.
+func (a Article) Collaborator(uid int64) (Collaborator, bool) {
+	for _, c := range a.Collaborators {
+		if c.UID == uid {
+			return c, true
+		}
+	}
+	return Collaborator{}, false
+}
+
+// CanView tells if uid can read the draft.
+func (a Article) CanView(uid int64) bool {
+	_, ok := a.Collaborator(uid)
+	return ok || a.Author.ID == uid
+}
+
+// CanEdit tells if uid can edit, publish and withdraw the article.
+func (a Article) CanEdit(uid int64) bool {
+	c, ok := a.Collaborator(uid)
+	return a.Author.ID == uid || ok && c.Role == CollaboratorRoleEditor
+}
+
+// ReviewRequired tells if the draft must be approved before it is published,
+// which is the case once the author has invited a reviewer.
+func (a Article) ReviewRequired() bool {
+	for _, c := range a.Collaborators {
+		if c.Role == CollaboratorRoleReviewer {
+			return true
+		}
+	}
+	return false
+}
+
+// Approved tells if a reviewer has approved the current version of the
+// draft.
+func (a Article) Approved() bool {
+	for _, c := range a.Collaborators {
+		if c.ApprovedDraft(a) {
+			return true
+		}
+	}
+	return false
+}
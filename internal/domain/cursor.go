@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// Cursor is a position in a list sorted by (Time, ID) desc, the next page
+// starts right after it. The zero value is the beginning of the list.
+type Cursor struct {
+	Time time.Time
+	ID   int64
+}
+
+func (c Cursor) IsZero() bool {
+	return c.Time.IsZero()
+}
+
+// NearbyCursor is a position in a list sorted by (Distance, ArticleID) asc,
+// the next page starts right after it. The zero value is the beginning of the
+// list.
+type NearbyCursor struct {
+	Distance  float64
+	ArticleID int64
+}
+
+// Before tells whether the article at distance is listed before the cursor or
+// at it.
+func (c NearbyCursor) Before(distance float64, articleID int64) bool {
+	return distance < c.Distance || distance == c.Distance && articleID <= c.ArticleID
+}
+
+// ArticleCursor returns the cursor after the article in a list sorted by
+// update time.
+func ArticleCursor(article Article) Cursor {
+	return Cursor{
+		Time: article.Utime,
+		ID:   article.ID,
+	}
+}
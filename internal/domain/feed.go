@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type FeedFormat string
+
+const (
+	FeedFormatAtom FeedFormat = "atom"
+	FeedFormatRSS  FeedFormat = "rss"
+)
+
+// FeedDoc is a rendered feed or sitemap, with its validators for the
+// conditional requests.
+type FeedDoc struct {
+	Body     []byte
+	ETag     string
+	Modified time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// ReadRecord is an article in the reading history of a user, only the last
+// read is kept.
+type ReadRecord struct {
+	UID      int64
+	Article  Article
+	ReadTime time.Time
+}
+
+// ReadRecordCursor returns the cursor after the record in a history sorted by
+// read time.
+func ReadRecordCursor(r ReadRecord) Cursor {
+	return Cursor{
+		Time: r.ReadTime,
+		ID:   r.Article.ID,
+	}
+}
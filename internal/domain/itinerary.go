@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// Itinerary is a travel plan made of ordered days. It can be attached to an
+// article, and readers can clone a published one into their own private copy.
+type Itinerary struct {
+	ID     int64
+	Title  string
+	Author Author
+	// attached article, 0 if none
+	ArticleID int64
+	// published itinerary this one was cloned from, 0 if none
+	SourceID int64
+	// currency of all the costs, like "EUR"
+	Currency string
+	Days     []ItineraryDay
+	Status   ItineraryStatus
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+type ItineraryDay struct {
+	Date  time.Time
+	Notes string
+	Stops []ItineraryStop
+}
+
+type ItineraryStop struct {
+	Place   Place
+	Arrival time.Time
+	// in the minor unit of the currency
+	Cost  int64
+	Notes string
+}
+
+type ItineraryStatus uint8
+
+const (
+	ItineraryStatusUnknown = iota
+	ItineraryStatusUnpublished
+	ItineraryStatusPublished
+	ItineraryStatusPrivate
+)
+
+func (d ItineraryDay) Cost() int64 {
+	var res int64
+	for _, s := range d.Stops {
+		res += s.Cost
+	}
+	return res
+}
+
+func (i Itinerary) TotalCost() int64 {
+	var res int64
+	for _, d := range i.Days {
+		res += d.Cost()
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+type RewardStatus uint8
+
+const (
+	RewardStatusUnknown RewardStatus = iota
+	// waiting for the payment
+	RewardStatusInit
+	RewardStatusPaid
+	RewardStatusFailed
+)
+
+// Reward is a tip paid by a user to the author of a resource.
+type Reward struct {
+	ID    int64
+	Biz   string
+	BizID int64
+	UID   int64
+	// the author receiving the reward
+	TargetUID int64
+	// in cents
+	Amount int64
+	Status RewardStatus
+	Ctime  time.Time
+	Utime  time.Time
+}
+
+// RewardCursor is the position of a reward in the rewards listed by ctime
+// then ID.
+type RewardCursor struct {
+	Ctime time.Time
+	ID    int64
+}
@@ -0,0 +1,80 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/chenmuyao/go-bootcamp/pkg/saramax"
+)
+
+const (
+	exportGroup   = "article_export"
+	exportTimeout = 10 * time.Minute
+)
+
+// Exporter runs the export of the articles of a user.
+type Exporter interface {
+	RunExport(ctx context.Context, uid int64) error
+}
+
+// ExportConsumer runs the exports asked by the ExportEvent.
+type ExportConsumer struct {
+	l        logger.Logger
+	exporter Exporter
+	client   sarama.Client
+}
+
+func (e *ExportConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient(exportGroup, e.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(
+			context.Background(),
+			[]string{TopicExportEvent},
+			saramax.NewBatchHandler[ExportEvent](e.l, e.BatchConsume),
+		)
+		if er != nil {
+			e.l.Error("quit consuming", logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+// NOTE: a failed export is recorded by the exporter, the user can start it
+// again. So only the errors to record it are returned.
+func (e *ExportConsumer) BatchConsume(
+	msgs []*sarama.ConsumerMessage,
+	events []ExportEvent,
+) error {
+	// the same user can ask several times before the export starts
+	seen := make(map[int64]struct{}, len(events))
+	for _, evt := range events {
+		if _, ok := seen[evt.Uid]; ok || evt.Uid <= 0 {
+			continue
+		}
+		seen[evt.Uid] = struct{}{}
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		err := e.exporter.RunExport(ctx, evt.Uid)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewExportConsumer(
+	l logger.Logger,
+	exporter Exporter,
+	client sarama.Client,
+) *ExportConsumer {
+	return &ExportConsumer{
+		l:        l,
+		exporter: exporter,
+		client:   client,
+	}
+}
@@ -6,10 +6,14 @@ import (
 	"github.com/IBM/sarama"
 )
 
-const TopicReadEvent = "article_read"
+const (
+	TopicReadEvent   = "article_read"
+	TopicExportEvent = "article_export"
+)
 
 type Producer interface {
 	ProduceReadEvent(evt ReadEvent) error
+	ProduceExportEvent(evt ExportEvent) error
 }
 
 type ReadEvent struct {
@@ -17,6 +21,11 @@ type ReadEvent struct {
 	Uid int64
 }
 
+// ExportEvent asks to export all the articles of the user in the background.
+type ExportEvent struct {
+	Uid int64
+}
+
 type SaramaSyncProducer struct {
 	producer sarama.SyncProducer
 }
@@ -34,6 +43,19 @@ func (s *SaramaSyncProducer) ProduceReadEvent(evt ReadEvent) error {
 	return err
 }
 
+// ProduceExportEvent implements Producer.
+func (s *SaramaSyncProducer) ProduceExportEvent(evt ExportEvent) error {
+	val, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicExportEvent,
+		Value: sarama.StringEncoder(val),
+	})
+	return err
+}
+
 func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
 	return &SaramaSyncProducer{producer: producer}
 }
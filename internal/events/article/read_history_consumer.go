@@ -0,0 +1,81 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/chenmuyao/go-bootcamp/pkg/saramax"
+)
+
+const (
+	readHistoryGroup   = "read_history"
+	readHistoryTimeout = time.Second
+)
+
+// ReadHistoryConsumer records the ReadEvent into the reading history of the
+// users.
+type ReadHistoryConsumer struct {
+	l      logger.Logger
+	repo   repository.ReadHistoryRepository
+	client sarama.Client
+}
+
+func (r *ReadHistoryConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient(readHistoryGroup, r.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(
+			context.Background(),
+			[]string{TopicReadEvent},
+			saramax.NewBatchHandler[ReadEvent](r.l, r.BatchConsume),
+		)
+		if er != nil {
+			r.l.Error("quit consuming", logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+func (r *ReadHistoryConsumer) BatchConsume(
+	msgs []*sarama.ConsumerMessage,
+	events []ReadEvent,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), readHistoryTimeout)
+	defer cancel()
+
+	records := make([]domain.ReadRecord, 0, len(events))
+	for i, evt := range events {
+		if evt.Uid <= 0 {
+			continue
+		}
+		// the message timestamp is when the article was read
+		readTime := msgs[i].Timestamp
+		if readTime.IsZero() {
+			readTime = time.Now()
+		}
+		records = append(records, domain.ReadRecord{
+			UID:      evt.Uid,
+			Article:  domain.Article{ID: evt.Aid},
+			ReadTime: readTime,
+		})
+	}
+	return r.repo.Record(ctx, records)
+}
+
+func NewReadHistoryConsumer(
+	l logger.Logger,
+	repo repository.ReadHistoryRepository,
+	client sarama.Client,
+) *ReadHistoryConsumer {
+	return &ReadHistoryConsumer{
+		l:      l,
+		repo:   repo,
+		client: client,
+	}
+}
@@ -0,0 +1,75 @@
+package reward
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/chenmuyao/go-bootcamp/pkg/saramax"
+)
+
+const (
+	accountGroup   = "account"
+	accountTimeout = 5 * time.Second
+)
+
+// Crediter credits the paid rewards to the accounts of the authors.
+type Crediter interface {
+	CreditReward(ctx context.Context, r domain.Reward) error
+}
+
+// AccountConsumer credits the PaidEvent. The crediter dedups the rewards, the
+// events can be delivered several times.
+type AccountConsumer struct {
+	l        logger.Logger
+	crediter Crediter
+	client   sarama.Client
+}
+
+func (a *AccountConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient(accountGroup, a.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(
+			context.Background(),
+			[]string{TopicRewardPaid},
+			saramax.NewBatchHandler[PaidEvent](a.l, a.BatchConsume),
+		)
+		if er != nil {
+			a.l.Error("quit consuming", logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+func (a *AccountConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, events []PaidEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), accountTimeout)
+	defer cancel()
+	for _, evt := range events {
+		err := a.crediter.CreditReward(ctx, domain.Reward{
+			ID:        evt.ID,
+			Biz:       evt.Biz,
+			BizID:     evt.BizID,
+			UID:       evt.UID,
+			TargetUID: evt.TargetUID,
+			Amount:    evt.Amount,
+			Status:    domain.RewardStatusPaid,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewAccountConsumer(l logger.Logger, crediter Crediter, client sarama.Client) *AccountConsumer {
+	return &AccountConsumer{
+		l:        l,
+		crediter: crediter,
+		client:   client,
+	}
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./producer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./producer.go -package=rewardmocks -destination=./mocks/producer.mock.go
+//
+
+// Package rewardmocks is a generated GoMock package.
+package rewardmocks
+
+import (
+	reflect "reflect"
+
+	reward "github.com/chenmuyao/go-bootcamp/internal/events/reward"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+	isgomock struct{}
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// ProducePaidEvent mocks base method.
+func (m *MockProducer) ProducePaidEvent(evt reward.PaidEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProducePaidEvent", evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProducePaidEvent indicates an expected call of ProducePaidEvent.
+func (mr *MockProducerMockRecorder) ProducePaidEvent(evt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProducePaidEvent", reflect.TypeOf((*MockProducer)(nil).ProducePaidEvent), evt)
+}
@@ -0,0 +1,46 @@
+package reward
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/sarama"
+)
+
+const TopicRewardPaid = "reward_paid"
+
+//go:generate mockgen -source=./producer.go -package=rewardmocks -destination=./mocks/producer.mock.go
+type Producer interface {
+	ProducePaidEvent(evt PaidEvent) error
+}
+
+// PaidEvent is produced at least once when a reward is paid.
+type PaidEvent struct {
+	ID        int64
+	Biz       string
+	BizID     int64
+	UID       int64
+	TargetUID int64
+	// in cents
+	Amount int64
+}
+
+type SaramaSyncProducer struct {
+	producer sarama.SyncProducer
+}
+
+// ProducePaidEvent implements Producer.
+func (s *SaramaSyncProducer) ProducePaidEvent(evt PaidEvent) error {
+	val, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicRewardPaid,
+		Value: sarama.StringEncoder(val),
+	})
+	return err
+}
+
+func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
+	return &SaramaSyncProducer{producer: producer}
+}
@@ -3,6 +3,7 @@ package integration
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -17,35 +18,169 @@ import (
 	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
 	"github.com/gin-gonic/gin"
 	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"golang.org/x/net/context"
+	"gorm.io/gorm"
 )
 
-type MongoDBArticleHandlerSuite struct {
-	suite.Suite
+// articleStore gives the suite a direct access to the storage, so that the
+// same cases run against each ArticleDAO implementation.
+type articleStore interface {
+	newDAO(t *testing.T) dao.ArticleDAO
+	// MongoDB transactions need a replica set
+	supportTransaction() bool
+	insert(t *testing.T, article dao.Article)
+	insertPub(t *testing.T, article dao.PublishedArticle)
+	findByAuthor(t *testing.T, uid int64) dao.Article
+	findByID(t *testing.T, id int64) dao.Article
+	findPubByID(t *testing.T, id int64) dao.PublishedArticle
+	clean(t *testing.T)
+}
+
+type mongoArticleStore struct {
 	client   *mongo.Client
 	coll     *mongo.Collection
 	liveColl *mongo.Collection
-	server   *gin.Engine
 }
 
-func (s *MongoDBArticleHandlerSuite) SetupSuite() {
+func newMongoArticleStore() *mongoArticleStore {
+	client := startup.InitMongoDB()
+	return &mongoArticleStore{
+		client:   client,
+		coll:     client.Database(dao.DatabaseName).Collection(dao.ArticleCollName),
+		liveColl: client.Database(dao.DatabaseName).Collection(dao.PublishedArticleCollName),
+	}
+}
+
+func (m *mongoArticleStore) newDAO(t *testing.T) dao.ArticleDAO {
+	node, err := snowflake.NewNode(1)
+	assert.NoError(t, err)
+	return dao.NewMongoDBArticleDAO(m.client, node)
+}
+
+func (m *mongoArticleStore) supportTransaction() bool {
+	return false
+}
+
+func (m *mongoArticleStore) insert(t *testing.T, article dao.Article) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := m.coll.InsertOne(ctx, &article)
+	assert.NoError(t, err)
+}
+
+func (m *mongoArticleStore) insertPub(t *testing.T, article dao.PublishedArticle) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := m.liveColl.InsertOne(ctx, &article)
+	assert.NoError(t, err)
+}
+
+func (m *mongoArticleStore) findByAuthor(t *testing.T, uid int64) dao.Article {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var article dao.Article
+	err := m.coll.FindOne(ctx, bson.M{"author_id": uid}).Decode(&article)
+	assert.NoError(t, err)
+	return article
+}
+
+func (m *mongoArticleStore) findByID(t *testing.T, id int64) dao.Article {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var article dao.Article
+	err := m.coll.FindOne(ctx, bson.M{"id": id}).Decode(&article)
+	assert.NoError(t, err)
+	return article
+}
+
+func (m *mongoArticleStore) findPubByID(t *testing.T, id int64) dao.PublishedArticle {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var article dao.PublishedArticle
+	err := m.liveColl.FindOne(ctx, bson.M{"id": id}).Decode(&article)
+	assert.NoError(t, err)
+	return article
+}
+
+func (m *mongoArticleStore) clean(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := m.coll.DeleteMany(ctx, bson.D{})
+	assert.NoError(t, err)
+	_, err = m.liveColl.DeleteMany(ctx, bson.D{})
+	assert.NoError(t, err)
+}
+
+type gormArticleStore struct {
+	db *gorm.DB
+}
+
+func newGORMArticleStore() *gormArticleStore {
+	return &gormArticleStore{
+		db: startup.InitDB(),
+	}
+}
+
+func (g *gormArticleStore) newDAO(t *testing.T) dao.ArticleDAO {
+	return dao.NewArticleDAO(g.db)
+}
+
+func (g *gormArticleStore) supportTransaction() bool {
+	return true
+}
+
+func (g *gormArticleStore) insert(t *testing.T, article dao.Article) {
+	assert.NoError(t, g.db.Create(&article).Error)
+}
+
+func (g *gormArticleStore) insertPub(t *testing.T, article dao.PublishedArticle) {
+	assert.NoError(t, g.db.Create(&article).Error)
+}
+
+func (g *gormArticleStore) findByAuthor(t *testing.T, uid int64) dao.Article {
+	var article dao.Article
+	assert.NoError(t, g.db.Where("author_id = ?", uid).First(&article).Error)
+	return article
+}
+
+func (g *gormArticleStore) findByID(t *testing.T, id int64) dao.Article {
+	var article dao.Article
+	assert.NoError(t, g.db.Where("id = ?", id).First(&article).Error)
+	return article
+}
+
+func (g *gormArticleStore) findPubByID(t *testing.T, id int64) dao.PublishedArticle {
+	var article dao.PublishedArticle
+	assert.NoError(t, g.db.Where("id = ?", id).First(&article).Error)
+	return article
+}
+
+func (g *gormArticleStore) clean(t *testing.T) {
+	assert.NoError(t, g.db.Exec("TRUNCATE articles").Error)
+	assert.NoError(t, g.db.Exec("TRUNCATE published_articles").Error)
+}
+
+type ArticleStorageHandlerSuite struct {
+	suite.Suite
+	store  articleStore
+	rdb    redis.Cmdable
+	server *gin.Engine
+}
+
+func (s *ArticleStorageHandlerSuite) SetupSuite() {
 	s.server = gin.Default()
 	s.server.Use(func(ctx *gin.Context) {
 		ctx.Set("user", ijwt.UserClaims{
 			UID: 123,
 		})
 	})
-	s.client = startup.InitMongoDB()
-	// s.rdb = startup.InitRedis()
-	s.coll = s.client.Database(dao.DatabaseName).Collection(dao.ArticleCollName)
-	s.liveColl = s.client.Database(dao.DatabaseName).Collection(dao.PublishedArticleCollName)
-	node, err := snowflake.NewNode(1)
-	assert.NoError(s.T(), err)
-	hdl := startup.InitArticleHandler(dao.NewMongoDBArticleDAO(s.client, node))
+	s.rdb = startup.InitRedis()
+	hdl := startup.InitArticleHandler(s.store.newDAO(s.T()))
 	hdl.RegisterRoutes(s.server)
 	ginx.InitCounter(prom.CounterOpts{
 		Namespace: "my_company",
@@ -58,17 +193,14 @@ func (s *MongoDBArticleHandlerSuite) SetupSuite() {
 	})
 }
 
-func (s *MongoDBArticleHandlerSuite) TearDownTest() {
+func (s *ArticleStorageHandlerSuite) TearDownTest() {
+	s.store.clean(s.T())
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-
-	_, err := s.coll.DeleteMany(ctx, bson.D{})
-	assert.NoError(s.T(), err)
-	_, err = s.liveColl.DeleteMany(ctx, bson.D{})
-	assert.NoError(s.T(), err)
+	s.rdb.Del(ctx, "article:first_page:123")
 }
 
-func (s *MongoDBArticleHandlerSuite) TestEdit() {
+func (s *ArticleStorageHandlerSuite) TestEdit() {
 	t := s.T()
 
 	testCases := []struct {
@@ -87,16 +219,7 @@ func (s *MongoDBArticleHandlerSuite) TestEdit() {
 			before: func(t *testing.T) {},
 			after: func(t *testing.T) {
 				// check that the article is saved into the DB
-				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-				defer cancel()
-
-				filter := bson.M{
-					"author_id": 123,
-				}
-				var article dao.Article
-				err := s.coll.FindOne(ctx, filter).Decode(&article)
-				assert.NoError(t, err)
-
+				article := s.store.findByAuthor(t, 123)
 				assert.True(t, article.ID > 0)
 				article.ID = 0
 				assert.True(t, article.Ctime > 789)
@@ -108,6 +231,7 @@ func (s *MongoDBArticleHandlerSuite) TestEdit() {
 					Content:  "my content",
 					AuthorID: 123,
 					Status:   domain.ArticleStatusUnpublished,
+					Version:  1,
 				}, article)
 			},
 			article: web.ArticleEditReq{
@@ -123,10 +247,7 @@ func (s *MongoDBArticleHandlerSuite) TestEdit() {
 		{
 			name: "Edit a post",
 			before: func(t *testing.T) {
-				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-				defer cancel()
-
-				article := dao.Article{
+				s.store.insert(t, dao.Article{
 					ID:       2,
 					Title:    "my title",
 					Content:  "my content",
@@ -134,20 +255,11 @@ func (s *MongoDBArticleHandlerSuite) TestEdit() {
 					Status:   domain.ArticleStatusPublished,
 					Ctime:    456,
 					Utime:    789,
-				}
-
-				_, err := s.coll.InsertOne(ctx, &article)
-				assert.NoError(t, err)
+				})
 			},
 			after: func(t *testing.T) {
-				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-				defer cancel()
 				// check that the article is saved into the DB
-				var article dao.Article
-				err := s.coll.FindOne(ctx, bson.M{
-					"id": 2,
-				}).Decode(&article)
-				assert.NoError(t, err)
+				article := s.store.findByID(t, 2)
 				assert.True(t, article.Utime > 789)
 				article.Utime = 0
 				assert.Equal(t, dao.Article{
@@ -157,6 +269,7 @@ func (s *MongoDBArticleHandlerSuite) TestEdit() {
 					AuthorID: 123,
 					Status:   domain.ArticleStatusUnpublished,
 					Ctime:    456,
+					Version:  1,
 				}, article)
 			},
 			article: web.ArticleEditReq{
@@ -173,10 +286,7 @@ func (s *MongoDBArticleHandlerSuite) TestEdit() {
 		{
 			name: "Edit a post of someone else",
 			before: func(t *testing.T) {
-				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-				defer cancel()
-
-				article := dao.Article{
+				s.store.insert(t, dao.Article{
 					ID:       3,
 					Title:    "my title",
 					Content:  "my content",
@@ -184,20 +294,11 @@ func (s *MongoDBArticleHandlerSuite) TestEdit() {
 					Status:   domain.ArticleStatusPublished,
 					Ctime:    456,
 					Utime:    789,
-				}
-
-				_, err := s.coll.InsertOne(ctx, &article)
-				assert.NoError(t, err)
+				})
 			},
 			after: func(t *testing.T) {
-				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-				defer cancel()
-				// check that the article is saved into the DB
-				var article dao.Article
-				err := s.coll.FindOne(ctx, bson.M{
-					"id": 3,
-				}).Decode(&article)
-				assert.NoError(t, err)
+				// check that the article is not changed
+				article := s.store.findByID(t, 3)
 				assert.Equal(t, dao.Article{
 					ID:       3,
 					Title:    "my title",
@@ -253,181 +354,303 @@ func (s *MongoDBArticleHandlerSuite) TestEdit() {
 	}
 }
 
-// NOTE: Need to set replica set for mongo
-// func (s *MongoDBArticleHandlerSuite) TestPublish() {
-// 	t := s.T()
-
-// 	testCases := []struct {
-// 		name   string
-// 		before func(t *testing.T)
-// 		after  func(t *testing.T)
-
-// 		// json article from frontend
-// 		article web.ArticlePublishReq
-
-// 		wantCode int
-// 		wantRes  Result[int64]
-// 	}{
-// 		{
-// 			name:   "Publish a new post",
-// 			before: func(t *testing.T) {},
-// 			after: func(t *testing.T) {
-// 				// check that the article is saved into the DB
-// 				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-// 				defer cancel()
-
-// 				filter := bson.M{
-// 					"author_id": 123,
-// 				}
-// 				var article dao.Article
-// 				err := s.liveColl.FindOne(ctx, filter).Decode(&article)
-// 				assert.NoError(t, err)
-
-// 				assert.True(t, article.ID > 0)
-// 				article.ID = 0
-// 				assert.True(t, article.Ctime > 789)
-// 				article.Ctime = 0
-// 				assert.True(t, article.Utime > 789)
-// 				article.Utime = 0
-// 				assert.Equal(t, dao.Article{
-// 					Title:    "my super title",
-// 					Content:  "my content",
-// 					AuthorID: 123,
-// 					Status:   domain.ArticleStatusPublished,
-// 				}, article)
-// 			},
-// 			article: web.ArticlePublishReq{
-// 				Title:   "my super title",
-// 				Content: "my content",
-// 			},
-// 			wantCode: http.StatusOK,
-// 			wantRes: Result[int64]{
-// 				Code: ginx.CodeOK,
-// 				Data: 1,
-// 			},
-// 		},
-// 		// {
-// 		// 	name: "Edit and publish a post",
-// 		// 	before: func(t *testing.T) {
-// 		// 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-// 		// 		defer cancel()
-// 		//
-// 		// 		article := dao.Article{
-// 		// 			ID:       2,
-// 		// 			Title:    "my title",
-// 		// 			Content:  "my content",
-// 		// 			AuthorID: 123,
-// 		// 			Status:   domain.ArticleStatusPublished,
-// 		// 			Ctime:    456,
-// 		// 			Utime:    789,
-// 		// 		}
-// 		//
-// 		// 		_, err := s.liveColl.InsertOne(ctx, &article)
-// 		// 		assert.NoError(t, err)
-// 		// 	},
-// 		// 	after: func(t *testing.T) {
-// 		// 		// check that the article is saved into the DB
-// 		// 		var article dao.Article
-// 		// 		err := s.mdb.Where("id = ?", 2).First(&article).Error
-// 		// 		assert.NoError(t, err)
-// 		// 		assert.True(t, article.Utime > 789)
-// 		// 		article.Utime = 0
-// 		// 		assert.Equal(t, dao.Article{
-// 		// 			ID:       2,
-// 		// 			Title:    "new title",
-// 		// 			Content:  "new content",
-// 		// 			AuthorID: 123,
-// 		// 			Status:   domain.ArticleStatusPublished,
-// 		// 			Ctime:    456,
-// 		// 		}, article)
-// 		// 	},
-// 		// 	article: web.ArticlePublishReq{
-// 		// 		ID:      2,
-// 		// 		Title:   "new title",
-// 		// 		Content: "new content",
-// 		// 	},
-// 		// 	wantCode: http.StatusOK,
-// 		// 	wantRes: Result[int64]{
-// 		// 		Code: ginx.CodeOK,
-// 		// 		Data: 2,
-// 		// 	},
-// 		// },
-// 		// {
-// 		// 	name: "Publish a post of someone else",
-// 		// 	before: func(t *testing.T) {
-// 		// 		err := s.mdb.Create(dao.Article{
-// 		// 			ID:       3,
-// 		// 			Title:    "my title",
-// 		// 			Content:  "my content",
-// 		// 			AuthorID: 234,
-// 		// 			Status:   domain.ArticleStatusPublished,
-// 		// 			Ctime:    456,
-// 		// 			Utime:    789,
-// 		// 		}).Error
-// 		// 		assert.NoError(t, err)
-// 		// 	},
-// 		// 	after: func(t *testing.T) {
-// 		// 		// check that the article is saved into the DB
-// 		// 		var article dao.Article
-// 		// 		err := s.mdb.Where("id = ?", 3).First(&article).Error
-// 		// 		assert.NoError(t, err)
-// 		// 		assert.Equal(t, dao.Article{
-// 		// 			ID:       3,
-// 		// 			Title:    "my title",
-// 		// 			Content:  "my content",
-// 		// 			AuthorID: 234,
-// 		// 			Status:   domain.ArticleStatusPublished,
-// 		// 			Ctime:    456,
-// 		// 			Utime:    789,
-// 		// 		}, article)
-// 		// 	},
-// 		// 	article: web.ArticlePublishReq{
-// 		// 		ID:      3,
-// 		// 		Title:   "new title",
-// 		// 		Content: "new content",
-// 		// 	},
-// 		// 	wantCode: http.StatusBadRequest,
-// 		// 	wantRes: Result[int64]{
-// 		// 		Code: ginx.CodeUserSide,
-// 		// 		Msg:  "article not found",
-// 		// 	},
-// 		// },
-// 	}
-
-// 	for _, tc := range testCases {
-// 		t.Run(tc.name, func(t *testing.T) {
-// 			tc.before(t)
-// 			defer tc.after(t)
-
-// 			reqBody, err := json.Marshal(tc.article)
-// 			assert.NoError(t, err)
-// 			req, err := http.NewRequest(
-// 				http.MethodPost,
-// 				"/articles/publish",
-// 				bytes.NewReader(reqBody),
-// 			)
-// 			req.Header.Set("Content-Type", "application/json")
-// 			assert.NoError(t, err)
-// 			rec := httptest.NewRecorder()
-
-// 			s.server.ServeHTTP(rec, req)
-
-// 			assert.Equal(t, tc.wantCode, rec.Code)
-// 			var res Result[int64]
-// 			err = json.NewDecoder(rec.Body).Decode(&res)
-// 			assert.NoError(t, err)
-// 			if tc.wantRes.Data > 0 {
-// 				// can only assert the existence of an ID
-// 				assert.True(t, res.Data > 0)
-// 				tc.wantRes.Data = res.Data
-// 			}
-// 			assert.Equal(t, tc.wantRes, res)
-// 		})
-// 	}
-// }
+func (s *ArticleStorageHandlerSuite) TestPublish() {
+	t := s.T()
+	if !s.store.supportTransaction() {
+		t.Skip("the storage does not support transactions")
+	}
+
+	testCases := []struct {
+		name   string
+		before func(t *testing.T)
+		after  func(t *testing.T)
+
+		// json article from frontend
+		article web.ArticlePublishReq
+
+		wantCode int
+		wantRes  Result[int64]
+	}{
+		{
+			name: "Edit and publish a post",
+			before: func(t *testing.T) {
+				s.store.insert(t, dao.Article{
+					ID:       12,
+					Title:    "my title",
+					Content:  "my content",
+					AuthorID: 123,
+					Status:   domain.ArticleStatusUnpublished,
+					Ctime:    456,
+					Utime:    789,
+				})
+			},
+			after: func(t *testing.T) {
+				article := s.store.findPubByID(t, 12)
+				assert.True(t, article.Utime > 789)
+				article.Utime = 0
+				assert.True(t, article.Ctime > 0)
+				article.Ctime = 0
+				assert.Equal(t, dao.PublishedArticle{
+					ID:       12,
+					Title:    "new title",
+					Content:  "new content",
+					AuthorID: 123,
+					Status:   domain.ArticleStatusPublished,
+				}, article)
+			},
+			article: web.ArticlePublishReq{
+				ID:      12,
+				Title:   "new title",
+				Content: "new content",
+			},
+			wantCode: http.StatusOK,
+			wantRes: Result[int64]{
+				Code: ginx.CodeOK,
+				Data: 12,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			defer tc.after(t)
+
+			reqBody, err := json.Marshal(tc.article)
+			assert.NoError(t, err)
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/articles/publish",
+				bytes.NewReader(reqBody),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			assert.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			s.server.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			var res Result[int64]
+			err = json.NewDecoder(rec.Body).Decode(&res)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func (s *ArticleStorageHandlerSuite) TestDetail() {
+	t := s.T()
+
+	testCases := []struct {
+		name   string
+		before func(t *testing.T)
+		after  func(t *testing.T)
+
+		id int64
+
+		wantCode int
+		wantRes  Result[web.ArticleVO]
+	}{
+		{
+			name: "authors see detail of an article",
+			before: func(t *testing.T) {
+				s.store.insert(t, dao.Article{
+					ID:       21,
+					Title:    "my title",
+					Content:  "my content",
+					AuthorID: 123,
+					Status:   domain.ArticleStatusUnpublished,
+					Ctime:    456,
+					Utime:    789,
+				})
+			},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				time.Sleep(100 * time.Millisecond) // wait for goroutines
+				assert.NoError(t, s.rdb.Del(ctx, "article:content:21").Err())
+			},
+			id:       21,
+			wantCode: http.StatusOK,
+			wantRes: Result[web.ArticleVO]{
+				Code: ginx.CodeOK,
+				Data: web.ArticleVO{
+					ID:       21,
+					Title:    "my title",
+					Content:  "my content",
+					AuthorID: 123,
+					Status:   domain.ArticleStatusUnpublished,
+					Ctime:    time.UnixMilli(456).Format(time.DateTime),
+					Utime:    time.UnixMilli(456).Format(time.DateTime),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			defer tc.after(t)
+
+			req, err := http.NewRequest(
+				http.MethodGet,
+				fmt.Sprintf("/articles/detail/%d", tc.id),
+				nil,
+			)
+			assert.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			s.server.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			var res Result[web.ArticleVO]
+			err = json.NewDecoder(rec.Body).Decode(&res)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func (s *ArticleStorageHandlerSuite) TestList() {
+	t := s.T()
+
+	insertArticles := func(t *testing.T) {
+		for i, utime := range []int64{789, 790} {
+			s.store.insert(t, dao.Article{
+				ID:       int64(31 + i),
+				Title:    "my title",
+				Content:  "my content",
+				AuthorID: 123,
+				Status:   domain.ArticleStatusUnpublished,
+				Ctime:    456,
+				Utime:    utime,
+			})
+		}
+		s.store.insert(t, dao.Article{
+			ID:       33,
+			Title:    "my title",
+			Content:  "my content",
+			AuthorID: 234,
+			Status:   domain.ArticleStatusUnpublished,
+			Ctime:    456,
+			Utime:    791,
+		})
+	}
+	articleVO := func(id int64) web.ArticleVO {
+		return web.ArticleVO{
+			ID:       id,
+			Title:    "my title",
+			Abstract: "my content",
+			Status:   domain.ArticleStatusUnpublished,
+			Ctime:    time.UnixMilli(456).Format(time.DateTime),
+			Utime:    time.UnixMilli(456).Format(time.DateTime),
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		before func(t *testing.T)
+		after  func(t *testing.T)
+
+		req web.ArticleListReq
+
+		wantCode int
+		wantRes  Result[web.ArticleListVO]
+	}{
+		{
+			name:   "authors see their latest articles",
+			before: insertArticles,
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				time.Sleep(100 * time.Millisecond) // wait for goroutines
+				assert.NoError(t, s.rdb.Del(ctx, "article:content:32").Err())
+			},
+			req: web.ArticleListReq{
+				Limit: 1,
+			},
+			wantCode: http.StatusOK,
+			wantRes: Result[web.ArticleListVO]{
+				Code: ginx.CodeOK,
+				Data: web.ArticleListVO{
+					Articles:   []web.ArticleVO{articleVO(32)},
+					NextCursor: keysetCursor(790, 32),
+				},
+			},
+		},
+		{
+			name:   "authors see the next page",
+			before: insertArticles,
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				time.Sleep(100 * time.Millisecond) // wait for goroutines
+				assert.NoError(t, s.rdb.Del(ctx, "article:content:31").Err())
+			},
+			req: web.ArticleListReq{
+				Cursor: keysetCursor(790, 32),
+				Limit:  2,
+			},
+			wantCode: http.StatusOK,
+			wantRes: Result[web.ArticleListVO]{
+				Code: ginx.CodeOK,
+				Data: web.ArticleListVO{
+					Articles: []web.ArticleVO{articleVO(31)},
+				},
+			},
+		},
+		{
+			name:   "invalid cursor",
+			before: func(t *testing.T) {},
+			after:  func(t *testing.T) {},
+			req: web.ArticleListReq{
+				Cursor: "invalid",
+				Limit:  2,
+			},
+			wantCode: http.StatusBadRequest,
+			wantRes: Result[web.ArticleListVO]{
+				Code: ginx.CodeUserSide,
+				Msg:  "invalid cursor",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			defer func() {
+				tc.after(t)
+				s.store.clean(t)
+			}()
+
+			reqBody, err := json.Marshal(tc.req)
+			assert.NoError(t, err)
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/articles/list",
+				bytes.NewReader(reqBody),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			assert.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			s.server.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			var res Result[web.ArticleListVO]
+			err = json.NewDecoder(rec.Body).Decode(&res)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
 
 func TestMongoDBArticleHandler(t *testing.T) {
-	suite.Run(t, &MongoDBArticleHandlerSuite{})
+	suite.Run(t, &ArticleStorageHandlerSuite{store: newMongoArticleStore()})
+}
+
+func TestGORMArticleStorageHandler(t *testing.T) {
+	suite.Run(t, &ArticleStorageHandlerSuite{store: newGORMArticleStore()})
 }
 
 func init() {
@@ -3,6 +3,7 @@ package integration
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -64,6 +65,7 @@ func (s *ArticleHandlerSuite) TearDownTest() {
 	log.Println("TRUNCATE")
 	s.db.Exec("TRUNCATE articles")
 	s.db.Exec("TRUNCATE published_articles")
+	s.db.Exec("TRUNCATE article_collaborators")
 	s.db.Exec("TRUNCATE users")
 	s.db.Exec("TRUNCATE interactives")
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
@@ -103,6 +105,7 @@ func (s *ArticleHandlerSuite) TestEdit() {
 					Content:  "my content",
 					AuthorID: 123,
 					Status:   domain.ArticleStatusUnpublished,
+					Version:  1,
 				}, article)
 			},
 			article: web.ArticleEditReq{
@@ -143,6 +146,7 @@ func (s *ArticleHandlerSuite) TestEdit() {
 					AuthorID: 123,
 					Status:   domain.ArticleStatusUnpublished,
 					Ctime:    456,
+					Version:  1,
 				}, article)
 			},
 			article: web.ArticleEditReq{
@@ -257,6 +261,7 @@ func (s *ArticleHandlerSuite) TestPublish() {
 					Content:  "my content",
 					AuthorID: 123,
 					Status:   domain.ArticleStatusPublished,
+					Version:  1,
 				}, article)
 
 				var articlePub dao.PublishedArticle
@@ -342,6 +347,7 @@ func (s *ArticleHandlerSuite) TestPublish() {
 					AuthorID: 123,
 					Status:   domain.ArticleStatusPublished,
 					Ctime:    456,
+					Version:  1,
 				}, article)
 
 				var pubArticle dao.PublishedArticle
@@ -667,12 +673,13 @@ func (s *ArticleHandlerSuite) TestDetail() {
 			wantRes: Result[web.ArticleVO]{
 				Code: ginx.CodeOK,
 				Data: web.ArticleVO{
-					ID:      41,
-					Title:   "my title",
-					Content: "my content",
-					Status:  domain.ArticleStatusUnpublished,
-					Ctime:   time.UnixMilli(456).Format(time.DateTime),
-					Utime:   time.UnixMilli(789).Format(time.DateTime),
+					ID:       41,
+					Title:    "my title",
+					Content:  "my content",
+					AuthorID: 123,
+					Status:   domain.ArticleStatusUnpublished,
+					Ctime:    time.UnixMilli(456).Format(time.DateTime),
+					Utime:    time.UnixMilli(789).Format(time.DateTime),
 				},
 			},
 		},
@@ -704,12 +711,13 @@ func (s *ArticleHandlerSuite) TestDetail() {
 			wantRes: Result[web.ArticleVO]{
 				Code: ginx.CodeOK,
 				Data: web.ArticleVO{
-					ID:      42,
-					Title:   "my title",
-					Content: "my content",
-					Status:  domain.ArticleStatusUnpublished,
-					Ctime:   time.UnixMilli(456).Format(time.DateTime),
-					Utime:   time.UnixMilli(789).Format(time.DateTime),
+					ID:       42,
+					Title:    "my title",
+					Content:  "my content",
+					AuthorID: 123,
+					Status:   domain.ArticleStatusUnpublished,
+					Ctime:    time.UnixMilli(456).Format(time.DateTime),
+					Utime:    time.UnixMilli(789).Format(time.DateTime),
 				},
 			},
 		},
@@ -780,10 +788,10 @@ func (s *ArticleHandlerSuite) TestList() {
 		before func(t *testing.T)
 		after  func(t *testing.T)
 
-		page web.Page
+		req web.ArticleListReq
 
 		wantCode int
-		wantRes  Result[[]web.ArticleVO]
+		wantRes  Result[web.ArticleListVO]
 	}{
 		{
 			name: "authors see list of their articles from db",
@@ -822,21 +830,24 @@ func (s *ArticleHandlerSuite) TestList() {
 				assert.NoError(t, err)
 				assert.NoError(t, s.rdb.Del(ctx, "article:content:51").Err())
 			},
-			page: web.Page{
-				Limit:  1,
-				Offset: 0,
+			req: web.ArticleListReq{
+				Limit: 1,
 			},
 			wantCode: http.StatusOK,
-			wantRes: Result[[]web.ArticleVO]{
+			wantRes: Result[web.ArticleListVO]{
 				Code: ginx.CodeOK,
-				Data: []web.ArticleVO{{
-					ID:       51,
-					Title:    "my title",
-					Abstract: "my content",
-					Status:   domain.ArticleStatusUnpublished,
-					Ctime:    time.UnixMilli(456).Format(time.DateTime),
-					Utime:    time.UnixMilli(789).Format(time.DateTime),
-				}},
+				Data: web.ArticleListVO{
+					Articles: []web.ArticleVO{{
+						ID:       51,
+						Title:    "my title",
+						Abstract: "my content",
+						Status:   domain.ArticleStatusUnpublished,
+						Ctime:    time.UnixMilli(456).Format(time.DateTime),
+						Utime:    time.UnixMilli(789).Format(time.DateTime),
+					}},
+					// a full page has a next one
+					NextCursor: keysetCursor(789, 51),
+				},
 			},
 		},
 		{
@@ -862,21 +873,24 @@ func (s *ArticleHandlerSuite) TestList() {
 				defer cancel()
 				assert.NoError(t, s.rdb.Del(ctx, "article:first_page:123").Err())
 			},
-			page: web.Page{
-				Limit:  1,
-				Offset: 0,
+			req: web.ArticleListReq{
+				Limit: 1,
 			},
 			wantCode: http.StatusOK,
-			wantRes: Result[[]web.ArticleVO]{
+			wantRes: Result[web.ArticleListVO]{
 				Code: ginx.CodeOK,
-				Data: []web.ArticleVO{{
-					ID:       52,
-					Title:    "my title",
-					Abstract: "my content",
-					Status:   domain.ArticleStatusUnpublished,
-					Ctime:    time.UnixMilli(456).Format(time.DateTime),
-					Utime:    time.UnixMilli(789).Format(time.DateTime),
-				}},
+				Data: web.ArticleListVO{
+					Articles: []web.ArticleVO{{
+						ID:       52,
+						Title:    "my title",
+						Abstract: "my content",
+						Status:   domain.ArticleStatusUnpublished,
+						Ctime:    time.UnixMilli(456).Format(time.DateTime),
+						Utime:    time.UnixMilli(789).Format(time.DateTime),
+					}},
+					// a full page has a next one
+					NextCursor: keysetCursor(789, 52),
+				},
 			},
 		},
 	}
@@ -886,7 +900,7 @@ func (s *ArticleHandlerSuite) TestList() {
 			tc.before(t)
 			defer tc.after(t)
 
-			reqBody, err := json.Marshal(tc.page)
+			reqBody, err := json.Marshal(tc.req)
 			req, err := http.NewRequest(
 				http.MethodPost,
 				"/articles/list",
@@ -899,7 +913,7 @@ func (s *ArticleHandlerSuite) TestList() {
 			s.server.ServeHTTP(rec, req)
 
 			assert.Equal(t, tc.wantCode, rec.Code)
-			var res Result[[]web.ArticleVO]
+			var res Result[web.ArticleListVO]
 			err = json.NewDecoder(rec.Body).Decode(&res)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.wantRes, res)
@@ -1059,3 +1073,8 @@ func init() {
 	// limit log output
 	gin.SetMode(gin.ReleaseMode)
 }
+
+// keysetCursor is the token of the /articles/list cursor.
+func keysetCursor(utime, id int64) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d_%d", utime, id))
+}
@@ -0,0 +1,19 @@
+package startup
+
+import (
+	"os"
+
+	"github.com/chenmuyao/go-bootcamp/pkg/objstore"
+)
+
+func InitObjStore() objstore.Store {
+	dir, err := os.MkdirTemp("", "wetravel-objstore-*")
+	if err != nil {
+		panic(err)
+	}
+	store, err := objstore.NewLocalStore(dir)
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
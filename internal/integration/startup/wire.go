@@ -4,11 +4,13 @@
 package startup
 
 import (
+	intrIoc "github.com/chenmuyao/go-bootcamp/interactive/ioc"
 	intrRepository "github.com/chenmuyao/go-bootcamp/interactive/repository"
 	intrRediscache "github.com/chenmuyao/go-bootcamp/interactive/repository/cache/rediscache"
 	intrDao "github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
 	intrService "github.com/chenmuyao/go-bootcamp/interactive/service"
 	"github.com/chenmuyao/go-bootcamp/internal/events/article"
+	"github.com/chenmuyao/go-bootcamp/internal/events/reward"
 	"github.com/chenmuyao/go-bootcamp/internal/job"
 	"github.com/chenmuyao/go-bootcamp/internal/repository"
 	"github.com/chenmuyao/go-bootcamp/internal/repository/cache/rediscache"
@@ -27,14 +29,21 @@ var thirdPartySet = wire.NewSet(
 	InitLogger,
 	InitSaramaClient,
 	InitSyncProducer,
+	InitObjStore,
 )
 
 var interactiveSvcSet = wire.NewSet(
 	intrDao.NewGORMInteractiveDAO,
+	intrDao.NewGORMCollectionDAO,
+	intrDao.NewGORMPublishedDAO,
 	intrRediscache.NewInteractiveRedisCache,
 	ioc.InitTopArticlesCache,
+	intrRepository.NewWriteThroughCntWriter,
 	intrRepository.NewCachedInteractiveRepository,
+	intrRepository.NewGORMCollectionRepository,
+	intrRepository.NewGORMPublishedRepository,
 	intrService.NewInteractiveService,
+	intrIoc.InitBizRegistry,
 )
 
 var jobProviderSet = wire.NewSet(
@@ -55,15 +64,28 @@ func InitWebServer() *gin.Engine {
 		dao.NewUserDAO,
 		dao.NewAsyncSMSDAO,
 		dao.NewArticleDAO,
+		dao.NewGORMArticleCollaboratorDAO,
+		dao.NewItineraryGORMAuthorDAO,
+		dao.NewItineraryGORMReaderDAO,
+		dao.NewGORMArticleExportDAO,
+		dao.NewGORMRewardDAO,
+		dao.NewGORMAccountDAO,
 
 		// Cache
 		rediscache.NewCodeRedisCache,
 		rediscache.NewUserRedisCache,
 		rediscache.NewArticleRedisCache,
+		rediscache.NewArticleGeoRedisCache,
+		rediscache.NewFeedRedisCache,
+		rediscache.NewReadHistoryRedisCache,
+		rediscache.NewRecommendRedisCache,
+		rediscache.NewRankingRedisCache,
+		ioc.InitRankingLocalCache,
 		// ioc.InitCodeLocalCache,
 		// ioc.InitUserLocalCache,
 
 		article.NewSaramaSyncProducer,
+		reward.NewSaramaSyncProducer,
 		// article.NewInteractiveReadEventConsumer,
 
 		// Repo
@@ -71,18 +93,44 @@ func InitWebServer() *gin.Engine {
 		repository.NewCodeRepository,
 		repository.NewAsyncSMSRepository,
 		repository.NewArticleRepository,
+		repository.NewArticleCollaboratorRepository,
+		repository.NewCachedFeedRepository,
+		repository.NewCachedReadHistoryRepository,
+		repository.NewCachedRecommendRepository,
+		repository.NewCachedRankingRepository,
+		repository.NewItineraryRepository,
+		repository.NewObjStoreArticleArchiveRepository,
+		repository.NewRewardRepository,
+		repository.NewAccountRepository,
 
 		// Services
 		ioc.InitSMSService,
 		service.NewCodeService,
 		service.NewUserService,
 		service.NewArticleService,
+		service.NewItineraryService,
+		ioc.InitFeedService,
+		service.NewReadHistoryService,
+		service.NewBatchRankingService,
+		service.NewRecommendService,
+		service.NewArchiveService,
+		ioc.InitPaymentService,
+		service.NewRewardService,
+		ioc.InitAccountService,
 
 		// handler
 		web.NewUserHandler,
 		NewDummyGiteaHandler,
 		ijwt.NewRedisJWTHandler,
 		web.NewArticleHandler,
+		web.NewItineraryHandler,
+		web.NewFeedHandler,
+		web.NewReadHistoryHandler,
+		web.NewRecommendHandler,
+		web.NewArchiveHandler,
+		web.NewCollectionHandler,
+		web.NewRewardHandler,
+		web.NewAccountHandler,
 
 		ioc.InitGinMiddlewares,
 		ioc.InitWebServer,
@@ -94,6 +142,8 @@ func InitArticleHandler(articleDAO dao.ArticleDAO) *web.ArticleHandler {
 	wire.Build(
 		thirdPartySet,
 		rediscache.NewArticleRedisCache,
+		rediscache.NewArticleGeoRedisCache,
+		rediscache.NewFeedRedisCache,
 		interactiveSvcSet,
 		ioc.InitIntrClient,
 
@@ -104,6 +154,8 @@ func InitArticleHandler(articleDAO dao.ArticleDAO) *web.ArticleHandler {
 		article.NewSaramaSyncProducer,
 
 		repository.NewArticleRepository,
+		dao.NewGORMArticleCollaboratorDAO,
+		repository.NewArticleCollaboratorRepository,
 		service.NewArticleService,
 		web.NewArticleHandler,
 	)
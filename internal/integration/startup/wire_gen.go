@@ -7,11 +7,13 @@
 package startup
 
 import (
+	ioc2 "github.com/chenmuyao/go-bootcamp/interactive/ioc"
 	repository2 "github.com/chenmuyao/go-bootcamp/interactive/repository"
 	rediscache2 "github.com/chenmuyao/go-bootcamp/interactive/repository/cache/rediscache"
 	dao2 "github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
 	service2 "github.com/chenmuyao/go-bootcamp/interactive/service"
 	"github.com/chenmuyao/go-bootcamp/internal/events/article"
+	"github.com/chenmuyao/go-bootcamp/internal/events/reward"
 	"github.com/chenmuyao/go-bootcamp/internal/job"
 	"github.com/chenmuyao/go-bootcamp/internal/repository"
 	"github.com/chenmuyao/go-bootcamp/internal/repository/cache/rediscache"
@@ -46,19 +48,65 @@ func InitWebServer() *gin.Engine {
 	oAuth2GiteaHandler := NewDummyGiteaHandler(userService, handler, logger)
 	articleDAO := dao.NewArticleDAO(db)
 	articleCache := rediscache.NewArticleRedisCache(cmdable)
-	articleRepository := repository.NewArticleRepository(logger, articleDAO, articleCache, userRepository)
+	articleGeoCache := rediscache.NewArticleGeoRedisCache(cmdable)
+	feedCache := rediscache.NewFeedRedisCache(cmdable)
+	articleRepository := repository.NewArticleRepository(logger, articleDAO, articleCache, articleGeoCache, feedCache, userRepository)
+	articleCollaboratorDAO := dao.NewGORMArticleCollaboratorDAO(db)
+	articleCollaboratorRepository := repository.NewArticleCollaboratorRepository(articleCollaboratorDAO)
 	client := InitSaramaClient()
 	syncProducer := InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer)
 	interactiveDAO := dao2.NewGORMInteractiveDAO(db)
+	cntWriter := repository2.NewWriteThroughCntWriter(interactiveDAO)
 	interactiveCache := rediscache2.NewInteractiveRedisCache(cmdable)
 	topArticlesCache := ioc.InitTopArticlesCache()
-	interactiveRepository := repository2.NewCachedInteractiveRepository(logger, interactiveDAO, interactiveCache, topArticlesCache)
-	interactiveService := service2.NewInteractiveService(interactiveRepository)
-	interactiveServiceClient := ioc.InitIntrClient(interactiveService)
+	interactiveRepository := repository2.NewCachedInteractiveRepository(logger, interactiveDAO, cntWriter, interactiveCache, topArticlesCache)
+	collectionDAO := dao2.NewGORMCollectionDAO(db)
+	collectionRepository := repository2.NewGORMCollectionRepository(collectionDAO)
+	publishedDAO := dao2.NewGORMPublishedDAO(db)
+	publishedRepository := repository2.NewGORMPublishedRepository(publishedDAO)
+	bizRegistry := ioc2.InitBizRegistry(publishedRepository)
+	interactiveService := service2.NewInteractiveService(interactiveRepository, collectionRepository, bizRegistry)
+	interactiveServiceClient := ioc.InitIntrClient(interactiveService, logger)
+	articleService := service.NewArticleService(logger, articleRepository, articleCollaboratorRepository, producer, interactiveServiceClient)
 	articleHandler := web.NewArticleHandler(logger, articleService, interactiveServiceClient)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2GiteaHandler, articleHandler)
+	itineraryAuthorDAO := dao.NewItineraryGORMAuthorDAO(db)
+	itineraryReaderDAO := dao.NewItineraryGORMReaderDAO(db)
+	itineraryRepository := repository.NewItineraryRepository(logger, itineraryAuthorDAO, itineraryReaderDAO, userRepository)
+	itineraryService := service.NewItineraryService(logger, itineraryRepository, articleRepository)
+	itineraryHandler := web.NewItineraryHandler(logger, itineraryService, interactiveServiceClient)
+	feedRepository := repository.NewCachedFeedRepository(feedCache)
+	feedService := ioc.InitFeedService(logger, feedRepository, articleRepository, userRepository)
+	feedHandler := web.NewFeedHandler(logger, feedService)
+	readHistoryCache := rediscache.NewReadHistoryRedisCache(cmdable)
+	readHistoryRepository := repository.NewCachedReadHistoryRepository(readHistoryCache)
+	readHistoryService := service.NewReadHistoryService(readHistoryRepository, articleRepository)
+	readHistoryHandler := web.NewReadHistoryHandler(logger, readHistoryService)
+	recommendCache := rediscache.NewRecommendRedisCache(cmdable)
+	recommendRepository := repository.NewCachedRecommendRepository(recommendCache)
+	rankingCache := rediscache.NewRankingRedisCache(cmdable)
+	rankingLocalCache := ioc.InitRankingLocalCache()
+	rankingRepository := repository.NewCachedRankingRepository(rankingCache, rankingLocalCache)
+	rankingService := service.NewBatchRankingService(interactiveServiceClient, articleService, rankingRepository)
+	recommendService := service.NewRecommendService(logger, recommendRepository, articleRepository, readHistoryRepository, interactiveServiceClient, rankingService)
+	recommendHandler := web.NewRecommendHandler(logger, recommendService)
+	articleExportDAO := dao.NewGORMArticleExportDAO(db)
+	store := InitObjStore()
+	articleArchiveRepository := repository.NewObjStoreArticleArchiveRepository(articleExportDAO, store)
+	archiveService := service.NewArchiveService(logger, articleArchiveRepository, articleRepository, articleService, producer)
+	archiveHandler := web.NewArchiveHandler(logger, archiveService)
+	collectionHandler := web.NewCollectionHandler(logger, articleService, interactiveServiceClient)
+	rewardDAO := dao.NewGORMRewardDAO(db)
+	rewardRepository := repository.NewRewardRepository(rewardDAO)
+	paymentService := ioc.InitPaymentService()
+	rewardProducer := reward.NewSaramaSyncProducer(syncProducer)
+	rewardService := service.NewRewardService(logger, rewardRepository, articleRepository, paymentService, rewardProducer)
+	rewardHandler := web.NewRewardHandler(logger, rewardService)
+	accountDAO := dao.NewGORMAccountDAO(db)
+	accountRepository := repository.NewAccountRepository(accountDAO)
+	accountService := ioc.InitAccountService(accountRepository)
+	accountHandler := web.NewAccountHandler(logger, accountService)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2GiteaHandler, articleHandler, itineraryHandler, feedHandler, readHistoryHandler, recommendHandler, archiveHandler, collectionHandler, rewardHandler, accountHandler)
 	return engine
 }
 
@@ -66,21 +114,31 @@ func InitArticleHandler(articleDAO dao.ArticleDAO) *web.ArticleHandler {
 	logger := InitLogger()
 	cmdable := InitRedis()
 	articleCache := rediscache.NewArticleRedisCache(cmdable)
+	articleGeoCache := rediscache.NewArticleGeoRedisCache(cmdable)
+	feedCache := rediscache.NewFeedRedisCache(cmdable)
 	db := InitDB()
 	userDAO := dao.NewUserDAO(db)
 	userCache := rediscache.NewUserRedisCache(cmdable)
 	userRepository := repository.NewUserRepository(userDAO, userCache)
-	articleRepository := repository.NewArticleRepository(logger, articleDAO, articleCache, userRepository)
+	articleRepository := repository.NewArticleRepository(logger, articleDAO, articleCache, articleGeoCache, feedCache, userRepository)
+	articleCollaboratorDAO := dao.NewGORMArticleCollaboratorDAO(db)
+	articleCollaboratorRepository := repository.NewArticleCollaboratorRepository(articleCollaboratorDAO)
 	client := InitSaramaClient()
 	syncProducer := InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer)
 	interactiveDAO := dao2.NewGORMInteractiveDAO(db)
+	cntWriter := repository2.NewWriteThroughCntWriter(interactiveDAO)
 	interactiveCache := rediscache2.NewInteractiveRedisCache(cmdable)
 	topArticlesCache := ioc.InitTopArticlesCache()
-	interactiveRepository := repository2.NewCachedInteractiveRepository(logger, interactiveDAO, interactiveCache, topArticlesCache)
-	interactiveService := service2.NewInteractiveService(interactiveRepository)
-	interactiveServiceClient := ioc.InitIntrClient(interactiveService)
+	interactiveRepository := repository2.NewCachedInteractiveRepository(logger, interactiveDAO, cntWriter, interactiveCache, topArticlesCache)
+	collectionDAO := dao2.NewGORMCollectionDAO(db)
+	collectionRepository := repository2.NewGORMCollectionRepository(collectionDAO)
+	publishedDAO := dao2.NewGORMPublishedDAO(db)
+	publishedRepository := repository2.NewGORMPublishedRepository(publishedDAO)
+	bizRegistry := ioc2.InitBizRegistry(publishedRepository)
+	interactiveService := service2.NewInteractiveService(interactiveRepository, collectionRepository, bizRegistry)
+	interactiveServiceClient := ioc.InitIntrClient(interactiveService, logger)
+	articleService := service.NewArticleService(logger, articleRepository, articleCollaboratorRepository, producer, interactiveServiceClient)
 	articleHandler := web.NewArticleHandler(logger, articleService, interactiveServiceClient)
 	return articleHandler
 }
@@ -103,8 +161,9 @@ var thirdPartySet = wire.NewSet(
 	InitLogger,
 	InitSaramaClient,
 	InitSyncProducer,
+	InitObjStore,
 )
 
-var interactiveSvcSet = wire.NewSet(dao2.NewGORMInteractiveDAO, rediscache2.NewInteractiveRedisCache, ioc.InitTopArticlesCache, repository2.NewCachedInteractiveRepository, service2.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao2.NewGORMInteractiveDAO, dao2.NewGORMCollectionDAO, dao2.NewGORMPublishedDAO, rediscache2.NewInteractiveRedisCache, ioc.InitTopArticlesCache, repository2.NewWriteThroughCntWriter, repository2.NewCachedInteractiveRepository, repository2.NewGORMCollectionRepository, repository2.NewGORMPublishedRepository, service2.NewInteractiveService, ioc2.InitBizRegistry)
 
 var jobProviderSet = wire.NewSet(service.NewCronJobService, repository.NewPreemptJobRepository, dao.NewGORMJobDAO)
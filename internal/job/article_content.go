@@ -0,0 +1,89 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+// ContentMigrator moves the article contents out of the DB by batches, like
+// dao.ObjStoreArticleDAO.
+type ContentMigrator interface {
+	// MigrateContent returns the number of rows handled, 0 when it is done.
+	MigrateContent(ctx context.Context, batchSize int) (int, error)
+}
+
+// ArticleContentMigrationJob moves the article contents to the object store
+// in the background, the articles are readable during the migration.
+type ArticleContentMigrationJob struct {
+	l          logger.Logger
+	migrator   ContentMigrator
+	batchSize  int
+	timeout    time.Duration
+	lockClient *redislock.Client
+}
+
+// Name implements Job.
+func (a *ArticleContentMigrationJob) Name() string {
+	return "article_content_migration"
+}
+
+// Run implements Job.
+func (a *ArticleContentMigrationJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	defer cancel()
+	lock, err := a.lockClient.Obtain(ctx, "job:article_content_migration", a.timeout, nil)
+	if err != nil {
+		if errors.Is(err, redislock.ErrNotObtained) {
+			// another instance is migrating
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Release(ctx)
+		if er != nil {
+			a.l.Error("article content migration job failed to release distributed lock",
+				logger.Error(er))
+		}
+	}()
+
+	bizCtx, bizCancel := context.WithTimeout(context.Background(), a.timeout)
+	defer bizCancel()
+	total := 0
+	for {
+		n, err := a.migrator.MigrateContent(bizCtx, a.batchSize)
+		total += n
+		if err != nil {
+			return err
+		}
+		if n == 0 || bizCtx.Err() != nil {
+			break
+		}
+	}
+	if total > 0 {
+		a.l.Info("article contents migrated", logger.Int("cnt", total))
+	}
+	return nil
+}
+
+func NewArticleContentMigrationJob(
+	migrator ContentMigrator,
+	lock *redislock.Client,
+	batchSize int,
+	timeout time.Duration,
+	l logger.Logger,
+) *ArticleContentMigrationJob {
+	return &ArticleContentMigrationJob{
+		l:          l,
+		migrator:   migrator,
+		batchSize:  batchSize,
+		timeout:    timeout,
+		lockClient: lock,
+	}
+}
@@ -0,0 +1,87 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+// ArticlePurgeJob permanently deletes the articles kept in the trash for
+// longer than the retention.
+type ArticlePurgeJob struct {
+	l          logger.Logger
+	svc        service.ArticleService
+	retention  time.Duration
+	batchSize  int
+	timeout    time.Duration
+	lockClient *redislock.Client
+}
+
+// Name implements Job.
+func (a *ArticlePurgeJob) Name() string {
+	return "article_purge"
+}
+
+// Run implements Job.
+func (a *ArticlePurgeJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	defer cancel()
+	lock, err := a.lockClient.Obtain(ctx, "job:article_purge", a.timeout, nil)
+	if err != nil {
+		if errors.Is(err, redislock.ErrNotObtained) {
+			// another instance is purging
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Release(ctx)
+		if er != nil {
+			a.l.Error("article purge job failed to release distributed lock",
+				logger.Error(er))
+		}
+	}()
+
+	bizCtx, bizCancel := context.WithTimeout(context.Background(), a.timeout)
+	defer bizCancel()
+	before := time.Now().Add(-a.retention)
+	total := 0
+	for {
+		n, err := a.svc.PurgeTrash(bizCtx, before, a.batchSize)
+		total += n
+		if err != nil {
+			return err
+		}
+		if n < a.batchSize || bizCtx.Err() != nil {
+			break
+		}
+	}
+	if total > 0 {
+		a.l.Info("articles purged from the trash", logger.Int("cnt", total))
+	}
+	return nil
+}
+
+func NewArticlePurgeJob(
+	svc service.ArticleService,
+	lock *redislock.Client,
+	retention time.Duration,
+	batchSize int,
+	timeout time.Duration,
+	l logger.Logger,
+) *ArticlePurgeJob {
+	return &ArticlePurgeJob{
+		l:          l,
+		svc:        svc,
+		retention:  retention,
+		batchSize:  batchSize,
+		timeout:    timeout,
+		lockClient: lock,
+	}
+}
@@ -0,0 +1,65 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+// RecommendJob computes the related articles and the recommendations of the
+// users.
+type RecommendJob struct {
+	l          logger.Logger
+	svc        service.RecommendService
+	timeout    time.Duration
+	lockClient *redislock.Client
+}
+
+// Name implements Job.
+func (r *RecommendJob) Name() string {
+	return "recommend"
+}
+
+// Run implements Job.
+func (r *RecommendJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	defer cancel()
+	lock, err := r.lockClient.Obtain(ctx, "job:recommend", r.timeout, nil)
+	if err != nil {
+		if errors.Is(err, redislock.ErrNotObtained) {
+			// another instance is computing
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Release(ctx)
+		if er != nil {
+			r.l.Error("recommend job failed to release distributed lock", logger.Error(er))
+		}
+	}()
+
+	bizCtx, bizCancel := context.WithTimeout(context.Background(), r.timeout)
+	defer bizCancel()
+	return r.svc.Compute(bizCtx)
+}
+
+func NewRecommendJob(
+	svc service.RecommendService,
+	lock *redislock.Client,
+	timeout time.Duration,
+	l logger.Logger,
+) *RecommendJob {
+	return &RecommendJob{
+		l:          l,
+		svc:        svc,
+		timeout:    timeout,
+		lockClient: lock,
+	}
+}
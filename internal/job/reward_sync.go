@@ -0,0 +1,107 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+// RewardSyncJob settles the rewards whose payment callback is lost, and
+// produces the paid events which failed.
+type RewardSyncJob struct {
+	l          logger.Logger
+	svc        service.RewardService
+	stuckAfter time.Duration
+	batchSize  int
+	timeout    time.Duration
+	lockClient *redislock.Client
+}
+
+// Name implements Job.
+func (r *RewardSyncJob) Name() string {
+	return "reward_sync"
+}
+
+// Run implements Job.
+func (r *RewardSyncJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
+	defer cancel()
+	lock, err := r.lockClient.Obtain(ctx, "job:reward_sync", r.timeout, nil)
+	if err != nil {
+		if errors.Is(err, redislock.ErrNotObtained) {
+			// another instance is syncing
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Release(ctx)
+		if er != nil {
+			r.l.Error("reward sync job failed to release distributed lock",
+				logger.Error(er))
+		}
+	}()
+
+	bizCtx, bizCancel := context.WithTimeout(context.Background(), r.timeout)
+	defer bizCancel()
+	// the rewards paid in the meantime are left to their callback
+	before := time.Now().Add(-r.stuckAfter)
+	var cursor domain.RewardCursor
+	synced := 0
+	for {
+		var n int
+		cursor, n, err = r.svc.SyncPending(bizCtx, before, cursor, r.batchSize)
+		synced += n
+		if err != nil {
+			return err
+		}
+		if n < r.batchSize || bizCtx.Err() != nil {
+			break
+		}
+	}
+
+	var afterID int64
+	notified := 0
+	for {
+		var n int
+		afterID, n, err = r.svc.NotifyPaid(bizCtx, afterID, r.batchSize)
+		notified += n
+		if err != nil {
+			return err
+		}
+		if n < r.batchSize || bizCtx.Err() != nil {
+			break
+		}
+	}
+	if synced > 0 || notified > 0 {
+		r.l.Info("rewards synced",
+			logger.Int("synced", synced),
+			logger.Int("notified", notified))
+	}
+	return nil
+}
+
+func NewRewardSyncJob(
+	svc service.RewardService,
+	lock *redislock.Client,
+	stuckAfter time.Duration,
+	batchSize int,
+	timeout time.Duration,
+	l logger.Logger,
+) *RewardSyncJob {
+	return &RewardSyncJob{
+		l:          l,
+		svc:        svc,
+		stuckAfter: stuckAfter,
+		batchSize:  batchSize,
+		timeout:    timeout,
+		lockClient: lock,
+	}
+}
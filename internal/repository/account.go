@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+)
+
+var (
+	ErrInsufficientBalance = dao.ErrInsufficientBalance
+	ErrWithdrawalNotFound  = dao.ErrWithdrawalNotFound
+	ErrWithdrawalSettled   = dao.ErrWithdrawalSettled
+)
+
+//go:generate mockgen -source=./account.go -package=repomocks -destination=./mocks/account.mock.go
+type AccountRepository interface {
+	// Transfer writes the entries of the transaction of the biz, once. Only
+	// the payin account can be overdrawn.
+	Transfer(ctx context.Context, biz string, bizID int64, entries []domain.AccountEntry) error
+	GetAccount(ctx context.Context, uid int64, typ domain.AccountType) (domain.Account, error)
+	ListEntries(
+		ctx context.Context,
+		uid int64,
+		typ domain.AccountType,
+		offset, limit int,
+	) ([]domain.AccountEntry, error)
+
+	// CreateWithdrawal creates the withdrawal with the transaction of the
+	// biz, whose ID is the withdrawal.
+	CreateWithdrawal(
+		ctx context.Context,
+		w domain.Withdrawal,
+		biz string,
+		entries []domain.AccountEntry,
+	) (int64, error)
+	// SettleWithdrawal moves a pending withdrawal to its status with the
+	// transaction of the biz.
+	SettleWithdrawal(
+		ctx context.Context,
+		w domain.Withdrawal,
+		biz string,
+		entries []domain.AccountEntry,
+	) error
+	GetWithdrawal(ctx context.Context, id int64) (domain.Withdrawal, error)
+	ListWithdrawals(
+		ctx context.Context,
+		uid int64,
+		status domain.WithdrawalStatus,
+		offset, limit int,
+	) ([]domain.Withdrawal, error)
+}
+
+type accountRepository struct {
+	dao dao.AccountDAO
+}
+
+// Transfer implements AccountRepository.
+func (a *accountRepository) Transfer(
+	ctx context.Context,
+	biz string,
+	bizID int64,
+	entries []domain.AccountEntry,
+) error {
+	return a.dao.Transfer(ctx, dao.AccountTxn{Biz: biz, BizID: bizID}, a.toEntities(entries))
+}
+
+// GetAccount implements AccountRepository.
+func (a *accountRepository) GetAccount(
+	ctx context.Context,
+	uid int64,
+	typ domain.AccountType,
+) (domain.Account, error) {
+	acc, err := a.dao.GetAccount(ctx, uid, uint8(typ))
+	if err != nil {
+		return domain.Account{}, err
+	}
+	res := domain.Account{
+		UID:     acc.UID,
+		Type:    domain.AccountType(acc.Type),
+		Balance: acc.Balance,
+	}
+	if acc.Utime > 0 {
+		res.Utime = time.UnixMilli(acc.Utime)
+	}
+	return res, nil
+}
+
+// ListEntries implements AccountRepository.
+func (a *accountRepository) ListEntries(
+	ctx context.Context,
+	uid int64,
+	typ domain.AccountType,
+	offset, limit int,
+) ([]domain.AccountEntry, error) {
+	entries, err := a.dao.ListEntries(ctx, uid, uint8(typ), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(entries, func(_ int, src dao.AccountEntry) domain.AccountEntry {
+		return domain.AccountEntry{
+			ID:    src.ID,
+			TxnID: src.TxnID,
+			Biz:   src.Biz,
+			BizID: src.BizID,
+			Account: domain.Account{
+				UID:  src.UID,
+				Type: domain.AccountType(src.Type),
+			},
+			Amount: src.Amount,
+			Ctime:  time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
+
+// CreateWithdrawal implements AccountRepository.
+func (a *accountRepository) CreateWithdrawal(
+	ctx context.Context,
+	w domain.Withdrawal,
+	biz string,
+	entries []domain.AccountEntry,
+) (int64, error) {
+	return a.dao.CreateWithdrawal(ctx, dao.Withdrawal{
+		UID:    w.UID,
+		Amount: w.Amount,
+		Status: uint8(w.Status),
+	}, dao.AccountTxn{Biz: biz}, a.toEntities(entries))
+}
+
+// SettleWithdrawal implements AccountRepository.
+func (a *accountRepository) SettleWithdrawal(
+	ctx context.Context,
+	w domain.Withdrawal,
+	biz string,
+	entries []domain.AccountEntry,
+) error {
+	return a.dao.SettleWithdrawal(ctx, dao.Withdrawal{
+		ID:       w.ID,
+		Status:   uint8(w.Status),
+		Reviewer: w.Reviewer,
+	}, dao.AccountTxn{Biz: biz, BizID: w.ID}, a.toEntities(entries))
+}
+
+// GetWithdrawal implements AccountRepository.
+func (a *accountRepository) GetWithdrawal(
+	ctx context.Context,
+	id int64,
+) (domain.Withdrawal, error) {
+	w, err := a.dao.GetWithdrawal(ctx, id)
+	if err != nil {
+		return domain.Withdrawal{}, err
+	}
+	return a.toWithdrawal(w), nil
+}
+
+// ListWithdrawals implements AccountRepository.
+func (a *accountRepository) ListWithdrawals(
+	ctx context.Context,
+	uid int64,
+	status domain.WithdrawalStatus,
+	offset, limit int,
+) ([]domain.Withdrawal, error) {
+	ws, err := a.dao.ListWithdrawals(ctx, uid, uint8(status), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(ws, func(_ int, src dao.Withdrawal) domain.Withdrawal {
+		return a.toWithdrawal(src)
+	}), nil
+}
+
+func (a *accountRepository) toEntities(entries []domain.AccountEntry) []dao.AccountEntry {
+	return gslice.Map(entries, func(_ int, src domain.AccountEntry) dao.AccountEntry {
+		return dao.AccountEntry{
+			UID:       src.Account.UID,
+			Type:      uint8(src.Account.Type),
+			Amount:    src.Amount,
+			Overdraft: src.Account.Type == domain.AccountTypePayin,
+		}
+	})
+}
+
+func (a *accountRepository) toWithdrawal(w dao.Withdrawal) domain.Withdrawal {
+	return domain.Withdrawal{
+		ID:       w.ID,
+		UID:      w.UID,
+		Amount:   w.Amount,
+		Status:   domain.WithdrawalStatus(w.Status),
+		Reviewer: w.Reviewer,
+		Ctime:    time.UnixMilli(w.Ctime),
+		Utime:    time.UnixMilli(w.Utime),
+	}
+}
+
+func NewAccountRepository(dao dao.AccountDAO) AccountRepository {
+	return &accountRepository{
+		dao: dao,
+	}
+}
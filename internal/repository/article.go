@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"slices"
 	"time"
 
 	"github.com/chenmuyao/generique/gslice"
@@ -9,16 +12,23 @@ import (
 	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
 	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
 	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
 )
 
 const (
 	pageSize           = 100
 	maxCacheArticleLen = 1024 * 1024
+	// max number of places fetched from the geo index for a nearby query
+	maxNearbyHits = 1000
+	// max number of articles read at the same time for a nearby query
+	maxNearbyReads = 10
 )
 
 var ErrArticleNotFound = dao.ErrArticleNotFound
 
+type VersionConflictError = dao.VersionConflictError
+
 //go:generate mockgen -source=./article.go -package=repomocks -destination=./mocks/article.mock.go
 type ArticleRepository interface {
 	Create(ctx context.Context, article domain.Article) (int64, error)
@@ -30,17 +40,57 @@ type ArticleRepository interface {
 		articleID int64,
 		status domain.ArticleStatus,
 	) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// GetByAuthor and ListPub list the articles after the cursor, the latest
+	// updated first.
+	GetByAuthor(
+		ctx context.Context,
+		uid int64,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.Article, error)
 	GetByID(ctx context.Context, id int64) (domain.Article, error)
+	// BatchGetByIDs returns the articles of the author among ids, with their
+	// content. The missing ones are skipped.
+	BatchGetByIDs(ctx context.Context, uid int64, ids []int64) ([]domain.Article, error)
 	BatchGetPubByIDs(ctx context.Context, ids []int64) ([]domain.Article, error)
 	GetPubByID(ctx context.Context, id int64) (domain.Article, error)
-	ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error)
+	ListPub(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error)
+	GetPubByAuthor(
+		ctx context.Context,
+		uid int64,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.Article, error)
+	// ListPubNearby lists the published articles near the point after the
+	// cursor, the nearest first.
+	ListPubNearby(
+		ctx context.Context,
+		lng, lat, radius float64,
+		after domain.NearbyCursor,
+		limit int,
+	) ([]domain.NearbyArticle, error)
+	// Delete moves the article to the trash, Restore takes it back.
+	Delete(ctx context.Context, uid int64, id int64) error
+	Restore(ctx context.Context, uid int64, id int64) error
+	// GetTrashByAuthor lists the trash after the cursor, the latest deleted
+	// first.
+	GetTrashByAuthor(
+		ctx context.Context,
+		uid int64,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.Article, error)
+	// PurgeTrash permanently deletes at most limit articles put in the trash
+	// before the given time, and returns the ones deleted.
+	PurgeTrash(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
 }
 
 type CachedArticleRepository struct {
-	l        logger.Logger
-	cache    cache.ArticleCache
-	userRepo UserRepository
+	l         logger.Logger
+	cache     cache.ArticleCache
+	geoCache  cache.ArticleGeoCache
+	feedCache cache.FeedCache
+	userRepo  UserRepository
 
 	// 1 DB 1 table
 	dao dao.ArticleDAO
@@ -56,11 +106,10 @@ type CachedArticleRepository struct {
 // ListPub implements ArticleRepository.
 func (c *CachedArticleRepository) ListPub(
 	ctx context.Context,
-	start time.Time,
-	offset int,
+	cursor domain.Cursor,
 	limit int,
 ) ([]domain.Article, error) {
-	daoArticles, err := c.dao.ListPub(ctx, start, offset, limit)
+	daoArticles, err := c.dao.ListPub(ctx, cursor, limit)
 	if err != nil {
 		return []domain.Article{}, err
 	}
@@ -74,6 +123,122 @@ func (c *CachedArticleRepository) ListPub(
 	return domainArticles, nil
 }
 
+// GetPubByAuthor implements ArticleRepository.
+func (c *CachedArticleRepository) GetPubByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.Article, error) {
+	daoArticles, err := c.dao.GetPubByAuthor(ctx, uid, cursor, limit)
+	if err != nil {
+		return []domain.Article{}, err
+	}
+	return gslice.Map(
+		daoArticles,
+		func(id int, src dao.PublishedArticle) domain.Article {
+			return c.toDomain(dao.Article(src))
+		},
+	), nil
+}
+
+// ListPubNearby implements ArticleRepository.
+func (c *CachedArticleRepository) ListPubNearby(
+	ctx context.Context,
+	lng, lat, radius float64,
+	after domain.NearbyCursor,
+	limit int,
+) ([]domain.NearbyArticle, error) {
+	hits, err := c.geoCache.SearchRadius(ctx, lng, lat, radius, maxNearbyHits)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: hits are sorted by distance, keep the nearest place of each article
+	seen := make(map[int64]struct{}, len(hits))
+	nearest := make([]cache.ArticleGeoHit, 0, len(hits))
+	for _, hit := range hits {
+		if _, ok := seen[hit.ArticleID]; ok {
+			continue
+		}
+		seen[hit.ArticleID] = struct{}{}
+		if !after.Before(hit.Distance, hit.ArticleID) {
+			nearest = append(nearest, hit)
+		}
+	}
+	// the articles at the same distance are sorted by ID for the cursor
+	slices.SortStableFunc(nearest, func(a, b cache.ArticleGeoHit) int {
+		return cmp.Or(cmp.Compare(a.Distance, b.Distance), cmp.Compare(a.ArticleID, b.ArticleID))
+	})
+	// NOTE: the articles are filtered before the page is cut, the index can
+	// be stale if the withdrawal or the deletion failed to clean it. The hits
+	// are loaded by chunks until the page is full.
+	res := make([]domain.NearbyArticle, 0, limit)
+	var stale []int64
+	for len(nearest) > 0 && len(res) < limit {
+		chunk := nearest[:min(limit-len(res), len(nearest))]
+		nearest = nearest[len(chunk):]
+		articles, err := c.getPubsByIDs(ctx, gslice.Map(
+			chunk,
+			func(id int, src cache.ArticleGeoHit) int64 {
+				return src.ArticleID
+			},
+		))
+		if err != nil {
+			return nil, err
+		}
+		for i, art := range articles {
+			hit := chunk[i]
+			if art.Status != domain.ArticleStatusPublished {
+				stale = append(stale, hit.ArticleID)
+				continue
+			}
+			var place domain.Place
+			if hit.PlaceIdx < len(art.Places) {
+				place = art.Places[hit.PlaceIdx]
+			}
+			res = append(res, domain.NearbyArticle{
+				Article:  art,
+				Place:    place,
+				Distance: hit.Distance,
+			})
+		}
+	}
+	for _, aid := range stale {
+		err := c.geoCache.DelPlaces(ctx, aid)
+		if err != nil {
+			c.l.Warn("delete stale places error", logger.Int64("aid", aid), logger.Error(err))
+		}
+	}
+	return res, nil
+}
+
+// getPubsByIDs gets the published articles one by one from the cache, the
+// missing ones are left empty.
+func (c *CachedArticleRepository) getPubsByIDs(
+	ctx context.Context,
+	ids []int64,
+) ([]domain.Article, error) {
+	res := make([]domain.Article, len(ids))
+	var eg errgroup.Group
+	eg.SetLimit(maxNearbyReads)
+	for i, id := range ids {
+		eg.Go(func() error {
+			art, err := c.GetPubByID(ctx, id)
+			switch err {
+			case nil:
+				res[i] = art
+				return nil
+			case ErrArticleNotFound:
+				return nil
+			default:
+				return err
+			}
+		})
+	}
+	return res, eg.Wait()
+}
+
 func (c *CachedArticleRepository) BatchGetPubByIDs(
 	ctx context.Context,
 	ids []int64,
@@ -175,6 +340,21 @@ func (c *CachedArticleRepository) GetByID(ctx context.Context, id int64) (domain
 	return domainArticle, nil
 }
 
+// BatchGetByIDs implements ArticleRepository.
+func (c *CachedArticleRepository) BatchGetByIDs(
+	ctx context.Context,
+	uid int64,
+	ids []int64,
+) ([]domain.Article, error) {
+	daoArticles, err := c.dao.BatchGetByIDs(ctx, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(daoArticles, func(id int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
 func NewArticleRepositoryV2(
 	readerDAO dao.ArticleReaderDAO,
 	authorDAO dao.ArticleAuthorDAO,
@@ -194,6 +374,11 @@ func (c *CachedArticleRepository) Update(ctx context.Context, article domain.Art
 	if errCache != nil {
 		c.l.Warn("delete first page cache error", logger.Error(errCache))
 	}
+	// NOTE: the cached draft has an old version
+	errCache = c.cache.Del(ctx, article.ID)
+	if errCache != nil {
+		c.l.Warn("delete article cache error", logger.Int64("aid", article.ID), logger.Error(errCache))
+	}
 	return nil
 }
 
@@ -262,6 +447,16 @@ func (c *CachedArticleRepository) Sync(
 	if errCache != nil {
 		c.l.Warn("delete first page cache error", logger.Error(errCache))
 	}
+	errCache = c.cache.Del(ctx, id)
+	if errCache != nil {
+		c.l.Warn("delete article cache error", logger.Int64("aid", id), logger.Error(errCache))
+	}
+	errCache = c.geoCache.SetPlaces(ctx, id, article.Places)
+	if errCache != nil {
+		// WARN: Monitor here, the article cannot be found by nearby search
+		c.l.Error("set article places error", logger.Int64("aid", id), logger.Error(errCache))
+	}
+	c.invalidateFeeds(ctx, article.Author.ID)
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
@@ -369,10 +564,146 @@ func (c *CachedArticleRepository) SyncStatus(
 	if errCache != nil {
 		c.l.Warn("delete first page cache error", logger.Error(errCache))
 	}
+	c.invalidateFeeds(ctx, userID)
+	if status != domain.ArticleStatusPublished {
+		errCache = c.geoCache.DelPlaces(ctx, articleID)
+		if errCache != nil {
+			c.l.Error(
+				"delete article places error",
+				logger.Int64("aid", articleID),
+				logger.Error(errCache),
+			)
+		}
+	}
+	return nil
+}
+
+// Delete implements ArticleRepository.
+func (c *CachedArticleRepository) Delete(ctx context.Context, uid int64, id int64) error {
+	err := c.dao.DeleteByID(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	c.evict(ctx, uid, id)
+	return nil
+}
+
+// Restore implements ArticleRepository.
+func (c *CachedArticleRepository) Restore(ctx context.Context, uid int64, id int64) error {
+	err := c.dao.RestoreByID(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	errCache := c.cache.DelFirstPage(ctx, uid)
+	if errCache != nil {
+		c.l.Warn("delete first page cache error", logger.Error(errCache))
+	}
+	// put the places of the published copy back into the geo index
+	pub, err := c.dao.GetPubByID(ctx, id)
+	switch err {
+	case nil:
+		if pub.Status == domain.ArticleStatusPublished {
+			article := c.toDomain(dao.Article(pub))
+			err = c.geoCache.SetPlaces(ctx, id, article.Places)
+		}
+	case dao.ErrArticleNotFound:
+		// never published
+		err = nil
+	}
+	if err != nil {
+		c.l.Error("restore article places error", logger.Int64("aid", id), logger.Error(err))
+	}
+	c.invalidateFeeds(ctx, uid)
 	return nil
 }
 
+// GetTrashByAuthor implements ArticleRepository.
+func (c *CachedArticleRepository) GetTrashByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.Article, error) {
+	articles, err := c.dao.GetTrashByAuthor(ctx, uid, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(
+		articles,
+		func(id int, src dao.Article) domain.Article { return c.toDomain(src) },
+	), nil
+}
+
+// PurgeTrash implements ArticleRepository.
+// NOTE: the articles deleted are returned even if it fails in the middle, so
+// that the caller can clean up what depends on them. The ones of a rolled
+// back transaction are still in the trash and are not returned.
+func (c *CachedArticleRepository) PurgeTrash(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) ([]domain.Article, error) {
+	articles, err := c.dao.PurgeTrash(ctx, before, limit)
+	res := gslice.Map(
+		articles,
+		func(id int, src dao.Article) domain.Article { return c.toDomain(src) },
+	)
+	for _, article := range res {
+		c.evict(ctx, article.Author.ID, article.ID)
+	}
+	return res, err
+}
+
+// evict removes an article no longer visible from the caches.
+func (c *CachedArticleRepository) evict(ctx context.Context, uid int64, id int64) {
+	err := c.cache.DelFirstPage(ctx, uid)
+	if err != nil {
+		c.l.Warn("delete first page cache error", logger.Error(err))
+	}
+	err = c.cache.Del(ctx, id)
+	if err != nil {
+		c.l.Warn("delete article cache error", logger.Int64("aid", id), logger.Error(err))
+	}
+	err = c.geoCache.DelPlaces(ctx, id)
+	if err != nil {
+		c.l.Error("delete article places error", logger.Int64("aid", id), logger.Error(err))
+	}
+	c.invalidateFeeds(ctx, uid)
+}
+
+// invalidateFeeds drops the feeds and the sitemaps which may list the
+// published articles of the author.
+func (c *CachedArticleRepository) invalidateFeeds(ctx context.Context, uid int64) {
+	err := c.feedCache.Invalidate(ctx, uid)
+	if err != nil {
+		// WARN: stale feeds until they expire
+		c.l.Error("invalidate feeds error", logger.Int64("uid", uid), logger.Error(err))
+	}
+}
+
 func (c *CachedArticleRepository) toDomain(article dao.Article) domain.Article {
+	var places []domain.Place
+	if article.Places != "" {
+		err := json.Unmarshal([]byte(article.Places), &places)
+		if err != nil {
+			c.l.Warn("invalid article places",
+				logger.Int64("aid", article.ID),
+				logger.Error(err))
+		}
+	}
+	var tags []string
+	if article.Tags != "" {
+		err := json.Unmarshal([]byte(article.Tags), &tags)
+		if err != nil {
+			c.l.Warn("invalid article tags",
+				logger.Int64("aid", article.ID),
+				logger.Error(err))
+		}
+	}
+	var dtime time.Time
+	if article.Dtime > 0 {
+		dtime = time.UnixMilli(article.Dtime)
+	}
 	return domain.Article{
 		ID:      article.ID,
 		Title:   article.Title,
@@ -380,19 +711,37 @@ func (c *CachedArticleRepository) toDomain(article dao.Article) domain.Article {
 		Author: domain.Author{
 			ID: article.AuthorID,
 		},
-		Status: domain.ArticleStatus(article.Status),
-		Ctime:  time.UnixMilli(article.Ctime),
-		Utime:  time.UnixMilli(article.Utime),
+		Status:  domain.ArticleStatus(article.Status),
+		Places:  places,
+		Tags:    tags,
+		Ctime:   time.UnixMilli(article.Ctime),
+		Utime:   time.UnixMilli(article.Utime),
+		Dtime:   dtime,
+		Version: article.Version,
 	}
 }
 
 func (c *CachedArticleRepository) toEntity(article domain.Article) dao.Article {
+	var places string
+	if len(article.Places) > 0 {
+		// NOTE: cannot fail
+		val, _ := json.Marshal(article.Places)
+		places = string(val)
+	}
+	var tags string
+	if len(article.Tags) > 0 {
+		val, _ := json.Marshal(article.Tags)
+		tags = string(val)
+	}
 	return dao.Article{
 		ID:       article.ID,
 		Title:    article.Title,
 		Content:  article.Content,
+		Places:   places,
+		Tags:     tags,
 		AuthorID: article.Author.ID,
 		Status:   uint8(article.Status),
+		Version:  article.Version,
 	}
 }
 
@@ -400,11 +749,11 @@ func (c *CachedArticleRepository) toEntity(article domain.Article) dao.Article {
 func (c *CachedArticleRepository) GetByAuthor(
 	ctx context.Context,
 	uid int64,
-	offset int,
+	cursor domain.Cursor,
 	limit int,
 ) ([]domain.Article, error) {
 	// check if query the cache
-	if offset == 0 && limit <= pageSize {
+	if cursor.IsZero() && limit <= pageSize {
 		cachedArticles, err := c.cache.GetFirstPage(ctx, uid)
 		switch err {
 		case nil:
@@ -420,7 +769,7 @@ func (c *CachedArticleRepository) GetByAuthor(
 			c.l.Warn("article cache get error", logger.Error(err))
 		}
 	}
-	articlesDAO, err := c.dao.GetByAuthor(ctx, uid, offset, limit)
+	articlesDAO, err := c.dao.GetByAuthor(ctx, uid, cursor, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -432,7 +781,7 @@ func (c *CachedArticleRepository) GetByAuthor(
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		if offset == 0 && limit <= pageSize {
+		if cursor.IsZero() && limit <= pageSize {
 			c.l.Debug("Set first page", logger.Field{Key: "res", Value: res})
 			err = c.cache.SetFirstPage(ctx, uid, res)
 			// err 1: network issue, maybe temporary
@@ -444,12 +793,15 @@ func (c *CachedArticleRepository) GetByAuthor(
 		}
 	}()
 
-	// NOTE: Preload the first article into cache
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
-		c.preCache(ctx, res)
-	}()
+	// NOTE: Preload the first article into cache, unless its content is in
+	// the object store, which the lists don't fetch.
+	if len(articlesDAO) > 0 && articlesDAO[0].ContentKey == "" {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			c.preCache(ctx, res)
+		}()
+	}
 
 	return res, nil
 }
@@ -486,12 +838,16 @@ func NewArticleRepository(
 	l logger.Logger,
 	dao dao.ArticleDAO,
 	cache cache.ArticleCache,
+	geoCache cache.ArticleGeoCache,
+	feedCache cache.FeedCache,
 	userRepo UserRepository,
 ) ArticleRepository {
 	return &CachedArticleRepository{
-		l:        l,
-		dao:      dao,
-		cache:    cache,
-		userRepo: userRepo,
+		l:         l,
+		dao:       dao,
+		cache:     cache,
+		geoCache:  geoCache,
+		feedCache: feedCache,
+		userRepo:  userRepo,
 	}
 }
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	"github.com/chenmuyao/go-bootcamp/pkg/objstore"
+)
+
+// the size of the error column
+const maxExportErrLen = 1024
+
+var (
+	ErrArticleExportNotFound = dao.ErrArticleExportNotFound
+	ErrArchiveNotFound       = objstore.ErrObjectNotFound
+	ErrImageNotFound         = objstore.ErrObjectNotFound
+)
+
+//go:generate mockgen -source=./article_archive.go -package=repomocks -destination=./mocks/article_archive.mock.go
+type ArticleArchiveRepository interface {
+	// StartExport marks the export of the user as pending, replacing the
+	// previous one.
+	StartExport(ctx context.Context, uid int64) error
+	// FinishExport saves the archive if the export succeeded, or the error
+	// otherwise.
+	FinishExport(ctx context.Context, uid int64, archive []byte, exportErr error) error
+	GetExport(ctx context.Context, uid int64) (domain.ArticleExport, error)
+	GetArchive(ctx context.Context, uid int64) ([]byte, error)
+	// PutImage and GetImage store the images of the articles of a user.
+	PutImage(ctx context.Context, uid int64, name string, data []byte) error
+	GetImage(ctx context.Context, uid int64, name string) ([]byte, error)
+}
+
+// ObjStoreArticleArchiveRepository keeps the status of the exports in the DB,
+// and the archives and the images in the object store.
+type ObjStoreArticleArchiveRepository struct {
+	dao   dao.ArticleExportDAO
+	store objstore.Store
+}
+
+// StartExport implements ArticleArchiveRepository.
+func (o *ObjStoreArticleArchiveRepository) StartExport(ctx context.Context, uid int64) error {
+	return o.dao.Upsert(ctx, dao.ArticleExport{
+		UID:    uid,
+		Status: uint8(domain.ExportStatusPending),
+	})
+}
+
+// FinishExport implements ArticleArchiveRepository.
+func (o *ObjStoreArticleArchiveRepository) FinishExport(
+	ctx context.Context,
+	uid int64,
+	archive []byte,
+	exportErr error,
+) error {
+	if exportErr == nil {
+		exportErr = o.store.Put(ctx, o.archiveKey(uid), archive)
+	}
+	if exportErr != nil {
+		msg := exportErr.Error()
+		if len(msg) > maxExportErrLen {
+			msg = msg[:maxExportErrLen]
+		}
+		return o.dao.UpdateStatus(ctx, uid, uint8(domain.ExportStatusFailed), msg)
+	}
+	return o.dao.UpdateStatus(ctx, uid, uint8(domain.ExportStatusDone), "")
+}
+
+// GetExport implements ArticleArchiveRepository.
+func (o *ObjStoreArticleArchiveRepository) GetExport(
+	ctx context.Context,
+	uid int64,
+) (domain.ArticleExport, error) {
+	export, err := o.dao.Get(ctx, uid)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	return domain.ArticleExport{
+		UID:    export.UID,
+		Status: domain.ExportStatus(export.Status),
+		Err:    export.Err,
+		Ctime:  time.UnixMilli(export.Ctime),
+		Utime:  time.UnixMilli(export.Utime),
+	}, nil
+}
+
+// GetArchive implements ArticleArchiveRepository.
+func (o *ObjStoreArticleArchiveRepository) GetArchive(ctx context.Context, uid int64) ([]byte, error) {
+	return o.store.Get(ctx, o.archiveKey(uid))
+}
+
+// PutImage implements ArticleArchiveRepository.
+func (o *ObjStoreArticleArchiveRepository) PutImage(
+	ctx context.Context,
+	uid int64,
+	name string,
+	data []byte,
+) error {
+	return o.store.Put(ctx, o.imageKey(uid, name), data)
+}
+
+// GetImage implements ArticleArchiveRepository.
+func (o *ObjStoreArticleArchiveRepository) GetImage(
+	ctx context.Context,
+	uid int64,
+	name string,
+) ([]byte, error) {
+	return o.store.Get(ctx, o.imageKey(uid, name))
+}
+
+// NOTE: only the latest archive of a user is kept.
+func (o *ObjStoreArticleArchiveRepository) archiveKey(uid int64) string {
+	return fmt.Sprintf("exports/%d.zip", uid)
+}
+
+func (o *ObjStoreArticleArchiveRepository) imageKey(uid int64, name string) string {
+	return fmt.Sprintf("images/%d/%s", uid, name)
+}
+
+func NewObjStoreArticleArchiveRepository(
+	dao dao.ArticleExportDAO,
+	store objstore.Store,
+) ArticleArchiveRepository {
+	return &ObjStoreArticleArchiveRepository{
+		dao:   dao,
+		store: store,
+	}
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+)
+
+//go:generate mockgen -source=./article_collaborator.go -package=repomocks -destination=./mocks/article_collaborator.mock.go
+type ArticleCollaboratorRepository interface {
+	Save(ctx context.Context, aid int64, c domain.Collaborator) error
+	Delete(ctx context.Context, aid int64, uid int64) error
+	GetByArticle(ctx context.Context, aid int64) ([]domain.Collaborator, error)
+	// Approve approves the version of the draft.
+	Approve(ctx context.Context, aid int64, uid int64, version int64) error
+	DeleteByArticles(ctx context.Context, aids []int64) error
+}
+
+type articleCollaboratorRepository struct {
+	dao dao.ArticleCollaboratorDAO
+}
+
+// Save implements ArticleCollaboratorRepository.
+func (a *articleCollaboratorRepository) Save(
+	ctx context.Context,
+	aid int64,
+	c domain.Collaborator,
+) error {
+	return a.dao.Upsert(ctx, dao.ArticleCollaborator{
+		Aid:  aid,
+		UID:  c.UID,
+		Role: uint8(c.Role),
+	})
+}
+
+// Delete implements ArticleCollaboratorRepository.
+func (a *articleCollaboratorRepository) Delete(ctx context.Context, aid int64, uid int64) error {
+	return a.dao.Delete(ctx, aid, uid)
+}
+
+// GetByArticle implements ArticleCollaboratorRepository.
+func (a *articleCollaboratorRepository) GetByArticle(
+	ctx context.Context,
+	aid int64,
+) ([]domain.Collaborator, error) {
+	res, err := a.dao.GetByArticle(ctx, aid)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(res, func(id int, src dao.ArticleCollaborator) domain.Collaborator {
+		return domain.Collaborator{
+			UID:             src.UID,
+			Role:            domain.CollaboratorRole(src.Role),
+			Approved:        src.Approved,
+			ApprovedVersion: src.ApprovedVersion,
+			Ctime:           time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
+
+// Approve implements ArticleCollaboratorRepository.
+func (a *articleCollaboratorRepository) Approve(
+	ctx context.Context,
+	aid int64,
+	uid int64,
+	version int64,
+) error {
+	return a.dao.Approve(ctx, aid, uid, version)
+}
+
+// DeleteByArticles implements ArticleCollaboratorRepository.
+func (a *articleCollaboratorRepository) DeleteByArticles(ctx context.Context, aids []int64) error {
+	return a.dao.DeleteByArticles(ctx, aids)
+}
+
+func NewArticleCollaboratorRepository(dao dao.ArticleCollaboratorDAO) ArticleCollaboratorRepository {
+	return &articleCollaboratorRepository{
+		dao: dao,
+	}
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
+	cachemocks "github.com/chenmuyao/go-bootcamp/internal/repository/cache/mocks"
 	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
 	daomocks "github.com/chenmuyao/go-bootcamp/internal/repository/dao/mocks"
 	"github.com/stretchr/testify/assert"
@@ -91,3 +94,220 @@ func TestCachedArticleRepository_Sync(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedArticleRepository_ListPubNearby(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (
+			dao.ArticleDAO,
+			cache.ArticleCache,
+			cache.ArticleGeoCache,
+		)
+
+		after domain.NearbyCursor
+		limit int
+
+		wantRes []domain.NearbyArticle
+		wantErr error
+	}{
+		{
+			name: "keep the nearest place of each article",
+			mock: func(ctrl *gomock.Controller) (
+				dao.ArticleDAO,
+				cache.ArticleCache,
+				cache.ArticleGeoCache,
+			) {
+				geoCache := cachemocks.NewMockArticleGeoCache(ctrl)
+				geoCache.EXPECT().
+					SearchRadius(gomock.Any(), 2.35, 48.85, float64(1000), maxNearbyHits).
+					Return([]cache.ArticleGeoHit{
+						{ArticleID: 1, PlaceIdx: 1, Distance: 10},
+						{ArticleID: 2, PlaceIdx: 0, Distance: 20},
+						{ArticleID: 1, PlaceIdx: 0, Distance: 30},
+						{ArticleID: 3, PlaceIdx: 0, Distance: 40},
+					}, nil)
+				artCache := cachemocks.NewMockArticleCache(ctrl)
+				artCache.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Status: domain.ArticleStatusPublished,
+					Places: []domain.Place{{Name: "a"}, {Name: "b"}},
+				}, nil)
+				artCache.EXPECT().GetPub(gomock.Any(), int64(2)).Return(domain.Article{
+					ID:     2,
+					Status: domain.ArticleStatusPublished,
+					Places: []domain.Place{{Name: "c"}},
+				}, nil)
+				return daomocks.NewMockArticleDAO(ctrl), artCache, geoCache
+			},
+			limit: 2,
+			wantRes: []domain.NearbyArticle{
+				{
+					Article: domain.Article{
+						ID:     1,
+						Status: domain.ArticleStatusPublished,
+						Places: []domain.Place{{Name: "a"}, {Name: "b"}},
+					},
+					Place:    domain.Place{Name: "b"},
+					Distance: 10,
+				},
+				{
+					Article: domain.Article{
+						ID:     2,
+						Status: domain.ArticleStatusPublished,
+						Places: []domain.Place{{Name: "c"}},
+					},
+					Place:    domain.Place{Name: "c"},
+					Distance: 20,
+				},
+			},
+		},
+		{
+			name: "skip the stale articles after the cursor",
+			mock: func(ctrl *gomock.Controller) (
+				dao.ArticleDAO,
+				cache.ArticleCache,
+				cache.ArticleGeoCache,
+			) {
+				geoCache := cachemocks.NewMockArticleGeoCache(ctrl)
+				geoCache.EXPECT().
+					SearchRadius(gomock.Any(), 2.35, 48.85, float64(1000), maxNearbyHits).
+					Return([]cache.ArticleGeoHit{
+						{ArticleID: 1, PlaceIdx: 0, Distance: 10},
+						{ArticleID: 2, PlaceIdx: 0, Distance: 20},
+						{ArticleID: 3, PlaceIdx: 0, Distance: 30},
+						{ArticleID: 4, PlaceIdx: 0, Distance: 40},
+						{ArticleID: 5, PlaceIdx: 0, Distance: 50},
+					}, nil)
+				geoCache.EXPECT().DelPlaces(gomock.Any(), int64(2)).Return(nil)
+				geoCache.EXPECT().DelPlaces(gomock.Any(), int64(3)).Return(nil)
+				artCache := cachemocks.NewMockArticleCache(ctrl)
+				// withdrawn
+				artCache.EXPECT().GetPub(gomock.Any(), int64(2)).Return(domain.Article{
+					ID:     2,
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				// deleted
+				artCache.EXPECT().GetPub(gomock.Any(), int64(3)).
+					Return(domain.Article{}, cache.ErrKeyNotExist)
+				artDAO := daomocks.NewMockArticleDAO(ctrl)
+				artDAO.EXPECT().GetPubByID(gomock.Any(), int64(3)).
+					Return(dao.PublishedArticle{}, dao.ErrArticleNotFound)
+				artCache.EXPECT().GetPub(gomock.Any(), int64(4)).Return(domain.Article{
+					ID:     4,
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				return artDAO, artCache, geoCache
+			},
+			after: domain.NearbyCursor{Distance: 10, ArticleID: 1},
+			limit: 1,
+			wantRes: []domain.NearbyArticle{
+				{
+					Article:  domain.Article{ID: 4, Status: domain.ArticleStatusPublished},
+					Distance: 40,
+				},
+			},
+		},
+		{
+			name: "same distance sorted by id",
+			mock: func(ctrl *gomock.Controller) (
+				dao.ArticleDAO,
+				cache.ArticleCache,
+				cache.ArticleGeoCache,
+			) {
+				geoCache := cachemocks.NewMockArticleGeoCache(ctrl)
+				geoCache.EXPECT().
+					SearchRadius(gomock.Any(), 2.35, 48.85, float64(1000), maxNearbyHits).
+					Return([]cache.ArticleGeoHit{
+						{ArticleID: 3, PlaceIdx: 0, Distance: 10},
+						{ArticleID: 1, PlaceIdx: 0, Distance: 10},
+						{ArticleID: 2, PlaceIdx: 0, Distance: 10},
+					}, nil)
+				artCache := cachemocks.NewMockArticleCache(ctrl)
+				artCache.EXPECT().GetPub(gomock.Any(), int64(3)).Return(domain.Article{
+					ID:     3,
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				return daomocks.NewMockArticleDAO(ctrl), artCache, geoCache
+			},
+			after: domain.NearbyCursor{Distance: 10, ArticleID: 2},
+			limit: 2,
+			wantRes: []domain.NearbyArticle{
+				{
+					Article:  domain.Article{ID: 3, Status: domain.ArticleStatusPublished},
+					Distance: 10,
+				},
+			},
+		},
+		{
+			name: "cursor out of range",
+			mock: func(ctrl *gomock.Controller) (
+				dao.ArticleDAO,
+				cache.ArticleCache,
+				cache.ArticleGeoCache,
+			) {
+				geoCache := cachemocks.NewMockArticleGeoCache(ctrl)
+				geoCache.EXPECT().
+					SearchRadius(gomock.Any(), 2.35, 48.85, float64(1000), maxNearbyHits).
+					Return([]cache.ArticleGeoHit{
+						{ArticleID: 1, PlaceIdx: 0, Distance: 10},
+					}, nil)
+				return daomocks.NewMockArticleDAO(ctrl), cachemocks.NewMockArticleCache(ctrl), geoCache
+			},
+			after:   domain.NearbyCursor{Distance: 10, ArticleID: 1},
+			limit:   2,
+			wantRes: []domain.NearbyArticle{},
+		},
+		{
+			name: "geo cache error",
+			mock: func(ctrl *gomock.Controller) (
+				dao.ArticleDAO,
+				cache.ArticleCache,
+				cache.ArticleGeoCache,
+			) {
+				geoCache := cachemocks.NewMockArticleGeoCache(ctrl)
+				geoCache.EXPECT().
+					SearchRadius(gomock.Any(), 2.35, 48.85, float64(1000), maxNearbyHits).
+					Return(nil, errors.New("redis error"))
+				artCache := cachemocks.NewMockArticleCache(ctrl)
+				return daomocks.NewMockArticleDAO(ctrl), artCache, geoCache
+			},
+			limit:   2,
+			wantErr: errors.New("redis error"),
+		},
+		{
+			name: "article error",
+			mock: func(ctrl *gomock.Controller) (
+				dao.ArticleDAO,
+				cache.ArticleCache,
+				cache.ArticleGeoCache,
+			) {
+				geoCache := cachemocks.NewMockArticleGeoCache(ctrl)
+				geoCache.EXPECT().
+					SearchRadius(gomock.Any(), 2.35, 48.85, float64(1000), maxNearbyHits).
+					Return([]cache.ArticleGeoHit{
+						{ArticleID: 1, PlaceIdx: 0, Distance: 10},
+					}, nil)
+				artCache := cachemocks.NewMockArticleCache(ctrl)
+				artCache.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{}, cache.ErrKeyNotExist)
+				artDAO := daomocks.NewMockArticleDAO(ctrl)
+				artDAO.EXPECT().GetPubByID(gomock.Any(), int64(1)).
+					Return(dao.PublishedArticle{}, errors.New("db error"))
+				return artDAO, artCache, geoCache
+			},
+			limit:   2,
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			artDAO, artCache, geoCache := tc.mock(ctrl)
+			repo := NewArticleRepository(nil, artDAO, artCache, geoCache, nil, nil)
+			res, err := repo.ListPubNearby(context.TODO(), 2.35, 48.85, 1000, tc.after, tc.limit)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
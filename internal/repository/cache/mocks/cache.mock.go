@@ -12,8 +12,10 @@ package cachemocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	cache "github.com/chenmuyao/go-bootcamp/internal/repository/cache"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -205,6 +207,20 @@ func (mr *MockArticleCacheMockRecorder) BatchSetPub(ctx, articles any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchSetPub", reflect.TypeOf((*MockArticleCache)(nil).BatchSetPub), ctx, articles)
 }
 
+// Del mocks base method.
+func (m *MockArticleCache) Del(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockArticleCacheMockRecorder) Del(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockArticleCache)(nil).Del), ctx, id)
+}
+
 // DelFirstPage mocks base method.
 func (m *MockArticleCache) DelFirstPage(ctx context.Context, uid int64) error {
 	m.ctrl.T.Helper()
@@ -306,6 +322,347 @@ func (mr *MockArticleCacheMockRecorder) SetPub(ctx, article any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPub", reflect.TypeOf((*MockArticleCache)(nil).SetPub), ctx, article)
 }
 
+// MockArticleGeoCache is a mock of ArticleGeoCache interface.
+type MockArticleGeoCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleGeoCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleGeoCacheMockRecorder is the mock recorder for MockArticleGeoCache.
+type MockArticleGeoCacheMockRecorder struct {
+	mock *MockArticleGeoCache
+}
+
+// NewMockArticleGeoCache creates a new mock instance.
+func NewMockArticleGeoCache(ctrl *gomock.Controller) *MockArticleGeoCache {
+	mock := &MockArticleGeoCache{ctrl: ctrl}
+	mock.recorder = &MockArticleGeoCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleGeoCache) EXPECT() *MockArticleGeoCacheMockRecorder {
+	return m.recorder
+}
+
+// DelPlaces mocks base method.
+func (m *MockArticleGeoCache) DelPlaces(ctx context.Context, aid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelPlaces", ctx, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelPlaces indicates an expected call of DelPlaces.
+func (mr *MockArticleGeoCacheMockRecorder) DelPlaces(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelPlaces", reflect.TypeOf((*MockArticleGeoCache)(nil).DelPlaces), ctx, aid)
+}
+
+// SearchRadius mocks base method.
+func (m *MockArticleGeoCache) SearchRadius(ctx context.Context, lng, lat, radius float64, count int) ([]cache.ArticleGeoHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRadius", ctx, lng, lat, radius, count)
+	ret0, _ := ret[0].([]cache.ArticleGeoHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchRadius indicates an expected call of SearchRadius.
+func (mr *MockArticleGeoCacheMockRecorder) SearchRadius(ctx, lng, lat, radius, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRadius", reflect.TypeOf((*MockArticleGeoCache)(nil).SearchRadius), ctx, lng, lat, radius, count)
+}
+
+// SetPlaces mocks base method.
+func (m *MockArticleGeoCache) SetPlaces(ctx context.Context, aid int64, places []domain.Place) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPlaces", ctx, aid, places)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPlaces indicates an expected call of SetPlaces.
+func (mr *MockArticleGeoCacheMockRecorder) SetPlaces(ctx, aid, places any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlaces", reflect.TypeOf((*MockArticleGeoCache)(nil).SetPlaces), ctx, aid, places)
+}
+
+// MockReadHistoryCache is a mock of ReadHistoryCache interface.
+type MockReadHistoryCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadHistoryCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockReadHistoryCacheMockRecorder is the mock recorder for MockReadHistoryCache.
+type MockReadHistoryCacheMockRecorder struct {
+	mock *MockReadHistoryCache
+}
+
+// NewMockReadHistoryCache creates a new mock instance.
+func NewMockReadHistoryCache(ctrl *gomock.Controller) *MockReadHistoryCache {
+	mock := &MockReadHistoryCache{ctrl: ctrl}
+	mock.recorder = &MockReadHistoryCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadHistoryCache) EXPECT() *MockReadHistoryCacheMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockReadHistoryCache) Add(ctx context.Context, records []domain.ReadRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockReadHistoryCacheMockRecorder) Add(ctx, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockReadHistoryCache)(nil).Add), ctx, records)
+}
+
+// Clear mocks base method.
+func (m *MockReadHistoryCache) Clear(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockReadHistoryCacheMockRecorder) Clear(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockReadHistoryCache)(nil).Clear), ctx, uid)
+}
+
+// Del mocks base method.
+func (m *MockReadHistoryCache) Del(ctx context.Context, uid, aid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, uid, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockReadHistoryCacheMockRecorder) Del(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockReadHistoryCache)(nil).Del), ctx, uid, aid)
+}
+
+// List mocks base method.
+func (m *MockReadHistoryCache) List(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.ReadRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.ReadRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockReadHistoryCacheMockRecorder) List(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReadHistoryCache)(nil).List), ctx, uid, cursor, limit)
+}
+
+// Readers mocks base method.
+func (m *MockReadHistoryCache) Readers(ctx context.Context, since time.Time, offset, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Readers", ctx, since, offset, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Readers indicates an expected call of Readers.
+func (mr *MockReadHistoryCacheMockRecorder) Readers(ctx, since, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Readers", reflect.TypeOf((*MockReadHistoryCache)(nil).Readers), ctx, since, offset, limit)
+}
+
+// MockRecommendCache is a mock of RecommendCache interface.
+type MockRecommendCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockRecommendCacheMockRecorder is the mock recorder for MockRecommendCache.
+type MockRecommendCacheMockRecorder struct {
+	mock *MockRecommendCache
+}
+
+// NewMockRecommendCache creates a new mock instance.
+func NewMockRecommendCache(ctrl *gomock.Controller) *MockRecommendCache {
+	mock := &MockRecommendCache{ctrl: ctrl}
+	mock.recorder = &MockRecommendCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendCache) EXPECT() *MockRecommendCacheMockRecorder {
+	return m.recorder
+}
+
+// GetForUser mocks base method.
+func (m *MockRecommendCache) GetForUser(ctx context.Context, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUser", ctx, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUser indicates an expected call of GetForUser.
+func (mr *MockRecommendCacheMockRecorder) GetForUser(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUser", reflect.TypeOf((*MockRecommendCache)(nil).GetForUser), ctx, uid)
+}
+
+// GetRelated mocks base method.
+func (m *MockRecommendCache) GetRelated(ctx context.Context, aid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelated", ctx, aid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelated indicates an expected call of GetRelated.
+func (mr *MockRecommendCacheMockRecorder) GetRelated(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelated", reflect.TypeOf((*MockRecommendCache)(nil).GetRelated), ctx, aid)
+}
+
+// SetForUsers mocks base method.
+func (m *MockRecommendCache) SetForUsers(ctx context.Context, recs map[int64][]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetForUsers", ctx, recs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetForUsers indicates an expected call of SetForUsers.
+func (mr *MockRecommendCacheMockRecorder) SetForUsers(ctx, recs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetForUsers", reflect.TypeOf((*MockRecommendCache)(nil).SetForUsers), ctx, recs)
+}
+
+// SetRelated mocks base method.
+func (m *MockRecommendCache) SetRelated(ctx context.Context, related map[int64][]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRelated", ctx, related)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRelated indicates an expected call of SetRelated.
+func (mr *MockRecommendCacheMockRecorder) SetRelated(ctx, related any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRelated", reflect.TypeOf((*MockRecommendCache)(nil).SetRelated), ctx, related)
+}
+
+// MockFeedCache is a mock of FeedCache interface.
+type MockFeedCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockFeedCacheMockRecorder is the mock recorder for MockFeedCache.
+type MockFeedCacheMockRecorder struct {
+	mock *MockFeedCache
+}
+
+// NewMockFeedCache creates a new mock instance.
+func NewMockFeedCache(ctrl *gomock.Controller) *MockFeedCache {
+	mock := &MockFeedCache{ctrl: ctrl}
+	mock.recorder = &MockFeedCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedCache) EXPECT() *MockFeedCacheMockRecorder {
+	return m.recorder
+}
+
+// GetFeed mocks base method.
+func (m *MockFeedCache) GetFeed(ctx context.Context, uid int64, format domain.FeedFormat) (domain.FeedDoc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, uid, format)
+	ret0, _ := ret[0].(domain.FeedDoc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockFeedCacheMockRecorder) GetFeed(ctx, uid, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockFeedCache)(nil).GetFeed), ctx, uid, format)
+}
+
+// GetSitemap mocks base method.
+func (m *MockFeedCache) GetSitemap(ctx context.Context, page int) (domain.FeedDoc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSitemap", ctx, page)
+	ret0, _ := ret[0].(domain.FeedDoc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSitemap indicates an expected call of GetSitemap.
+func (mr *MockFeedCacheMockRecorder) GetSitemap(ctx, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSitemap", reflect.TypeOf((*MockFeedCache)(nil).GetSitemap), ctx, page)
+}
+
+// Invalidate mocks base method.
+func (m *MockFeedCache) Invalidate(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockFeedCacheMockRecorder) Invalidate(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockFeedCache)(nil).Invalidate), ctx, uid)
+}
+
+// SetFeed mocks base method.
+func (m *MockFeedCache) SetFeed(ctx context.Context, uid int64, format domain.FeedFormat, doc domain.FeedDoc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeed", ctx, uid, format, doc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFeed indicates an expected call of SetFeed.
+func (mr *MockFeedCacheMockRecorder) SetFeed(ctx, uid, format, doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeed", reflect.TypeOf((*MockFeedCache)(nil).SetFeed), ctx, uid, format, doc)
+}
+
+// SetSitemaps mocks base method.
+func (m *MockFeedCache) SetSitemaps(ctx context.Context, docs []domain.FeedDoc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSitemaps", ctx, docs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSitemaps indicates an expected call of SetSitemaps.
+func (mr *MockFeedCacheMockRecorder) SetSitemaps(ctx, docs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSitemaps", reflect.TypeOf((*MockFeedCache)(nil).SetSitemaps), ctx, docs)
+}
+
 // MockRankingCache is a mock of RankingCache interface.
 type MockRankingCache struct {
 	ctrl     *gomock.Controller
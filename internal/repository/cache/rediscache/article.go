@@ -97,6 +97,11 @@ func (a *ArticleRedisCache) Set(ctx context.Context, article domain.Article) err
 	return a.client.Set(ctx, key, val, articleContentPreCacheExpiryTime).Err()
 }
 
+// Del implements cache.ArticleCache.
+func (a *ArticleRedisCache) Del(ctx context.Context, id int64) error {
+	return a.client.Del(ctx, a.Key(motifContent, id), a.Key(motifPublishedContent, id)).Err()
+}
+
 // DelFirstPage implements cache.ArticleCache.
 func (a *ArticleRedisCache) DelFirstPage(ctx context.Context, uid int64) error {
 	key := a.Key(motifFirstPage, uid)
@@ -0,0 +1,103 @@
+package rediscache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed lua/set_geo_places.lua
+var luaSetGeoPlaces string
+
+// ArticleGeoRedisCache indexes the places of published articles with Redis
+// GEO. An article can have several places, so each member is "aid:idx".
+type ArticleGeoRedisCache struct {
+	cache.BaseArticleGeoCache
+	client redis.Cmdable
+}
+
+// SetPlaces implements cache.ArticleGeoCache.
+func (a *ArticleGeoRedisCache) SetPlaces(
+	ctx context.Context,
+	aid int64,
+	places []domain.Place,
+) error {
+	args := make([]any, 0, len(places)*3)
+	for i, p := range places {
+		args = append(args, p.Lng, p.Lat, a.member(aid, i))
+	}
+	return a.client.Eval(ctx, luaSetGeoPlaces, []string{a.Key(), a.MembersKey(aid)}, args...).
+		Err()
+}
+
+// DelPlaces implements cache.ArticleGeoCache.
+func (a *ArticleGeoRedisCache) DelPlaces(ctx context.Context, aid int64) error {
+	return a.client.Eval(ctx, luaSetGeoPlaces, []string{a.Key(), a.MembersKey(aid)}).Err()
+}
+
+// SearchRadius implements cache.ArticleGeoCache.
+func (a *ArticleGeoRedisCache) SearchRadius(
+	ctx context.Context,
+	lng, lat, radius float64,
+	count int,
+) ([]cache.ArticleGeoHit, error) {
+	locs, err := a.client.GeoSearchLocation(ctx, a.Key(), &redis.GeoSearchLocationQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{
+			Longitude:  lng,
+			Latitude:   lat,
+			Radius:     radius,
+			RadiusUnit: "m",
+			Sort:       "ASC",
+			Count:      count,
+		},
+		WithDist: true,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]cache.ArticleGeoHit, 0, len(locs))
+	for _, loc := range locs {
+		aid, idx, err := a.parseMember(loc.Name)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cache.ArticleGeoHit{
+			ArticleID: aid,
+			PlaceIdx:  idx,
+			Distance:  loc.Dist,
+		})
+	}
+	return res, nil
+}
+
+func (a *ArticleGeoRedisCache) member(aid int64, idx int) string {
+	return fmt.Sprintf("%d:%d", aid, idx)
+}
+
+func (a *ArticleGeoRedisCache) parseMember(member string) (int64, int, error) {
+	aidStr, idxStr, ok := strings.Cut(member, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid geo member %q", member)
+	}
+	aid, err := strconv.ParseInt(aidStr, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	idx, err := strconv.Atoi(idxStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	return aid, idx, nil
+}
+
+func NewArticleGeoRedisCache(client redis.Cmdable) cache.ArticleGeoCache {
+	return &ArticleGeoRedisCache{
+		client: client,
+	}
+}
@@ -0,0 +1,97 @@
+package rediscache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	feedExpiryTime    = 10 * time.Minute
+	sitemapExpiryTime = time.Hour
+)
+
+type FeedRedisCache struct {
+	cache.BaseFeedCache
+	client redis.Cmdable
+}
+
+// GetFeed implements cache.FeedCache.
+func (f *FeedRedisCache) GetFeed(
+	ctx context.Context,
+	uid int64,
+	format domain.FeedFormat,
+) (domain.FeedDoc, error) {
+	return f.get(ctx, f.Key(uid), string(format))
+}
+
+// SetFeed implements cache.FeedCache.
+func (f *FeedRedisCache) SetFeed(
+	ctx context.Context,
+	uid int64,
+	format domain.FeedFormat,
+	doc domain.FeedDoc,
+) error {
+	val, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	key := f.Key(uid)
+	pipe := f.client.TxPipeline()
+	pipe.HSet(ctx, key, string(format), val)
+	pipe.Expire(ctx, key, feedExpiryTime)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetSitemap implements cache.FeedCache.
+func (f *FeedRedisCache) GetSitemap(ctx context.Context, page int) (domain.FeedDoc, error) {
+	return f.get(ctx, f.SitemapKey(), strconv.Itoa(page))
+}
+
+// SetSitemaps implements cache.FeedCache.
+func (f *FeedRedisCache) SetSitemaps(ctx context.Context, docs []domain.FeedDoc) error {
+	fields := make([]any, 0, 2*len(docs))
+	for i, doc := range docs {
+		val, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, strconv.Itoa(i), val)
+	}
+	key := f.SitemapKey()
+	pipe := f.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(fields) > 0 {
+		pipe.HSet(ctx, key, fields...)
+		pipe.Expire(ctx, key, sitemapExpiryTime)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Invalidate implements cache.FeedCache.
+func (f *FeedRedisCache) Invalidate(ctx context.Context, uid int64) error {
+	return f.client.Del(ctx, f.Key(0), f.Key(uid), f.SitemapKey()).Err()
+}
+
+func (f *FeedRedisCache) get(ctx context.Context, key, field string) (domain.FeedDoc, error) {
+	val, err := f.client.HGet(ctx, key, field).Bytes()
+	if err != nil {
+		return domain.FeedDoc{}, err
+	}
+	var res domain.FeedDoc
+	err = json.Unmarshal(val, &res)
+	return res, err
+}
+
+func NewFeedRedisCache(client redis.Cmdable) cache.FeedCache {
+	return &FeedRedisCache{
+		client: client,
+	}
+}
@@ -0,0 +1,17 @@
+local geoKey = KEYS[1]
+-- set of the members of the article in the geo index
+local membersKey = KEYS[2]
+
+-- remove the old places of the article
+local old = redis.call("smembers", membersKey)
+if #old > 0 then
+	redis.call("zrem", geoKey, unpack(old))
+end
+redis.call("del", membersKey)
+
+-- ARGV: lng1, lat1, member1, lng2, lat2, member2, ...
+for i = 1, #ARGV, 3 do
+	redis.call("geoadd", geoKey, ARGV[i], ARGV[i + 1], ARGV[i + 2])
+	redis.call("sadd", membersKey, ARGV[i + 2])
+end
+return #ARGV / 3
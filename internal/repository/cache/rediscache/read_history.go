@@ -0,0 +1,165 @@
+package rediscache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	readHistoryRetention = 90 * 24 * time.Hour
+	readHistoryMaxSize   = 1000
+)
+
+// ReadHistoryRedisCache keeps the history of a user in a sorted set of the
+// article IDs scored by the read time in milliseconds.
+type ReadHistoryRedisCache struct {
+	cache.BaseReadHistoryCache
+	client    redis.Cmdable
+	retention time.Duration
+	maxSize   int64
+}
+
+// Add implements cache.ReadHistoryCache.
+func (r *ReadHistoryRedisCache) Add(ctx context.Context, records []domain.ReadRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	members := make(map[int64][]redis.Z)
+	readers := make([]redis.Z, 0, len(records))
+	for _, record := range records {
+		score := float64(record.ReadTime.UnixMilli())
+		members[record.UID] = append(members[record.UID], redis.Z{
+			Score:  score,
+			Member: record.Article.ID,
+		})
+		readers = append(readers, redis.Z{Score: score, Member: record.UID})
+	}
+	oldest := "(" + strconv.FormatInt(time.Now().Add(-r.retention).UnixMilli(), 10)
+
+	pipe := r.client.Pipeline()
+	for uid, zs := range members {
+		key := r.Key(uid)
+		// NOTE: GT keeps the latest read when the events are out of order.
+		pipe.ZAddArgs(ctx, key, redis.ZAddArgs{GT: true, Members: zs})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", oldest)
+		pipe.ZRemRangeByRank(ctx, key, 0, -r.maxSize-1)
+		pipe.Expire(ctx, key, r.retention)
+	}
+	pipe.ZAddArgs(ctx, r.ReadersKey(), redis.ZAddArgs{GT: true, Members: readers})
+	pipe.ZRemRangeByScore(ctx, r.ReadersKey(), "-inf", oldest)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// List implements cache.ReadHistoryCache.
+func (r *ReadHistoryRedisCache) List(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.ReadRecord, error) {
+	key := r.Key(uid)
+	args := redis.ZRangeArgs{
+		Key:     key,
+		Start:   "-inf",
+		Stop:    "+inf",
+		ByScore: true,
+		Rev:     true,
+		Count:   int64(limit),
+	}
+	if !cursor.IsZero() {
+		score := strconv.FormatInt(cursor.Time.UnixMilli(), 10)
+		// The records read at the same time are sorted by member in reverse
+		// lexicographical order, skip those up to the cursor.
+		ties, err := r.client.ZRangeArgs(ctx, redis.ZRangeArgs{
+			Key:     key,
+			Start:   score,
+			Stop:    score,
+			ByScore: true,
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+		last := strconv.FormatInt(cursor.ID, 10)
+		for _, member := range ties {
+			if member >= last {
+				args.Offset++
+			}
+		}
+		args.Stop = score
+	}
+	zs, err := r.client.ZRangeArgsWithScores(ctx, args).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ReadRecord, 0, len(zs))
+	for _, z := range zs {
+		aid, err := strconv.ParseInt(z.Member.(string), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, domain.ReadRecord{
+			UID:      uid,
+			Article:  domain.Article{ID: aid},
+			ReadTime: time.UnixMilli(int64(z.Score)),
+		})
+	}
+	return res, nil
+}
+
+// Del implements cache.ReadHistoryCache.
+func (r *ReadHistoryRedisCache) Del(ctx context.Context, uid int64, aid int64) error {
+	return r.client.ZRem(ctx, r.Key(uid), aid).Err()
+}
+
+// Clear implements cache.ReadHistoryCache.
+func (r *ReadHistoryRedisCache) Clear(ctx context.Context, uid int64) error {
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, r.Key(uid))
+	pipe.ZRem(ctx, r.ReadersKey(), uid)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Readers implements cache.ReadHistoryCache.
+func (r *ReadHistoryRedisCache) Readers(
+	ctx context.Context,
+	since time.Time,
+	offset int,
+	limit int,
+) ([]int64, error) {
+	members, err := r.client.ZRangeArgs(ctx, redis.ZRangeArgs{
+		Key:     r.ReadersKey(),
+		Start:   strconv.FormatInt(since.UnixMilli(), 10),
+		Stop:    "+inf",
+		ByScore: true,
+		Rev:     true,
+		Offset:  int64(offset),
+		Count:   int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int64, 0, len(members))
+	for _, member := range members {
+		uid, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, uid)
+	}
+	return res, nil
+}
+
+func NewReadHistoryRedisCache(client redis.Cmdable) cache.ReadHistoryCache {
+	return &ReadHistoryRedisCache{
+		client:    client,
+		retention: readHistoryRetention,
+		maxSize:   readHistoryMaxSize,
+	}
+}
@@ -0,0 +1,80 @@
+package rediscache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// RecommendRedisCache keeps each list of recommended article IDs in a JSON
+// string.
+type RecommendRedisCache struct {
+	cache.BaseRecommendCache
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+// SetRelated implements cache.RecommendCache.
+func (r *RecommendRedisCache) SetRelated(ctx context.Context, related map[int64][]int64) error {
+	return r.set(ctx, related, r.RelatedKey)
+}
+
+// GetRelated implements cache.RecommendCache.
+func (r *RecommendRedisCache) GetRelated(ctx context.Context, aid int64) ([]int64, error) {
+	return r.get(ctx, r.RelatedKey(aid))
+}
+
+// SetForUsers implements cache.RecommendCache.
+func (r *RecommendRedisCache) SetForUsers(ctx context.Context, recs map[int64][]int64) error {
+	return r.set(ctx, recs, r.UserKey)
+}
+
+// GetForUser implements cache.RecommendCache.
+func (r *RecommendRedisCache) GetForUser(ctx context.Context, uid int64) ([]int64, error) {
+	return r.get(ctx, r.UserKey(uid))
+}
+
+func (r *RecommendRedisCache) set(
+	ctx context.Context,
+	lists map[int64][]int64,
+	key func(id int64) string,
+) error {
+	if len(lists) == 0 {
+		return nil
+	}
+	pipe := r.client.Pipeline()
+	for id, ids := range lists {
+		val, err := json.Marshal(ids)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, key(id), val, r.expiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RecommendRedisCache) get(ctx context.Context, key string) ([]int64, error) {
+	val, err := r.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, cache.ErrKeyNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	err = json.Unmarshal(val, &ids)
+	return ids, err
+}
+
+func NewRecommendRedisCache(client redis.Cmdable) cache.RecommendCache {
+	return &RecommendRedisCache{
+		client: client,
+		// NOTE: a few runs of the job, the recommendations of the articles
+		// and the users left out by the job expire.
+		expiration: 3 * time.Hour,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/chenmuyao/go-bootcamp/internal/domain"
 )
@@ -47,6 +48,60 @@ type ArticleCache interface {
 	SetPub(ctx context.Context, article domain.Article) error
 	BatchGetPub(ctx context.Context, ids []int64) ([]domain.Article, error)
 	BatchSetPub(ctx context.Context, articles []domain.Article) error
+	// Del removes the author and the reader copies of an article.
+	Del(ctx context.Context, id int64) error
+}
+
+type ArticleGeoCache interface {
+	// SetPlaces replaces all the indexed places of an article.
+	SetPlaces(ctx context.Context, aid int64, places []domain.Place) error
+	DelPlaces(ctx context.Context, aid int64) error
+	// SearchRadius returns at most count places within radius meters,
+	// sorted by distance.
+	SearchRadius(
+		ctx context.Context,
+		lng, lat, radius float64,
+		count int,
+	) ([]ArticleGeoHit, error)
+}
+
+// ReadHistoryCache is the storage of the reading histories, the oldest
+// records age out.
+type ReadHistoryCache interface {
+	// Add records the reads, a repeated read only updates the read time.
+	Add(ctx context.Context, records []domain.ReadRecord) error
+	// List returns the records after the cursor, the latest read first.
+	List(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.ReadRecord, error)
+	Del(ctx context.Context, uid int64, aid int64) error
+	Clear(ctx context.Context, uid int64) error
+	// Readers returns the users who have read since the given time, the
+	// latest first.
+	Readers(ctx context.Context, since time.Time, offset int, limit int) ([]int64, error)
+}
+
+// RecommendCache keeps the recommendations computed offline, the ones not
+// computed again expire.
+type RecommendCache interface {
+	SetRelated(ctx context.Context, related map[int64][]int64) error
+	GetRelated(ctx context.Context, aid int64) ([]int64, error)
+	SetForUsers(ctx context.Context, recs map[int64][]int64) error
+	GetForUser(ctx context.Context, uid int64) ([]int64, error)
+}
+
+// FeedCache keeps the rendered feeds and sitemaps until the published articles
+// change.
+type FeedCache interface {
+	// GetFeed and SetFeed access the feeds of an author, or the site-wide
+	// feeds if uid is 0.
+	GetFeed(ctx context.Context, uid int64, format domain.FeedFormat) (domain.FeedDoc, error)
+	SetFeed(ctx context.Context, uid int64, format domain.FeedFormat, doc domain.FeedDoc) error
+	// GetSitemap returns the index for page 0, and the sitemaps from 1.
+	GetSitemap(ctx context.Context, page int) (domain.FeedDoc, error)
+	// SetSitemaps replaces the index and all the sitemaps.
+	SetSitemaps(ctx context.Context, docs []domain.FeedDoc) error
+	// Invalidate removes the site-wide feeds, the feeds of the author and the
+	// sitemaps.
+	Invalidate(ctx context.Context, uid int64) error
 }
 
 type RankingCache interface {
@@ -67,9 +122,24 @@ type BaseInteractiveCache struct{}
 
 type BaseRankingCache struct{}
 
+type BaseArticleGeoCache struct{}
+
+type BaseFeedCache struct{}
+
+type BaseReadHistoryCache struct{}
+
+type BaseRecommendCache struct{}
+
 // }}}
 // {{{ Other structs
 
+type ArticleGeoHit struct {
+	ArticleID int64
+	// index of the place in domain.Article.Places
+	PlaceIdx int
+	Distance float64
+}
+
 // }}}
 // {{{ Struct Methods
 
@@ -93,6 +163,44 @@ func (c *BaseRankingCache) Key(biz string) string {
 	return fmt.Sprintf("ranking:%s", biz)
 }
 
+func (c *BaseArticleGeoCache) Key() string {
+	return "article:geo:published"
+}
+
+func (c *BaseArticleGeoCache) MembersKey(aid int64) string {
+	return fmt.Sprintf("article:geo:members:%d", aid)
+}
+
+// Key returns the hash of the feeds of an author, or the site-wide feeds if
+// uid is 0.
+func (c *BaseFeedCache) Key(uid int64) string {
+	if uid == 0 {
+		return "feed:site"
+	}
+	return fmt.Sprintf("feed:author:%d", uid)
+}
+
+func (c *BaseFeedCache) SitemapKey() string {
+	return "feed:sitemap"
+}
+
+func (c *BaseReadHistoryCache) Key(uid int64) string {
+	return fmt.Sprintf("history:read:%d", uid)
+}
+
+// ReadersKey returns the sorted set of the users scored by their last read.
+func (c *BaseReadHistoryCache) ReadersKey() string {
+	return "history:readers"
+}
+
+func (c *BaseRecommendCache) RelatedKey(aid int64) string {
+	return fmt.Sprintf("recommend:related:%d", aid)
+}
+
+func (c *BaseRecommendCache) UserKey(uid int64) string {
+	return fmt.Sprintf("recommend:user:%d", uid)
+}
+
 // }}}
 // {{{ Private functions
 
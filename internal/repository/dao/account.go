@@ -0,0 +1,291 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrWithdrawalNotFound  = errors.New("withdrawal not found")
+	// the withdrawal is no longer pending
+	ErrWithdrawalSettled = errors.New("withdrawal settled")
+)
+
+// the values of domain.WithdrawalStatus
+const withdrawalStatusPending uint8 = 1
+
+//go:generate mockgen -source=./account.go -package=daomocks -destination=./mocks/account.mock.go
+type AccountDAO interface {
+	// Transfer writes the entries of a transaction and updates the balances
+	// at once. It does nothing if the transaction of the biz is written.
+	Transfer(ctx context.Context, txn AccountTxn, entries []AccountEntry) error
+	// GetAccount returns an empty account if it has no entry yet.
+	GetAccount(ctx context.Context, uid int64, typ uint8) (Account, error)
+	ListEntries(
+		ctx context.Context,
+		uid int64,
+		typ uint8,
+		offset, limit int,
+	) ([]AccountEntry, error)
+
+	// CreateWithdrawal creates the withdrawal and writes the entries of its
+	// transaction at once.
+	CreateWithdrawal(
+		ctx context.Context,
+		w Withdrawal,
+		txn AccountTxn,
+		entries []AccountEntry,
+	) (int64, error)
+	// SettleWithdrawal moves a pending withdrawal to its status and writes
+	// the entries of its transaction at once.
+	SettleWithdrawal(
+		ctx context.Context,
+		w Withdrawal,
+		txn AccountTxn,
+		entries []AccountEntry,
+	) error
+	GetWithdrawal(ctx context.Context, id int64) (Withdrawal, error)
+	// ListWithdrawals lists the withdrawals of the user, of all the users if
+	// uid is 0, in the status if it is not 0.
+	ListWithdrawals(
+		ctx context.Context,
+		uid int64,
+		status uint8,
+		offset, limit int,
+	) ([]Withdrawal, error)
+}
+
+type Account struct {
+	ID      int64 `gorm:"primaryKey,autoIncrement"`
+	UID     int64 `gorm:"uniqueIndex:uid_type"`
+	Type    uint8 `gorm:"uniqueIndex:uid_type"`
+	Balance int64
+	Ctime   int64
+	Utime   int64
+}
+
+// AccountTxn makes the transactions idempotent, there is one per biz.
+type AccountTxn struct {
+	ID          int64  `gorm:"primaryKey,autoIncrement"`
+	Biz         string `gorm:"type:varchar(64);uniqueIndex:biz_type_id"`
+	BizID       int64  `gorm:"uniqueIndex:biz_type_id"`
+	Description string `gorm:"type:varchar(256)"`
+	Ctime       int64
+}
+
+// AccountEntry is only inserted, never updated.
+type AccountEntry struct {
+	ID        int64 `gorm:"primaryKey,autoIncrement"`
+	TxnID     int64 `gorm:"index"`
+	AccountID int64
+	Biz       string `gorm:"type:varchar(64)"`
+	BizID     int64
+	UID       int64 `gorm:"index:uid_type_id"`
+	Type      uint8 `gorm:"index:uid_type_id"`
+	Amount    int64
+	Ctime     int64
+
+	// the balance of the account can be negative after the entry
+	Overdraft bool `gorm:"-"`
+}
+
+type Withdrawal struct {
+	ID       int64 `gorm:"primaryKey,autoIncrement"`
+	UID      int64 `gorm:"index"`
+	Amount   int64
+	Status   uint8 `gorm:"index"`
+	Reviewer int64
+	Ctime    int64
+	Utime    int64
+}
+
+type GORMAccountDAO struct {
+	db *gorm.DB
+}
+
+// Transfer implements AccountDAO.
+func (g *GORMAccountDAO) Transfer(
+	ctx context.Context,
+	txn AccountTxn,
+	entries []AccountEntry,
+) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return g.transfer(tx, txn, entries)
+	})
+}
+
+// GetAccount implements AccountDAO.
+func (g *GORMAccountDAO) GetAccount(ctx context.Context, uid int64, typ uint8) (Account, error) {
+	var res Account
+	err := g.db.WithContext(ctx).Where("uid = ? AND type = ?", uid, typ).First(&res).Error
+	if err == gorm.ErrRecordNotFound {
+		return Account{UID: uid, Type: typ}, nil
+	}
+	return res, err
+}
+
+// ListEntries implements AccountDAO.
+func (g *GORMAccountDAO) ListEntries(
+	ctx context.Context,
+	uid int64,
+	typ uint8,
+	offset, limit int,
+) ([]AccountEntry, error) {
+	var res []AccountEntry
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND type = ?", uid, typ).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// CreateWithdrawal implements AccountDAO.
+func (g *GORMAccountDAO) CreateWithdrawal(
+	ctx context.Context,
+	w Withdrawal,
+	txn AccountTxn,
+	entries []AccountEntry,
+) (int64, error) {
+	now := time.Now().UnixMilli()
+	w.Ctime = now
+	w.Utime = now
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&w).Error
+		if err != nil {
+			return err
+		}
+		txn.BizID = w.ID
+		return g.transfer(tx, txn, entries)
+	})
+	return w.ID, err
+}
+
+// SettleWithdrawal implements AccountDAO.
+func (g *GORMAccountDAO) SettleWithdrawal(
+	ctx context.Context,
+	w Withdrawal,
+	txn AccountTxn,
+	entries []AccountEntry,
+) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// NOTE: the status guard settles a withdrawal only once, whatever
+		// the concurrent reviews.
+		res := tx.Model(&Withdrawal{}).
+			Where("id = ? AND status = ?", w.ID, withdrawalStatusPending).
+			Updates(map[string]any{
+				"status":   w.Status,
+				"reviewer": w.Reviewer,
+				"utime":    time.Now().UnixMilli(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrWithdrawalSettled
+		}
+		return g.transfer(tx, txn, entries)
+	})
+}
+
+// GetWithdrawal implements AccountDAO.
+func (g *GORMAccountDAO) GetWithdrawal(ctx context.Context, id int64) (Withdrawal, error) {
+	var res Withdrawal
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	if err == gorm.ErrRecordNotFound {
+		return Withdrawal{}, ErrWithdrawalNotFound
+	}
+	return res, err
+}
+
+// ListWithdrawals implements AccountDAO.
+func (g *GORMAccountDAO) ListWithdrawals(
+	ctx context.Context,
+	uid int64,
+	status uint8,
+	offset, limit int,
+) ([]Withdrawal, error) {
+	db := g.db.WithContext(ctx)
+	if uid > 0 {
+		db = db.Where("uid = ?", uid)
+	}
+	if status > 0 {
+		db = db.Where("status = ?", status)
+	}
+	var res []Withdrawal
+	err := db.Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMAccountDAO) transfer(tx *gorm.DB, txn AccountTxn, entries []AccountEntry) error {
+	now := time.Now().UnixMilli()
+	txn.Ctime = now
+	res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&txn)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		// already written
+		return nil
+	}
+
+	// NOTE: the balances are updated in place, the rows stay locked until
+	// the commit. Lock them in the same order everywhere to avoid the
+	// deadlocks.
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].UID != entries[j].UID {
+			return entries[i].UID < entries[j].UID
+		}
+		return entries[i].Type < entries[j].Type
+	})
+	for i := range entries {
+		e := &entries[i]
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&Account{
+			UID:   e.UID,
+			Type:  e.Type,
+			Ctime: now,
+			Utime: now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		update := tx.Model(&Account{}).Where("uid = ? AND type = ?", e.UID, e.Type)
+		if !e.Overdraft {
+			update = update.Where("balance + ? >= 0", e.Amount)
+		}
+		res := update.Updates(map[string]any{
+			"balance": gorm.Expr("balance + ?", e.Amount),
+			"utime":   now,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrInsufficientBalance
+		}
+		var acc Account
+		err = tx.Select("id").Where("uid = ? AND type = ?", e.UID, e.Type).First(&acc).Error
+		if err != nil {
+			return err
+		}
+		e.AccountID = acc.ID
+		e.TxnID = txn.ID
+		e.Biz = txn.Biz
+		e.BizID = txn.BizID
+		e.Ctime = now
+	}
+	return tx.Create(&entries).Error
+}
+
+func NewGORMAccountDAO(db *gorm.DB) AccountDAO {
+	return &GORMAccountDAO{
+		db: db,
+	}
+}
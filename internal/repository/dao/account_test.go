@@ -0,0 +1,124 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMAccountDAO_Transfer(t *testing.T) {
+	txn := AccountTxn{Biz: "reward", BizID: 7}
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		entries []AccountEntry
+		wantErr error
+	}{
+		{
+			name: "written",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `account_txns`").WillReturnResult(sqlmock.NewResult(3, 1))
+				// the accounts are locked in order
+				mock.ExpectExec("INSERT INTO `accounts`").WillReturnResult(sqlmock.NewResult(1, 1))
+				// the payin account is overdrawn
+				mock.ExpectExec("UPDATE `accounts` SET .* WHERE uid = \\? AND type = \\?$").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(0), uint8(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `id` FROM `accounts`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO `accounts`").WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("UPDATE `accounts` SET .* AND balance \\+ \\? >= 0").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(123), uint8(1), int64(500)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT `id` FROM `accounts`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec("INSERT INTO `account_entries`").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+				return db, mock
+			},
+			entries: []AccountEntry{
+				{UID: 123, Type: 1, Amount: 500},
+				{UID: 0, Type: 3, Amount: -500, Overdraft: true},
+			},
+		},
+		{
+			name: "already written",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `account_txns`").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db, mock
+			},
+			entries: []AccountEntry{
+				{UID: 123, Type: 1, Amount: 500},
+				{UID: 0, Type: 3, Amount: -500, Overdraft: true},
+			},
+		},
+		{
+			name: "insufficient balance",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `account_txns`").WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("INSERT INTO `accounts`").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE `accounts`").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(0), uint8(4), int64(-500)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return db, mock
+			},
+			// the withdrawing account without the money
+			entries: []AccountEntry{
+				{UID: 0, Type: 4, Amount: -500},
+				{UID: 0, Type: 5, Amount: 500},
+			},
+			wantErr: ErrInsufficientBalance,
+		},
+		{
+			name: "db error",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `account_txns`").WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return db, mock
+			},
+			entries: []AccountEntry{{UID: 123, Type: 1, Amount: 500}},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock := tc.mock(t)
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sqlDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+
+			dao := NewGORMAccountDAO(db)
+			err = dao.Transfer(context.Background(), txn, tc.entries)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
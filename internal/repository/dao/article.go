@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/chenmuyao/go-bootcamp/internal/domain"
@@ -12,9 +13,22 @@ import (
 
 var ErrArticleNotFound = errors.New("article not found")
 
+// VersionConflictError is returned when the draft has been saved by someone
+// else since the version was read.
+type VersionConflictError struct {
+	// the current version of the draft
+	Version int64
+}
+
+func (e VersionConflictError) Error() string {
+	return fmt.Sprintf("article version conflict, current version %d", e.Version)
+}
+
 //go:generate mockgen -source=./article.go -package=daomocks -destination=./mocks/article.mock.go
 type ArticleDAO interface {
 	Insert(ctx context.Context, article Article) (int64, error)
+	// UpdateByID returns a VersionConflictError if the version of the article
+	// is not the current one, and increases the version otherwise.
 	UpdateByID(ctx context.Context, article Article) error
 	Sync(ctx context.Context, article Article) (int64, error)
 	Transaction(ctx context.Context, fn func(ctx context.Context, tx any) (any, error)) (any, error)
@@ -27,11 +41,40 @@ type ArticleDAO interface {
 		articleID int64,
 		status uint8,
 	) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error)
+	// GetByAuthor and ListPub list the articles after the cursor, sorted by
+	// (utime, id) desc.
+	GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]Article, error)
 	GetByID(ctx context.Context, id int64) (Article, error)
+	// BatchGetByIDs returns the articles of the author among ids, the missing
+	// ones are skipped.
+	BatchGetByIDs(ctx context.Context, uid int64, ids []int64) ([]Article, error)
 	GetPubByID(ctx context.Context, id int64) (PublishedArticle, error)
 	BatchGetPubByIDs(ctx context.Context, ids []int64) ([]PublishedArticle, error)
-	ListPub(ctx context.Context, start time.Time, offset, limit int) ([]PublishedArticle, error)
+	ListPub(ctx context.Context, cursor domain.Cursor, limit int) ([]PublishedArticle, error)
+	GetPubByAuthor(
+		ctx context.Context,
+		uid int64,
+		cursor domain.Cursor,
+		limit int,
+	) ([]PublishedArticle, error)
+
+	// Trash bin, the articles in the trash are ignored by the methods above.
+
+	// DeleteByID moves the article and its published copy to the trash.
+	DeleteByID(ctx context.Context, uid int64, id int64) error
+	RestoreByID(ctx context.Context, uid int64, id int64) error
+	// GetTrashByAuthor lists the trash after the cursor, sorted by
+	// (dtime, id) desc.
+	GetTrashByAuthor(
+		ctx context.Context,
+		uid int64,
+		cursor domain.Cursor,
+		limit int,
+	) ([]Article, error)
+	// PurgeTrash deletes at most limit articles moved to the trash before the
+	// time, with their published copies, and returns the ones deleted, even
+	// if it fails in the middle.
+	PurgeTrash(ctx context.Context, before time.Time, limit int) ([]Article, error)
 }
 
 type GORMArticleDAO struct {
@@ -41,17 +84,43 @@ type GORMArticleDAO struct {
 // ListPub implements ArticleDAO.
 func (a *GORMArticleDAO) ListPub(
 	ctx context.Context,
-	start time.Time,
-	offset int,
+	cursor domain.Cursor,
 	limit int,
 ) ([]PublishedArticle, error) {
-	var article []PublishedArticle
-	err := a.db.WithContext(ctx).
-		Where("utime < ? AND status = ?", start.UnixMilli(), domain.ArticleStatusPublished).
-		Offset(offset).
+	var articles []PublishedArticle
+	err := a.afterCursor(a.db.WithContext(ctx), cursor).
+		Where("status = ? AND dtime = ?", domain.ArticleStatusPublished, 0).
+		Order("utime DESC, id DESC").
 		Limit(limit).
+		Find(&articles).
 		Error
-	return article, err
+	return articles, err
+}
+
+// GetPubByAuthor implements ArticleDAO.
+func (a *GORMArticleDAO) GetPubByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]PublishedArticle, error) {
+	var articles []PublishedArticle
+	err := a.afterCursor(a.db.WithContext(ctx), cursor).
+		Where("author_id = ? AND status = ? AND dtime = ?", uid, domain.ArticleStatusPublished, 0).
+		Order("utime DESC, id DESC").
+		Limit(limit).
+		Find(&articles).
+		Error
+	return articles, err
+}
+
+// afterCursor filters the rows after the cursor in (utime, id) desc order.
+func (a *GORMArticleDAO) afterCursor(db *gorm.DB, cursor domain.Cursor) *gorm.DB {
+	if cursor.IsZero() {
+		return db
+	}
+	utime := cursor.Time.UnixMilli()
+	return db.Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, cursor.ID)
 }
 
 func (a *GORMArticleDAO) BatchGetPubByIDs(
@@ -76,26 +145,58 @@ func (a *GORMArticleDAO) BatchGetPubByIDs(
 // GetPubByID implements ArticleDAO.
 func (a *GORMArticleDAO) GetPubByID(ctx context.Context, id int64) (PublishedArticle, error) {
 	var article PublishedArticle
-	err := a.db.WithContext(ctx).Where("id = ?", id).First(&article).Error
+	err := a.db.WithContext(ctx).Where("id = ? AND dtime = ?", id, 0).First(&article).Error
+	if err == gorm.ErrRecordNotFound {
+		return PublishedArticle{}, ErrArticleNotFound
+	}
 	return article, err
 }
 
 // GetByID implements ArticleDAO.
 func (a *GORMArticleDAO) GetByID(ctx context.Context, id int64) (Article, error) {
 	var article Article
-	err := a.db.WithContext(ctx).Where("id = ?", id).First(&article).Error
+	err := a.db.WithContext(ctx).Where("id = ? AND dtime = ?", id, 0).First(&article).Error
+	if err == gorm.ErrRecordNotFound {
+		return Article{}, ErrArticleNotFound
+	}
 	return article, err
 }
 
+// BatchGetByIDs implements ArticleDAO.
+func (a *GORMArticleDAO) BatchGetByIDs(
+	ctx context.Context,
+	uid int64,
+	ids []int64,
+) ([]Article, error) {
+	var articles []Article
+	err := a.db.WithContext(ctx).
+		Where("id IN ? AND author_id = ? AND dtime = ?", ids, uid, 0).
+		Find(&articles).
+		Error
+	return articles, err
+}
+
 type Article struct {
 	ID      int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
 	Title   string `gorm:"type=varchar(4096)"       bson:"title,omitempty"`
 	Content string `gorm:"type=BLOB"                bson:"content,omitempty"`
+	Places  string `gorm:"type=TEXT"                bson:"places,omitempty"` // JSON array
+	Tags    string `gorm:"type=TEXT"                bson:"tags,omitempty"`   // JSON array
+	// key of the content in the object store, the content is in the Content
+	// column if empty, its abstract otherwise
+	ContentKey string `gorm:"type:varchar(256);not null;default:''" bson:"content_key,omitempty"`
 
-	AuthorID int64 `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8 `             bson:"status,omitempty"`
-	Ctime    int64 `             bson:"ctime,omitempty"`
-	Utime    int64 `             bson:"utime,omitempty"`
+	// NOTE: the InnoDB secondary indexes end with the primary key, so they
+	// cover the (utime, id) keyset pagination.
+	AuthorID int64 `gorm:"index;index:idx_author_utime,priority:1" bson:"author_id,omitempty"`
+	Status   uint8 `gorm:"index:idx_status_utime,priority:1"       bson:"status,omitempty"`
+	Ctime    int64 `                                                bson:"ctime,omitempty"`
+	Utime    int64 `gorm:"index:idx_author_utime,priority:2;index:idx_status_utime,priority:2" bson:"utime,omitempty"`
+	// moved to the trash at, 0 if not in the trash
+	Dtime int64 `gorm:"not null;default:0;index" bson:"dtime,omitempty"`
+	// Version of the draft for the optimistic locking of the edits, it is not
+	// maintained in the published copy.
+	Version int64 `gorm:"not null;default:0" bson:"version,omitempty"`
 }
 
 // same DB, different tables
@@ -105,6 +206,7 @@ func (a *GORMArticleDAO) Insert(ctx context.Context, article Article) (int64, er
 	now := time.Now().UnixMilli()
 	article.Ctime = now
 	article.Utime = now
+	article.Version = 1
 	err := a.db.WithContext(ctx).Create(&article).Error
 	return article.ID, err
 }
@@ -113,22 +215,45 @@ func (a *GORMArticleDAO) UpdateByID(ctx context.Context, article Article) error
 	now := time.Now().UnixMilli()
 	res := a.db.WithContext(ctx).
 		Model(&Article{}).
-		Where("id = ? AND author_id = ?", article.ID, article.AuthorID).
+		Where("id = ? AND author_id = ? AND dtime = ? AND version = ?",
+			article.ID, article.AuthorID, 0, article.Version).
 		Updates(map[string]any{
-			"title":   article.Title,
-			"content": article.Content,
-			"status":  article.Status,
-			"utime":   now,
+			"title":       article.Title,
+			"content":     article.Content,
+			"places":      article.Places,
+			"tags":        article.Tags,
+			"content_key": article.ContentKey,
+			"status":      article.Status,
+			"utime":       now,
+			"version":     gorm.Expr("`version` + 1"), // NOTE: don't forget ``
 		})
 	if res.Error != nil {
 		return res.Error
 	}
 	if res.RowsAffected == 0 {
-		return ErrArticleNotFound
+		return a.updateMissed(ctx, article)
 	}
 	return nil
 }
 
+// updateMissed tells why an update of the draft has no effect.
+func (a *GORMArticleDAO) updateMissed(ctx context.Context, article Article) error {
+	var current Article
+	err := a.db.WithContext(ctx).
+		Select("version").
+		Where("id = ? AND author_id = ? AND dtime = ?", article.ID, article.AuthorID, 0).
+		First(&current).
+		Error
+	switch err {
+	case nil:
+		return VersionConflictError{Version: current.Version}
+	case gorm.ErrRecordNotFound:
+		return ErrArticleNotFound
+	default:
+		return err
+	}
+}
+
 func (a *GORMArticleDAO) UpdateStatusByID(
 	ctx context.Context,
 	model any,
@@ -138,8 +263,8 @@ func (a *GORMArticleDAO) UpdateStatusByID(
 ) error {
 	now := time.Now().UnixMilli()
 	res := a.db.WithContext(ctx).
-		Model(&Article{}).
-		Where("id = ? AND author_id = ?", articleID, userID).
+		Model(model).
+		Where("id = ? AND author_id = ? AND dtime = ?", articleID, userID, 0).
 		Updates(map[string]any{
 			"status": status,
 			"utime":  now,
@@ -232,10 +357,13 @@ func (a *GORMArticleDAO) Upsert(ctx context.Context, article PublishedArticle) e
 	res := a.db.Clauses(clause.OnConflict{
 		Columns: []clause.Column{{Name: "id"}},
 		DoUpdates: clause.Assignments(map[string]interface{}{
-			"title":   article.Title,
-			"content": article.Content,
-			"status":  article.Status,
-			"utime":   article.Utime,
+			"title":       article.Title,
+			"content":     article.Content,
+			"places":      article.Places,
+			"tags":        article.Tags,
+			"content_key": article.ContentKey,
+			"status":      article.Status,
+			"utime":       article.Utime,
 		}),
 	}).Create(&article)
 	if res.Error != nil {
@@ -252,20 +380,109 @@ func (a *GORMArticleDAO) Upsert(ctx context.Context, article PublishedArticle) e
 func (a *GORMArticleDAO) GetByAuthor(
 	ctx context.Context,
 	uid int64,
-	offset int,
+	cursor domain.Cursor,
 	limit int,
 ) ([]Article, error) {
 	var articles []Article
-	err := a.db.WithContext(ctx).
-		Where("author_id = ?", uid).
-		Offset(offset).
+	err := a.afterCursor(a.db.WithContext(ctx), cursor).
+		Where("author_id = ? AND dtime = ?", uid, 0).
+		Order("utime DESC, id DESC").
 		Limit(limit).
-		Order("utime DESC").
 		Find(&articles).
 		Error
 	return articles, err
 }
 
+// DeleteByID implements ArticleDAO.
+func (a *GORMArticleDAO) DeleteByID(ctx context.Context, uid int64, id int64) error {
+	return a.setDtime(ctx, uid, id, time.Now().UnixMilli())
+}
+
+// RestoreByID implements ArticleDAO.
+func (a *GORMArticleDAO) RestoreByID(ctx context.Context, uid int64, id int64) error {
+	return a.setDtime(ctx, uid, id, 0)
+}
+
+// setDtime moves the article in or out of the trash, the published copy
+// follows if it exists.
+func (a *GORMArticleDAO) setDtime(ctx context.Context, uid int64, id int64, dtime int64) error {
+	cond := "id = ? AND author_id = ? AND dtime > ?"
+	if dtime > 0 {
+		cond = "id = ? AND author_id = ? AND dtime = ?"
+	}
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where(cond, id, uid, 0).
+			Update("dtime", dtime)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrArticleNotFound
+		}
+		return tx.Model(&PublishedArticle{}).
+			Where(cond, id, uid, 0).
+			Update("dtime", dtime).
+			Error
+	})
+}
+
+// GetTrashByAuthor implements ArticleDAO.
+func (a *GORMArticleDAO) GetTrashByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]Article, error) {
+	db := a.db.WithContext(ctx)
+	if !cursor.IsZero() {
+		dtime := cursor.Time.UnixMilli()
+		db = db.Where("dtime < ? OR (dtime = ? AND id < ?)", dtime, dtime, cursor.ID)
+	}
+	var articles []Article
+	err := db.Where("author_id = ? AND dtime > ?", uid, 0).
+		Order("dtime DESC, id DESC").
+		Limit(limit).
+		Find(&articles).
+		Error
+	return articles, err
+}
+
+// PurgeTrash implements ArticleDAO.
+func (a *GORMArticleDAO) PurgeTrash(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) ([]Article, error) {
+	var articles []Article
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// NOTE: lock the rows, so that they cannot be restored meanwhile
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("dtime > ? AND dtime < ?", 0, before.UnixMilli()).
+			Order("dtime").
+			Limit(limit).
+			Find(&articles).
+			Error
+		if err != nil || len(articles) == 0 {
+			return err
+		}
+		ids := make([]int64, 0, len(articles))
+		for _, article := range articles {
+			ids = append(ids, article.ID)
+		}
+		err = tx.Where("id IN ?", ids).Delete(&Article{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&PublishedArticle{}).Error
+	})
+	if err != nil {
+		// rolled back, nothing was purged
+		return nil, err
+	}
+	return articles, nil
+}
+
 func NewArticleDAO(db *gorm.DB) ArticleDAO {
 	return &GORMArticleDAO{
 		db: db,
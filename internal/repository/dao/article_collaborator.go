@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=./article_collaborator.go -package=daomocks -destination=./mocks/article_collaborator.mock.go
+type ArticleCollaboratorDAO interface {
+	// Upsert adds a collaborator or changes its role.
+	Upsert(ctx context.Context, c ArticleCollaborator) error
+	Delete(ctx context.Context, aid int64, uid int64) error
+	GetByArticle(ctx context.Context, aid int64) ([]ArticleCollaborator, error)
+	// Approve sets the approval of a reviewer for the version of the draft.
+	Approve(ctx context.Context, aid int64, uid int64, version int64) error
+	// DeleteByArticles is called when the articles are purged.
+	DeleteByArticles(ctx context.Context, aids []int64) error
+}
+
+// ArticleCollaborator is stored in MySQL whatever the article storage is.
+type ArticleCollaborator struct {
+	ID       int64 `gorm:"primaryKey,autoIncrement"`
+	Aid      int64 `gorm:"uniqueIndex:aid_uid"`
+	UID      int64 `gorm:"uniqueIndex:aid_uid"`
+	Role     uint8
+	Approved bool
+	// version of the draft approved, the approval is stale once the draft
+	// is saved again
+	ApprovedVersion int64
+	Ctime           int64
+	Utime           int64
+}
+
+type GORMArticleCollaboratorDAO struct {
+	db *gorm.DB
+}
+
+// Upsert implements ArticleCollaboratorDAO.
+func (g *GORMArticleCollaboratorDAO) Upsert(ctx context.Context, c ArticleCollaborator) error {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"role": c.Role,
+			// a new role has to approve again
+			"approved": false,
+			"utime":    now,
+		}),
+	}).Create(&c).Error
+}
+
+// Delete implements ArticleCollaboratorDAO.
+func (g *GORMArticleCollaboratorDAO) Delete(ctx context.Context, aid int64, uid int64) error {
+	return g.db.WithContext(ctx).
+		Where("aid = ? AND uid = ?", aid, uid).
+		Delete(&ArticleCollaborator{}).
+		Error
+}
+
+// DeleteByArticles implements ArticleCollaboratorDAO.
+func (g *GORMArticleCollaboratorDAO) DeleteByArticles(ctx context.Context, aids []int64) error {
+	return g.db.WithContext(ctx).
+		Where("aid IN ?", aids).
+		Delete(&ArticleCollaborator{}).
+		Error
+}
+
+// GetByArticle implements ArticleCollaboratorDAO.
+func (g *GORMArticleCollaboratorDAO) GetByArticle(
+	ctx context.Context,
+	aid int64,
+) ([]ArticleCollaborator, error) {
+	var res []ArticleCollaborator
+	err := g.db.WithContext(ctx).
+		Where("aid = ?", aid).
+		Order("id").
+		Find(&res).
+		Error
+	return res, err
+}
+
+// Approve implements ArticleCollaboratorDAO.
+func (g *GORMArticleCollaboratorDAO) Approve(
+	ctx context.Context,
+	aid int64,
+	uid int64,
+	version int64,
+) error {
+	return g.db.WithContext(ctx).
+		Model(&ArticleCollaborator{}).
+		Where("aid = ? AND uid = ?", aid, uid).
+		Updates(map[string]any{
+			"approved":         true,
+			"approved_version": version,
+			"utime":            time.Now().UnixMilli(),
+		}).
+		Error
+}
+
+func NewGORMArticleCollaboratorDAO(db *gorm.DB) ArticleCollaboratorDAO {
+	return &GORMArticleCollaboratorDAO{
+		db: db,
+	}
+}
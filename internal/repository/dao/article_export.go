@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrArticleExportNotFound = errors.New("article export not found")
+
+//go:generate mockgen -source=./article_export.go -package=daomocks -destination=./mocks/article_export.mock.go
+type ArticleExportDAO interface {
+	Get(ctx context.Context, uid int64) (ArticleExport, error)
+	// Upsert replaces the previous export of the user.
+	Upsert(ctx context.Context, export ArticleExport) error
+	UpdateStatus(ctx context.Context, uid int64, status uint8, errMsg string) error
+}
+
+// ArticleExport only keeps the latest export of each user.
+type ArticleExport struct {
+	ID     int64 `gorm:"primaryKey,autoIncrement"`
+	UID    int64 `gorm:"uniqueIndex"`
+	Status uint8
+	Err    string `gorm:"type:varchar(1024)"`
+	Ctime  int64
+	Utime  int64
+}
+
+type GORMArticleExportDAO struct {
+	db *gorm.DB
+}
+
+// Get implements ArticleExportDAO.
+func (g *GORMArticleExportDAO) Get(ctx context.Context, uid int64) (ArticleExport, error) {
+	var res ArticleExport
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	if err == gorm.ErrRecordNotFound {
+		return ArticleExport{}, ErrArticleExportNotFound
+	}
+	return res, err
+}
+
+// Upsert implements ArticleExportDAO.
+func (g *GORMArticleExportDAO) Upsert(ctx context.Context, export ArticleExport) error {
+	now := time.Now().UnixMilli()
+	export.Ctime = now
+	export.Utime = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "uid"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"status": export.Status,
+			"err":    export.Err,
+			"ctime":  now,
+			"utime":  now,
+		}),
+	}).Create(&export).Error
+}
+
+// UpdateStatus implements ArticleExportDAO.
+func (g *GORMArticleExportDAO) UpdateStatus(
+	ctx context.Context,
+	uid int64,
+	status uint8,
+	errMsg string,
+) error {
+	res := g.db.WithContext(ctx).
+		Model(&ArticleExport{}).
+		Where("uid = ?", uid).
+		Updates(map[string]any{
+			"status": status,
+			"err":    errMsg,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrArticleExportNotFound
+	}
+	return nil
+}
+
+func NewGORMArticleExportDAO(db *gorm.DB) ArticleExportDAO {
+	return &GORMArticleExportDAO{
+		db: db,
+	}
+}
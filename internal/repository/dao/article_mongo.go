@@ -2,9 +2,11 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/bwmarrin/snowflake"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -16,6 +18,9 @@ const (
 	DatabaseName             = "wetravel"
 )
 
+// the documents out of the trash have no dtime
+var notInTrash = bson.M{"$exists": false}
+
 type MongoDBArticleDAO struct {
 	node     *snowflake.Node
 	coll     *mongo.Collection
@@ -26,11 +31,61 @@ type MongoDBArticleDAO struct {
 // ListPub implements ArticleDAO.
 func (m *MongoDBArticleDAO) ListPub(
 	ctx context.Context,
-	start time.Time,
-	offset int,
+	cursor domain.Cursor,
+	limit int,
+) ([]PublishedArticle, error) {
+	filter := m.afterCursor(bson.M{
+		"status": domain.ArticleStatusPublished,
+		"dtime":  notInTrash,
+	}, cursor)
+	cur, err := m.liveColl.Find(ctx, filter, m.cursorFindOptions(limit))
+	if err != nil {
+		return nil, err
+	}
+	var articles []PublishedArticle
+	err = cur.All(ctx, &articles)
+	return articles, err
+}
+
+// GetPubByAuthor implements ArticleDAO.
+func (m *MongoDBArticleDAO) GetPubByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
 	limit int,
 ) ([]PublishedArticle, error) {
-	panic("unimplemented")
+	filter := m.afterCursor(bson.M{
+		"author_id": uid,
+		"status":    domain.ArticleStatusPublished,
+		"dtime":     notInTrash,
+	}, cursor)
+	cur, err := m.liveColl.Find(ctx, filter, m.cursorFindOptions(limit))
+	if err != nil {
+		return nil, err
+	}
+	var articles []PublishedArticle
+	err = cur.All(ctx, &articles)
+	return articles, err
+}
+
+// afterCursor adds the filter of the documents after the cursor in
+// (utime, id) desc order.
+func (m *MongoDBArticleDAO) afterCursor(filter bson.M, cursor domain.Cursor) bson.M {
+	if cursor.IsZero() {
+		return filter
+	}
+	utime := cursor.Time.UnixMilli()
+	filter["$or"] = bson.A{
+		bson.M{"utime": bson.M{"$lt": utime}},
+		bson.M{"utime": utime, "id": bson.M{"$lt": cursor.ID}},
+	}
+	return filter
+}
+
+func (m *MongoDBArticleDAO) cursorFindOptions(limit int) *options.FindOptionsBuilder {
+	return options.Find().
+		SetSort(bson.D{{Key: "utime", Value: -1}, {Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
 }
 
 // BatchGetPubByIDs implements ArticleDAO.
@@ -38,27 +93,83 @@ func (m *MongoDBArticleDAO) BatchGetPubByIDs(
 	ctx context.Context,
 	ids []int64,
 ) ([]PublishedArticle, error) {
-	panic("unimplemented")
+	cursor, err := m.liveColl.Find(ctx, bson.M{"id": bson.M{"$in": ids}, "dtime": notInTrash})
+	if err != nil {
+		return nil, err
+	}
+	var articles []PublishedArticle
+	err = cursor.All(ctx, &articles)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: keep the order of ids, like the GORM version
+	articleMap := make(map[int64]PublishedArticle, len(articles))
+	for _, a := range articles {
+		articleMap[a.ID] = a
+	}
+	res := make([]PublishedArticle, 0, len(ids))
+	for _, id := range ids {
+		a, ok := articleMap[id]
+		if !ok {
+			return nil, ErrArticleNotFound
+		}
+		res = append(res, a)
+	}
+	return res, nil
 }
 
 // GetPubByID implements ArticleDAO.
 func (m *MongoDBArticleDAO) GetPubByID(ctx context.Context, id int64) (PublishedArticle, error) {
-	panic("unimplemented")
+	var article PublishedArticle
+	err := m.liveColl.FindOne(ctx, bson.M{"id": id, "dtime": notInTrash}).Decode(&article)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return PublishedArticle{}, ErrArticleNotFound
+	}
+	return article, err
 }
 
 // GetByID implements ArticleDAO.
 func (m *MongoDBArticleDAO) GetByID(ctx context.Context, id int64) (Article, error) {
-	panic("unimplemented")
+	var article Article
+	err := m.coll.FindOne(ctx, bson.M{"id": id, "dtime": notInTrash}).Decode(&article)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Article{}, ErrArticleNotFound
+	}
+	return article, err
+}
+
+// BatchGetByIDs implements ArticleDAO.
+func (m *MongoDBArticleDAO) BatchGetByIDs(
+	ctx context.Context,
+	uid int64,
+	ids []int64,
+) ([]Article, error) {
+	filter := bson.M{"id": bson.M{"$in": ids}, "author_id": uid, "dtime": notInTrash}
+	cur, err := m.coll.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	err = cur.All(ctx, &articles)
+	return articles, err
 }
 
 // GetByAuthor implements ArticleDAO.
 func (m *MongoDBArticleDAO) GetByAuthor(
 	ctx context.Context,
 	uid int64,
-	offset int,
+	cursor domain.Cursor,
 	limit int,
 ) ([]Article, error) {
-	panic("unimplemented")
+	filter := m.afterCursor(bson.M{"author_id": uid, "dtime": notInTrash}, cursor)
+	cur, err := m.coll.Find(ctx, filter, m.cursorFindOptions(limit))
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	err = cur.All(ctx, &articles)
+	return articles, err
 }
 
 // Insert implements ArticleDAO.
@@ -67,6 +178,7 @@ func (m *MongoDBArticleDAO) Insert(ctx context.Context, article Article) (int64,
 	now := time.Now().UnixMilli()
 	article.Ctime = now
 	article.Utime = now
+	article.Version = 1
 	_, err := m.coll.InsertOne(ctx, &article)
 	return article.ID, err
 }
@@ -83,7 +195,7 @@ func (m *MongoDBArticleDAO) Sync(ctx context.Context, article Article) (int64, e
 		article.ID = id
 	}
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
 	// liveColl upsert
@@ -92,6 +204,7 @@ func (m *MongoDBArticleDAO) Sync(ctx context.Context, article Article) (int64, e
 }
 
 // Transaction implements ArticleDAO.
+// NOTE: MongoDB transactions need a replica set.
 func (m *MongoDBArticleDAO) Transaction(
 	ctx context.Context,
 	fn func(ctx context.Context, tx any) (any, error),
@@ -104,10 +217,10 @@ func (m *MongoDBArticleDAO) Transaction(
 		sess.EndSession(ctx)
 	}()
 
-	_, err = sess.WithTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+	// the operations using the session context are in the transaction
+	return sess.WithTransaction(ctx, func(ctx context.Context) (any, error) {
 		return fn(ctx, m)
 	})
-	return nil, err
 }
 
 // UpdateByID implements ArticleDAO.
@@ -116,23 +229,43 @@ func (m *MongoDBArticleDAO) UpdateByID(ctx context.Context, article Article) err
 	filter := bson.M{
 		"id":        article.ID,
 		"author_id": article.AuthorID,
+		"dtime":     notInTrash,
+	}
+	versionFilter := bson.M{"version": article.Version}
+	if article.Version == 0 {
+		// NOTE: the version is omitted when it is 0, null matches the
+		// documents without the field.
+		versionFilter = bson.M{"version": bson.M{"$in": bson.A{0, nil}}}
 	}
 	set := bson.M{
 		"$set": bson.M{
-			"title":   article.Title,
-			"content": article.Content,
-			"status":  article.Status,
-			"utime":   now,
+			"title":       article.Title,
+			"content":     article.Content,
+			"places":      article.Places,
+			"tags":        article.Tags,
+			"content_key": article.ContentKey,
+			"status":      article.Status,
+			"utime":       now,
 		},
+		"$inc": bson.M{"version": 1},
 	}
-	res, err := m.coll.UpdateOne(ctx, filter, set)
+	res, err := m.coll.UpdateOne(ctx, bson.M{"$and": bson.A{filter, versionFilter}}, set)
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount == 0 {
+	if res.MatchedCount > 0 {
+		return nil
+	}
+	var current Article
+	err = m.coll.FindOne(ctx, filter).Decode(&current)
+	switch {
+	case err == nil:
+		return VersionConflictError{Version: current.Version}
+	case errors.Is(err, mongo.ErrNoDocuments):
 		return ErrArticleNotFound
+	default:
+		return err
 	}
-	return nil
 }
 
 // UpdateStatusByID implements ArticleDAO.
@@ -143,19 +276,49 @@ func (m *MongoDBArticleDAO) UpdateStatusByID(
 	articleID int64,
 	status uint8,
 ) error {
-	panic("unimplemented")
+	coll, err := m.collOf(model)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	filter := bson.M{
+		"id":        articleID,
+		"author_id": userID,
+		"dtime":     notInTrash,
+	}
+	set := bson.M{
+		"$set": bson.M{
+			"status": status,
+			"utime":  now,
+		},
+	}
+	res, err := coll.UpdateOne(ctx, filter, set)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotFound
+	}
+	return nil
 }
 
 // Upsert implements ArticleDAO.
 func (m *MongoDBArticleDAO) Upsert(ctx context.Context, article PublishedArticle) error {
 	now := time.Now().UnixMilli()
-	article.Utime = now
 	filter := bson.M{
 		"id":        article.ID,
 		"author_id": article.AuthorID,
 	}
 	set := bson.M{
-		"$set": article,
+		"$set": bson.M{
+			"title":       article.Title,
+			"content":     article.Content,
+			"places":      article.Places,
+			"tags":        article.Tags,
+			"content_key": article.ContentKey,
+			"status":      article.Status,
+			"utime":       now,
+		},
 		// set new attribut on onsert
 		"$setOnInsert": bson.M{
 			"ctime": now,
@@ -164,14 +327,137 @@ func (m *MongoDBArticleDAO) Upsert(ctx context.Context, article PublishedArticle
 
 	res, err := m.liveColl.UpdateOne(ctx, filter, set, options.UpdateOne().SetUpsert(true))
 	if err != nil {
+		// NOTE: the id exists with another author_id
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrArticleNotFound
+		}
 		return err
 	}
-	if res.ModifiedCount == 0 {
+	if res.MatchedCount == 0 && res.UpsertedCount == 0 {
 		return ErrArticleNotFound
 	}
 	return nil
 }
 
+// DeleteByID implements ArticleDAO.
+func (m *MongoDBArticleDAO) DeleteByID(ctx context.Context, uid int64, id int64) error {
+	filter := bson.M{
+		"id":        id,
+		"author_id": uid,
+		"dtime":     notInTrash,
+	}
+	set := bson.M{"$set": bson.M{"dtime": time.Now().UnixMilli()}}
+	return m.updateTrash(ctx, filter, set)
+}
+
+// RestoreByID implements ArticleDAO.
+func (m *MongoDBArticleDAO) RestoreByID(ctx context.Context, uid int64, id int64) error {
+	filter := bson.M{
+		"id":        id,
+		"author_id": uid,
+		"dtime":     bson.M{"$exists": true},
+	}
+	set := bson.M{"$unset": bson.M{"dtime": ""}}
+	return m.updateTrash(ctx, filter, set)
+}
+
+// updateTrash updates the article, then its published copy if it exists.
+// NOTE: not in a transaction, which needs a replica set.
+func (m *MongoDBArticleDAO) updateTrash(ctx context.Context, filter bson.M, set bson.M) error {
+	res, err := m.coll.UpdateOne(ctx, filter, set)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotFound
+	}
+	_, err = m.liveColl.UpdateOne(ctx, filter, set)
+	return err
+}
+
+// GetTrashByAuthor implements ArticleDAO.
+func (m *MongoDBArticleDAO) GetTrashByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]Article, error) {
+	filter := bson.M{
+		"author_id": uid,
+		"dtime":     bson.M{"$exists": true},
+	}
+	if !cursor.IsZero() {
+		dtime := cursor.Time.UnixMilli()
+		filter["$or"] = bson.A{
+			bson.M{"dtime": bson.M{"$lt": dtime}},
+			bson.M{"dtime": dtime, "id": bson.M{"$lt": cursor.ID}},
+		}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "dtime", Value: -1}, {Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cur, err := m.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	err = cur.All(ctx, &articles)
+	return articles, err
+}
+
+// PurgeTrash implements ArticleDAO.
+func (m *MongoDBArticleDAO) PurgeTrash(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) ([]Article, error) {
+	inTrash := bson.M{"$exists": true, "$lt": before.UnixMilli()}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "dtime", Value: 1}}).
+		SetLimit(int64(limit))
+	cur, err := m.coll.Find(ctx, bson.M{"dtime": inTrash}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	err = cur.All(ctx, &articles)
+	if err != nil || len(articles) == 0 {
+		return articles, err
+	}
+	// NOTE: one by one and keep the dtime in the filters, the articles
+	// restored meanwhile are neither deleted nor returned.
+	res := make([]Article, 0, len(articles))
+	for _, article := range articles {
+		filter := bson.M{"id": article.ID, "dtime": inTrash}
+		dr, err := m.coll.DeleteOne(ctx, filter)
+		if err != nil {
+			return res, err
+		}
+		if dr.DeletedCount == 0 {
+			continue
+		}
+		_, err = m.liveColl.DeleteOne(ctx, filter)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, article)
+	}
+	return res, nil
+}
+
+// collOf returns the collection of the model, like &Article{} or
+// &PublishedArticle{}.
+func (m *MongoDBArticleDAO) collOf(model any) (*mongo.Collection, error) {
+	switch model.(type) {
+	case *Article:
+		return m.coll, nil
+	case *PublishedArticle:
+		return m.liveColl, nil
+	default:
+		return nil, errors.New("unknown article model")
+	}
+}
+
 func NewMongoDBArticleDAO(client *mongo.Client, node *snowflake.Node) ArticleDAO {
 	return &MongoDBArticleDAO{
 		node:     node,
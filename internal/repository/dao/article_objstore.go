@@ -0,0 +1,373 @@
+package dao
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/pkg/objstore"
+	"gorm.io/gorm"
+)
+
+// objects to get concurrently for the batch reads
+const articleContentConcurrency = 10
+
+// ObjStoreArticleDAO keeps the metadata of the articles in the DB, and the
+// contents in an object store. A content is addressed by the article ID and
+// its revision, which is the hash of the content, so that the author and the
+// reader tables share the same object.
+//
+// The contents are fetched lazily: only the detail reads get them from the
+// store, the lists return the abstract kept in the Content column of the
+// rows. The rows with an empty ContentKey still have their content in the
+// DB, they can be moved with MigrateContent without downtime.
+//
+// NOTE: Old revisions are not deleted, the lifecycle rules of the bucket are
+// supposed to expire them.
+type ObjStoreArticleDAO struct {
+	db    *gorm.DB
+	meta  ArticleDAO
+	store objstore.Store
+}
+
+// Insert implements ArticleDAO.
+func (o *ObjStoreArticleDAO) Insert(ctx context.Context, article Article) (int64, error) {
+	var id int64
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		article, err = o.insert(ctx, tx, article)
+		id = article.ID
+		return err
+	})
+	return id, err
+}
+
+// insert needs the ID to put the content, so it is done in 2 steps.
+func (o *ObjStoreArticleDAO) insert(
+	ctx context.Context,
+	tx *gorm.DB,
+	article Article,
+) (Article, error) {
+	content := article.Content
+	article.Content = ""
+	id, err := NewArticleDAO(tx).Insert(ctx, article)
+	if err != nil {
+		return Article{}, err
+	}
+	article.ID = id
+	if content == "" {
+		return article, nil
+	}
+	article.Content = content
+	err = o.putContent(ctx, &article)
+	if err != nil {
+		return Article{}, err
+	}
+	// NOTE: not an edit, the version stays the same.
+	return article, tx.WithContext(ctx).
+		Model(&Article{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"content":     article.Content,
+			"content_key": article.ContentKey,
+		}).
+		Error
+}
+
+// UpdateByID implements ArticleDAO.
+func (o *ObjStoreArticleDAO) UpdateByID(ctx context.Context, article Article) error {
+	// NOTE: put the content first, so that a row never refers to a missing
+	// object.
+	err := o.putContent(ctx, &article)
+	if err != nil {
+		return err
+	}
+	return o.meta.UpdateByID(ctx, article)
+}
+
+// Sync implements ArticleDAO.
+func (o *ObjStoreArticleDAO) Sync(ctx context.Context, article Article) (int64, error) {
+	id := article.ID
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txDAO := NewArticleDAO(tx)
+		var err error
+		if id > 0 {
+			err = o.putContent(ctx, &article)
+			if err != nil {
+				return err
+			}
+			err = txDAO.UpdateByID(ctx, article)
+		} else {
+			article, err = o.insert(ctx, tx, article)
+			id = article.ID
+		}
+		if err != nil {
+			return err
+		}
+		return txDAO.Upsert(ctx, PublishedArticle(article))
+	})
+	return id, err
+}
+
+// Transaction implements ArticleDAO.
+func (o *ObjStoreArticleDAO) Transaction(
+	ctx context.Context,
+	fn func(ctx context.Context, tx any) (any, error),
+) (any, error) {
+	var ret any
+	var err error
+	err = o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txDAO := NewObjStoreArticleDAO(tx, o.store)
+		ret, err = fn(ctx, txDAO)
+		return err
+	})
+	return ret, err
+}
+
+// Upsert implements ArticleDAO.
+func (o *ObjStoreArticleDAO) Upsert(ctx context.Context, article PublishedArticle) error {
+	err := o.putContent(ctx, (*Article)(&article))
+	if err != nil {
+		return err
+	}
+	return o.meta.Upsert(ctx, article)
+}
+
+// UpdateStatusByID implements ArticleDAO.
+func (o *ObjStoreArticleDAO) UpdateStatusByID(
+	ctx context.Context,
+	model any,
+	userID int64,
+	articleID int64,
+	status uint8,
+) error {
+	return o.meta.UpdateStatusByID(ctx, model, userID, articleID, status)
+}
+
+// GetByAuthor implements ArticleDAO.
+func (o *ObjStoreArticleDAO) GetByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]Article, error) {
+	return o.meta.GetByAuthor(ctx, uid, cursor, limit)
+}
+
+// GetByID implements ArticleDAO.
+func (o *ObjStoreArticleDAO) GetByID(ctx context.Context, id int64) (Article, error) {
+	article, err := o.meta.GetByID(ctx, id)
+	if err != nil {
+		return Article{}, err
+	}
+	return article, o.fillContents(ctx, []*Article{&article})
+}
+
+// BatchGetByIDs implements ArticleDAO.
+func (o *ObjStoreArticleDAO) BatchGetByIDs(
+	ctx context.Context,
+	uid int64,
+	ids []int64,
+) ([]Article, error) {
+	articles, err := o.meta.BatchGetByIDs(ctx, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	ptrs := make([]*Article, len(articles))
+	for i := range articles {
+		ptrs[i] = &articles[i]
+	}
+	return articles, o.fillContents(ctx, ptrs)
+}
+
+// GetPubByID implements ArticleDAO.
+func (o *ObjStoreArticleDAO) GetPubByID(ctx context.Context, id int64) (PublishedArticle, error) {
+	article, err := o.meta.GetPubByID(ctx, id)
+	if err != nil {
+		return PublishedArticle{}, err
+	}
+	return article, o.fillContents(ctx, []*Article{(*Article)(&article)})
+}
+
+// BatchGetPubByIDs implements ArticleDAO.
+// NOTE: the articles are cached as the detail reads, so they are complete.
+func (o *ObjStoreArticleDAO) BatchGetPubByIDs(
+	ctx context.Context,
+	ids []int64,
+) ([]PublishedArticle, error) {
+	articles, err := o.meta.BatchGetPubByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return articles, o.fillPubContents(ctx, articles)
+}
+
+// ListPub implements ArticleDAO.
+func (o *ObjStoreArticleDAO) ListPub(
+	ctx context.Context,
+	cursor domain.Cursor,
+	limit int,
+) ([]PublishedArticle, error) {
+	return o.meta.ListPub(ctx, cursor, limit)
+}
+
+// GetPubByAuthor implements ArticleDAO.
+func (o *ObjStoreArticleDAO) GetPubByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]PublishedArticle, error) {
+	return o.meta.GetPubByAuthor(ctx, uid, cursor, limit)
+}
+
+// DeleteByID implements ArticleDAO.
+func (o *ObjStoreArticleDAO) DeleteByID(ctx context.Context, uid int64, id int64) error {
+	return o.meta.DeleteByID(ctx, uid, id)
+}
+
+// RestoreByID implements ArticleDAO.
+func (o *ObjStoreArticleDAO) RestoreByID(ctx context.Context, uid int64, id int64) error {
+	return o.meta.RestoreByID(ctx, uid, id)
+}
+
+// GetTrashByAuthor implements ArticleDAO.
+func (o *ObjStoreArticleDAO) GetTrashByAuthor(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]Article, error) {
+	return o.meta.GetTrashByAuthor(ctx, uid, cursor, limit)
+}
+
+// PurgeTrash implements ArticleDAO.
+// NOTE: the contents are left to the lifecycle rules of the bucket too.
+func (o *ObjStoreArticleDAO) PurgeTrash(
+	ctx context.Context,
+	before time.Time,
+	limit int,
+) ([]Article, error) {
+	return o.meta.PurgeTrash(ctx, before, limit)
+}
+
+// MigrateContent moves at most batchSize contents of each table from the DB to
+// the object store, and returns the number of rows handled. It is done when
+// it returns 0.
+func (o *ObjStoreArticleDAO) MigrateContent(ctx context.Context, batchSize int) (int, error) {
+	cnt := 0
+	for _, model := range []any{&Article{}, &PublishedArticle{}} {
+		n, err := o.migrateContent(ctx, model, batchSize)
+		cnt += n
+		if err != nil {
+			return cnt, err
+		}
+	}
+	return cnt, nil
+}
+
+func (o *ObjStoreArticleDAO) migrateContent(
+	ctx context.Context,
+	model any,
+	batchSize int,
+) (int, error) {
+	var articles []Article
+	err := o.db.WithContext(ctx).
+		Model(model).
+		Select("id", "content").
+		Where("(content_key IS NULL OR content_key = ?) AND content <> ?", "", "").
+		Order("id").
+		Limit(batchSize).
+		Find(&articles).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	for _, article := range articles {
+		err = o.putContent(ctx, &article)
+		if err != nil {
+			return 0, err
+		}
+		// NOTE: The writes during the migration go through this DAO and set
+		// the content key, so a row with a content key is never overwritten.
+		err = o.db.WithContext(ctx).
+			Model(model).
+			Where("id = ? AND (content_key IS NULL OR content_key = ?)", article.ID, "").
+			Updates(map[string]any{
+				"content":     article.Content,
+				"content_key": article.ContentKey,
+			}).
+			Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(articles), nil
+}
+
+// putContent puts the content into the object store, and replaces it with
+// its key and its abstract in the article.
+func (o *ObjStoreArticleDAO) putContent(ctx context.Context, article *Article) error {
+	if article.Content == "" || article.ContentKey != "" {
+		// nothing to store, or already stored
+		return nil
+	}
+	key := articleContentKey(article.ID, article.Content)
+	err := o.store.Put(ctx, key, []byte(article.Content))
+	if err != nil {
+		return err
+	}
+	article.Content = domain.Article{Content: article.Content}.Abstract()
+	article.ContentKey = key
+	return nil
+}
+
+func (o *ObjStoreArticleDAO) fillPubContents(
+	ctx context.Context,
+	articles []PublishedArticle,
+) error {
+	ptrs := make([]*Article, len(articles))
+	for i := range articles {
+		ptrs[i] = (*Article)(&articles[i])
+	}
+	return o.fillContents(ctx, ptrs)
+}
+
+// fillContents gets the contents of the articles having a content key.
+func (o *ObjStoreArticleDAO) fillContents(ctx context.Context, articles []*Article) error {
+	keys := make([]string, 0, len(articles))
+	targets := make([]*Article, 0, len(articles))
+	for _, article := range articles {
+		if article.ContentKey != "" {
+			keys = append(keys, article.ContentKey)
+			targets = append(targets, article)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	contents, err := objstore.BatchGet(ctx, o.store, keys, articleContentConcurrency)
+	if err != nil {
+		return err
+	}
+	for i, article := range targets {
+		article.Content = string(contents[i])
+	}
+	return nil
+}
+
+func articleContentKey(id int64, content string) string {
+	return fmt.Sprintf("articles/%d/%x", id, sha256.Sum256([]byte(content)))
+}
+
+func NewObjStoreArticleDAO(db *gorm.DB, store objstore.Store) ArticleDAO {
+	return &ObjStoreArticleDAO{
+		db:    db,
+		meta:  NewArticleDAO(db),
+		store: store,
+	}
+}
+
+var _ ArticleDAO = &ObjStoreArticleDAO{}
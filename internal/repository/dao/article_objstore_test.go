@@ -0,0 +1,170 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/pkg/objstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestObjStoreArticleDAO_GetByAuthor(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "content_key", "author_id"}).
+		AddRow(1, "migrated", "abstract", "articles/1/abc", 123).
+		AddRow(2, "not migrated", "in db", "", 123)
+	mock.ExpectQuery("SELECT .* FROM `articles`").WillReturnRows(rows)
+	// the lists don't fetch the contents, the store is empty
+	store, err := objstore.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	dao := NewObjStoreArticleDAO(newMockGORM(t, sqlDB), store)
+
+	articles, err := dao.GetByAuthor(context.Background(), 123, domain.Cursor{}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Article{
+		{
+			ID:         1,
+			Title:      "migrated",
+			Content:    "abstract",
+			ContentKey: "articles/1/abc",
+			AuthorID:   123,
+		},
+		{
+			ID:       2,
+			Title:    "not migrated",
+			Content:  "in db",
+			AuthorID: 123,
+		},
+	}, articles)
+}
+
+func TestObjStoreArticleDAO_GetByID(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(t *testing.T) *sql.DB
+		before func(t *testing.T, store objstore.Store)
+
+		wantErr     error
+		wantArticle Article
+	}{
+		{
+			name: "content from the store",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				rows := sqlmock.NewRows([]string{"id", "title", "content", "content_key"}).
+					AddRow(1, "migrated", "abstract", "articles/1/abc")
+				mock.ExpectQuery("SELECT .* FROM `articles`").WillReturnRows(rows)
+				return db
+			},
+			before: func(t *testing.T, store objstore.Store) {
+				err := store.Put(context.Background(), "articles/1/abc", []byte("in store"))
+				require.NoError(t, err)
+			},
+			wantArticle: Article{
+				ID:         1,
+				Title:      "migrated",
+				Content:    "in store",
+				ContentKey: "articles/1/abc",
+			},
+		},
+		{
+			name: "content from the DB",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				rows := sqlmock.NewRows([]string{"id", "title", "content", "content_key"}).
+					AddRow(1, "not migrated", "in db", "")
+				mock.ExpectQuery("SELECT .* FROM `articles`").WillReturnRows(rows)
+				return db
+			},
+			before: func(t *testing.T, store objstore.Store) {},
+			wantArticle: Article{
+				ID:      1,
+				Title:   "not migrated",
+				Content: "in db",
+			},
+		},
+		{
+			name: "missing object",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				rows := sqlmock.NewRows([]string{"id", "content_key"}).
+					AddRow(1, "articles/1/abc")
+				mock.ExpectQuery("SELECT .* FROM `articles`").WillReturnRows(rows)
+				return db
+			},
+			before:  func(t *testing.T, store objstore.Store) {},
+			wantErr: objstore.ErrObjectNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, err := objstore.NewLocalStore(t.TempDir())
+			require.NoError(t, err)
+			tc.before(t, store)
+			dao := NewObjStoreArticleDAO(newMockGORM(t, tc.mock(t)), store)
+
+			article, err := dao.GetByID(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			if err == nil {
+				assert.Equal(t, tc.wantArticle, article)
+			}
+		})
+	}
+}
+
+func TestObjStoreArticleDAO_MigrateContent(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	key := articleContentKey(1, "my content")
+	// the legacy rows have a NULL content key
+	mock.ExpectQuery("SELECT `id`,`content` FROM `articles` WHERE " +
+		regexp.QuoteMeta("(content_key IS NULL OR content_key = ?)")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content"}).AddRow(1, "my content"))
+	mock.ExpectExec("UPDATE `articles`").
+		WithArgs("my content", key, 1, "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT `id`,`content` FROM `published_articles`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content"}))
+
+	store, err := objstore.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	dao := &ObjStoreArticleDAO{
+		db:    newMockGORM(t, sqlDB),
+		store: store,
+	}
+
+	n, err := dao.MigrateContent(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	content, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, "my content", string(content))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockGORM(t *testing.T, sqlDB *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn: sqlDB,
+		// mock has no version
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		// mock doesn't respond to ping
+		DisableAutomaticPing: true,
+		// use plein commands, don't add bigin-commit automatically
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
@@ -0,0 +1,274 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGORMArticleDAO_ListPub(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(t *testing.T) *sql.DB
+		cursor domain.Cursor
+
+		wantErr      error
+		wantArticles []PublishedArticle
+	}{
+		{
+			name: "first page",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				rows := sqlmock.NewRows([]string{"id", "utime"}).
+					AddRow(2, 790).
+					AddRow(1, 789)
+				mock.ExpectQuery("SELECT \\* FROM `published_articles` "+
+					"WHERE status = \\? AND dtime = \\? "+
+					"ORDER BY utime DESC, id DESC LIMIT \\?").
+					WithArgs(domain.ArticleStatusPublished, 0, 2).
+					WillReturnRows(rows)
+				return db
+			},
+			wantArticles: []PublishedArticle{
+				{ID: 2, Utime: 790},
+				{ID: 1, Utime: 789},
+			},
+		},
+		{
+			name: "after cursor",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				rows := sqlmock.NewRows([]string{"id", "utime"}).
+					AddRow(1, 789)
+				mock.ExpectQuery("SELECT \\* FROM `published_articles` "+
+					"WHERE \\(utime < \\? OR \\(utime = \\? AND id < \\?\\)\\) "+
+					"AND \\(status = \\? AND dtime = \\?\\) "+
+					"ORDER BY utime DESC, id DESC LIMIT \\?").
+					WithArgs(790, 790, 2, domain.ArticleStatusPublished, 0, 2).
+					WillReturnRows(rows)
+				return db
+			},
+			cursor: domain.Cursor{
+				Time: time.UnixMilli(790),
+				ID:   2,
+			},
+			wantArticles: []PublishedArticle{
+				{ID: 1, Utime: 789},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewArticleDAO(newMockGORM(t, tc.mock(t)))
+
+			articles, err := dao.ListPub(context.Background(), tc.cursor, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArticles, articles)
+		})
+	}
+}
+
+func TestGORMArticleDAO_DeleteByID(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantErr error
+	}{
+		{
+			name: "moved to the trash with the published copy",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET `dtime`=\\? "+
+					"WHERE id = \\? AND author_id = \\? AND dtime = \\?").
+					WithArgs(sqlmock.AnyArg(), 1, 123, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `published_articles` SET `dtime`=\\? "+
+					"WHERE id = \\? AND author_id = \\? AND dtime = \\?").
+					WithArgs(sqlmock.AnyArg(), 1, 123, 0).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "not the author or already deleted",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET `dtime`=\\? "+
+					"WHERE id = \\? AND author_id = \\? AND dtime = \\?").
+					WithArgs(sqlmock.AnyArg(), 1, 123, 0).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: ErrArticleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewArticleDAO(newMockGORM(t, tc.mock(t)))
+
+			err := dao.DeleteByID(context.Background(), 123, 1)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMArticleDAO_PurgeTrash(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		wantIDs []int64
+		wantErr error
+	}{
+		{
+			name: "purged",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `articles` WHERE dtime > \\? AND dtime < \\? " +
+					"ORDER BY dtime LIMIT \\? FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectExec("DELETE FROM `articles`").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM `published_articles`").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+				return db
+			},
+			wantIDs: []int64{1, 2},
+		},
+		{
+			name: "rolled back",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectExec("DELETE FROM `articles`").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM `published_articles`").
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewArticleDAO(newMockGORM(t, tc.mock(t)))
+
+			articles, err := dao.PurgeTrash(context.Background(), time.Now(), 10)
+			assert.Equal(t, tc.wantErr, err)
+			var ids []int64
+			for _, article := range articles {
+				ids = append(ids, article.ID)
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+		})
+	}
+}
+
+func TestGORMArticleDAO_UpdateByID(t *testing.T) {
+	updateSQL := "UPDATE `articles` SET `content`=\\?,`content_key`=\\?,`places`=\\?," +
+		"`status`=\\?,`tags`=\\?,`title`=\\?,`utime`=\\?,`version`=`version` \\+ 1 " +
+		"WHERE id = \\? AND author_id = \\? AND dtime = \\? AND version = \\?"
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		version int64
+
+		wantErr error
+	}{
+		{
+			name: "updated",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectExec(updateSQL).
+					WithArgs("new content", "", "", 0, "", "new title", sqlmock.AnyArg(), 1, 123, 0, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db
+			},
+			version: 3,
+		},
+		{
+			// written before the versioning, its version was backfilled to 0
+			name: "pre-existing article",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectExec(updateSQL).
+					WithArgs("new content", "", "", 0, "", "new title", sqlmock.AnyArg(), 1, 123, 0, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db
+			},
+			version: 0,
+		},
+		{
+			name: "stale version",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectExec(updateSQL).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT `version` FROM `articles` "+
+					"WHERE id = \\? AND author_id = \\? AND dtime = \\?").
+					WithArgs(1, 123, 0, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+				return db
+			},
+			version: 3,
+			wantErr: VersionConflictError{Version: 4},
+		},
+		{
+			name: "not found",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				mock.ExpectExec(updateSQL).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT `version` FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}))
+				return db
+			},
+			version: 3,
+			wantErr: ErrArticleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := NewArticleDAO(newMockGORM(t, tc.mock(t)))
+
+			err := dao.UpdateByID(context.Background(), Article{
+				ID:       1,
+				Title:    "new title",
+				Content:  "new content",
+				AuthorID: 123,
+				Version:  tc.version,
+			})
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -11,16 +11,53 @@ import (
 )
 
 func InitTable(db *gorm.DB) error {
+	// NOTE: the columns first added as nullable get their default before
+	// being migrated to NOT NULL.
+	for _, model := range []any{&Article{}, &PublishedArticle{}} {
+		err := backfillNull(db, model, "content_key", "")
+		if err != nil {
+			return err
+		}
+		err = backfillNull(db, model, "dtime", 0)
+		if err != nil {
+			return err
+		}
+		// NOTE: the articles written before the versioning start at 0
+		err = backfillNull(db, model, "version", 0)
+		if err != nil {
+			return err
+		}
+	}
 	// NOTE: Not the best practice. Too risky. Strong dependency
 	return db.AutoMigrate(
 		&User{},
 		&SMSInfo{},
 		&Article{},
 		&PublishedArticle{},
+		&ArticleCollaborator{},
+		&Itinerary{},
+		&PublishedItinerary{},
 		&Job{},
+		&ArticleExport{},
+		&Reward{},
+		&Account{},
+		&AccountTxn{},
+		&AccountEntry{},
+		&Withdrawal{},
 	)
 }
 
+// backfillNull sets the NULL values of the column, if it exists, to the value.
+func backfillNull(db *gorm.DB, model any, column string, value any) error {
+	if !db.Migrator().HasColumn(model, column) {
+		return nil
+	}
+	return db.Model(model).
+		Where(column+" IS NULL").
+		UpdateColumn(column, value).
+		Error
+}
+
 func InitCollection(mdb *mongo.Database) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -32,7 +69,12 @@ func InitCollection(mdb *mongo.Database) error {
 			Options: options.Index().SetUnique(true),
 		},
 		{
-			Keys: bson.M{"author_id": 1},
+			// GetByAuthor
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "utime", Value: -1},
+				{Key: "id", Value: -1},
+			},
 		},
 	})
 	if err != nil {
@@ -46,7 +88,20 @@ func InitCollection(mdb *mongo.Database) error {
 			Options: options.Index().SetUnique(true),
 		},
 		{
-			Keys: bson.M{"author_id": 1},
+			// GetPubByAuthor
+			Keys: bson.D{
+				{Key: "author_id", Value: 1},
+				{Key: "utime", Value: -1},
+				{Key: "id", Value: -1},
+			},
+		},
+		{
+			// ListPub
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "utime", Value: -1},
+				{Key: "id", Value: -1},
+			},
 		},
 	})
 	if err != nil {
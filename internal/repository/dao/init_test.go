@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillNull(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(mock sqlmock.Sqlmock)
+		column string
+		value  any
+	}{
+		{
+			name: "legacy column",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT DATABASE()").
+					WillReturnRows(sqlmock.NewRows([]string{"database()"}).AddRow("webook"))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM INFORMATION_SCHEMA.columns").
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `articles` SET `content_key`=? WHERE content_key IS NULL",
+				)).
+					WithArgs("").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			column: "content_key",
+			value:  "",
+		},
+		{
+			name: "legacy version",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT DATABASE()").
+					WillReturnRows(sqlmock.NewRows([]string{"database()"}).AddRow("webook"))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM INFORMATION_SCHEMA.columns").
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `articles` SET `version`=? WHERE version IS NULL",
+				)).
+					WithArgs(0).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			column: "version",
+			value:  0,
+		},
+		{
+			name: "new table",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT DATABASE()").
+					WillReturnRows(sqlmock.NewRows([]string{"database()"}).AddRow("webook"))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM INFORMATION_SCHEMA.columns").
+					WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+			},
+			column: "content_key",
+			value:  "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			err = backfillNull(newMockGORM(t, sqlDB), &Article{}, tc.column, tc.value)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
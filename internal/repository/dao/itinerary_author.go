@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrItineraryNotFound = errors.New("itinerary not found")
+
+//go:generate mockgen -source=./itinerary_author.go -package=daomocks -destination=./mocks/itinerary_author.mock.go
+type ItineraryAuthorDAO interface {
+	Insert(ctx context.Context, itinerary Itinerary) (int64, error)
+	UpdateByID(ctx context.Context, itinerary Itinerary) error
+	UpdateStatusByID(ctx context.Context, uid int64, id int64, status uint8) error
+	GetByID(ctx context.Context, id int64) (Itinerary, error)
+	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Itinerary, error)
+}
+
+type Itinerary struct {
+	ID       int64  `gorm:"primaryKey,autoIncrement"`
+	Title    string `gorm:"type=varchar(4096)"`
+	AuthorID int64  `gorm:"index"`
+	// attached article
+	ArticleID int64 `gorm:"index"`
+	// cloned from this published itinerary
+	SourceID int64
+	Currency string `gorm:"type=varchar(8)"`
+	Days     string `gorm:"type=TEXT"` // JSON array
+	Status   uint8
+	Ctime    int64
+	Utime    int64
+}
+
+// same DB, different tables. Can be moved to another DB.
+type PublishedItinerary Itinerary
+
+type ItineraryGORMAuthorDAO struct {
+	db *gorm.DB
+}
+
+// Insert implements ItineraryAuthorDAO.
+func (i *ItineraryGORMAuthorDAO) Insert(ctx context.Context, itinerary Itinerary) (int64, error) {
+	now := time.Now().UnixMilli()
+	itinerary.Ctime = now
+	itinerary.Utime = now
+	err := i.db.WithContext(ctx).Create(&itinerary).Error
+	return itinerary.ID, err
+}
+
+// UpdateByID implements ItineraryAuthorDAO.
+func (i *ItineraryGORMAuthorDAO) UpdateByID(ctx context.Context, itinerary Itinerary) error {
+	now := time.Now().UnixMilli()
+	res := i.db.WithContext(ctx).
+		Model(&Itinerary{}).
+		Where("id = ? AND author_id = ?", itinerary.ID, itinerary.AuthorID).
+		Updates(map[string]any{
+			"title":      itinerary.Title,
+			"article_id": itinerary.ArticleID,
+			"currency":   itinerary.Currency,
+			"days":       itinerary.Days,
+			"status":     itinerary.Status,
+			"utime":      now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrItineraryNotFound
+	}
+	return nil
+}
+
+// UpdateStatusByID implements ItineraryAuthorDAO.
+func (i *ItineraryGORMAuthorDAO) UpdateStatusByID(
+	ctx context.Context,
+	uid int64,
+	id int64,
+	status uint8,
+) error {
+	now := time.Now().UnixMilli()
+	res := i.db.WithContext(ctx).
+		Model(&Itinerary{}).
+		Where("id = ? AND author_id = ?", id, uid).
+		Updates(map[string]any{
+			"status": status,
+			"utime":  now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrItineraryNotFound
+	}
+	return nil
+}
+
+// GetByID implements ItineraryAuthorDAO.
+func (i *ItineraryGORMAuthorDAO) GetByID(ctx context.Context, id int64) (Itinerary, error) {
+	var itinerary Itinerary
+	err := i.db.WithContext(ctx).Where("id = ?", id).First(&itinerary).Error
+	if err == gorm.ErrRecordNotFound {
+		return Itinerary{}, ErrItineraryNotFound
+	}
+	return itinerary, err
+}
+
+// GetByAuthor implements ItineraryAuthorDAO.
+func (i *ItineraryGORMAuthorDAO) GetByAuthor(
+	ctx context.Context,
+	uid int64,
+	offset int,
+	limit int,
+) ([]Itinerary, error) {
+	var itineraries []Itinerary
+	err := i.db.WithContext(ctx).
+		Where("author_id = ?", uid).
+		Order("utime DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&itineraries).
+		Error
+	return itineraries, err
+}
+
+func NewItineraryGORMAuthorDAO(db *gorm.DB) ItineraryAuthorDAO {
+	return &ItineraryGORMAuthorDAO{
+		db: db,
+	}
+}
@@ -0,0 +1,96 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=./itinerary_reader.go -package=daomocks -destination=./mocks/itinerary_reader.mock.go
+type ItineraryReaderDAO interface {
+	// Insert and Update
+	Upsert(ctx context.Context, itinerary PublishedItinerary) error
+	UpdateStatusByID(ctx context.Context, uid int64, id int64, status uint8) error
+	GetByID(ctx context.Context, id int64) (PublishedItinerary, error)
+	// GetByArticleID returns the published itinerary attached to the article.
+	GetByArticleID(ctx context.Context, aid int64) (PublishedItinerary, error)
+}
+
+type ItineraryGORMReaderDAO struct {
+	db *gorm.DB
+}
+
+// Upsert implements ItineraryReaderDAO.
+func (i *ItineraryGORMReaderDAO) Upsert(ctx context.Context, itinerary PublishedItinerary) error {
+	now := time.Now().UnixMilli()
+	itinerary.Ctime = now
+	itinerary.Utime = now
+	return i.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"title":      itinerary.Title,
+			"article_id": itinerary.ArticleID,
+			"currency":   itinerary.Currency,
+			"days":       itinerary.Days,
+			"status":     itinerary.Status,
+			"utime":      now,
+		}),
+	}).Create(&itinerary).Error
+}
+
+// UpdateStatusByID implements ItineraryReaderDAO.
+func (i *ItineraryGORMReaderDAO) UpdateStatusByID(
+	ctx context.Context,
+	uid int64,
+	id int64,
+	status uint8,
+) error {
+	now := time.Now().UnixMilli()
+	// NOTE: it's fine if the itinerary has never been published
+	return i.db.WithContext(ctx).
+		Model(&PublishedItinerary{}).
+		Where("id = ? AND author_id = ?", id, uid).
+		Updates(map[string]any{
+			"status": status,
+			"utime":  now,
+		}).Error
+}
+
+// GetByID implements ItineraryReaderDAO.
+func (i *ItineraryGORMReaderDAO) GetByID(ctx context.Context, id int64) (PublishedItinerary, error) {
+	var itinerary PublishedItinerary
+	err := i.db.WithContext(ctx).
+		Where("id = ? AND status = ?", id, domain.ItineraryStatusPublished).
+		First(&itinerary).
+		Error
+	if err == gorm.ErrRecordNotFound {
+		return PublishedItinerary{}, ErrItineraryNotFound
+	}
+	return itinerary, err
+}
+
+// GetByArticleID implements ItineraryReaderDAO.
+func (i *ItineraryGORMReaderDAO) GetByArticleID(
+	ctx context.Context,
+	aid int64,
+) (PublishedItinerary, error) {
+	var itinerary PublishedItinerary
+	err := i.db.WithContext(ctx).
+		Where("article_id = ? AND status = ?", aid, domain.ItineraryStatusPublished).
+		Order("utime DESC").
+		First(&itinerary).
+		Error
+	if err == gorm.ErrRecordNotFound {
+		return PublishedItinerary{}, ErrItineraryNotFound
+	}
+	return itinerary, err
+}
+
+func NewItineraryGORMReaderDAO(db *gorm.DB) ItineraryReaderDAO {
+	return &ItineraryGORMReaderDAO{
+		db: db,
+	}
+}
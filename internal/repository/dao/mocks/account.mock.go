@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./account.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./account.go -package=daomocks -destination=./mocks/account.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountDAO is a mock of AccountDAO interface.
+type MockAccountDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountDAOMockRecorder is the mock recorder for MockAccountDAO.
+type MockAccountDAOMockRecorder struct {
+	mock *MockAccountDAO
+}
+
+// NewMockAccountDAO creates a new mock instance.
+func NewMockAccountDAO(ctrl *gomock.Controller) *MockAccountDAO {
+	mock := &MockAccountDAO{ctrl: ctrl}
+	mock.recorder = &MockAccountDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountDAO) EXPECT() *MockAccountDAOMockRecorder {
+	return m.recorder
+}
+
+// CreateWithdrawal mocks base method.
+func (m *MockAccountDAO) CreateWithdrawal(ctx context.Context, w dao.Withdrawal, txn dao.AccountTxn, entries []dao.AccountEntry) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithdrawal", ctx, w, txn, entries)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithdrawal indicates an expected call of CreateWithdrawal.
+func (mr *MockAccountDAOMockRecorder) CreateWithdrawal(ctx, w, txn, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithdrawal", reflect.TypeOf((*MockAccountDAO)(nil).CreateWithdrawal), ctx, w, txn, entries)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccountDAO) GetAccount(ctx context.Context, uid int64, typ uint8) (dao.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, uid, typ)
+	ret0, _ := ret[0].(dao.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountDAOMockRecorder) GetAccount(ctx, uid, typ any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountDAO)(nil).GetAccount), ctx, uid, typ)
+}
+
+// GetWithdrawal mocks base method.
+func (m *MockAccountDAO) GetWithdrawal(ctx context.Context, id int64) (dao.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawal", ctx, id)
+	ret0, _ := ret[0].(dao.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawal indicates an expected call of GetWithdrawal.
+func (mr *MockAccountDAOMockRecorder) GetWithdrawal(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawal", reflect.TypeOf((*MockAccountDAO)(nil).GetWithdrawal), ctx, id)
+}
+
+// ListEntries mocks base method.
+func (m *MockAccountDAO) ListEntries(ctx context.Context, uid int64, typ uint8, offset, limit int) ([]dao.AccountEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, uid, typ, offset, limit)
+	ret0, _ := ret[0].([]dao.AccountEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockAccountDAOMockRecorder) ListEntries(ctx, uid, typ, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockAccountDAO)(nil).ListEntries), ctx, uid, typ, offset, limit)
+}
+
+// ListWithdrawals mocks base method.
+func (m *MockAccountDAO) ListWithdrawals(ctx context.Context, uid int64, status uint8, offset, limit int) ([]dao.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWithdrawals", ctx, uid, status, offset, limit)
+	ret0, _ := ret[0].([]dao.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWithdrawals indicates an expected call of ListWithdrawals.
+func (mr *MockAccountDAOMockRecorder) ListWithdrawals(ctx, uid, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithdrawals", reflect.TypeOf((*MockAccountDAO)(nil).ListWithdrawals), ctx, uid, status, offset, limit)
+}
+
+// SettleWithdrawal mocks base method.
+func (m *MockAccountDAO) SettleWithdrawal(ctx context.Context, w dao.Withdrawal, txn dao.AccountTxn, entries []dao.AccountEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleWithdrawal", ctx, w, txn, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SettleWithdrawal indicates an expected call of SettleWithdrawal.
+func (mr *MockAccountDAOMockRecorder) SettleWithdrawal(ctx, w, txn, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleWithdrawal", reflect.TypeOf((*MockAccountDAO)(nil).SettleWithdrawal), ctx, w, txn, entries)
+}
+
+// Transfer mocks base method.
+func (m *MockAccountDAO) Transfer(ctx context.Context, txn dao.AccountTxn, entries []dao.AccountEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, txn, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockAccountDAOMockRecorder) Transfer(ctx, txn, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockAccountDAO)(nil).Transfer), ctx, txn, entries)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
 	dao "github.com/chenmuyao/go-bootcamp/internal/repository/dao"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,6 +43,21 @@ func (m *MockArticleDAO) EXPECT() *MockArticleDAOMockRecorder {
 	return m.recorder
 }
 
+// BatchGetByIDs mocks base method.
+func (m *MockArticleDAO) BatchGetByIDs(ctx context.Context, uid int64, ids []int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetByIDs", ctx, uid, ids)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetByIDs indicates an expected call of BatchGetByIDs.
+func (mr *MockArticleDAOMockRecorder) BatchGetByIDs(ctx, uid, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetByIDs", reflect.TypeOf((*MockArticleDAO)(nil).BatchGetByIDs), ctx, uid, ids)
+}
+
 // BatchGetPubByIDs mocks base method.
 func (m *MockArticleDAO) BatchGetPubByIDs(ctx context.Context, ids []int64) ([]dao.PublishedArticle, error) {
 	m.ctrl.T.Helper()
@@ -57,19 +73,33 @@ func (mr *MockArticleDAOMockRecorder) BatchGetPubByIDs(ctx, ids any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetPubByIDs", reflect.TypeOf((*MockArticleDAO)(nil).BatchGetPubByIDs), ctx, ids)
 }
 
+// DeleteByID mocks base method.
+func (m *MockArticleDAO) DeleteByID(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockArticleDAOMockRecorder) DeleteByID(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockArticleDAO)(nil).DeleteByID), ctx, uid, id)
+}
+
 // GetByAuthor mocks base method.
-func (m *MockArticleDAO) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]dao.Article, error) {
+func (m *MockArticleDAO) GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]dao.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, cursor, limit)
 	ret0, _ := ret[0].([]dao.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByAuthor indicates an expected call of GetByAuthor.
-func (mr *MockArticleDAOMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+func (mr *MockArticleDAOMockRecorder) GetByAuthor(ctx, uid, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetByAuthor), ctx, uid, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetByAuthor), ctx, uid, cursor, limit)
 }
 
 // GetByID mocks base method.
@@ -87,6 +117,21 @@ func (mr *MockArticleDAOMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockArticleDAO)(nil).GetByID), ctx, id)
 }
 
+// GetPubByAuthor mocks base method.
+func (m *MockArticleDAO) GetPubByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]dao.PublishedArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByAuthor", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]dao.PublishedArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByAuthor indicates an expected call of GetPubByAuthor.
+func (mr *MockArticleDAOMockRecorder) GetPubByAuthor(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetPubByAuthor), ctx, uid, cursor, limit)
+}
+
 // GetPubByID mocks base method.
 func (m *MockArticleDAO) GetPubByID(ctx context.Context, id int64) (dao.PublishedArticle, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +147,21 @@ func (mr *MockArticleDAOMockRecorder) GetPubByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByID", reflect.TypeOf((*MockArticleDAO)(nil).GetPubByID), ctx, id)
 }
 
+// GetTrashByAuthor mocks base method.
+func (m *MockArticleDAO) GetTrashByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashByAuthor", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashByAuthor indicates an expected call of GetTrashByAuthor.
+func (mr *MockArticleDAOMockRecorder) GetTrashByAuthor(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetTrashByAuthor), ctx, uid, cursor, limit)
+}
+
 // Insert mocks base method.
 func (m *MockArticleDAO) Insert(ctx context.Context, article dao.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -118,18 +178,47 @@ func (mr *MockArticleDAOMockRecorder) Insert(ctx, article any) *gomock.Call {
 }
 
 // ListPub mocks base method.
-func (m *MockArticleDAO) ListPub(ctx context.Context, start time.Time, offset, limit int) ([]dao.PublishedArticle, error) {
+func (m *MockArticleDAO) ListPub(ctx context.Context, cursor domain.Cursor, limit int) ([]dao.PublishedArticle, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPub", ctx, start, offset, limit)
+	ret := m.ctrl.Call(m, "ListPub", ctx, cursor, limit)
 	ret0, _ := ret[0].([]dao.PublishedArticle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPub indicates an expected call of ListPub.
-func (mr *MockArticleDAOMockRecorder) ListPub(ctx, start, offset, limit any) *gomock.Call {
+func (mr *MockArticleDAOMockRecorder) ListPub(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleDAO)(nil).ListPub), ctx, cursor, limit)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockArticleDAO) PurgeTrash(ctx context.Context, before time.Time, limit int) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", ctx, before, limit)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockArticleDAOMockRecorder) PurgeTrash(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockArticleDAO)(nil).PurgeTrash), ctx, before, limit)
+}
+
+// RestoreByID mocks base method.
+func (m *MockArticleDAO) RestoreByID(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreByID", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreByID indicates an expected call of RestoreByID.
+func (mr *MockArticleDAOMockRecorder) RestoreByID(ctx, uid, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleDAO)(nil).ListPub), ctx, start, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreByID", reflect.TypeOf((*MockArticleDAO)(nil).RestoreByID), ctx, uid, id)
 }
 
 // Sync mocks base method.
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./article_collaborator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./article_collaborator.go -package=daomocks -destination=./mocks/article_collaborator.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleCollaboratorDAO is a mock of ArticleCollaboratorDAO interface.
+type MockArticleCollaboratorDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleCollaboratorDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleCollaboratorDAOMockRecorder is the mock recorder for MockArticleCollaboratorDAO.
+type MockArticleCollaboratorDAOMockRecorder struct {
+	mock *MockArticleCollaboratorDAO
+}
+
+// NewMockArticleCollaboratorDAO creates a new mock instance.
+func NewMockArticleCollaboratorDAO(ctrl *gomock.Controller) *MockArticleCollaboratorDAO {
+	mock := &MockArticleCollaboratorDAO{ctrl: ctrl}
+	mock.recorder = &MockArticleCollaboratorDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleCollaboratorDAO) EXPECT() *MockArticleCollaboratorDAOMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockArticleCollaboratorDAO) Approve(ctx context.Context, aid, uid, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, aid, uid, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockArticleCollaboratorDAOMockRecorder) Approve(ctx, aid, uid, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockArticleCollaboratorDAO)(nil).Approve), ctx, aid, uid, version)
+}
+
+// Delete mocks base method.
+func (m *MockArticleCollaboratorDAO) Delete(ctx context.Context, aid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, aid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleCollaboratorDAOMockRecorder) Delete(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleCollaboratorDAO)(nil).Delete), ctx, aid, uid)
+}
+
+// DeleteByArticles mocks base method.
+func (m *MockArticleCollaboratorDAO) DeleteByArticles(ctx context.Context, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticles", ctx, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticles indicates an expected call of DeleteByArticles.
+func (mr *MockArticleCollaboratorDAOMockRecorder) DeleteByArticles(ctx, aids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticles", reflect.TypeOf((*MockArticleCollaboratorDAO)(nil).DeleteByArticles), ctx, aids)
+}
+
+// GetByArticle mocks base method.
+func (m *MockArticleCollaboratorDAO) GetByArticle(ctx context.Context, aid int64) ([]dao.ArticleCollaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticle", ctx, aid)
+	ret0, _ := ret[0].([]dao.ArticleCollaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticle indicates an expected call of GetByArticle.
+func (mr *MockArticleCollaboratorDAOMockRecorder) GetByArticle(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticle", reflect.TypeOf((*MockArticleCollaboratorDAO)(nil).GetByArticle), ctx, aid)
+}
+
+// Upsert mocks base method.
+func (m *MockArticleCollaboratorDAO) Upsert(ctx context.Context, c dao.ArticleCollaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockArticleCollaboratorDAOMockRecorder) Upsert(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockArticleCollaboratorDAO)(nil).Upsert), ctx, c)
+}
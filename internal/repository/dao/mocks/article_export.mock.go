@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./article_export.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./article_export.go -package=daomocks -destination=./mocks/article_export.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleExportDAO is a mock of ArticleExportDAO interface.
+type MockArticleExportDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleExportDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleExportDAOMockRecorder is the mock recorder for MockArticleExportDAO.
+type MockArticleExportDAOMockRecorder struct {
+	mock *MockArticleExportDAO
+}
+
+// NewMockArticleExportDAO creates a new mock instance.
+func NewMockArticleExportDAO(ctrl *gomock.Controller) *MockArticleExportDAO {
+	mock := &MockArticleExportDAO{ctrl: ctrl}
+	mock.recorder = &MockArticleExportDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleExportDAO) EXPECT() *MockArticleExportDAOMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockArticleExportDAO) Get(ctx context.Context, uid int64) (dao.ArticleExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, uid)
+	ret0, _ := ret[0].(dao.ArticleExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockArticleExportDAOMockRecorder) Get(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArticleExportDAO)(nil).Get), ctx, uid)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockArticleExportDAO) UpdateStatus(ctx context.Context, uid int64, status uint8, errMsg string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, uid, status, errMsg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockArticleExportDAOMockRecorder) UpdateStatus(ctx, uid, status, errMsg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockArticleExportDAO)(nil).UpdateStatus), ctx, uid, status, errMsg)
+}
+
+// Upsert mocks base method.
+func (m *MockArticleExportDAO) Upsert(ctx context.Context, export dao.ArticleExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockArticleExportDAOMockRecorder) Upsert(ctx, export any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockArticleExportDAO)(nil).Upsert), ctx, export)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./itinerary_author.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./itinerary_author.go -package=daomocks -destination=./mocks/itinerary_author.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockItineraryAuthorDAO is a mock of ItineraryAuthorDAO interface.
+type MockItineraryAuthorDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockItineraryAuthorDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockItineraryAuthorDAOMockRecorder is the mock recorder for MockItineraryAuthorDAO.
+type MockItineraryAuthorDAOMockRecorder struct {
+	mock *MockItineraryAuthorDAO
+}
+
+// NewMockItineraryAuthorDAO creates a new mock instance.
+func NewMockItineraryAuthorDAO(ctrl *gomock.Controller) *MockItineraryAuthorDAO {
+	mock := &MockItineraryAuthorDAO{ctrl: ctrl}
+	mock.recorder = &MockItineraryAuthorDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItineraryAuthorDAO) EXPECT() *MockItineraryAuthorDAOMockRecorder {
+	return m.recorder
+}
+
+// GetByAuthor mocks base method.
+func (m *MockItineraryAuthorDAO) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]dao.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]dao.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockItineraryAuthorDAOMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockItineraryAuthorDAO)(nil).GetByAuthor), ctx, uid, offset, limit)
+}
+
+// GetByID mocks base method.
+func (m *MockItineraryAuthorDAO) GetByID(ctx context.Context, id int64) (dao.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(dao.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockItineraryAuthorDAOMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockItineraryAuthorDAO)(nil).GetByID), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockItineraryAuthorDAO) Insert(ctx context.Context, itinerary dao.Itinerary) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, itinerary)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockItineraryAuthorDAOMockRecorder) Insert(ctx, itinerary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockItineraryAuthorDAO)(nil).Insert), ctx, itinerary)
+}
+
+// UpdateByID mocks base method.
+func (m *MockItineraryAuthorDAO) UpdateByID(ctx context.Context, itinerary dao.Itinerary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByID", ctx, itinerary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateByID indicates an expected call of UpdateByID.
+func (mr *MockItineraryAuthorDAOMockRecorder) UpdateByID(ctx, itinerary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByID", reflect.TypeOf((*MockItineraryAuthorDAO)(nil).UpdateByID), ctx, itinerary)
+}
+
+// UpdateStatusByID mocks base method.
+func (m *MockItineraryAuthorDAO) UpdateStatusByID(ctx context.Context, uid, id int64, status uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusByID", ctx, uid, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusByID indicates an expected call of UpdateStatusByID.
+func (mr *MockItineraryAuthorDAOMockRecorder) UpdateStatusByID(ctx, uid, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusByID", reflect.TypeOf((*MockItineraryAuthorDAO)(nil).UpdateStatusByID), ctx, uid, id, status)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./itinerary_reader.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./itinerary_reader.go -package=daomocks -destination=./mocks/itinerary_reader.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockItineraryReaderDAO is a mock of ItineraryReaderDAO interface.
+type MockItineraryReaderDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockItineraryReaderDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockItineraryReaderDAOMockRecorder is the mock recorder for MockItineraryReaderDAO.
+type MockItineraryReaderDAOMockRecorder struct {
+	mock *MockItineraryReaderDAO
+}
+
+// NewMockItineraryReaderDAO creates a new mock instance.
+func NewMockItineraryReaderDAO(ctrl *gomock.Controller) *MockItineraryReaderDAO {
+	mock := &MockItineraryReaderDAO{ctrl: ctrl}
+	mock.recorder = &MockItineraryReaderDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItineraryReaderDAO) EXPECT() *MockItineraryReaderDAOMockRecorder {
+	return m.recorder
+}
+
+// GetByArticleID mocks base method.
+func (m *MockItineraryReaderDAO) GetByArticleID(ctx context.Context, aid int64) (dao.PublishedItinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticleID", ctx, aid)
+	ret0, _ := ret[0].(dao.PublishedItinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticleID indicates an expected call of GetByArticleID.
+func (mr *MockItineraryReaderDAOMockRecorder) GetByArticleID(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticleID", reflect.TypeOf((*MockItineraryReaderDAO)(nil).GetByArticleID), ctx, aid)
+}
+
+// GetByID mocks base method.
+func (m *MockItineraryReaderDAO) GetByID(ctx context.Context, id int64) (dao.PublishedItinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(dao.PublishedItinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockItineraryReaderDAOMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockItineraryReaderDAO)(nil).GetByID), ctx, id)
+}
+
+// UpdateStatusByID mocks base method.
+func (m *MockItineraryReaderDAO) UpdateStatusByID(ctx context.Context, uid, id int64, status uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusByID", ctx, uid, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusByID indicates an expected call of UpdateStatusByID.
+func (mr *MockItineraryReaderDAOMockRecorder) UpdateStatusByID(ctx, uid, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusByID", reflect.TypeOf((*MockItineraryReaderDAO)(nil).UpdateStatusByID), ctx, uid, id, status)
+}
+
+// Upsert mocks base method.
+func (m *MockItineraryReaderDAO) Upsert(ctx context.Context, itinerary dao.PublishedItinerary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, itinerary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockItineraryReaderDAOMockRecorder) Upsert(ctx, itinerary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockItineraryReaderDAO)(nil).Upsert), ctx, itinerary)
+}
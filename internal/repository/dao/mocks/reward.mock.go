@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./reward.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./reward.go -package=daomocks -destination=./mocks/reward.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRewardDAO is a mock of RewardDAO interface.
+type MockRewardDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockRewardDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockRewardDAOMockRecorder is the mock recorder for MockRewardDAO.
+type MockRewardDAOMockRecorder struct {
+	mock *MockRewardDAO
+}
+
+// NewMockRewardDAO creates a new mock instance.
+func NewMockRewardDAO(ctrl *gomock.Controller) *MockRewardDAO {
+	mock := &MockRewardDAO{ctrl: ctrl}
+	mock.recorder = &MockRewardDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRewardDAO) EXPECT() *MockRewardDAOMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockRewardDAO) GetByID(ctx context.Context, id int64) (dao.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(dao.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRewardDAOMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRewardDAO)(nil).GetByID), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockRewardDAO) Insert(ctx context.Context, r dao.Reward) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRewardDAOMockRecorder) Insert(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRewardDAO)(nil).Insert), ctx, r)
+}
+
+// ListPending mocks base method.
+func (m *MockRewardDAO) ListPending(ctx context.Context, before, afterCtime, afterID int64, limit int) ([]dao.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, before, afterCtime, afterID, limit)
+	ret0, _ := ret[0].([]dao.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockRewardDAOMockRecorder) ListPending(ctx, before, afterCtime, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockRewardDAO)(nil).ListPending), ctx, before, afterCtime, afterID, limit)
+}
+
+// ListUnnotified mocks base method.
+func (m *MockRewardDAO) ListUnnotified(ctx context.Context, afterID int64, limit int) ([]dao.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnnotified", ctx, afterID, limit)
+	ret0, _ := ret[0].([]dao.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnnotified indicates an expected call of ListUnnotified.
+func (mr *MockRewardDAOMockRecorder) ListUnnotified(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnnotified", reflect.TypeOf((*MockRewardDAO)(nil).ListUnnotified), ctx, afterID, limit)
+}
+
+// SetNotified mocks base method.
+func (m *MockRewardDAO) SetNotified(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotified", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotified indicates an expected call of SetNotified.
+func (mr *MockRewardDAOMockRecorder) SetNotified(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotified", reflect.TypeOf((*MockRewardDAO)(nil).SetNotified), ctx, id)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRewardDAO) UpdateStatus(ctx context.Context, id int64, status uint8) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRewardDAOMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRewardDAO)(nil).UpdateStatus), ctx, id, status)
+}
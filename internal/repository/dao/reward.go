@@ -0,0 +1,140 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrRewardNotFound = errors.New("reward not found")
+
+// the values of domain.RewardStatus
+const (
+	rewardStatusInit uint8 = iota + 1
+	rewardStatusPaid
+)
+
+//go:generate mockgen -source=./reward.go -package=daomocks -destination=./mocks/reward.mock.go
+type RewardDAO interface {
+	Insert(ctx context.Context, r Reward) (int64, error)
+	GetByID(ctx context.Context, id int64) (Reward, error)
+	// UpdateStatus settles a reward waiting for its payment. It reports
+	// false if the reward was already settled.
+	UpdateStatus(ctx context.Context, id int64, status uint8) (bool, error)
+	// ListPending lists by ctime then ID the rewards waiting for their
+	// payment since before, after the reward (afterCtime, afterID).
+	ListPending(
+		ctx context.Context,
+		before int64,
+		afterCtime int64,
+		afterID int64,
+		limit int,
+	) ([]Reward, error)
+	// ListUnnotified lists the paid rewards whose event is not produced,
+	// with an ID greater than afterID.
+	ListUnnotified(ctx context.Context, afterID int64, limit int) ([]Reward, error)
+	SetNotified(ctx context.Context, id int64) error
+}
+
+type Reward struct {
+	ID        int64  `gorm:"primaryKey,autoIncrement"`
+	Biz       string `gorm:"type:varchar(128);index:biz_type_id"`
+	BizID     int64  `gorm:"index:biz_type_id"`
+	UID       int64  `gorm:"index"`
+	TargetUID int64  `gorm:"index"`
+	Amount    int64
+	Status    uint8 `gorm:"index:status_ctime"`
+	// whether the paid event is produced
+	Notified bool
+	Ctime    int64 `gorm:"index:status_ctime"`
+	Utime    int64
+}
+
+type GORMRewardDAO struct {
+	db *gorm.DB
+}
+
+// Insert implements RewardDAO.
+func (g *GORMRewardDAO) Insert(ctx context.Context, r Reward) (int64, error) {
+	now := time.Now().UnixMilli()
+	r.Ctime = now
+	r.Utime = now
+	err := g.db.WithContext(ctx).Create(&r).Error
+	return r.ID, err
+}
+
+// GetByID implements RewardDAO.
+func (g *GORMRewardDAO) GetByID(ctx context.Context, id int64) (Reward, error) {
+	var res Reward
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	if err == gorm.ErrRecordNotFound {
+		return Reward{}, ErrRewardNotFound
+	}
+	return res, err
+}
+
+// UpdateStatus implements RewardDAO.
+func (g *GORMRewardDAO) UpdateStatus(ctx context.Context, id int64, status uint8) (bool, error) {
+	// NOTE: the status only moves out of init, so that a late or repeated
+	// callback does not change a settled reward.
+	res := g.db.WithContext(ctx).
+		Model(&Reward{}).
+		Where("id = ? AND status = ?", id, rewardStatusInit).
+		Updates(map[string]any{
+			"status": status,
+			"utime":  time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+// ListPending implements RewardDAO.
+func (g *GORMRewardDAO) ListPending(
+	ctx context.Context,
+	before int64,
+	afterCtime int64,
+	afterID int64,
+	limit int,
+) ([]Reward, error) {
+	var res []Reward
+	err := g.db.WithContext(ctx).
+		Where("status = ? AND ctime < ?", rewardStatusInit, before).
+		Where("ctime > ? OR (ctime = ? AND id > ?)", afterCtime, afterCtime, afterID).
+		Order("ctime, id").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// ListUnnotified implements RewardDAO.
+func (g *GORMRewardDAO) ListUnnotified(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+) ([]Reward, error) {
+	var res []Reward
+	err := g.db.WithContext(ctx).
+		Where("status = ? AND notified = ? AND id > ?", rewardStatusPaid, false, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// SetNotified implements RewardDAO.
+func (g *GORMRewardDAO) SetNotified(ctx context.Context, id int64) error {
+	return g.db.WithContext(ctx).
+		Model(&Reward{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"notified": true,
+			"utime":    time.Now().UnixMilli(),
+		}).Error
+}
+
+func NewGORMRewardDAO(db *gorm.DB) RewardDAO {
+	return &GORMRewardDAO{
+		db: db,
+	}
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
+)
+
+//go:generate mockgen -source=./feed.go -package=repomocks -destination=./mocks/feed.mock.go
+type FeedRepository interface {
+	// GetFeed and SetFeed access the feeds of an author, or the site-wide
+	// feeds if uid is 0.
+	GetFeed(ctx context.Context, uid int64, format domain.FeedFormat) (domain.FeedDoc, error)
+	SetFeed(ctx context.Context, uid int64, format domain.FeedFormat, doc domain.FeedDoc) error
+	// GetSitemap returns the index for page 0, and the sitemaps from 1.
+	GetSitemap(ctx context.Context, page int) (domain.FeedDoc, error)
+	SetSitemaps(ctx context.Context, docs []domain.FeedDoc) error
+}
+
+// NOTE: the feeds are only kept in the cache. They are invalidated by the
+// ArticleRepository whenever the published articles change.
+type CachedFeedRepository struct {
+	cache cache.FeedCache
+}
+
+// GetFeed implements FeedRepository.
+func (c *CachedFeedRepository) GetFeed(
+	ctx context.Context,
+	uid int64,
+	format domain.FeedFormat,
+) (domain.FeedDoc, error) {
+	return c.cache.GetFeed(ctx, uid, format)
+}
+
+// SetFeed implements FeedRepository.
+func (c *CachedFeedRepository) SetFeed(
+	ctx context.Context,
+	uid int64,
+	format domain.FeedFormat,
+	doc domain.FeedDoc,
+) error {
+	return c.cache.SetFeed(ctx, uid, format, doc)
+}
+
+// GetSitemap implements FeedRepository.
+func (c *CachedFeedRepository) GetSitemap(ctx context.Context, page int) (domain.FeedDoc, error) {
+	return c.cache.GetSitemap(ctx, page)
+}
+
+// SetSitemaps implements FeedRepository.
+func (c *CachedFeedRepository) SetSitemaps(ctx context.Context, docs []domain.FeedDoc) error {
+	return c.cache.SetSitemaps(ctx, docs)
+}
+
+func NewCachedFeedRepository(cache cache.FeedCache) FeedRepository {
+	return &CachedFeedRepository{
+		cache: cache,
+	}
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+var ErrItineraryNotFound = dao.ErrItineraryNotFound
+
+//go:generate mockgen -source=./itinerary.go -package=repomocks -destination=./mocks/itinerary.mock.go
+type ItineraryRepository interface {
+	// author side
+	Create(ctx context.Context, itinerary domain.Itinerary) (int64, error)
+	Update(ctx context.Context, itinerary domain.Itinerary) error
+	UpdateStatus(ctx context.Context, uid int64, id int64, status domain.ItineraryStatus) error
+	GetByID(ctx context.Context, id int64) (domain.Itinerary, error)
+	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Itinerary, error)
+
+	// reader side
+	SavePub(ctx context.Context, itinerary domain.Itinerary) error
+	UpdatePubStatus(
+		ctx context.Context,
+		uid int64,
+		id int64,
+		status domain.ItineraryStatus,
+	) error
+	GetPubByID(ctx context.Context, id int64) (domain.Itinerary, error)
+	GetPubByArticleID(ctx context.Context, aid int64) (domain.Itinerary, error)
+}
+
+// SplitItineraryRepository keeps the drafts with the author DAO and the
+// published copies with the reader DAO, so they can live in different DBs.
+type SplitItineraryRepository struct {
+	l         logger.Logger
+	authorDAO dao.ItineraryAuthorDAO
+	readerDAO dao.ItineraryReaderDAO
+	userRepo  UserRepository
+}
+
+// Create implements ItineraryRepository.
+func (s *SplitItineraryRepository) Create(
+	ctx context.Context,
+	itinerary domain.Itinerary,
+) (int64, error) {
+	return s.authorDAO.Insert(ctx, s.toEntity(itinerary))
+}
+
+// Update implements ItineraryRepository.
+func (s *SplitItineraryRepository) Update(ctx context.Context, itinerary domain.Itinerary) error {
+	return s.authorDAO.UpdateByID(ctx, s.toEntity(itinerary))
+}
+
+// UpdateStatus implements ItineraryRepository.
+func (s *SplitItineraryRepository) UpdateStatus(
+	ctx context.Context,
+	uid int64,
+	id int64,
+	status domain.ItineraryStatus,
+) error {
+	return s.authorDAO.UpdateStatusByID(ctx, uid, id, uint8(status))
+}
+
+// GetByID implements ItineraryRepository.
+func (s *SplitItineraryRepository) GetByID(
+	ctx context.Context,
+	id int64,
+) (domain.Itinerary, error) {
+	itinerary, err := s.authorDAO.GetByID(ctx, id)
+	if err != nil {
+		return domain.Itinerary{}, err
+	}
+	return s.toDomain(itinerary), nil
+}
+
+// GetByAuthor implements ItineraryRepository.
+func (s *SplitItineraryRepository) GetByAuthor(
+	ctx context.Context,
+	uid int64,
+	offset int,
+	limit int,
+) ([]domain.Itinerary, error) {
+	itineraries, err := s.authorDAO.GetByAuthor(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(itineraries, func(id int, src dao.Itinerary) domain.Itinerary {
+		return s.toDomain(src)
+	}), nil
+}
+
+// SavePub implements ItineraryRepository.
+func (s *SplitItineraryRepository) SavePub(
+	ctx context.Context,
+	itinerary domain.Itinerary,
+) error {
+	return s.readerDAO.Upsert(ctx, dao.PublishedItinerary(s.toEntity(itinerary)))
+}
+
+// UpdatePubStatus implements ItineraryRepository.
+func (s *SplitItineraryRepository) UpdatePubStatus(
+	ctx context.Context,
+	uid int64,
+	id int64,
+	status domain.ItineraryStatus,
+) error {
+	return s.readerDAO.UpdateStatusByID(ctx, uid, id, uint8(status))
+}
+
+// GetPubByID implements ItineraryRepository.
+func (s *SplitItineraryRepository) GetPubByID(
+	ctx context.Context,
+	id int64,
+) (domain.Itinerary, error) {
+	itinerary, err := s.readerDAO.GetByID(ctx, id)
+	if err != nil {
+		return domain.Itinerary{}, err
+	}
+	return s.withAuthorName(ctx, s.toDomain(dao.Itinerary(itinerary)))
+}
+
+// GetPubByArticleID implements ItineraryRepository.
+func (s *SplitItineraryRepository) GetPubByArticleID(
+	ctx context.Context,
+	aid int64,
+) (domain.Itinerary, error) {
+	itinerary, err := s.readerDAO.GetByArticleID(ctx, aid)
+	if err != nil {
+		return domain.Itinerary{}, err
+	}
+	return s.withAuthorName(ctx, s.toDomain(dao.Itinerary(itinerary)))
+}
+
+func (s *SplitItineraryRepository) withAuthorName(
+	ctx context.Context,
+	itinerary domain.Itinerary,
+) (domain.Itinerary, error) {
+	author, err := s.userRepo.FindByID(ctx, itinerary.Author.ID)
+	if err != nil {
+		return domain.Itinerary{}, err
+	}
+	itinerary.Author.Name = author.Name
+	return itinerary, nil
+}
+
+func (s *SplitItineraryRepository) toDomain(itinerary dao.Itinerary) domain.Itinerary {
+	var days []domain.ItineraryDay
+	if itinerary.Days != "" {
+		err := json.Unmarshal([]byte(itinerary.Days), &days)
+		if err != nil {
+			s.l.Warn("invalid itinerary days",
+				logger.Int64("id", itinerary.ID),
+				logger.Error(err))
+		}
+	}
+	return domain.Itinerary{
+		ID:    itinerary.ID,
+		Title: itinerary.Title,
+		Author: domain.Author{
+			ID: itinerary.AuthorID,
+		},
+		ArticleID: itinerary.ArticleID,
+		SourceID:  itinerary.SourceID,
+		Currency:  itinerary.Currency,
+		Days:      days,
+		Status:    domain.ItineraryStatus(itinerary.Status),
+		Ctime:     time.UnixMilli(itinerary.Ctime),
+		Utime:     time.UnixMilli(itinerary.Utime),
+	}
+}
+
+func (s *SplitItineraryRepository) toEntity(itinerary domain.Itinerary) dao.Itinerary {
+	var days string
+	if len(itinerary.Days) > 0 {
+		// NOTE: cannot fail
+		val, _ := json.Marshal(itinerary.Days)
+		days = string(val)
+	}
+	return dao.Itinerary{
+		ID:        itinerary.ID,
+		Title:     itinerary.Title,
+		AuthorID:  itinerary.Author.ID,
+		ArticleID: itinerary.ArticleID,
+		SourceID:  itinerary.SourceID,
+		Currency:  itinerary.Currency,
+		Days:      days,
+		Status:    uint8(itinerary.Status),
+	}
+}
+
+func NewItineraryRepository(
+	l logger.Logger,
+	authorDAO dao.ItineraryAuthorDAO,
+	readerDAO dao.ItineraryReaderDAO,
+	userRepo UserRepository,
+) ItineraryRepository {
+	return &SplitItineraryRepository{
+		l:         l,
+		authorDAO: authorDAO,
+		readerDAO: readerDAO,
+		userRepo:  userRepo,
+	}
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./account.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./account.go -package=repomocks -destination=./mocks/account.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountRepository is a mock of AccountRepository interface.
+type MockAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountRepositoryMockRecorder is the mock recorder for MockAccountRepository.
+type MockAccountRepositoryMockRecorder struct {
+	mock *MockAccountRepository
+}
+
+// NewMockAccountRepository creates a new mock instance.
+func NewMockAccountRepository(ctrl *gomock.Controller) *MockAccountRepository {
+	mock := &MockAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWithdrawal mocks base method.
+func (m *MockAccountRepository) CreateWithdrawal(ctx context.Context, w domain.Withdrawal, biz string, entries []domain.AccountEntry) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithdrawal", ctx, w, biz, entries)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithdrawal indicates an expected call of CreateWithdrawal.
+func (mr *MockAccountRepositoryMockRecorder) CreateWithdrawal(ctx, w, biz, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithdrawal", reflect.TypeOf((*MockAccountRepository)(nil).CreateWithdrawal), ctx, w, biz, entries)
+}
+
+// GetAccount mocks base method.
+func (m *MockAccountRepository) GetAccount(ctx context.Context, uid int64, typ domain.AccountType) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, uid, typ)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountRepositoryMockRecorder) GetAccount(ctx, uid, typ any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountRepository)(nil).GetAccount), ctx, uid, typ)
+}
+
+// GetWithdrawal mocks base method.
+func (m *MockAccountRepository) GetWithdrawal(ctx context.Context, id int64) (domain.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawal", ctx, id)
+	ret0, _ := ret[0].(domain.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawal indicates an expected call of GetWithdrawal.
+func (mr *MockAccountRepositoryMockRecorder) GetWithdrawal(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawal", reflect.TypeOf((*MockAccountRepository)(nil).GetWithdrawal), ctx, id)
+}
+
+// ListEntries mocks base method.
+func (m *MockAccountRepository) ListEntries(ctx context.Context, uid int64, typ domain.AccountType, offset, limit int) ([]domain.AccountEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, uid, typ, offset, limit)
+	ret0, _ := ret[0].([]domain.AccountEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockAccountRepositoryMockRecorder) ListEntries(ctx, uid, typ, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockAccountRepository)(nil).ListEntries), ctx, uid, typ, offset, limit)
+}
+
+// ListWithdrawals mocks base method.
+func (m *MockAccountRepository) ListWithdrawals(ctx context.Context, uid int64, status domain.WithdrawalStatus, offset, limit int) ([]domain.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWithdrawals", ctx, uid, status, offset, limit)
+	ret0, _ := ret[0].([]domain.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWithdrawals indicates an expected call of ListWithdrawals.
+func (mr *MockAccountRepositoryMockRecorder) ListWithdrawals(ctx, uid, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithdrawals", reflect.TypeOf((*MockAccountRepository)(nil).ListWithdrawals), ctx, uid, status, offset, limit)
+}
+
+// SettleWithdrawal mocks base method.
+func (m *MockAccountRepository) SettleWithdrawal(ctx context.Context, w domain.Withdrawal, biz string, entries []domain.AccountEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleWithdrawal", ctx, w, biz, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SettleWithdrawal indicates an expected call of SettleWithdrawal.
+func (mr *MockAccountRepositoryMockRecorder) SettleWithdrawal(ctx, w, biz, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleWithdrawal", reflect.TypeOf((*MockAccountRepository)(nil).SettleWithdrawal), ctx, w, biz, entries)
+}
+
+// Transfer mocks base method.
+func (m *MockAccountRepository) Transfer(ctx context.Context, biz string, bizID int64, entries []domain.AccountEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, biz, bizID, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockAccountRepositoryMockRecorder) Transfer(ctx, biz, bizID, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockAccountRepository)(nil).Transfer), ctx, biz, bizID, entries)
+}
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./article_archive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./article_archive.go -package=repomocks -destination=./mocks/article_archive.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleArchiveRepository is a mock of ArticleArchiveRepository interface.
+type MockArticleArchiveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleArchiveRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleArchiveRepositoryMockRecorder is the mock recorder for MockArticleArchiveRepository.
+type MockArticleArchiveRepositoryMockRecorder struct {
+	mock *MockArticleArchiveRepository
+}
+
+// NewMockArticleArchiveRepository creates a new mock instance.
+func NewMockArticleArchiveRepository(ctrl *gomock.Controller) *MockArticleArchiveRepository {
+	mock := &MockArticleArchiveRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleArchiveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleArchiveRepository) EXPECT() *MockArticleArchiveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FinishExport mocks base method.
+func (m *MockArticleArchiveRepository) FinishExport(ctx context.Context, uid int64, archive []byte, exportErr error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishExport", ctx, uid, archive, exportErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishExport indicates an expected call of FinishExport.
+func (mr *MockArticleArchiveRepositoryMockRecorder) FinishExport(ctx, uid, archive, exportErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishExport", reflect.TypeOf((*MockArticleArchiveRepository)(nil).FinishExport), ctx, uid, archive, exportErr)
+}
+
+// GetArchive mocks base method.
+func (m *MockArticleArchiveRepository) GetArchive(ctx context.Context, uid int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchive", ctx, uid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchive indicates an expected call of GetArchive.
+func (mr *MockArticleArchiveRepositoryMockRecorder) GetArchive(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchive", reflect.TypeOf((*MockArticleArchiveRepository)(nil).GetArchive), ctx, uid)
+}
+
+// GetExport mocks base method.
+func (m *MockArticleArchiveRepository) GetExport(ctx context.Context, uid int64) (domain.ArticleExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, uid)
+	ret0, _ := ret[0].(domain.ArticleExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockArticleArchiveRepositoryMockRecorder) GetExport(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockArticleArchiveRepository)(nil).GetExport), ctx, uid)
+}
+
+// GetImage mocks base method.
+func (m *MockArticleArchiveRepository) GetImage(ctx context.Context, uid int64, name string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImage", ctx, uid, name)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImage indicates an expected call of GetImage.
+func (mr *MockArticleArchiveRepositoryMockRecorder) GetImage(ctx, uid, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockArticleArchiveRepository)(nil).GetImage), ctx, uid, name)
+}
+
+// PutImage mocks base method.
+func (m *MockArticleArchiveRepository) PutImage(ctx context.Context, uid int64, name string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutImage", ctx, uid, name, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutImage indicates an expected call of PutImage.
+func (mr *MockArticleArchiveRepositoryMockRecorder) PutImage(ctx, uid, name, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutImage", reflect.TypeOf((*MockArticleArchiveRepository)(nil).PutImage), ctx, uid, name, data)
+}
+
+// StartExport mocks base method.
+func (m *MockArticleArchiveRepository) StartExport(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartExport", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartExport indicates an expected call of StartExport.
+func (mr *MockArticleArchiveRepositoryMockRecorder) StartExport(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExport", reflect.TypeOf((*MockArticleArchiveRepository)(nil).StartExport), ctx, uid)
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./article_collaborator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./article_collaborator.go -package=repomocks -destination=./mocks/article_collaborator.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleCollaboratorRepository is a mock of ArticleCollaboratorRepository interface.
+type MockArticleCollaboratorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleCollaboratorRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleCollaboratorRepositoryMockRecorder is the mock recorder for MockArticleCollaboratorRepository.
+type MockArticleCollaboratorRepositoryMockRecorder struct {
+	mock *MockArticleCollaboratorRepository
+}
+
+// NewMockArticleCollaboratorRepository creates a new mock instance.
+func NewMockArticleCollaboratorRepository(ctrl *gomock.Controller) *MockArticleCollaboratorRepository {
+	mock := &MockArticleCollaboratorRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleCollaboratorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleCollaboratorRepository) EXPECT() *MockArticleCollaboratorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockArticleCollaboratorRepository) Approve(ctx context.Context, aid, uid, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, aid, uid, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockArticleCollaboratorRepositoryMockRecorder) Approve(ctx, aid, uid, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockArticleCollaboratorRepository)(nil).Approve), ctx, aid, uid, version)
+}
+
+// Delete mocks base method.
+func (m *MockArticleCollaboratorRepository) Delete(ctx context.Context, aid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, aid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleCollaboratorRepositoryMockRecorder) Delete(ctx, aid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleCollaboratorRepository)(nil).Delete), ctx, aid, uid)
+}
+
+// DeleteByArticles mocks base method.
+func (m *MockArticleCollaboratorRepository) DeleteByArticles(ctx context.Context, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticles", ctx, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticles indicates an expected call of DeleteByArticles.
+func (mr *MockArticleCollaboratorRepositoryMockRecorder) DeleteByArticles(ctx, aids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticles", reflect.TypeOf((*MockArticleCollaboratorRepository)(nil).DeleteByArticles), ctx, aids)
+}
+
+// GetByArticle mocks base method.
+func (m *MockArticleCollaboratorRepository) GetByArticle(ctx context.Context, aid int64) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticle", ctx, aid)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticle indicates an expected call of GetByArticle.
+func (mr *MockArticleCollaboratorRepositoryMockRecorder) GetByArticle(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticle", reflect.TypeOf((*MockArticleCollaboratorRepository)(nil).GetByArticle), ctx, aid)
+}
+
+// Save mocks base method.
+func (m *MockArticleCollaboratorRepository) Save(ctx context.Context, aid int64, c domain.Collaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, aid, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockArticleCollaboratorRepositoryMockRecorder) Save(ctx, aid, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleCollaboratorRepository)(nil).Save), ctx, aid, c)
+}
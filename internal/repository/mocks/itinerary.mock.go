@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./itinerary.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./itinerary.go -package=repomocks -destination=./mocks/itinerary.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockItineraryRepository is a mock of ItineraryRepository interface.
+type MockItineraryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItineraryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockItineraryRepositoryMockRecorder is the mock recorder for MockItineraryRepository.
+type MockItineraryRepositoryMockRecorder struct {
+	mock *MockItineraryRepository
+}
+
+// NewMockItineraryRepository creates a new mock instance.
+func NewMockItineraryRepository(ctrl *gomock.Controller) *MockItineraryRepository {
+	mock := &MockItineraryRepository{ctrl: ctrl}
+	mock.recorder = &MockItineraryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItineraryRepository) EXPECT() *MockItineraryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockItineraryRepository) Create(ctx context.Context, itinerary domain.Itinerary) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, itinerary)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockItineraryRepositoryMockRecorder) Create(ctx, itinerary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockItineraryRepository)(nil).Create), ctx, itinerary)
+}
+
+// GetByAuthor mocks base method.
+func (m *MockItineraryRepository) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]domain.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockItineraryRepositoryMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockItineraryRepository)(nil).GetByAuthor), ctx, uid, offset, limit)
+}
+
+// GetByID mocks base method.
+func (m *MockItineraryRepository) GetByID(ctx context.Context, id int64) (domain.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockItineraryRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockItineraryRepository)(nil).GetByID), ctx, id)
+}
+
+// GetPubByArticleID mocks base method.
+func (m *MockItineraryRepository) GetPubByArticleID(ctx context.Context, aid int64) (domain.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByArticleID", ctx, aid)
+	ret0, _ := ret[0].(domain.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByArticleID indicates an expected call of GetPubByArticleID.
+func (mr *MockItineraryRepositoryMockRecorder) GetPubByArticleID(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByArticleID", reflect.TypeOf((*MockItineraryRepository)(nil).GetPubByArticleID), ctx, aid)
+}
+
+// GetPubByID mocks base method.
+func (m *MockItineraryRepository) GetPubByID(ctx context.Context, id int64) (domain.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByID", ctx, id)
+	ret0, _ := ret[0].(domain.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByID indicates an expected call of GetPubByID.
+func (mr *MockItineraryRepositoryMockRecorder) GetPubByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByID", reflect.TypeOf((*MockItineraryRepository)(nil).GetPubByID), ctx, id)
+}
+
+// SavePub mocks base method.
+func (m *MockItineraryRepository) SavePub(ctx context.Context, itinerary domain.Itinerary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePub", ctx, itinerary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePub indicates an expected call of SavePub.
+func (mr *MockItineraryRepositoryMockRecorder) SavePub(ctx, itinerary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePub", reflect.TypeOf((*MockItineraryRepository)(nil).SavePub), ctx, itinerary)
+}
+
+// Update mocks base method.
+func (m *MockItineraryRepository) Update(ctx context.Context, itinerary domain.Itinerary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, itinerary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockItineraryRepositoryMockRecorder) Update(ctx, itinerary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockItineraryRepository)(nil).Update), ctx, itinerary)
+}
+
+// UpdatePubStatus mocks base method.
+func (m *MockItineraryRepository) UpdatePubStatus(ctx context.Context, uid, id int64, status domain.ItineraryStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePubStatus", ctx, uid, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePubStatus indicates an expected call of UpdatePubStatus.
+func (mr *MockItineraryRepositoryMockRecorder) UpdatePubStatus(ctx, uid, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePubStatus", reflect.TypeOf((*MockItineraryRepository)(nil).UpdatePubStatus), ctx, uid, id, status)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockItineraryRepository) UpdateStatus(ctx context.Context, uid, id int64, status domain.ItineraryStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, uid, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockItineraryRepositoryMockRecorder) UpdateStatus(ctx, uid, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockItineraryRepository)(nil).UpdateStatus), ctx, uid, id, status)
+}
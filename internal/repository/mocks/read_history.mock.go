@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./read_history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./read_history.go -package=repomocks -destination=./mocks/read_history.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadHistoryRepository is a mock of ReadHistoryRepository interface.
+type MockReadHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReadHistoryRepositoryMockRecorder is the mock recorder for MockReadHistoryRepository.
+type MockReadHistoryRepositoryMockRecorder struct {
+	mock *MockReadHistoryRepository
+}
+
+// NewMockReadHistoryRepository creates a new mock instance.
+func NewMockReadHistoryRepository(ctrl *gomock.Controller) *MockReadHistoryRepository {
+	mock := &MockReadHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockReadHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadHistoryRepository) EXPECT() *MockReadHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockReadHistoryRepository) Clear(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockReadHistoryRepositoryMockRecorder) Clear(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockReadHistoryRepository)(nil).Clear), ctx, uid)
+}
+
+// Delete mocks base method.
+func (m *MockReadHistoryRepository) Delete(ctx context.Context, uid, aid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReadHistoryRepositoryMockRecorder) Delete(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReadHistoryRepository)(nil).Delete), ctx, uid, aid)
+}
+
+// List mocks base method.
+func (m *MockReadHistoryRepository) List(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.ReadRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.ReadRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockReadHistoryRepositoryMockRecorder) List(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReadHistoryRepository)(nil).List), ctx, uid, cursor, limit)
+}
+
+// Readers mocks base method.
+func (m *MockReadHistoryRepository) Readers(ctx context.Context, since time.Time, offset, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Readers", ctx, since, offset, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Readers indicates an expected call of Readers.
+func (mr *MockReadHistoryRepositoryMockRecorder) Readers(ctx, since, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Readers", reflect.TypeOf((*MockReadHistoryRepository)(nil).Readers), ctx, since, offset, limit)
+}
+
+// Record mocks base method.
+func (m *MockReadHistoryRepository) Record(ctx context.Context, records []domain.ReadRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockReadHistoryRepositoryMockRecorder) Record(ctx, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockReadHistoryRepository)(nil).Record), ctx, records)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./recommend.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./recommend.go -package=repomocks -destination=./mocks/recommend.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecommendRepository is a mock of RecommendRepository interface.
+type MockRecommendRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRecommendRepositoryMockRecorder is the mock recorder for MockRecommendRepository.
+type MockRecommendRepositoryMockRecorder struct {
+	mock *MockRecommendRepository
+}
+
+// NewMockRecommendRepository creates a new mock instance.
+func NewMockRecommendRepository(ctrl *gomock.Controller) *MockRecommendRepository {
+	mock := &MockRecommendRepository{ctrl: ctrl}
+	mock.recorder = &MockRecommendRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendRepository) EXPECT() *MockRecommendRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetForUser mocks base method.
+func (m *MockRecommendRepository) GetForUser(ctx context.Context, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUser", ctx, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUser indicates an expected call of GetForUser.
+func (mr *MockRecommendRepositoryMockRecorder) GetForUser(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUser", reflect.TypeOf((*MockRecommendRepository)(nil).GetForUser), ctx, uid)
+}
+
+// GetRelated mocks base method.
+func (m *MockRecommendRepository) GetRelated(ctx context.Context, aid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelated", ctx, aid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelated indicates an expected call of GetRelated.
+func (mr *MockRecommendRepositoryMockRecorder) GetRelated(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelated", reflect.TypeOf((*MockRecommendRepository)(nil).GetRelated), ctx, aid)
+}
+
+// SetForUsers mocks base method.
+func (m *MockRecommendRepository) SetForUsers(ctx context.Context, recs map[int64][]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetForUsers", ctx, recs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetForUsers indicates an expected call of SetForUsers.
+func (mr *MockRecommendRepositoryMockRecorder) SetForUsers(ctx, recs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetForUsers", reflect.TypeOf((*MockRecommendRepository)(nil).SetForUsers), ctx, recs)
+}
+
+// SetRelated mocks base method.
+func (m *MockRecommendRepository) SetRelated(ctx context.Context, related map[int64][]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRelated", ctx, related)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRelated indicates an expected call of SetRelated.
+func (mr *MockRecommendRepositoryMockRecorder) SetRelated(ctx, related any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRelated", reflect.TypeOf((*MockRecommendRepository)(nil).SetRelated), ctx, related)
+}
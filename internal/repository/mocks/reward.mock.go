@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./reward.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./reward.go -package=repomocks -destination=./mocks/reward.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRewardRepository is a mock of RewardRepository interface.
+type MockRewardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRewardRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRewardRepositoryMockRecorder is the mock recorder for MockRewardRepository.
+type MockRewardRepositoryMockRecorder struct {
+	mock *MockRewardRepository
+}
+
+// NewMockRewardRepository creates a new mock instance.
+func NewMockRewardRepository(ctrl *gomock.Controller) *MockRewardRepository {
+	mock := &MockRewardRepository{ctrl: ctrl}
+	mock.recorder = &MockRewardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRewardRepository) EXPECT() *MockRewardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRewardRepository) Create(ctx context.Context, r domain.Reward) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRewardRepositoryMockRecorder) Create(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRewardRepository)(nil).Create), ctx, r)
+}
+
+// GetByID mocks base method.
+func (m *MockRewardRepository) GetByID(ctx context.Context, id int64) (domain.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRewardRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRewardRepository)(nil).GetByID), ctx, id)
+}
+
+// ListPending mocks base method.
+func (m *MockRewardRepository) ListPending(ctx context.Context, before time.Time, after domain.RewardCursor, limit int) ([]domain.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, before, after, limit)
+	ret0, _ := ret[0].([]domain.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockRewardRepositoryMockRecorder) ListPending(ctx, before, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockRewardRepository)(nil).ListPending), ctx, before, after, limit)
+}
+
+// ListUnnotified mocks base method.
+func (m *MockRewardRepository) ListUnnotified(ctx context.Context, afterID int64, limit int) ([]domain.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnnotified", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnnotified indicates an expected call of ListUnnotified.
+func (mr *MockRewardRepositoryMockRecorder) ListUnnotified(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnnotified", reflect.TypeOf((*MockRewardRepository)(nil).ListUnnotified), ctx, afterID, limit)
+}
+
+// SetNotified mocks base method.
+func (m *MockRewardRepository) SetNotified(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotified", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotified indicates an expected call of SetNotified.
+func (mr *MockRewardRepositoryMockRecorder) SetNotified(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotified", reflect.TypeOf((*MockRewardRepository)(nil).SetNotified), ctx, id)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRewardRepository) UpdateStatus(ctx context.Context, id int64, status domain.RewardStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRewardRepositoryMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRewardRepository)(nil).UpdateStatus), ctx, id, status)
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
+)
+
+//go:generate mockgen -source=./read_history.go -package=repomocks -destination=./mocks/read_history.mock.go
+type ReadHistoryRepository interface {
+	Record(ctx context.Context, records []domain.ReadRecord) error
+	// List returns the records after the cursor, the latest read first. Only
+	// the IDs of the articles are set.
+	List(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.ReadRecord, error)
+	Delete(ctx context.Context, uid int64, aid int64) error
+	Clear(ctx context.Context, uid int64) error
+	// Readers returns the users who have read since the given time, the
+	// latest first.
+	Readers(ctx context.Context, since time.Time, offset int, limit int) ([]int64, error)
+}
+
+// NOTE: the history is only kept in Redis, losing it is acceptable.
+type CachedReadHistoryRepository struct {
+	cache cache.ReadHistoryCache
+}
+
+// Record implements ReadHistoryRepository.
+func (c *CachedReadHistoryRepository) Record(ctx context.Context, records []domain.ReadRecord) error {
+	return c.cache.Add(ctx, records)
+}
+
+// List implements ReadHistoryRepository.
+func (c *CachedReadHistoryRepository) List(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.ReadRecord, error) {
+	return c.cache.List(ctx, uid, cursor, limit)
+}
+
+// Delete implements ReadHistoryRepository.
+func (c *CachedReadHistoryRepository) Delete(ctx context.Context, uid int64, aid int64) error {
+	return c.cache.Del(ctx, uid, aid)
+}
+
+// Clear implements ReadHistoryRepository.
+func (c *CachedReadHistoryRepository) Clear(ctx context.Context, uid int64) error {
+	return c.cache.Clear(ctx, uid)
+}
+
+// Readers implements ReadHistoryRepository.
+func (c *CachedReadHistoryRepository) Readers(
+	ctx context.Context,
+	since time.Time,
+	offset int,
+	limit int,
+) ([]int64, error) {
+	return c.cache.Readers(ctx, since, offset, limit)
+}
+
+func NewCachedReadHistoryRepository(cache cache.ReadHistoryCache) ReadHistoryRepository {
+	return &CachedReadHistoryRepository{
+		cache: cache,
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/chenmuyao/go-bootcamp/internal/repository/cache"
+)
+
+var ErrRecommendationNotFound = cache.ErrKeyNotExist
+
+//go:generate mockgen -source=./recommend.go -package=repomocks -destination=./mocks/recommend.mock.go
+type RecommendRepository interface {
+	// SetRelated saves the IDs of the articles related to each article.
+	SetRelated(ctx context.Context, related map[int64][]int64) error
+	GetRelated(ctx context.Context, aid int64) ([]int64, error)
+	// SetForUsers saves the IDs of the articles recommended to each user.
+	SetForUsers(ctx context.Context, recs map[int64][]int64) error
+	GetForUser(ctx context.Context, uid int64) ([]int64, error)
+}
+
+// NOTE: the recommendations are computed again by the job, they are only
+// kept in Redis.
+type CachedRecommendRepository struct {
+	cache cache.RecommendCache
+}
+
+// SetRelated implements RecommendRepository.
+func (c *CachedRecommendRepository) SetRelated(
+	ctx context.Context,
+	related map[int64][]int64,
+) error {
+	return c.cache.SetRelated(ctx, related)
+}
+
+// GetRelated implements RecommendRepository.
+func (c *CachedRecommendRepository) GetRelated(ctx context.Context, aid int64) ([]int64, error) {
+	return c.cache.GetRelated(ctx, aid)
+}
+
+// SetForUsers implements RecommendRepository.
+func (c *CachedRecommendRepository) SetForUsers(
+	ctx context.Context,
+	recs map[int64][]int64,
+) error {
+	return c.cache.SetForUsers(ctx, recs)
+}
+
+// GetForUser implements RecommendRepository.
+func (c *CachedRecommendRepository) GetForUser(ctx context.Context, uid int64) ([]int64, error) {
+	return c.cache.GetForUser(ctx, uid)
+}
+
+func NewCachedRecommendRepository(cache cache.RecommendCache) RecommendRepository {
+	return &CachedRecommendRepository{
+		cache: cache,
+	}
+}
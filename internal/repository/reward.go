@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+)
+
+var ErrRewardNotFound = dao.ErrRewardNotFound
+
+//go:generate mockgen -source=./reward.go -package=repomocks -destination=./mocks/reward.mock.go
+type RewardRepository interface {
+	Create(ctx context.Context, r domain.Reward) (int64, error)
+	GetByID(ctx context.Context, id int64) (domain.Reward, error)
+	// UpdateStatus settles a reward waiting for its payment. It reports
+	// false if the reward was already settled.
+	UpdateStatus(ctx context.Context, id int64, status domain.RewardStatus) (bool, error)
+	// ListPending lists by ctime then ID the rewards waiting for their
+	// payment since before, after the cursor.
+	ListPending(
+		ctx context.Context,
+		before time.Time,
+		after domain.RewardCursor,
+		limit int,
+	) ([]domain.Reward, error)
+	// ListUnnotified lists the paid rewards whose event is not produced,
+	// with an ID greater than afterID.
+	ListUnnotified(ctx context.Context, afterID int64, limit int) ([]domain.Reward, error)
+	SetNotified(ctx context.Context, id int64) error
+}
+
+type rewardRepository struct {
+	dao dao.RewardDAO
+}
+
+// Create implements RewardRepository.
+func (r *rewardRepository) Create(ctx context.Context, reward domain.Reward) (int64, error) {
+	return r.dao.Insert(ctx, dao.Reward{
+		Biz:       reward.Biz,
+		BizID:     reward.BizID,
+		UID:       reward.UID,
+		TargetUID: reward.TargetUID,
+		Amount:    reward.Amount,
+		Status:    uint8(reward.Status),
+	})
+}
+
+// GetByID implements RewardRepository.
+func (r *rewardRepository) GetByID(ctx context.Context, id int64) (domain.Reward, error) {
+	reward, err := r.dao.GetByID(ctx, id)
+	if err != nil {
+		return domain.Reward{}, err
+	}
+	return r.toDomain(reward), nil
+}
+
+// UpdateStatus implements RewardRepository.
+func (r *rewardRepository) UpdateStatus(
+	ctx context.Context,
+	id int64,
+	status domain.RewardStatus,
+) (bool, error) {
+	return r.dao.UpdateStatus(ctx, id, uint8(status))
+}
+
+// ListPending implements RewardRepository.
+func (r *rewardRepository) ListPending(
+	ctx context.Context,
+	before time.Time,
+	after domain.RewardCursor,
+	limit int,
+) ([]domain.Reward, error) {
+	var afterCtime int64
+	if !after.Ctime.IsZero() {
+		afterCtime = after.Ctime.UnixMilli()
+	}
+	rewards, err := r.dao.ListPending(ctx, before.UnixMilli(), afterCtime, after.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(rewards, func(_ int, src dao.Reward) domain.Reward {
+		return r.toDomain(src)
+	}), nil
+}
+
+// ListUnnotified implements RewardRepository.
+func (r *rewardRepository) ListUnnotified(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+) ([]domain.Reward, error) {
+	rewards, err := r.dao.ListUnnotified(ctx, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return gslice.Map(rewards, func(_ int, src dao.Reward) domain.Reward {
+		return r.toDomain(src)
+	}), nil
+}
+
+// SetNotified implements RewardRepository.
+func (r *rewardRepository) SetNotified(ctx context.Context, id int64) error {
+	return r.dao.SetNotified(ctx, id)
+}
+
+func (r *rewardRepository) toDomain(reward dao.Reward) domain.Reward {
+	return domain.Reward{
+		ID:        reward.ID,
+		Biz:       reward.Biz,
+		BizID:     reward.BizID,
+		UID:       reward.UID,
+		TargetUID: reward.TargetUID,
+		Amount:    reward.Amount,
+		Status:    domain.RewardStatus(reward.Status),
+		Ctime:     time.UnixMilli(reward.Ctime),
+		Utime:     time.UnixMilli(reward.Utime),
+	}
+}
+
+func NewRewardRepository(dao dao.RewardDAO) RewardRepository {
+	return &rewardRepository{
+		dao: dao,
+	}
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+)
+
+const (
+	// the bizs of the ledger transactions, the ID is the reward or the
+	// withdrawal
+	accountBizReward              = "reward"
+	accountBizWithdrawal          = "withdrawal"
+	accountBizWithdrawalApproval  = "withdrawal_approval"
+	accountBizWithdrawalRejection = "withdrawal_rejection"
+
+	// the fee rates are in basis points
+	maxFeeRate = 10_000
+)
+
+var (
+	ErrInsufficientBalance     = repository.ErrInsufficientBalance
+	ErrWithdrawalNotFound      = repository.ErrWithdrawalNotFound
+	ErrWithdrawalSettled       = repository.ErrWithdrawalSettled
+	ErrInvalidWithdrawalAmount = errors.New("invalid withdrawal amount")
+	ErrNotAdmin                = errors.New("not an admin")
+)
+
+//go:generate mockgen -source=./account.go -package=svcmocks -destination=./mocks/account.mock.go
+type AccountService interface {
+	// CreditReward splits a paid reward between its author and the platform
+	// fee. It can be called several times for the same reward.
+	CreditReward(ctx context.Context, r domain.Reward) error
+	GetBalance(ctx context.Context, uid int64) (domain.Account, error)
+	// ListEntries lists the history of the account of the user, the latest
+	// first.
+	ListEntries(ctx context.Context, uid int64, offset, limit int) ([]domain.AccountEntry, error)
+
+	// RequestWithdrawal holds the amount out of the balance of the user until
+	// an admin reviews it.
+	RequestWithdrawal(ctx context.Context, uid, amount int64) (domain.Withdrawal, error)
+	ListWithdrawals(ctx context.Context, uid int64, offset, limit int) ([]domain.Withdrawal, error)
+	// ListPendingWithdrawals lists the withdrawals to review, for the admins.
+	ListPendingWithdrawals(
+		ctx context.Context,
+		reviewer int64,
+		offset, limit int,
+	) ([]domain.Withdrawal, error)
+	// ReviewWithdrawal approves or rejects a pending withdrawal, a rejected
+	// amount goes back to the author.
+	ReviewWithdrawal(ctx context.Context, id, reviewer int64, approve bool) error
+}
+
+type accountService struct {
+	repo repository.AccountRepository
+	// taken on the rewards
+	feeRate int64
+	admins  map[int64]struct{}
+}
+
+// CreditReward implements AccountService.
+func (a *accountService) CreditReward(ctx context.Context, r domain.Reward) error {
+	if r.TargetUID <= 0 || r.Amount <= 0 {
+		return errors.New("invalid reward")
+	}
+	fee := r.Amount * a.feeRate / maxFeeRate
+	entries := []domain.AccountEntry{
+		{Account: domain.Account{Type: domain.AccountTypePayin}, Amount: -r.Amount},
+		{
+			Account: domain.Account{UID: r.TargetUID, Type: domain.AccountTypeUser},
+			Amount:  r.Amount - fee,
+		},
+	}
+	if fee > 0 {
+		entries = append(entries, domain.AccountEntry{
+			Account: domain.Account{Type: domain.AccountTypePlatform},
+			Amount:  fee,
+		})
+	}
+	return a.repo.Transfer(ctx, accountBizReward, r.ID, entries)
+}
+
+// GetBalance implements AccountService.
+func (a *accountService) GetBalance(ctx context.Context, uid int64) (domain.Account, error) {
+	return a.repo.GetAccount(ctx, uid, domain.AccountTypeUser)
+}
+
+// ListEntries implements AccountService.
+func (a *accountService) ListEntries(
+	ctx context.Context,
+	uid int64,
+	offset, limit int,
+) ([]domain.AccountEntry, error) {
+	return a.repo.ListEntries(ctx, uid, domain.AccountTypeUser, offset, limit)
+}
+
+// RequestWithdrawal implements AccountService.
+func (a *accountService) RequestWithdrawal(
+	ctx context.Context,
+	uid, amount int64,
+) (domain.Withdrawal, error) {
+	if amount <= 0 {
+		return domain.Withdrawal{}, ErrInvalidWithdrawalAmount
+	}
+	now := time.Now()
+	res := domain.Withdrawal{
+		UID:    uid,
+		Amount: amount,
+		Status: domain.WithdrawalStatusPending,
+		Ctime:  now,
+		Utime:  now,
+	}
+	var err error
+	res.ID, err = a.repo.CreateWithdrawal(ctx, res, accountBizWithdrawal, []domain.AccountEntry{
+		{Account: domain.Account{UID: uid, Type: domain.AccountTypeUser}, Amount: -amount},
+		{Account: domain.Account{Type: domain.AccountTypeWithdrawing}, Amount: amount},
+	})
+	if err != nil {
+		return domain.Withdrawal{}, err
+	}
+	return res, nil
+}
+
+// ListWithdrawals implements AccountService.
+func (a *accountService) ListWithdrawals(
+	ctx context.Context,
+	uid int64,
+	offset, limit int,
+) ([]domain.Withdrawal, error) {
+	return a.repo.ListWithdrawals(ctx, uid, domain.WithdrawalStatusUnknown, offset, limit)
+}
+
+// ListPendingWithdrawals implements AccountService.
+func (a *accountService) ListPendingWithdrawals(
+	ctx context.Context,
+	reviewer int64,
+	offset, limit int,
+) ([]domain.Withdrawal, error) {
+	if !a.isAdmin(reviewer) {
+		return nil, ErrNotAdmin
+	}
+	return a.repo.ListWithdrawals(ctx, 0, domain.WithdrawalStatusPending, offset, limit)
+}
+
+// ReviewWithdrawal implements AccountService.
+func (a *accountService) ReviewWithdrawal(
+	ctx context.Context,
+	id, reviewer int64,
+	approve bool,
+) error {
+	if !a.isAdmin(reviewer) {
+		return ErrNotAdmin
+	}
+	w, err := a.repo.GetWithdrawal(ctx, id)
+	if err != nil {
+		return err
+	}
+	if w.Status != domain.WithdrawalStatusPending {
+		return ErrWithdrawalSettled
+	}
+	w.Reviewer = reviewer
+	held := domain.AccountEntry{
+		Account: domain.Account{Type: domain.AccountTypeWithdrawing},
+		Amount:  -w.Amount,
+	}
+	if approve {
+		// NOTE: the payout itself is made outside, the ledger only records it.
+		w.Status = domain.WithdrawalStatusApproved
+		return a.repo.SettleWithdrawal(ctx, w, accountBizWithdrawalApproval, []domain.AccountEntry{
+			held,
+			{Account: domain.Account{Type: domain.AccountTypePayout}, Amount: w.Amount},
+		})
+	}
+	w.Status = domain.WithdrawalStatusRejected
+	return a.repo.SettleWithdrawal(ctx, w, accountBizWithdrawalRejection, []domain.AccountEntry{
+		held,
+		{Account: domain.Account{UID: w.UID, Type: domain.AccountTypeUser}, Amount: w.Amount},
+	})
+}
+
+func (a *accountService) isAdmin(uid int64) bool {
+	_, ok := a.admins[uid]
+	return ok
+}
+
+// NewAccountService takes feeRate basis points of the rewards, and lets the
+// admins review the withdrawals.
+func NewAccountService(
+	repo repository.AccountRepository,
+	feeRate int64,
+	admins []int64,
+) AccountService {
+	set := make(map[int64]struct{}, len(admins))
+	for _, uid := range admins {
+		set[uid] = struct{}{}
+	}
+	return &accountService{
+		repo:    repo,
+		feeRate: min(max(feeRate, 0), maxFeeRate),
+		admins:  set,
+	}
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	repomocks "github.com/chenmuyao/go-bootcamp/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_accountService_CreditReward(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock    func(ctrl *gomock.Controller) repository.AccountRepository
+		feeRate int64
+		reward  domain.Reward
+
+		wantErr error
+	}{
+		{
+			name: "fee taken",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				repo.EXPECT().Transfer(gomock.Any(), "reward", int64(7), []domain.AccountEntry{
+					{Account: domain.Account{Type: domain.AccountTypePayin}, Amount: -505},
+					{
+						Account: domain.Account{UID: 456, Type: domain.AccountTypeUser},
+						// the fee is rounded down
+						Amount: 455,
+					},
+					{Account: domain.Account{Type: domain.AccountTypePlatform}, Amount: 50},
+				}).Return(nil)
+				return repo
+			},
+			feeRate: 1000,
+			reward:  domain.Reward{ID: 7, UID: 123, TargetUID: 456, Amount: 505},
+		},
+		{
+			name: "no fee",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				repo.EXPECT().Transfer(gomock.Any(), "reward", int64(7), []domain.AccountEntry{
+					{Account: domain.Account{Type: domain.AccountTypePayin}, Amount: -5},
+					{Account: domain.Account{UID: 456, Type: domain.AccountTypeUser}, Amount: 5},
+				}).Return(nil)
+				return repo
+			},
+			feeRate: 1000,
+			reward:  domain.Reward{ID: 7, UID: 123, TargetUID: 456, Amount: 5},
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				repo.EXPECT().
+					Transfer(gomock.Any(), "reward", int64(7), gomock.Any()).
+					Return(errors.New("db error"))
+				return repo
+			},
+			feeRate: 1000,
+			reward:  domain.Reward{ID: 7, UID: 123, TargetUID: 456, Amount: 500},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc := NewAccountService(tc.mock(ctrl), tc.feeRate, nil)
+			err := svc.CreditReward(context.Background(), tc.reward)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_accountService_RequestWithdrawal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock   func(ctrl *gomock.Controller) repository.AccountRepository
+		amount int64
+
+		wantID  int64
+		wantErr error
+	}{
+		{
+			name: "held",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				repo.EXPECT().
+					CreateWithdrawal(gomock.Any(), gomock.Any(), "withdrawal", []domain.AccountEntry{
+						{
+							Account: domain.Account{UID: 123, Type: domain.AccountTypeUser},
+							Amount:  -300,
+						},
+						{
+							Account: domain.Account{Type: domain.AccountTypeWithdrawing},
+							Amount:  300,
+						},
+					}).
+					Return(int64(9), nil)
+				return repo
+			},
+			amount: 300,
+			wantID: 9,
+		},
+		{
+			name: "insufficient balance",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				repo.EXPECT().
+					CreateWithdrawal(gomock.Any(), gomock.Any(), "withdrawal", gomock.Any()).
+					Return(int64(0), repository.ErrInsufficientBalance)
+				return repo
+			},
+			amount:  300,
+			wantErr: ErrInsufficientBalance,
+		},
+		{
+			name: "invalid amount",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				return nil
+			},
+			amount:  -1,
+			wantErr: ErrInvalidWithdrawalAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc := NewAccountService(tc.mock(ctrl), 1000, nil)
+			w, err := svc.RequestWithdrawal(context.Background(), 123, tc.amount)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantID, w.ID)
+			if err == nil {
+				assert.Equal(t, domain.WithdrawalStatusPending, w.Status)
+			}
+		})
+	}
+}
+
+func Test_accountService_ReviewWithdrawal(t *testing.T) {
+	pending := domain.Withdrawal{
+		ID:     9,
+		UID:    123,
+		Amount: 300,
+		Status: domain.WithdrawalStatusPending,
+	}
+	testCases := []struct {
+		name string
+
+		mock     func(ctrl *gomock.Controller) repository.AccountRepository
+		reviewer int64
+		approve  bool
+
+		wantErr error
+	}{
+		{
+			name: "approved",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				repo.EXPECT().GetWithdrawal(gomock.Any(), int64(9)).Return(pending, nil)
+				approved := pending
+				approved.Status = domain.WithdrawalStatusApproved
+				approved.Reviewer = 1
+				repo.EXPECT().
+					SettleWithdrawal(gomock.Any(), approved, "withdrawal_approval", []domain.AccountEntry{
+						{
+							Account: domain.Account{Type: domain.AccountTypeWithdrawing},
+							Amount:  -300,
+						},
+						{Account: domain.Account{Type: domain.AccountTypePayout}, Amount: 300},
+					}).
+					Return(nil)
+				return repo
+			},
+			reviewer: 1,
+			approve:  true,
+		},
+		{
+			name: "rejected",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				repo.EXPECT().GetWithdrawal(gomock.Any(), int64(9)).Return(pending, nil)
+				rejected := pending
+				rejected.Status = domain.WithdrawalStatusRejected
+				rejected.Reviewer = 1
+				repo.EXPECT().
+					SettleWithdrawal(gomock.Any(), rejected, "withdrawal_rejection", []domain.AccountEntry{
+						{
+							Account: domain.Account{Type: domain.AccountTypeWithdrawing},
+							Amount:  -300,
+						},
+						// back to the author
+						{
+							Account: domain.Account{UID: 123, Type: domain.AccountTypeUser},
+							Amount:  300,
+						},
+					}).
+					Return(nil)
+				return repo
+			},
+			reviewer: 1,
+		},
+		{
+			name: "already reviewed",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				approved := pending
+				approved.Status = domain.WithdrawalStatusApproved
+				repo.EXPECT().GetWithdrawal(gomock.Any(), int64(9)).Return(approved, nil)
+				return repo
+			},
+			reviewer: 1,
+			approve:  true,
+			wantErr:  ErrWithdrawalSettled,
+		},
+		{
+			name: "reviewed concurrently",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				repo := repomocks.NewMockAccountRepository(ctrl)
+				repo.EXPECT().GetWithdrawal(gomock.Any(), int64(9)).Return(pending, nil)
+				repo.EXPECT().
+					SettleWithdrawal(gomock.Any(), gomock.Any(), "withdrawal_approval", gomock.Any()).
+					Return(repository.ErrWithdrawalSettled)
+				return repo
+			},
+			reviewer: 1,
+			approve:  true,
+			wantErr:  ErrWithdrawalSettled,
+		},
+		{
+			name: "not admin",
+			mock: func(ctrl *gomock.Controller) repository.AccountRepository {
+				return nil
+			},
+			reviewer: 123,
+			approve:  true,
+			wantErr:  ErrNotAdmin,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc := NewAccountService(tc.mock(ctrl), 1000, []int64{1})
+			err := svc.ReviewWithdrawal(context.Background(), 9, tc.reviewer, tc.approve)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,534 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/events/article"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/chenmuyao/go-bootcamp/pkg/markdown"
+)
+
+const (
+	// the authors with more articles must export in the background
+	maxSyncExportArticles = 100
+	exportBatchSize       = 100
+	// a pending export is started again after that, the consumer may have
+	// died in the middle
+	exportStaleAfter = time.Hour
+
+	maxImportFiles     = 500
+	maxImportSize      = 100 << 20
+	maxMarkdownSize    = 1 << 20
+	maxImageSize       = 5 << 20
+	maxArticleTitleLen = 256
+
+	archiveImageDir = "images"
+)
+
+var (
+	ErrExportTooLarge        = errors.New("too many articles to export at once")
+	ErrArchiveNotFound       = repository.ErrArchiveNotFound
+	ErrArticleExportNotFound = repository.ErrArticleExportNotFound
+	ErrImageNotFound         = repository.ErrImageNotFound
+	ErrInvalidArchive        = errors.New("invalid archive")
+)
+
+var (
+	errFileTooLarge = errors.New("file too large")
+	errMissingTitle = errors.New("missing title")
+	errTitleTooLong = errors.New("title too long")
+)
+
+var (
+	// the images are named by their content hash, see importImage
+	imageNameRegexp = regexp.MustCompile(`^[0-9a-f]{64}\.(png|jpg|jpeg|gif|webp)$`)
+	// NOTE: no svg, it can carry scripts
+	imageExts = map[string]struct{}{
+		".png":  {},
+		".jpg":  {},
+		".jpeg": {},
+		".gif":  {},
+		".webp": {},
+	}
+)
+
+//go:generate mockgen -source=./archive.go -package=svcmocks -destination=./mocks/archive.mock.go
+type ArchiveService interface {
+	// Export writes a zip of all the articles of the author to w, with a
+	// Markdown file per article and their images. It returns
+	// ErrExportTooLarge before writing anything if there are too many
+	// articles, which must be exported in the background with StartExport.
+	Export(ctx context.Context, uid int64, w io.Writer) error
+	// StartExport starts a background export, unless one is running.
+	StartExport(ctx context.Context, uid int64) (domain.ArticleExport, error)
+	// RunExport builds the archive of a background export. A failed export
+	// is recorded, the error is only returned if it can't be recorded.
+	RunExport(ctx context.Context, uid int64) error
+	GetExport(ctx context.Context, uid int64) (domain.ArticleExport, error)
+	// GetArchive returns the archive of the last export done.
+	GetArchive(ctx context.Context, uid int64) ([]byte, error)
+	// Import creates a draft for each Markdown file of the zip, the errors of
+	// the files are reported in their result.
+	Import(ctx context.Context, uid int64, r io.ReaderAt, size int64) ([]domain.ImportResult, error)
+	GetImage(ctx context.Context, uid int64, name string) ([]byte, error)
+}
+
+type archiveService struct {
+	l           logger.Logger
+	repo        repository.ArticleArchiveRepository
+	artRepo     repository.ArticleRepository
+	artSvc      ArticleService
+	producer    article.Producer
+	maxSyncArts int
+	batchSize   int
+}
+
+// Export implements ArchiveService.
+func (a *archiveService) Export(ctx context.Context, uid int64, w io.Writer) error {
+	arts, err := a.listArticles(ctx, uid, a.maxSyncArts)
+	if err != nil {
+		return err
+	}
+	return a.writeArchive(ctx, uid, arts, w)
+}
+
+// StartExport implements ArchiveService.
+func (a *archiveService) StartExport(ctx context.Context, uid int64) (domain.ArticleExport, error) {
+	export, err := a.repo.GetExport(ctx, uid)
+	switch {
+	case err == nil:
+		if export.Status == domain.ExportStatusPending &&
+			time.Since(export.Utime) < exportStaleAfter {
+			return export, nil
+		}
+	case err != repository.ErrArticleExportNotFound:
+		return domain.ArticleExport{}, err
+	}
+
+	err = a.repo.StartExport(ctx, uid)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	err = a.producer.ProduceExportEvent(article.ExportEvent{Uid: uid})
+	if err != nil {
+		// NOTE: don't leave it pending, the user couldn't start it again
+		// before it becomes stale.
+		er := a.repo.FinishExport(ctx, uid, nil, err)
+		if er != nil {
+			a.l.Error("failed to record export error", logger.Int64("uid", uid), logger.Error(er))
+		}
+		return domain.ArticleExport{}, err
+	}
+	return a.repo.GetExport(ctx, uid)
+}
+
+// RunExport implements ArchiveService.
+func (a *archiveService) RunExport(ctx context.Context, uid int64) error {
+	var buf bytes.Buffer
+	arts, err := a.listArticles(ctx, uid, 0)
+	if err == nil {
+		err = a.writeArchive(ctx, uid, arts, &buf)
+	}
+	if err != nil {
+		a.l.Error("failed to export articles", logger.Int64("uid", uid), logger.Error(err))
+	}
+	return a.repo.FinishExport(ctx, uid, buf.Bytes(), err)
+}
+
+// GetExport implements ArchiveService.
+func (a *archiveService) GetExport(ctx context.Context, uid int64) (domain.ArticleExport, error) {
+	return a.repo.GetExport(ctx, uid)
+}
+
+// GetArchive implements ArchiveService.
+func (a *archiveService) GetArchive(ctx context.Context, uid int64) ([]byte, error) {
+	export, err := a.repo.GetExport(ctx, uid)
+	if err == repository.ErrArticleExportNotFound {
+		return nil, ErrArchiveNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != domain.ExportStatusDone {
+		return nil, ErrArchiveNotFound
+	}
+	return a.repo.GetArchive(ctx, uid)
+}
+
+// Import implements ArchiveService.
+func (a *archiveService) Import(
+	ctx context.Context,
+	uid int64,
+	r io.ReaderAt,
+	size int64,
+) ([]domain.ImportResult, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	if len(zr.File) > maxImportFiles {
+		return nil, fmt.Errorf("%w: more than %d files", ErrInvalidArchive, maxImportFiles)
+	}
+
+	imp := &importer{
+		svc:    a,
+		uid:    uid,
+		files:  make(map[string]*zip.File, len(zr.File)),
+		images: make(map[string]string),
+	}
+	var docs []*zip.File
+	for _, f := range zr.File {
+		name := path.Clean(f.Name)
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		imp.files[name] = f
+		if strings.EqualFold(path.Ext(name), ".md") {
+			docs = append(docs, f)
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+
+	res := make([]domain.ImportResult, 0, len(docs))
+	for _, f := range docs {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+		aid, err := imp.importFile(ctx, f)
+		result := domain.ImportResult{File: f.Name, ArticleID: aid}
+		if err != nil {
+			result.Err = err.Error()
+		}
+		res = append(res, result)
+	}
+	return res, nil
+}
+
+// GetImage implements ArchiveService.
+func (a *archiveService) GetImage(ctx context.Context, uid int64, name string) ([]byte, error) {
+	if !imageNameRegexp.MatchString(name) {
+		return nil, ErrImageNotFound
+	}
+	return a.repo.GetImage(ctx, uid, name)
+}
+
+// listArticles returns the drafts of the author, with their content. It
+// fails with ErrExportTooLarge if there are more than limit articles, 0 for
+// no limit.
+func (a *archiveService) listArticles(
+	ctx context.Context,
+	uid int64,
+	limit int,
+) ([]domain.Article, error) {
+	var res []domain.Article
+	cursor := domain.Cursor{}
+	for {
+		arts, err := a.artRepo.GetByAuthor(ctx, uid, cursor, a.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, arts...)
+		if limit > 0 && len(res) > limit {
+			return nil, ErrExportTooLarge
+		}
+		if len(arts) < a.batchSize {
+			return res, nil
+		}
+		cursor = domain.ArticleCursor(arts[len(arts)-1])
+	}
+}
+
+func (a *archiveService) writeArchive(
+	ctx context.Context,
+	uid int64,
+	arts []domain.Article,
+	w io.Writer,
+) error {
+	zw := zip.NewWriter(w)
+	images := make(map[string]string)
+	for start := 0; start < len(arts); start += a.batchSize {
+		// NOTE: the listed articles may only have their abstract.
+		ids := make([]int64, 0, a.batchSize)
+		for _, listed := range arts[start:min(start+a.batchSize, len(arts))] {
+			ids = append(ids, listed.ID)
+		}
+		// the ones deleted in the meantime are skipped
+		batch, err := a.artRepo.BatchGetByIDs(ctx, uid, ids)
+		if err != nil {
+			return err
+		}
+		for _, art := range batch {
+			err = a.writeArticle(ctx, uid, art, zw, images)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return zw.Close()
+}
+
+func (a *archiveService) writeArticle(
+	ctx context.Context,
+	uid int64,
+	art domain.Article,
+	zw *zip.Writer,
+	images map[string]string,
+) error {
+	content, err := a.exportImages(ctx, uid, art.Content, zw, images)
+	if err != nil {
+		return err
+	}
+	data, err := markdown.Marshal(markdown.Document{
+		FrontMatter: markdown.FrontMatter{
+			Title:   art.Title,
+			Status:  statusName(art.Status),
+			Tags:    art.Tags,
+			Created: art.Ctime,
+			Updated: art.Utime,
+		},
+		Content: content,
+	})
+	if err != nil {
+		return err
+	}
+	return writeZipFile(zw, articleFileName(art), art.Utime, data)
+}
+
+// exportImages adds the images of the author found in the content to the
+// archive, and returns the content linking to them. The other links are kept.
+func (a *archiveService) exportImages(
+	ctx context.Context,
+	uid int64,
+	content string,
+	zw *zip.Writer,
+	images map[string]string,
+) (string, error) {
+	prefix := imageURL(uid, "")
+	var err error
+	content = markdown.ReplaceImages(content, func(src string) string {
+		name, ok := strings.CutPrefix(src, prefix)
+		if !ok || err != nil {
+			return src
+		}
+		if dst, ok := images[name]; ok {
+			return dst
+		}
+		data, er := a.GetImage(ctx, uid, name)
+		switch {
+		case er == ErrImageNotFound:
+			return src
+		case er != nil:
+			err = er
+			return src
+		}
+		dst := path.Join(archiveImageDir, name)
+		err = writeZipFile(zw, dst, time.Now(), data)
+		images[name] = dst
+		return dst
+	})
+	return content, err
+}
+
+// importer imports the files of an archive.
+type importer struct {
+	svc   *archiveService
+	uid   int64
+	files map[string]*zip.File
+	// uncompressed bytes read so far
+	read int64
+	// path in the archive -> URL of the stored image
+	images map[string]string
+}
+
+func (i *importer) importFile(ctx context.Context, f *zip.File) (int64, error) {
+	data, err := i.readFile(f, maxMarkdownSize)
+	if err != nil {
+		return 0, err
+	}
+	doc, err := markdown.Unmarshal(data)
+	if err != nil {
+		return 0, err
+	}
+	title := strings.TrimSpace(doc.FrontMatter.Title)
+	if title == "" {
+		title = strings.TrimSuffix(path.Base(f.Name), path.Ext(f.Name))
+	}
+	switch {
+	case title == "":
+		return 0, errMissingTitle
+	case len([]rune(title)) > maxArticleTitleLen:
+		return 0, errTitleTooLong
+	}
+	tags, err := domain.NormalizeTags(doc.FrontMatter.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	dir := path.Dir(path.Clean(f.Name))
+	content := markdown.ReplaceImages(doc.Content, func(src string) string {
+		if err != nil {
+			return src
+		}
+		var url string
+		url, err = i.importImage(ctx, path.Join(dir, src))
+		if url == "" {
+			return src
+		}
+		return url
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// NOTE: always imported as drafts, the author publishes them after
+	// checking.
+	return i.svc.artSvc.Save(ctx, domain.Article{
+		Title:   title,
+		Content: content,
+		Tags:    tags,
+		Author:  domain.Author{ID: i.uid},
+	})
+}
+
+// importImage stores the image of the archive and returns its URL, or an
+// empty URL if the link is not to an image of the archive.
+func (i *importer) importImage(ctx context.Context, name string) (string, error) {
+	if url, ok := i.images[name]; ok {
+		return url, nil
+	}
+	f, ok := i.files[name]
+	if !ok {
+		return "", nil
+	}
+	ext := strings.ToLower(path.Ext(name))
+	if _, ok := imageExts[ext]; !ok {
+		return "", nil
+	}
+	data, err := i.readFile(f, maxImageSize)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	sum := sha256.Sum256(data)
+	stored := hex.EncodeToString(sum[:]) + ext
+	err = i.svc.repo.PutImage(ctx, i.uid, stored, data)
+	if err != nil {
+		return "", err
+	}
+	url := imageURL(i.uid, stored)
+	i.images[name] = url
+	return url, nil
+}
+
+// readFile reads at most limit bytes of the file, and maxImportSize in
+// total. The sizes in the header can't be trusted.
+func (i *importer) readFile(f *zip.File, limit int64) ([]byte, error) {
+	limit = min(limit, maxImportSize-i.read)
+	if f.UncompressedSize64 > uint64(max(limit, 0)) {
+		return nil, errFileTooLarge
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	i.read += int64(len(data))
+	if int64(len(data)) > limit {
+		return nil, errFileTooLarge
+	}
+	return data, nil
+}
+
+func writeZipFile(zw *zip.Writer, name string, modified time.Time, data []byte) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
+
+// imageURL is where the web serves the image.
+func imageURL(uid int64, name string) string {
+	return "/images/" + strconv.FormatInt(uid, 10) + "/" + name
+}
+
+// articleFileName is like "12-my-first-article.md".
+func articleFileName(art domain.Article) string {
+	const maxSlugLen = 50
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatInt(art.ID, 10))
+	dash := true
+	n := 0
+	for _, r := range strings.ToLower(art.Title) {
+		if n >= maxSlugLen {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			n++
+			continue
+		}
+		dash = true
+	}
+	sb.WriteString(".md")
+	return sb.String()
+}
+
+func statusName(status domain.ArticleStatus) string {
+	switch status {
+	case domain.ArticleStatusUnpublished:
+		return "draft"
+	case domain.ArticleStatusPublished:
+		return "published"
+	case domain.ArticleStatusPrivate:
+		return "private"
+	default:
+		return ""
+	}
+}
+
+func NewArchiveService(
+	l logger.Logger,
+	repo repository.ArticleArchiveRepository,
+	artRepo repository.ArticleRepository,
+	artSvc ArticleService,
+	producer article.Producer,
+) ArchiveService {
+	return &archiveService{
+		l:           l,
+		repo:        repo,
+		artRepo:     artRepo,
+		artSvc:      artSvc,
+		producer:    producer,
+		maxSyncArts: maxSyncExportArticles,
+		batchSize:   exportBatchSize,
+	}
+}
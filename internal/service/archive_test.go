@@ -0,0 +1,267 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	repomocks "github.com/chenmuyao/go-bootcamp/internal/repository/mocks"
+	svcmocks "github.com/chenmuyao/go-bootcamp/internal/service/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_archiveService_Export(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli()).UTC()
+	png := []byte("png data")
+	sum := sha256.Sum256(png)
+	imgName := hex.EncodeToString(sum[:]) + ".png"
+	arts := []domain.Article{
+		{
+			ID:      2,
+			Title:   "Hello, World!",
+			Content: "![cat](/images/10/" + imgName + ") ![ext](https://example.com/a.png)",
+			Tags:    []string{"go"},
+			Status:  domain.ArticleStatusPublished,
+			Ctime:   now,
+			Utime:   now,
+		},
+		{ID: 1, Title: "draft", Content: "text", Status: domain.ArticleStatusUnpublished, Utime: now},
+	}
+
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (
+			repository.ArticleArchiveRepository,
+			repository.ArticleRepository,
+		)
+		maxSyncArts int
+
+		wantErr   error
+		wantFiles map[string]string
+	}{
+		{
+			name: "exported",
+			mock: func(ctrl *gomock.Controller) (
+				repository.ArticleArchiveRepository,
+				repository.ArticleRepository,
+			) {
+				repo := repomocks.NewMockArticleArchiveRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				// abstracts only in the list
+				artRepo.EXPECT().GetByAuthor(gomock.Any(), int64(10), domain.Cursor{}, 2).Return([]domain.Article{
+					{ID: 2, Utime: now},
+					{ID: 1, Utime: now},
+				}, nil)
+				artRepo.EXPECT().
+					GetByAuthor(gomock.Any(), int64(10), domain.Cursor{ID: 1, Time: now}, 2).
+					Return(nil, nil)
+				artRepo.EXPECT().
+					BatchGetByIDs(gomock.Any(), int64(10), []int64{2, 1}).
+					Return(arts, nil)
+				repo.EXPECT().GetImage(gomock.Any(), int64(10), imgName).Return(png, nil)
+				return repo, artRepo
+			},
+			maxSyncArts: 100,
+			wantFiles: map[string]string{
+				"2-hello-world.md": "---\ntitle: Hello, World!\nstatus: published\ntags: [go]\n" +
+					"created: " + now.Format(time.RFC3339Nano) + "\n" +
+					"updated: " + now.Format(time.RFC3339Nano) + "\n---\n\n" +
+					"![cat](images/" + imgName + ") ![ext](https://example.com/a.png)",
+				"1-draft.md": "---\ntitle: draft\nstatus: draft\n" +
+					"updated: " + now.Format(time.RFC3339Nano) + "\n---\n\ntext",
+				"images/" + imgName: string(png),
+			},
+		},
+		{
+			name: "in batches, deleted in the meantime",
+			mock: func(ctrl *gomock.Controller) (
+				repository.ArticleArchiveRepository,
+				repository.ArticleRepository,
+			) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByAuthor(gomock.Any(), int64(10), domain.Cursor{}, 2).Return([]domain.Article{
+					{ID: 3, Utime: now},
+					{ID: 2, Utime: now},
+				}, nil)
+				artRepo.EXPECT().
+					GetByAuthor(gomock.Any(), int64(10), domain.Cursor{ID: 2, Time: now}, 2).
+					Return([]domain.Article{{ID: 1, Utime: now}}, nil)
+				artRepo.EXPECT().
+					BatchGetByIDs(gomock.Any(), int64(10), []int64{3, 2}).
+					Return([]domain.Article{{
+						ID:      3,
+						Title:   "third",
+						Content: "more",
+						Status:  domain.ArticleStatusUnpublished,
+						Utime:   now,
+					}}, nil)
+				artRepo.EXPECT().
+					BatchGetByIDs(gomock.Any(), int64(10), []int64{1}).
+					Return(arts[1:], nil)
+				return nil, artRepo
+			},
+			maxSyncArts: 100,
+			wantFiles: map[string]string{
+				"3-third.md": "---\ntitle: third\nstatus: draft\n" +
+					"updated: " + now.Format(time.RFC3339Nano) + "\n---\n\nmore",
+				"1-draft.md": "---\ntitle: draft\nstatus: draft\n" +
+					"updated: " + now.Format(time.RFC3339Nano) + "\n---\n\ntext",
+			},
+		},
+		{
+			name: "too many articles",
+			mock: func(ctrl *gomock.Controller) (
+				repository.ArticleArchiveRepository,
+				repository.ArticleRepository,
+			) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByAuthor(gomock.Any(), int64(10), domain.Cursor{}, 2).Return(arts, nil)
+				return nil, artRepo
+			},
+			maxSyncArts: 1,
+			wantErr:     ErrExportTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewArchiveService(logger.NewNopLogger(), repo, artRepo, nil, nil).(*archiveService)
+			svc.batchSize = 2
+			svc.maxSyncArts = tc.maxSyncArts
+
+			var buf bytes.Buffer
+			err := svc.Export(context.Background(), 10, &buf)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				assert.Zero(t, buf.Len())
+				return
+			}
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.NoError(t, err)
+			files := make(map[string]string, len(zr.File))
+			for _, f := range zr.File {
+				rc, err := f.Open()
+				require.NoError(t, err)
+				data, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				rc.Close()
+				files[f.Name] = string(data)
+			}
+			assert.Equal(t, tc.wantFiles, files)
+		})
+	}
+}
+
+func Test_archiveService_Import(t *testing.T) {
+	png := []byte("png data")
+	sum := sha256.Sum256(png)
+	imgURL := "/images/10/" + hex.EncodeToString(sum[:]) + ".png"
+
+	testCases := []struct {
+		name string
+
+		mock  func(ctrl *gomock.Controller) (repository.ArticleArchiveRepository, ArticleService)
+		files map[string][]byte
+
+		wantErr     error
+		wantResults []domain.ImportResult
+	}{
+		{
+			name: "imported with per file errors",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleArchiveRepository, ArticleService) {
+				repo := repomocks.NewMockArticleArchiveRepository(ctrl)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				// stored once for both files
+				repo.EXPECT().PutImage(gomock.Any(), int64(10), imgURL[len("/images/10/"):], png).Return(nil)
+				artSvc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:   "First",
+					Content: "![cat](" + imgURL + ") ![gone](images/missing.png)",
+					Tags:    []string{"go", "web"},
+					Author:  domain.Author{ID: 10},
+				}).Return(int64(1), nil)
+				artSvc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:   "no-front-matter",
+					Content: "![cat](" + imgURL + ")",
+					Author:  domain.Author{ID: 10},
+				}).Return(int64(2), nil)
+				artSvc.EXPECT().Save(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db error"))
+				return repo, artSvc
+			},
+			files: map[string][]byte{
+				"a.md": []byte("---\ntitle: First\nstatus: published\ntags: [Go, web, go]\n---\n" +
+					"![cat](images/cat.png) ![gone](images/missing.png)"),
+				"b/bad-tags.md":        []byte("---\ntitle: Bad\ntags: [\"\"]\n---\ntext"),
+				"b/no-front-matter.md": []byte("![cat](../images/cat.png)"),
+				"c.md":                 []byte("---\ntitle: Failed\n---\ntext"),
+				"images/cat.png":       png,
+				"notes.txt":            []byte("ignored"),
+			},
+			wantResults: []domain.ImportResult{
+				{File: "a.md", ArticleID: 1},
+				{File: "b/bad-tags.md", Err: domain.ErrInvalidTags.Error()},
+				{File: "b/no-front-matter.md", ArticleID: 2},
+				{File: "c.md", Err: "db error"},
+			},
+		},
+		{
+			name: "file too large",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleArchiveRepository, ArticleService) {
+				return nil, nil
+			},
+			files: map[string][]byte{
+				"big.md": bytes.Repeat([]byte("a"), maxMarkdownSize+1),
+			},
+			wantResults: []domain.ImportResult{
+				{File: "big.md", Err: errFileTooLarge.Error()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, artSvc := tc.mock(ctrl)
+			svc := NewArchiveService(logger.NewNopLogger(), repo, nil, artSvc, nil)
+
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			for name, data := range tc.files {
+				fw, err := zw.Create(name)
+				require.NoError(t, err)
+				_, err = fw.Write(data)
+				require.NoError(t, err)
+			}
+			require.NoError(t, zw.Close())
+
+			results, err := svc.Import(
+				context.Background(),
+				10,
+				bytes.NewReader(buf.Bytes()),
+				int64(buf.Len()),
+			)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantResults, results)
+		})
+	}
+
+	t.Run("invalid archive", func(t *testing.T) {
+		svc := NewArchiveService(logger.NewNopLogger(), nil, nil, nil, nil)
+		data := []byte("not a zip")
+		_, err := svc.Import(context.Background(), 10, bytes.NewReader(data), int64(len(data)))
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+	})
+}
@@ -5,34 +5,81 @@ import (
 	"errors"
 	"time"
 
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
 	"github.com/chenmuyao/go-bootcamp/internal/domain"
 	"github.com/chenmuyao/go-bootcamp/internal/events/article"
 	"github.com/chenmuyao/go-bootcamp/internal/repository"
 	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"golang.org/x/sync/errgroup"
 )
 
 const publishMaxRetry = 3
 
 var (
-	ErrArticleNotFound = repository.ErrArticleNotFound
-	ErrPublish         = errors.New("still failed to publish article after retries")
+	ErrArticleNotFound     = repository.ErrArticleNotFound
+	ErrPublish             = errors.New("still failed to publish article after retries")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrReviewRequired      = errors.New("the draft must be approved by a reviewer")
+	ErrInvalidCollaborator = errors.New("invalid collaborator")
 )
 
+type VersionConflictError = repository.VersionConflictError
+
 //go:generate mockgen -source=./article.go -package=svcmocks -destination=./mocks/article.mock.go
 type ArticleService interface {
 	Save(ctx context.Context, article domain.Article) (int64, error)
 	Publish(ctx context.Context, article domain.Article) (int64, error)
 	Withdraw(ctx context.Context, userID int64, articleID int64) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// GetByAuthor and ListPub list the articles after the cursor, the latest
+	// updated first.
+	GetByAuthor(
+		ctx context.Context,
+		uid int64,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.Article, error)
 	GetByID(ctx context.Context, id int64) (domain.Article, error)
 	GetPubByID(ctx context.Context, id int64, uid int64) (domain.Article, error)
 	BatchGetPubByIDs(ctx context.Context, ids []int64) ([]domain.Article, error)
-	ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error)
+	// ListPubByIDs returns the published articles among ids in the same
+	// order, the withdrawn or deleted ones are skipped.
+	ListPubByIDs(ctx context.Context, ids []int64) ([]domain.Article, error)
+	ListPub(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error)
+	// ListPubNearby returns the published articles having a place within
+	// radius meters after the cursor, sorted by distance.
+	ListPubNearby(
+		ctx context.Context,
+		lng, lat, radius float64,
+		after domain.NearbyCursor,
+		limit int,
+	) ([]domain.NearbyArticle, error)
+	// Delete moves an article to the trash, it can be restored until it is
+	// purged.
+	Delete(ctx context.Context, uid int64, id int64) error
+	Restore(ctx context.Context, uid int64, id int64) error
+	ListTrash(
+		ctx context.Context,
+		uid int64,
+		cursor domain.Cursor,
+		limit int,
+	) ([]domain.Article, error)
+	// PurgeTrash permanently deletes at most limit articles put in the trash
+	// before the given time, with their interactions, and returns the number
+	// of articles purged.
+	PurgeTrash(ctx context.Context, before time.Time, limit int) (int, error)
+	// AddCollaborator and RemoveCollaborator can only be called by the author.
+	AddCollaborator(ctx context.Context, uid int64, aid int64, c domain.Collaborator) error
+	RemoveCollaborator(ctx context.Context, uid int64, aid int64, collaboratorID int64) error
+	// Approve is called by a reviewer to allow the current version of the
+	// draft to be published.
+	Approve(ctx context.Context, uid int64, aid int64) error
 }
 
 type articleService struct {
-	l    logger.Logger
-	repo repository.ArticleRepository
+	l          logger.Logger
+	repo       repository.ArticleRepository
+	collabRepo repository.ArticleCollaboratorRepository
+	intrSvc    intrv1.InteractiveServiceClient
 
 	// v1: separate reader and author at repo level
 	readerRepo repository.ArticleReaderRepository
@@ -43,11 +90,20 @@ type articleService struct {
 // ListPub implements ArticleService.
 func (a *articleService) ListPub(
 	ctx context.Context,
-	start time.Time,
-	offset int,
+	cursor domain.Cursor,
 	limit int,
 ) ([]domain.Article, error) {
-	return a.repo.ListPub(ctx, start, offset, limit)
+	return a.repo.ListPub(ctx, cursor, limit)
+}
+
+// ListPubNearby implements ArticleService.
+func (a *articleService) ListPubNearby(
+	ctx context.Context,
+	lng, lat, radius float64,
+	after domain.NearbyCursor,
+	limit int,
+) ([]domain.NearbyArticle, error) {
+	return a.repo.ListPubNearby(ctx, lng, lat, radius, after, limit)
 }
 
 // BatchGetPubByIDs implements ArticleService.
@@ -58,6 +114,38 @@ func (a *articleService) BatchGetPubByIDs(
 	return a.repo.BatchGetPubByIDs(ctx, ids)
 }
 
+// ListPubByIDs implements ArticleService.
+func (a *articleService) ListPubByIDs(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	// NOTE: one by one from the cache, BatchGetPubByIDs fails as soon as an
+	// article has been withdrawn or deleted.
+	arts := make([]domain.Article, len(ids))
+	var eg errgroup.Group
+	for i, id := range ids {
+		eg.Go(func() error {
+			art, err := a.repo.GetPubByID(ctx, id)
+			switch {
+			case err == repository.ErrArticleNotFound:
+				return nil
+			case err != nil:
+				return err
+			case art.Status == domain.ArticleStatusPublished:
+				arts[i] = art
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	res := arts[:0]
+	for _, art := range arts {
+		if art.ID != 0 {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
 // GetPubByID implements ArticleService.
 func (a *articleService) GetPubByID(
 	ctx context.Context,
@@ -87,30 +175,82 @@ func (a *articleService) GetPubByID(
 
 // GetByID implements ArticleService.
 func (a *articleService) GetByID(ctx context.Context, id int64) (domain.Article, error) {
-	return a.repo.GetByID(ctx, id)
+	article, err := a.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	article.Collaborators, err = a.collabRepo.GetByArticle(ctx, id)
+	return article, err
+}
+
+// getEditable returns the draft if uid can edit it.
+func (a *articleService) getEditable(
+	ctx context.Context,
+	uid int64,
+	id int64,
+) (domain.Article, error) {
+	article, err := a.GetByID(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	switch {
+	case article.CanEdit(uid):
+		return article, nil
+	case article.CanView(uid):
+		return domain.Article{}, ErrPermissionDenied
+	default:
+		// NOTE: don't tell the others that the draft exists
+		return domain.Article{}, ErrArticleNotFound
+	}
 }
 
 // GetByAuthor implements ArticleService.
 func (a *articleService) GetByAuthor(
 	ctx context.Context,
 	uid int64,
-	offset int,
+	cursor domain.Cursor,
 	limit int,
 ) ([]domain.Article, error) {
-	return a.repo.GetByAuthor(ctx, uid, offset, limit)
+	return a.repo.GetByAuthor(ctx, uid, cursor, limit)
 }
 
+// Save and Publish get the author of the article for the editors.
 func (a *articleService) Save(ctx context.Context, article domain.Article) (int64, error) {
 	article.Status = domain.ArticleStatusUnpublished
-	if article.ID > 0 {
-		return article.ID, a.repo.Update(ctx, article)
-	} else {
+	if article.ID <= 0 {
 		return a.repo.Create(ctx, article)
 	}
+	draft, err := a.getEditable(ctx, article.Author.ID, article.ID)
+	if err != nil {
+		return 0, err
+	}
+	article.Author.ID = draft.Author.ID
+	// NOTE: the new version of the draft has to be reviewed again, the
+	// approvals are bound to the version they approved.
+	err = a.repo.Update(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+	return article.ID, nil
 }
 
 func (a *articleService) Publish(ctx context.Context, article domain.Article) (int64, error) {
 	article.Status = domain.ArticleStatusPublished
+	if article.ID <= 0 {
+		return a.repo.Sync(ctx, article)
+	}
+	draft, err := a.getEditable(ctx, article.Author.ID, article.ID)
+	if err != nil {
+		return 0, err
+	}
+	if draft.ReviewRequired() {
+		// NOTE: only the approved draft can be published, not a new version.
+		if !draft.Approved() || article.Version != draft.Version ||
+			!article.SameContent(draft) {
+			return 0, ErrReviewRequired
+		}
+	}
+	article.Author.ID = draft.Author.ID
 	return a.repo.Sync(ctx, article)
 }
 
@@ -149,7 +289,114 @@ func (a *articleService) PublishV1(ctx context.Context, article domain.Article)
 }
 
 func (a *articleService) Withdraw(ctx context.Context, userID int64, articleID int64) error {
-	return a.repo.SyncStatus(ctx, userID, articleID, domain.ArticleStatusPrivate)
+	draft, err := a.getEditable(ctx, userID, articleID)
+	if err != nil {
+		return err
+	}
+	return a.repo.SyncStatus(ctx, draft.Author.ID, articleID, domain.ArticleStatusPrivate)
+}
+
+// AddCollaborator implements ArticleService.
+func (a *articleService) AddCollaborator(
+	ctx context.Context,
+	uid int64,
+	aid int64,
+	c domain.Collaborator,
+) error {
+	if !c.Role.Valid() || c.UID == uid {
+		return ErrInvalidCollaborator
+	}
+	article, err := a.repo.GetByID(ctx, aid)
+	if err != nil {
+		return err
+	}
+	if article.Author.ID != uid {
+		return ErrPermissionDenied
+	}
+	return a.collabRepo.Save(ctx, aid, c)
+}
+
+// RemoveCollaborator implements ArticleService.
+func (a *articleService) RemoveCollaborator(
+	ctx context.Context,
+	uid int64,
+	aid int64,
+	collaboratorID int64,
+) error {
+	article, err := a.repo.GetByID(ctx, aid)
+	if err != nil {
+		return err
+	}
+	if article.Author.ID != uid {
+		return ErrPermissionDenied
+	}
+	return a.collabRepo.Delete(ctx, aid, collaboratorID)
+}
+
+// Approve implements ArticleService.
+func (a *articleService) Approve(ctx context.Context, uid int64, aid int64) error {
+	article, err := a.GetByID(ctx, aid)
+	if err != nil {
+		return err
+	}
+	c, ok := article.Collaborator(uid)
+	if !ok || c.Role != domain.CollaboratorRoleReviewer {
+		return ErrPermissionDenied
+	}
+	return a.collabRepo.Approve(ctx, aid, uid, article.Version)
+}
+
+// Delete implements ArticleService.
+func (a *articleService) Delete(ctx context.Context, uid int64, id int64) error {
+	return a.repo.Delete(ctx, uid, id)
+}
+
+// Restore implements ArticleService.
+func (a *articleService) Restore(ctx context.Context, uid int64, id int64) error {
+	return a.repo.Restore(ctx, uid, id)
+}
+
+// ListTrash implements ArticleService.
+func (a *articleService) ListTrash(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.Article, error) {
+	return a.repo.GetTrashByAuthor(ctx, uid, cursor, limit)
+}
+
+// PurgeTrash implements ArticleService.
+func (a *articleService) PurgeTrash(ctx context.Context, before time.Time, limit int) (int, error) {
+	articles, err := a.repo.PurgeTrash(ctx, before, limit)
+	if len(articles) == 0 {
+		return 0, err
+	}
+	ids := make([]int64, 0, len(articles))
+	for _, art := range articles {
+		ids = append(ids, art.ID)
+	}
+	er := a.collabRepo.DeleteByArticles(ctx, ids)
+	if er != nil {
+		a.l.Error("failed to delete the collaborators of purged articles",
+			logger.Field{Key: "aids", Value: ids},
+			logger.Error(er))
+	}
+	// NOTE: only the articles deleted are returned, their interactions are
+	// cleaned even if the purge has failed in the middle.
+	_, er = a.intrSvc.Delete(ctx, &intrv1.DeleteRequest{
+		Biz:    "article",
+		BizIds: ids,
+	})
+	if er != nil {
+		a.l.Error("failed to delete the interactions of purged articles",
+			logger.Field{Key: "aids", Value: ids},
+			logger.Error(er))
+		if err == nil {
+			err = er
+		}
+	}
+	return len(articles), err
 }
 
 func NewArticleServiceV1(
@@ -165,11 +412,17 @@ func NewArticleServiceV1(
 }
 
 func NewArticleService(
+	l logger.Logger,
 	repo repository.ArticleRepository,
+	collabRepo repository.ArticleCollaboratorRepository,
 	producer article.Producer,
+	intrSvc intrv1.InteractiveServiceClient,
 ) ArticleService {
 	return &articleService{
-		repo:     repo,
-		producer: producer,
+		l:          l,
+		repo:       repo,
+		collabRepo: collabRepo,
+		producer:   producer,
+		intrSvc:    intrSvc,
 	}
 }
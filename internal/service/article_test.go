@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	intrv1mock "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1/mock"
 	"github.com/chenmuyao/go-bootcamp/internal/domain"
 	"github.com/chenmuyao/go-bootcamp/internal/repository"
 	repomocks "github.com/chenmuyao/go-bootcamp/internal/repository/mocks"
@@ -213,3 +216,345 @@ func Test_articleService_Publish(t *testing.T) {
 		})
 	}
 }
+
+func Test_articleService_PurgeTrash(t *testing.T) {
+	before := time.Now().Add(-time.Hour)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository, intrv1.InteractiveServiceClient)
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "purged with interactions",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().PurgeTrash(gomock.Any(), before, 10).
+					Return([]domain.Article{{ID: 1}, {ID: 2}}, nil)
+				intrSvc := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().Delete(gomock.Any(), &intrv1.DeleteRequest{
+					Biz:    "article",
+					BizIds: []int64{1, 2},
+				}).Return(&intrv1.DeleteResponse{}, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().DeleteByArticles(gomock.Any(), gomock.Any())
+				return repo, collabRepo, intrSvc
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "nothing to purge",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().PurgeTrash(gomock.Any(), before, 10).Return(nil, nil)
+				return repo,
+					repomocks.NewMockArticleCollaboratorRepository(ctrl),
+					intrv1mock.NewMockInteractiveServiceClient(ctrl)
+			},
+		},
+		{
+			name: "rolled back, nothing to clean",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().PurgeTrash(gomock.Any(), before, 10).
+					Return(nil, errors.New("db error"))
+				return repo,
+					repomocks.NewMockArticleCollaboratorRepository(ctrl),
+					intrv1mock.NewMockInteractiveServiceClient(ctrl)
+			},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "failed in the middle, still clean the interactions",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().PurgeTrash(gomock.Any(), before, 10).
+					Return([]domain.Article{{ID: 1}}, errors.New("db error"))
+				intrSvc := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().Delete(gomock.Any(), &intrv1.DeleteRequest{
+					Biz:    "article",
+					BizIds: []int64{1},
+				}).Return(&intrv1.DeleteResponse{}, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().DeleteByArticles(gomock.Any(), gomock.Any())
+				return repo, collabRepo, intrSvc
+			},
+			wantCnt: 1,
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "failed to delete interactions",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().PurgeTrash(gomock.Any(), before, 10).
+					Return([]domain.Article{{ID: 1}}, nil)
+				intrSvc := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().Delete(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("intr error"))
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().DeleteByArticles(gomock.Any(), gomock.Any())
+				return repo, collabRepo, intrSvc
+			},
+			wantCnt: 1,
+			wantErr: errors.New("intr error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, collabRepo, intrSvc := tc.mock(ctrl)
+			svc := NewArticleService(logger.NewZapLogger(zap.L()), repo, collabRepo, nil, intrSvc)
+			cnt, err := svc.PurgeTrash(context.Background(), before, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func Test_articleService_PublishCollaboration(t *testing.T) {
+	draft := domain.Article{
+		ID:      1,
+		Title:   "my title",
+		Content: "my content",
+		Author:  domain.Author{ID: 123},
+		Status:  domain.ArticleStatusUnpublished,
+		Tags:    []string{"travel"},
+		Version: 3,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository)
+
+		uid     int64
+		content string
+		tags    []string
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "editor publishes for the author",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				repo.EXPECT().Sync(gomock.Any(), domain.Article{
+					ID:      1,
+					Title:   "my title",
+					Content: "new content",
+					Author:  domain.Author{ID: 123},
+					Status:  domain.ArticleStatusPublished,
+					Version: 3,
+				}).Return(int64(1), nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{UID: 234, Role: domain.CollaboratorRoleEditor},
+				}, nil)
+				return repo, collabRepo
+			},
+			uid:     234,
+			content: "new content",
+			wantId:  1,
+		},
+		{
+			name: "reviewer cannot publish",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{UID: 234, Role: domain.CollaboratorRoleReviewer},
+				}, nil)
+				return repo, collabRepo
+			},
+			uid:     234,
+			content: "my content",
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name: "not a collaborator",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return(nil, nil)
+				return repo, collabRepo
+			},
+			uid:     345,
+			content: "my content",
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "review required",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{UID: 234, Role: domain.CollaboratorRoleReviewer},
+				}, nil)
+				return repo, collabRepo
+			},
+			uid:     123,
+			content: "my content",
+			wantErr: ErrReviewRequired,
+		},
+		{
+			name: "approved draft changed",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{
+						UID:             234,
+						Role:            domain.CollaboratorRoleReviewer,
+						Approved:        true,
+						ApprovedVersion: 3,
+					},
+				}, nil)
+				return repo, collabRepo
+			},
+			uid:     123,
+			content: "new content",
+			wantErr: ErrReviewRequired,
+		},
+		{
+			name: "approved draft with other tags",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{
+						UID:             234,
+						Role:            domain.CollaboratorRoleReviewer,
+						Approved:        true,
+						ApprovedVersion: 3,
+					},
+				}, nil)
+				return repo, collabRepo
+			},
+			uid:     123,
+			content: "my content",
+			tags:    []string{"food"},
+			wantErr: ErrReviewRequired,
+		},
+		{
+			name: "older version approved",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{
+						UID:             234,
+						Role:            domain.CollaboratorRoleReviewer,
+						Approved:        true,
+						ApprovedVersion: 2,
+					},
+				}, nil)
+				return repo, collabRepo
+			},
+			uid:     123,
+			content: "my content",
+			tags:    []string{"travel"},
+			wantErr: ErrReviewRequired,
+		},
+		{
+			name: "approved draft published",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{
+						UID:             234,
+						Role:            domain.CollaboratorRoleReviewer,
+						Approved:        true,
+						ApprovedVersion: 3,
+					},
+				}, nil)
+				return repo, collabRepo
+			},
+			uid:     123,
+			content: "my content",
+			tags:    []string{"travel"},
+			wantId:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, collabRepo := tc.mock(ctrl)
+			svc := NewArticleService(logger.NewZapLogger(zap.L()), repo, collabRepo, nil, nil)
+			id, err := svc.Publish(context.Background(), domain.Article{
+				ID:      1,
+				Title:   "my title",
+				Content: tc.content,
+				Author:  domain.Author{ID: tc.uid},
+				Tags:    tc.tags,
+				Version: 3,
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func Test_articleService_Approve(t *testing.T) {
+	draft := domain.Article{
+		ID:      1,
+		Author:  domain.Author{ID: 123},
+		Version: 3,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository)
+
+		uid     int64
+		wantErr error
+	}{
+		{
+			name: "current version approved",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{UID: 234, Role: domain.CollaboratorRoleReviewer},
+				}, nil)
+				collabRepo.EXPECT().Approve(gomock.Any(), int64(1), int64(234), int64(3)).Return(nil)
+				return repo, collabRepo
+			},
+			uid: 234,
+		},
+		{
+			name: "editor cannot approve",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleCollaboratorRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo := repomocks.NewMockArticleCollaboratorRepository(ctrl)
+				collabRepo.EXPECT().GetByArticle(gomock.Any(), int64(1)).Return([]domain.Collaborator{
+					{UID: 234, Role: domain.CollaboratorRoleEditor},
+				}, nil)
+				return repo, collabRepo
+			},
+			uid:     234,
+			wantErr: ErrPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, collabRepo := tc.mock(ctrl)
+			svc := NewArticleService(logger.NewZapLogger(zap.L()), repo, collabRepo, nil, nil)
+			err := svc.Approve(context.Background(), tc.uid, 1)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"github.com/chenmuyao/go-bootcamp/pkg/feed"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+const (
+	defaultFeedSize        = 20
+	defaultSitemapBatch    = 1000
+	defaultSitemapPageSize = feed.MaxSitemapURLs
+)
+
+var (
+	ErrFeedNotFound    = errors.New("feed not found")
+	ErrSitemapNotFound = errors.New("sitemap not found")
+	ErrUnknownFormat   = errors.New("unknown feed format")
+)
+
+//go:generate mockgen -source=./feed.go -package=svcmocks -destination=./mocks/feed.mock.go
+type FeedService interface {
+	// Feed returns the feed of the latest published articles of an author,
+	// or of the whole site if uid is 0.
+	Feed(ctx context.Context, uid int64, format domain.FeedFormat) (domain.FeedDoc, error)
+	// Sitemap returns the sitemap index for page 0, and the sitemaps of the
+	// published articles from 1.
+	Sitemap(ctx context.Context, page int) (domain.FeedDoc, error)
+}
+
+type feedService struct {
+	l        logger.Logger
+	repo     repository.FeedRepository
+	artRepo  repository.ArticleRepository
+	userRepo repository.UserRepository
+
+	baseURL string
+	title   string
+
+	feedSize  int
+	batchSize int
+	pageSize  int
+}
+
+// Feed implements FeedService.
+func (f *feedService) Feed(
+	ctx context.Context,
+	uid int64,
+	format domain.FeedFormat,
+) (domain.FeedDoc, error) {
+	var render func(feed.Feed) ([]byte, error)
+	switch format {
+	case domain.FeedFormatAtom:
+		render = feed.Atom
+	case domain.FeedFormatRSS:
+		render = feed.RSS
+	default:
+		return domain.FeedDoc{}, ErrUnknownFormat
+	}
+
+	doc, err := f.repo.GetFeed(ctx, uid, format)
+	if err == nil {
+		return doc, nil
+	}
+
+	fd, err := f.buildFeed(ctx, uid, format)
+	if err != nil {
+		return domain.FeedDoc{}, err
+	}
+	body, err := render(fd)
+	if err != nil {
+		return domain.FeedDoc{}, err
+	}
+	doc = domain.FeedDoc{
+		Body:     body,
+		ETag:     feed.ETag(body),
+		Modified: fd.Updated,
+	}
+	err = f.repo.SetFeed(ctx, uid, format, doc)
+	if err != nil {
+		f.l.Warn("set feed cache error", logger.Int64("uid", uid), logger.Error(err))
+	}
+	return doc, nil
+}
+
+func (f *feedService) buildFeed(
+	ctx context.Context,
+	uid int64,
+	format domain.FeedFormat,
+) (feed.Feed, error) {
+	var (
+		articles []domain.Article
+		err      error
+	)
+	res := feed.Feed{
+		Title: f.title,
+		Link:  f.baseURL,
+	}
+	if uid == 0 {
+		res.ID = fmt.Sprintf("%s/feed/%s", f.baseURL, format)
+		articles, err = f.artRepo.ListPub(ctx, domain.Cursor{}, f.feedSize)
+	} else {
+		var author domain.User
+		author, err = f.userRepo.FindByID(ctx, uid)
+		if err == repository.ErrUserNotFound {
+			return feed.Feed{}, ErrFeedNotFound
+		}
+		if err != nil {
+			return feed.Feed{}, err
+		}
+		res.ID = fmt.Sprintf("%s/feed/authors/%d/%s", f.baseURL, uid, format)
+		res.Title = fmt.Sprintf("%s - %s", author.Name, f.title)
+		res.Author = author.Name
+		articles, err = f.artRepo.GetPubByAuthor(ctx, uid, domain.Cursor{}, f.feedSize)
+	}
+	if err != nil {
+		return feed.Feed{}, err
+	}
+
+	names := f.authorNames(ctx, articles)
+	res.Items = make([]feed.Item, 0, len(articles))
+	for _, art := range articles {
+		res.Items = append(res.Items, feed.Item{
+			Link:      f.articleURL(art.ID),
+			Title:     art.Title,
+			Summary:   art.Abstract(),
+			Author:    names[art.Author.ID],
+			Published: art.Ctime,
+			Updated:   art.Utime,
+		})
+		if art.Utime.After(res.Updated) {
+			res.Updated = art.Utime
+		}
+	}
+	if res.Updated.IsZero() {
+		// nothing published yet
+		res.Updated = time.UnixMilli(0)
+	}
+	return res, nil
+}
+
+// authorNames is best effort, the feed is still served without the names.
+func (f *feedService) authorNames(ctx context.Context, articles []domain.Article) map[int64]string {
+	ids := gslice.Map(articles, func(id int, src domain.Article) int64 {
+		return src.Author.ID
+	})
+	res := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return res
+	}
+	users, err := f.userRepo.BatchFindByIDs(ctx, ids)
+	if err != nil {
+		f.l.Warn("failed to get the authors of the feed", logger.Error(err))
+		return res
+	}
+	for _, u := range users {
+		res[u.ID] = u.Name
+	}
+	return res
+}
+
+// Sitemap implements FeedService.
+func (f *feedService) Sitemap(ctx context.Context, page int) (domain.FeedDoc, error) {
+	if page < 0 {
+		return domain.FeedDoc{}, ErrSitemapNotFound
+	}
+	doc, err := f.repo.GetSitemap(ctx, page)
+	if err == nil {
+		return doc, nil
+	}
+	// NOTE: the sitemaps are replaced all together, the page does not exist
+	// if the index is still there.
+	if page > 0 {
+		if _, err = f.repo.GetSitemap(ctx, 0); err == nil {
+			return domain.FeedDoc{}, ErrSitemapNotFound
+		}
+	}
+
+	docs, err := f.buildSitemaps(ctx)
+	if err != nil {
+		return domain.FeedDoc{}, err
+	}
+	err = f.repo.SetSitemaps(ctx, docs)
+	if err != nil {
+		f.l.Warn("set sitemap cache error", logger.Error(err))
+	}
+	if page >= len(docs) {
+		return domain.FeedDoc{}, ErrSitemapNotFound
+	}
+	return docs[page], nil
+}
+
+// buildSitemaps returns the index followed by the sitemaps, there is at least
+// one sitemap even if nothing is published.
+func (f *feedService) buildSitemaps(ctx context.Context) ([]domain.FeedDoc, error) {
+	var (
+		pages   [][]feed.URL
+		current = make([]feed.URL, 0, f.batchSize)
+		cursor  domain.Cursor
+	)
+	for {
+		arts, err := f.artRepo.ListPub(ctx, cursor, f.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range arts {
+			if len(current) == f.pageSize {
+				pages = append(pages, current)
+				current = make([]feed.URL, 0, f.batchSize)
+			}
+			current = append(current, feed.URL{
+				Loc:     f.articleURL(art.ID),
+				LastMod: art.Utime,
+			})
+		}
+		if len(arts) < f.batchSize {
+			break
+		}
+		cursor = domain.ArticleCursor(arts[len(arts)-1])
+	}
+	pages = append(pages, current)
+
+	docs := make([]domain.FeedDoc, 1, len(pages)+1)
+	index := make([]feed.URL, 0, len(pages))
+	var modified time.Time
+	for i, urls := range pages {
+		body, err := feed.Sitemap(urls)
+		if err != nil {
+			return nil, err
+		}
+		// the latest updated first
+		var lastMod time.Time
+		if len(urls) > 0 {
+			lastMod = urls[0].LastMod
+		}
+		if lastMod.After(modified) {
+			modified = lastMod
+		}
+		docs = append(docs, domain.FeedDoc{
+			Body:     body,
+			ETag:     feed.ETag(body),
+			Modified: lastMod,
+		})
+		index = append(index, feed.URL{
+			Loc:     fmt.Sprintf("%s/sitemaps/%d.xml", f.baseURL, i+1),
+			LastMod: lastMod,
+		})
+	}
+	body, err := feed.SitemapIndex(index)
+	if err != nil {
+		return nil, err
+	}
+	docs[0] = domain.FeedDoc{
+		Body:     body,
+		ETag:     feed.ETag(body),
+		Modified: modified,
+	}
+	return docs, nil
+}
+
+func (f *feedService) articleURL(id int64) string {
+	return fmt.Sprintf("%s/articles/pub/%d", f.baseURL, id)
+}
+
+func NewFeedService(
+	l logger.Logger,
+	repo repository.FeedRepository,
+	artRepo repository.ArticleRepository,
+	userRepo repository.UserRepository,
+	baseURL string,
+	title string,
+) FeedService {
+	return &feedService{
+		l:         l,
+		repo:      repo,
+		artRepo:   artRepo,
+		userRepo:  userRepo,
+		baseURL:   baseURL,
+		title:     title,
+		feedSize:  defaultFeedSize,
+		batchSize: defaultSitemapBatch,
+		pageSize:  defaultSitemapPageSize,
+	}
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	repomocks "github.com/chenmuyao/go-bootcamp/internal/repository/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/feed"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_feedService_Feed(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	cached := domain.FeedDoc{Body: []byte("<feed/>"), ETag: `"etag"`, Modified: now}
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (
+			repository.FeedRepository,
+			repository.ArticleRepository,
+			repository.UserRepository,
+		)
+		uid    int64
+		format domain.FeedFormat
+
+		wantErr      error
+		wantDoc      *domain.FeedDoc
+		wantModified time.Time
+		wantContains []string
+	}{
+		{
+			name: "cached",
+			mock: func(ctrl *gomock.Controller) (
+				repository.FeedRepository,
+				repository.ArticleRepository,
+				repository.UserRepository,
+			) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				repo.EXPECT().GetFeed(gomock.Any(), int64(0), domain.FeedFormatAtom).Return(cached, nil)
+				return repo, nil, nil
+			},
+			format:  domain.FeedFormatAtom,
+			wantDoc: &cached,
+		},
+		{
+			name: "site-wide feed built and cached",
+			mock: func(ctrl *gomock.Controller) (
+				repository.FeedRepository,
+				repository.ArticleRepository,
+				repository.UserRepository,
+			) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().
+					GetFeed(gomock.Any(), int64(0), domain.FeedFormatRSS).
+					Return(domain.FeedDoc{}, errors.New("cache miss"))
+				artRepo.EXPECT().ListPub(gomock.Any(), domain.Cursor{}, 20).Return([]domain.Article{
+					{ID: 2, Title: "second", Author: domain.Author{ID: 10}, Utime: now},
+					{ID: 1, Title: "first", Author: domain.Author{ID: 11}, Utime: now.Add(-time.Hour)},
+				}, nil)
+				userRepo.EXPECT().BatchFindByIDs(gomock.Any(), []int64{10, 11}).Return([]domain.User{
+					{ID: 10, Name: "alice"},
+					{ID: 11, Name: "bob"},
+				}, nil)
+				repo.EXPECT().SetFeed(gomock.Any(), int64(0), domain.FeedFormatRSS, gomock.Any()).Return(nil)
+				return repo, artRepo, userRepo
+			},
+			format:       domain.FeedFormatRSS,
+			wantModified: now,
+			wantContains: []string{
+				"<title>second</title>",
+				"https://example.com/articles/pub/2",
+				"<title>first</title>",
+			},
+		},
+		{
+			name: "author feed",
+			mock: func(ctrl *gomock.Controller) (
+				repository.FeedRepository,
+				repository.ArticleRepository,
+				repository.UserRepository,
+			) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().
+					GetFeed(gomock.Any(), int64(10), domain.FeedFormatAtom).
+					Return(domain.FeedDoc{}, errors.New("cache miss"))
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(domain.User{ID: 10, Name: "alice"}, nil)
+				artRepo.EXPECT().
+					GetPubByAuthor(gomock.Any(), int64(10), domain.Cursor{}, 20).
+					Return([]domain.Article{
+						{ID: 2, Title: "second", Author: domain.Author{ID: 10}, Utime: now},
+					}, nil)
+				userRepo.EXPECT().BatchFindByIDs(gomock.Any(), []int64{10}).Return(nil, errors.New("db error"))
+				repo.EXPECT().SetFeed(gomock.Any(), int64(10), domain.FeedFormatAtom, gomock.Any()).Return(nil)
+				return repo, artRepo, userRepo
+			},
+			uid:          10,
+			format:       domain.FeedFormatAtom,
+			wantModified: now,
+			wantContains: []string{
+				"<id>https://example.com/feed/authors/10/atom</id>",
+				"<title>alice - my site</title>",
+				"<title>second</title>",
+			},
+		},
+		{
+			name: "unknown author",
+			mock: func(ctrl *gomock.Controller) (
+				repository.FeedRepository,
+				repository.ArticleRepository,
+				repository.UserRepository,
+			) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().
+					GetFeed(gomock.Any(), int64(10), domain.FeedFormatAtom).
+					Return(domain.FeedDoc{}, errors.New("cache miss"))
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(domain.User{}, repository.ErrUserNotFound)
+				return repo, nil, userRepo
+			},
+			uid:     10,
+			format:  domain.FeedFormatAtom,
+			wantErr: ErrFeedNotFound,
+		},
+		{
+			name: "unknown format",
+			mock: func(ctrl *gomock.Controller) (
+				repository.FeedRepository,
+				repository.ArticleRepository,
+				repository.UserRepository,
+			) {
+				return nil, nil, nil
+			},
+			format:  "json",
+			wantErr: ErrUnknownFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, artRepo, userRepo := tc.mock(ctrl)
+			svc := NewFeedService(
+				logger.NewNopLogger(),
+				repo,
+				artRepo,
+				userRepo,
+				"https://example.com",
+				"my site",
+			)
+			doc, err := svc.Feed(context.Background(), tc.uid, tc.format)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			if tc.wantDoc != nil {
+				assert.Equal(t, *tc.wantDoc, doc)
+				return
+			}
+			assert.Equal(t, feed.ETag(doc.Body), doc.ETag)
+			assert.True(t, tc.wantModified.Equal(doc.Modified))
+			for _, s := range tc.wantContains {
+				assert.Contains(t, string(doc.Body), s)
+			}
+		})
+	}
+}
+
+func Test_feedService_Sitemap(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	arts := []domain.Article{
+		{ID: 5, Utime: now},
+		{ID: 4, Utime: now.Add(-time.Minute)},
+		{ID: 3, Utime: now.Add(-2 * time.Minute)},
+	}
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.FeedRepository, repository.ArticleRepository)
+		page int
+
+		wantErr      error
+		wantContains []string
+	}{
+		{
+			name: "index built and cached",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetSitemap(gomock.Any(), 0).Return(domain.FeedDoc{}, errors.New("cache miss"))
+				artRepo.EXPECT().ListPub(gomock.Any(), domain.Cursor{}, 2).Return(arts[:2], nil)
+				artRepo.EXPECT().
+					ListPub(gomock.Any(), domain.ArticleCursor(arts[1]), 2).
+					Return(arts[2:], nil)
+				repo.EXPECT().
+					SetSitemaps(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, docs []domain.FeedDoc) error {
+						// index and 2 pages
+						assert.Len(t, docs, 3)
+						assert.Contains(t, string(docs[1].Body), "/articles/pub/4")
+						assert.Contains(t, string(docs[2].Body), "/articles/pub/3")
+						return nil
+					})
+				return repo, artRepo
+			},
+			wantContains: []string{
+				"<sitemapindex",
+				"<loc>https://example.com/sitemaps/1.xml</loc>",
+				"<loc>https://example.com/sitemaps/2.xml</loc>",
+			},
+		},
+		{
+			name: "page out of range",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				repo.EXPECT().GetSitemap(gomock.Any(), 3).Return(domain.FeedDoc{}, errors.New("cache miss"))
+				repo.EXPECT().GetSitemap(gomock.Any(), 0).Return(domain.FeedDoc{Body: []byte("index")}, nil)
+				return repo, nil
+			},
+			page:    3,
+			wantErr: ErrSitemapNotFound,
+		},
+		{
+			name: "list error",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetSitemap(gomock.Any(), 1).Return(domain.FeedDoc{}, errors.New("cache miss"))
+				repo.EXPECT().GetSitemap(gomock.Any(), 0).Return(domain.FeedDoc{}, errors.New("cache miss"))
+				artRepo.EXPECT().ListPub(gomock.Any(), domain.Cursor{}, 2).Return(nil, errors.New("db error"))
+				return repo, artRepo
+			},
+			page:    1,
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewFeedService(
+				logger.NewNopLogger(),
+				repo,
+				artRepo,
+				nil,
+				"https://example.com",
+				"my site",
+			).(*feedService)
+			svc.batchSize = 2
+			svc.pageSize = 2
+
+			doc, err := svc.Sitemap(context.Background(), tc.page)
+			assert.Equal(t, tc.wantErr, err)
+			for _, s := range tc.wantContains {
+				assert.Contains(t, string(doc.Body), s)
+			}
+		})
+	}
+}
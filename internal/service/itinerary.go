@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+var ErrItineraryNotFound = repository.ErrItineraryNotFound
+
+//go:generate mockgen -source=./itinerary.go -package=svcmocks -destination=./mocks/itinerary.mock.go
+type ItineraryService interface {
+	Save(ctx context.Context, itinerary domain.Itinerary) (int64, error)
+	Publish(ctx context.Context, itinerary domain.Itinerary) (int64, error)
+	Withdraw(ctx context.Context, uid int64, id int64) error
+	GetByID(ctx context.Context, id int64) (domain.Itinerary, error)
+	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Itinerary, error)
+	GetPubByID(ctx context.Context, id int64) (domain.Itinerary, error)
+	GetPubByArticleID(ctx context.Context, aid int64) (domain.Itinerary, error)
+	// Clone copies a published itinerary into a private one owned by uid.
+	Clone(ctx context.Context, id int64, uid int64) (int64, error)
+}
+
+type itineraryService struct {
+	l           logger.Logger
+	repo        repository.ItineraryRepository
+	articleRepo repository.ArticleRepository
+}
+
+// Save implements ItineraryService.
+func (i *itineraryService) Save(
+	ctx context.Context,
+	itinerary domain.Itinerary,
+) (int64, error) {
+	err := i.checkArticle(ctx, itinerary)
+	if err != nil {
+		return 0, err
+	}
+	itinerary.Status = domain.ItineraryStatusUnpublished
+	if itinerary.ID > 0 {
+		return itinerary.ID, i.repo.Update(ctx, itinerary)
+	}
+	return i.repo.Create(ctx, itinerary)
+}
+
+// Publish implements ItineraryService.
+func (i *itineraryService) Publish(
+	ctx context.Context,
+	itinerary domain.Itinerary,
+) (int64, error) {
+	err := i.checkArticle(ctx, itinerary)
+	if err != nil {
+		return 0, err
+	}
+	itinerary.Status = domain.ItineraryStatusPublished
+	// first change the author side
+	id := itinerary.ID
+	if id > 0 {
+		err = i.repo.Update(ctx, itinerary)
+	} else {
+		id, err = i.repo.Create(ctx, itinerary)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// then the reader side, they can be in different DBs
+	itinerary.ID = id
+	for range publishMaxRetry {
+		err = i.repo.SavePub(ctx, itinerary)
+		if err == nil {
+			return id, nil
+		}
+		i.l.Error("Itinerary saved to author side, but failed to publish to reader side",
+			logger.Int64("id", id),
+			logger.Error(err))
+	}
+	return id, ErrPublish
+}
+
+// Withdraw implements ItineraryService.
+func (i *itineraryService) Withdraw(ctx context.Context, uid int64, id int64) error {
+	err := i.repo.UpdateStatus(ctx, uid, id, domain.ItineraryStatusPrivate)
+	if err != nil {
+		return err
+	}
+	return i.repo.UpdatePubStatus(ctx, uid, id, domain.ItineraryStatusPrivate)
+}
+
+// GetByID implements ItineraryService.
+func (i *itineraryService) GetByID(ctx context.Context, id int64) (domain.Itinerary, error) {
+	return i.repo.GetByID(ctx, id)
+}
+
+// GetByAuthor implements ItineraryService.
+func (i *itineraryService) GetByAuthor(
+	ctx context.Context,
+	uid int64,
+	offset int,
+	limit int,
+) ([]domain.Itinerary, error) {
+	return i.repo.GetByAuthor(ctx, uid, offset, limit)
+}
+
+// GetPubByID implements ItineraryService.
+func (i *itineraryService) GetPubByID(ctx context.Context, id int64) (domain.Itinerary, error) {
+	return i.repo.GetPubByID(ctx, id)
+}
+
+// GetPubByArticleID implements ItineraryService.
+func (i *itineraryService) GetPubByArticleID(
+	ctx context.Context,
+	aid int64,
+) (domain.Itinerary, error) {
+	return i.repo.GetPubByArticleID(ctx, aid)
+}
+
+// Clone implements ItineraryService.
+func (i *itineraryService) Clone(ctx context.Context, id int64, uid int64) (int64, error) {
+	src, err := i.repo.GetPubByID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return i.repo.Create(ctx, domain.Itinerary{
+		Title: src.Title,
+		Author: domain.Author{
+			ID: uid,
+		},
+		SourceID: src.ID,
+		Currency: src.Currency,
+		Days:     src.Days,
+		Status:   domain.ItineraryStatusPrivate,
+	})
+}
+
+// checkArticle makes sure that an itinerary can only be attached to an
+// article of the same author.
+func (i *itineraryService) checkArticle(ctx context.Context, itinerary domain.Itinerary) error {
+	if itinerary.ArticleID == 0 {
+		return nil
+	}
+	art, err := i.articleRepo.GetByID(ctx, itinerary.ArticleID)
+	if err != nil {
+		return err
+	}
+	if art.Author.ID != itinerary.Author.ID {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+func NewItineraryService(
+	l logger.Logger,
+	repo repository.ItineraryRepository,
+	articleRepo repository.ArticleRepository,
+) ItineraryService {
+	return &itineraryService{
+		l:           l,
+		repo:        repo,
+		articleRepo: articleRepo,
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	repomocks "github.com/chenmuyao/go-bootcamp/internal/repository/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func Test_itineraryService_Publish(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ItineraryRepository, repository.ArticleRepository)
+
+		itinerary domain.Itinerary
+
+		wantID  int64
+		wantErr error
+	}{
+		{
+			name: "publish new itinerary",
+			mock: func(ctrl *gomock.Controller) (repository.ItineraryRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockItineraryRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Itinerary{
+					Title:  "my trip",
+					Author: domain.Author{ID: 123},
+					Status: domain.ItineraryStatusPublished,
+				}).Return(int64(1), nil)
+				repo.EXPECT().SavePub(gomock.Any(), domain.Itinerary{
+					ID:     1,
+					Title:  "my trip",
+					Author: domain.Author{ID: 123},
+					Status: domain.ItineraryStatusPublished,
+				}).Return(nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			itinerary: domain.Itinerary{
+				Title:  "my trip",
+				Author: domain.Author{ID: 123},
+			},
+			wantID: 1,
+		},
+		{
+			name: "attach to an article of the author",
+			mock: func(ctrl *gomock.Controller) (repository.ItineraryRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(5)).Return(domain.Article{
+					ID:     5,
+					Author: domain.Author{ID: 123},
+				}, nil)
+				repo := repomocks.NewMockItineraryRepository(ctrl)
+				repo.EXPECT().Update(gomock.Any(), domain.Itinerary{
+					ID:        2,
+					Title:     "my trip",
+					Author:    domain.Author{ID: 123},
+					ArticleID: 5,
+					Status:    domain.ItineraryStatusPublished,
+				}).Return(nil)
+				repo.EXPECT().SavePub(gomock.Any(), domain.Itinerary{
+					ID:        2,
+					Title:     "my trip",
+					Author:    domain.Author{ID: 123},
+					ArticleID: 5,
+					Status:    domain.ItineraryStatusPublished,
+				}).Return(nil)
+				return repo, artRepo
+			},
+			itinerary: domain.Itinerary{
+				ID:        2,
+				Title:     "my trip",
+				Author:    domain.Author{ID: 123},
+				ArticleID: 5,
+			},
+			wantID: 2,
+		},
+		{
+			name: "attach to an article of another author",
+			mock: func(ctrl *gomock.Controller) (repository.ItineraryRepository, repository.ArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(5)).Return(domain.Article{
+					ID:     5,
+					Author: domain.Author{ID: 456},
+				}, nil)
+				return repomocks.NewMockItineraryRepository(ctrl), artRepo
+			},
+			itinerary: domain.Itinerary{
+				Title:     "my trip",
+				Author:    domain.Author{ID: 123},
+				ArticleID: 5,
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "reader side failed after retries",
+			mock: func(ctrl *gomock.Controller) (repository.ItineraryRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockItineraryRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				repo.EXPECT().SavePub(gomock.Any(), gomock.Any()).
+					Return(errors.New("db error")).
+					Times(publishMaxRetry)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			itinerary: domain.Itinerary{
+				Title:  "my trip",
+				Author: domain.Author{ID: 123},
+			},
+			wantID:  1,
+			wantErr: ErrPublish,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewItineraryService(logger.NewZapLogger(zap.L()), repo, artRepo)
+			id, err := svc.Publish(context.Background(), tc.itinerary)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantID, id)
+		})
+	}
+}
+
+func Test_itineraryService_Clone(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ItineraryRepository
+
+		wantID  int64
+		wantErr error
+	}{
+		{
+			name: "clone into a private copy",
+			mock: func(ctrl *gomock.Controller) repository.ItineraryRepository {
+				days := []domain.ItineraryDay{{Notes: "day 1"}}
+				repo := repomocks.NewMockItineraryRepository(ctrl)
+				repo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(domain.Itinerary{
+					ID:        1,
+					Title:     "my trip",
+					Author:    domain.Author{ID: 123, Name: "author"},
+					ArticleID: 5,
+					Currency:  "EUR",
+					Days:      days,
+					Status:    domain.ItineraryStatusPublished,
+				}, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Itinerary{
+					Title:    "my trip",
+					Author:   domain.Author{ID: 456},
+					SourceID: 1,
+					Currency: "EUR",
+					Days:     days,
+					Status:   domain.ItineraryStatusPrivate,
+				}).Return(int64(2), nil)
+				return repo
+			},
+			wantID: 2,
+		},
+		{
+			name: "not published",
+			mock: func(ctrl *gomock.Controller) repository.ItineraryRepository {
+				repo := repomocks.NewMockItineraryRepository(ctrl)
+				repo.EXPECT().GetPubByID(gomock.Any(), int64(1)).
+					Return(domain.Itinerary{}, ErrItineraryNotFound)
+				return repo
+			},
+			wantErr: ErrItineraryNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc := NewItineraryService(logger.NewZapLogger(zap.L()), tc.mock(ctrl), nil)
+			id, err := svc.Clone(context.Background(), 1, 456)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantID, id)
+		})
+	}
+}
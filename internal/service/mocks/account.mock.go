@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./account.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./account.go -package=svcmocks -destination=./mocks/account.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountService is a mock of AccountService interface.
+type MockAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountServiceMockRecorder is the mock recorder for MockAccountService.
+type MockAccountServiceMockRecorder struct {
+	mock *MockAccountService
+}
+
+// NewMockAccountService creates a new mock instance.
+func NewMockAccountService(ctrl *gomock.Controller) *MockAccountService {
+	mock := &MockAccountService{ctrl: ctrl}
+	mock.recorder = &MockAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// CreditReward mocks base method.
+func (m *MockAccountService) CreditReward(ctx context.Context, r domain.Reward) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditReward", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreditReward indicates an expected call of CreditReward.
+func (mr *MockAccountServiceMockRecorder) CreditReward(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditReward", reflect.TypeOf((*MockAccountService)(nil).CreditReward), ctx, r)
+}
+
+// GetBalance mocks base method.
+func (m *MockAccountService) GetBalance(ctx context.Context, uid int64) (domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, uid)
+	ret0, _ := ret[0].(domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockAccountServiceMockRecorder) GetBalance(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockAccountService)(nil).GetBalance), ctx, uid)
+}
+
+// ListEntries mocks base method.
+func (m *MockAccountService) ListEntries(ctx context.Context, uid int64, offset, limit int) ([]domain.AccountEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.AccountEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockAccountServiceMockRecorder) ListEntries(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockAccountService)(nil).ListEntries), ctx, uid, offset, limit)
+}
+
+// ListPendingWithdrawals mocks base method.
+func (m *MockAccountService) ListPendingWithdrawals(ctx context.Context, reviewer int64, offset, limit int) ([]domain.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingWithdrawals", ctx, reviewer, offset, limit)
+	ret0, _ := ret[0].([]domain.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingWithdrawals indicates an expected call of ListPendingWithdrawals.
+func (mr *MockAccountServiceMockRecorder) ListPendingWithdrawals(ctx, reviewer, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingWithdrawals", reflect.TypeOf((*MockAccountService)(nil).ListPendingWithdrawals), ctx, reviewer, offset, limit)
+}
+
+// ListWithdrawals mocks base method.
+func (m *MockAccountService) ListWithdrawals(ctx context.Context, uid int64, offset, limit int) ([]domain.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWithdrawals", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWithdrawals indicates an expected call of ListWithdrawals.
+func (mr *MockAccountServiceMockRecorder) ListWithdrawals(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithdrawals", reflect.TypeOf((*MockAccountService)(nil).ListWithdrawals), ctx, uid, offset, limit)
+}
+
+// RequestWithdrawal mocks base method.
+func (m *MockAccountService) RequestWithdrawal(ctx context.Context, uid, amount int64) (domain.Withdrawal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestWithdrawal", ctx, uid, amount)
+	ret0, _ := ret[0].(domain.Withdrawal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestWithdrawal indicates an expected call of RequestWithdrawal.
+func (mr *MockAccountServiceMockRecorder) RequestWithdrawal(ctx, uid, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestWithdrawal", reflect.TypeOf((*MockAccountService)(nil).RequestWithdrawal), ctx, uid, amount)
+}
+
+// ReviewWithdrawal mocks base method.
+func (m *MockAccountService) ReviewWithdrawal(ctx context.Context, id, reviewer int64, approve bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewWithdrawal", ctx, id, reviewer, approve)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReviewWithdrawal indicates an expected call of ReviewWithdrawal.
+func (mr *MockAccountServiceMockRecorder) ReviewWithdrawal(ctx, id, reviewer, approve any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewWithdrawal", reflect.TypeOf((*MockAccountService)(nil).ReviewWithdrawal), ctx, id, reviewer, approve)
+}
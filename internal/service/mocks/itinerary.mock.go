@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./itinerary.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./itinerary.go -package=svcmocks -destination=./mocks/itinerary.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockItineraryService is a mock of ItineraryService interface.
+type MockItineraryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItineraryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockItineraryServiceMockRecorder is the mock recorder for MockItineraryService.
+type MockItineraryServiceMockRecorder struct {
+	mock *MockItineraryService
+}
+
+// NewMockItineraryService creates a new mock instance.
+func NewMockItineraryService(ctrl *gomock.Controller) *MockItineraryService {
+	mock := &MockItineraryService{ctrl: ctrl}
+	mock.recorder = &MockItineraryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItineraryService) EXPECT() *MockItineraryServiceMockRecorder {
+	return m.recorder
+}
+
+// Clone mocks base method.
+func (m *MockItineraryService) Clone(ctx context.Context, id, uid int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", ctx, id, uid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockItineraryServiceMockRecorder) Clone(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockItineraryService)(nil).Clone), ctx, id, uid)
+}
+
+// GetByAuthor mocks base method.
+func (m *MockItineraryService) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]domain.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockItineraryServiceMockRecorder) GetByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockItineraryService)(nil).GetByAuthor), ctx, uid, offset, limit)
+}
+
+// GetByID mocks base method.
+func (m *MockItineraryService) GetByID(ctx context.Context, id int64) (domain.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockItineraryServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockItineraryService)(nil).GetByID), ctx, id)
+}
+
+// GetPubByArticleID mocks base method.
+func (m *MockItineraryService) GetPubByArticleID(ctx context.Context, aid int64) (domain.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByArticleID", ctx, aid)
+	ret0, _ := ret[0].(domain.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByArticleID indicates an expected call of GetPubByArticleID.
+func (mr *MockItineraryServiceMockRecorder) GetPubByArticleID(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByArticleID", reflect.TypeOf((*MockItineraryService)(nil).GetPubByArticleID), ctx, aid)
+}
+
+// GetPubByID mocks base method.
+func (m *MockItineraryService) GetPubByID(ctx context.Context, id int64) (domain.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByID", ctx, id)
+	ret0, _ := ret[0].(domain.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByID indicates an expected call of GetPubByID.
+func (mr *MockItineraryServiceMockRecorder) GetPubByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByID", reflect.TypeOf((*MockItineraryService)(nil).GetPubByID), ctx, id)
+}
+
+// Publish mocks base method.
+func (m *MockItineraryService) Publish(ctx context.Context, itinerary domain.Itinerary) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, itinerary)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockItineraryServiceMockRecorder) Publish(ctx, itinerary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockItineraryService)(nil).Publish), ctx, itinerary)
+}
+
+// Save mocks base method.
+func (m *MockItineraryService) Save(ctx context.Context, itinerary domain.Itinerary) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, itinerary)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockItineraryServiceMockRecorder) Save(ctx, itinerary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockItineraryService)(nil).Save), ctx, itinerary)
+}
+
+// Withdraw mocks base method.
+func (m *MockItineraryService) Withdraw(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockItineraryServiceMockRecorder) Withdraw(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockItineraryService)(nil).Withdraw), ctx, uid, id)
+}
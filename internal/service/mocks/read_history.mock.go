@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./read_history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./read_history.go -package=svcmocks -destination=./mocks/read_history.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadHistoryService is a mock of ReadHistoryService interface.
+type MockReadHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadHistoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReadHistoryServiceMockRecorder is the mock recorder for MockReadHistoryService.
+type MockReadHistoryServiceMockRecorder struct {
+	mock *MockReadHistoryService
+}
+
+// NewMockReadHistoryService creates a new mock instance.
+func NewMockReadHistoryService(ctrl *gomock.Controller) *MockReadHistoryService {
+	mock := &MockReadHistoryService{ctrl: ctrl}
+	mock.recorder = &MockReadHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadHistoryService) EXPECT() *MockReadHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockReadHistoryService) Clear(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockReadHistoryServiceMockRecorder) Clear(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockReadHistoryService)(nil).Clear), ctx, uid)
+}
+
+// Delete mocks base method.
+func (m *MockReadHistoryService) Delete(ctx context.Context, uid, aid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReadHistoryServiceMockRecorder) Delete(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReadHistoryService)(nil).Delete), ctx, uid, aid)
+}
+
+// List mocks base method.
+func (m *MockReadHistoryService) List(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.ReadRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.ReadRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockReadHistoryServiceMockRecorder) List(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReadHistoryService)(nil).List), ctx, uid, cursor, limit)
+}
@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./reward.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./reward.go -package=svcmocks -destination=./mocks/reward.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/chenmuyao/go-bootcamp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRewardService is a mock of RewardService interface.
+type MockRewardService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRewardServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRewardServiceMockRecorder is the mock recorder for MockRewardService.
+type MockRewardServiceMockRecorder struct {
+	mock *MockRewardService
+}
+
+// NewMockRewardService creates a new mock instance.
+func NewMockRewardService(ctrl *gomock.Controller) *MockRewardService {
+	mock := &MockRewardService{ctrl: ctrl}
+	mock.recorder = &MockRewardServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRewardService) EXPECT() *MockRewardServiceMockRecorder {
+	return m.recorder
+}
+
+// GetReward mocks base method.
+func (m *MockRewardService) GetReward(ctx context.Context, id, uid int64) (domain.Reward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReward", ctx, id, uid)
+	ret0, _ := ret[0].(domain.Reward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReward indicates an expected call of GetReward.
+func (mr *MockRewardServiceMockRecorder) GetReward(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReward", reflect.TypeOf((*MockRewardService)(nil).GetReward), ctx, id, uid)
+}
+
+// HandleCallback mocks base method.
+func (m *MockRewardService) HandleCallback(ctx context.Context, req *http.Request) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCallback", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleCallback indicates an expected call of HandleCallback.
+func (mr *MockRewardServiceMockRecorder) HandleCallback(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCallback", reflect.TypeOf((*MockRewardService)(nil).HandleCallback), ctx, req)
+}
+
+// NotifyPaid mocks base method.
+func (m *MockRewardService) NotifyPaid(ctx context.Context, afterID int64, limit int) (int64, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPaid", ctx, afterID, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NotifyPaid indicates an expected call of NotifyPaid.
+func (mr *MockRewardServiceMockRecorder) NotifyPaid(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPaid", reflect.TypeOf((*MockRewardService)(nil).NotifyPaid), ctx, afterID, limit)
+}
+
+// RewardArticle mocks base method.
+func (m *MockRewardService) RewardArticle(ctx context.Context, aid, uid, amount int64) (domain.Reward, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewardArticle", ctx, aid, uid, amount)
+	ret0, _ := ret[0].(domain.Reward)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RewardArticle indicates an expected call of RewardArticle.
+func (mr *MockRewardServiceMockRecorder) RewardArticle(ctx, aid, uid, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewardArticle", reflect.TypeOf((*MockRewardService)(nil).RewardArticle), ctx, aid, uid, amount)
+}
+
+// SyncPending mocks base method.
+func (m *MockRewardService) SyncPending(ctx context.Context, before time.Time, after domain.RewardCursor, limit int) (domain.RewardCursor, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncPending", ctx, before, after, limit)
+	ret0, _ := ret[0].(domain.RewardCursor)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SyncPending indicates an expected call of SyncPending.
+func (mr *MockRewardServiceMockRecorder) SyncPending(ctx, before, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncPending", reflect.TypeOf((*MockRewardService)(nil).SyncPending), ctx, before, after, limit)
+}
@@ -0,0 +1,177 @@
+package localpay
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/service/payment"
+)
+
+const (
+	SignatureHeader = "X-Localpay-Signature"
+
+	maxCallbackSize = 1 << 16
+	notifyTimeout   = 3 * time.Second
+)
+
+// {{{ Struct
+
+// Service is a local stand-in of a payment provider. The orders are kept in
+// memory and paid automatically after payDelay. Like a real provider, the
+// result is POSTed to notifyURL, signed with the secret.
+type Service struct {
+	mu     sync.Mutex
+	orders map[string]*payment.Result
+
+	secret    []byte
+	notifyURL string
+	payDelay  time.Duration
+	client    *http.Client
+}
+
+func NewService(secret string, notifyURL string, payDelay time.Duration) *Service {
+	return &Service{
+		orders:    make(map[string]*payment.Result),
+		secret:    []byte(secret),
+		notifyURL: notifyURL,
+		payDelay:  payDelay,
+		client:    &http.Client{Timeout: notifyTimeout},
+	}
+}
+
+// }}}
+// {{{ Struct Methods
+
+// Prepay implements payment.Service.
+func (s *Service) Prepay(ctx context.Context, p payment.Payment) (string, error) {
+	payURL := "http://localpay.local/pay?order_no=" + url.QueryEscape(p.OrderNo)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.orders[p.OrderNo]; ok {
+		return payURL, nil
+	}
+	s.orders[p.OrderNo] = &payment.Result{
+		OrderNo: p.OrderNo,
+		Status:  payment.StatusPending,
+		Amount:  p.Amount,
+	}
+	slog.Info("localpay prepay", "order", p.OrderNo, "amount", p.Amount)
+	if s.payDelay > 0 {
+		time.AfterFunc(s.payDelay, func() {
+			err := s.Pay(p.OrderNo, true)
+			if err != nil {
+				slog.Error("localpay failed to notify", "order", p.OrderNo, "err", err)
+			}
+		})
+	}
+	return payURL, nil
+}
+
+// Query implements payment.Service.
+func (s *Service) Query(ctx context.Context, orderNo string) (payment.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, ok := s.orders[orderNo]
+	if !ok {
+		return payment.Result{}, payment.ErrOrderNotFound
+	}
+	return *res, nil
+}
+
+// Close implements payment.Service.
+func (s *Service) Close(ctx context.Context, orderNo string) (payment.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, ok := s.orders[orderNo]
+	if !ok {
+		return payment.Result{}, payment.ErrOrderNotFound
+	}
+	if res.Status == payment.StatusPending {
+		res.Status = payment.StatusFailed
+	}
+	return *res, nil
+}
+
+// ParseCallback implements payment.Service.
+func (s *Service) ParseCallback(req *http.Request) (payment.Result, error) {
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxCallbackSize))
+	if err != nil {
+		return payment.Result{}, err
+	}
+	sig, err := hex.DecodeString(req.Header.Get(SignatureHeader))
+	if err != nil || !hmac.Equal(sig, s.sign(body)) {
+		return payment.Result{}, payment.ErrInvalidCallback
+	}
+	var res payment.Result
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return payment.Result{}, payment.ErrInvalidCallback
+	}
+	return res, nil
+}
+
+// Pay settles a pending order as the user would and notifies the result.
+// The settled orders are notified again, a real provider retries the
+// callbacks as well.
+func (s *Service) Pay(orderNo string, paid bool) error {
+	s.mu.Lock()
+	res, ok := s.orders[orderNo]
+	if !ok {
+		s.mu.Unlock()
+		return payment.ErrOrderNotFound
+	}
+	if res.Status == payment.StatusPending {
+		res.Status = payment.StatusFailed
+		if paid {
+			res.Status = payment.StatusPaid
+		}
+	}
+	cp := *res
+	s.mu.Unlock()
+	return s.notify(cp)
+}
+
+func (s *Service) notify(res payment.Result) error {
+	if s.notifyURL == "" {
+		return nil
+	}
+	body, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.notifyURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, hex.EncodeToString(s.sign(body)))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("callback status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *Service) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// }}}
@@ -0,0 +1,62 @@
+package localpay
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chenmuyao/go-bootcamp/internal/service/payment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Callback(t *testing.T) {
+	results := make(chan payment.Result, 1)
+	var svc *Service
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, err := svc.ParseCallback(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		results <- res
+	}))
+	defer server.Close()
+	svc = NewService("secret", server.URL, 0)
+
+	ctx := context.Background()
+	_, err := svc.Prepay(ctx, payment.Payment{OrderNo: "reward-1", Amount: 500})
+	require.NoError(t, err)
+	res, err := svc.Query(ctx, "reward-1")
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusPending, res.Status)
+
+	require.NoError(t, svc.Pay("reward-1", true))
+	want := payment.Result{OrderNo: "reward-1", Status: payment.StatusPaid, Amount: 500}
+	assert.Equal(t, want, <-results)
+
+	// settled once
+	require.NoError(t, svc.Pay("reward-1", false))
+	assert.Equal(t, want, <-results)
+
+	_, err = svc.Query(ctx, "reward-2")
+	assert.Equal(t, payment.ErrOrderNotFound, err)
+
+	// closed before being paid
+	_, err = svc.Prepay(ctx, payment.Payment{OrderNo: "reward-3", Amount: 100})
+	require.NoError(t, err)
+	res, err = svc.Close(ctx, "reward-3")
+	require.NoError(t, err)
+	assert.Equal(t, payment.StatusFailed, res.Status)
+	require.NoError(t, svc.Pay("reward-3", true))
+	assert.Equal(t, payment.StatusFailed, (<-results).Status)
+	_, err = svc.Close(ctx, "reward-2")
+	assert.Equal(t, payment.ErrOrderNotFound, err)
+
+	// signed with another secret
+	other := NewService("other", server.URL, 0)
+	_, err = other.Prepay(ctx, payment.Payment{OrderNo: "reward-1", Amount: 1})
+	require.NoError(t, err)
+	assert.Error(t, other.Pay("reward-1", true))
+}
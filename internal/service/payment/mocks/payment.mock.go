@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./types.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./types.go -package=paymentmocks -destination=./mocks/payment.mock.go
+//
+
+// Package paymentmocks is a generated GoMock package.
+package paymentmocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	payment "github.com/chenmuyao/go-bootcamp/internal/service/payment"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockService) Close(ctx context.Context, orderNo string) (payment.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, orderNo)
+	ret0, _ := ret[0].(payment.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockServiceMockRecorder) Close(ctx, orderNo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockService)(nil).Close), ctx, orderNo)
+}
+
+// ParseCallback mocks base method.
+func (m *MockService) ParseCallback(req *http.Request) (payment.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseCallback", req)
+	ret0, _ := ret[0].(payment.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseCallback indicates an expected call of ParseCallback.
+func (mr *MockServiceMockRecorder) ParseCallback(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseCallback", reflect.TypeOf((*MockService)(nil).ParseCallback), req)
+}
+
+// Prepay mocks base method.
+func (m *MockService) Prepay(ctx context.Context, p payment.Payment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prepay", ctx, p)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prepay indicates an expected call of Prepay.
+func (mr *MockServiceMockRecorder) Prepay(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prepay", reflect.TypeOf((*MockService)(nil).Prepay), ctx, p)
+}
+
+// Query mocks base method.
+func (m *MockService) Query(ctx context.Context, orderNo string) (payment.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, orderNo)
+	ret0, _ := ret[0].(payment.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockServiceMockRecorder) Query(ctx, orderNo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockService)(nil).Query), ctx, orderNo)
+}
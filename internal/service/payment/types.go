@@ -0,0 +1,52 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+type Status uint8
+
+const (
+	StatusUnknown Status = iota
+	StatusPending
+	StatusPaid
+	StatusFailed
+)
+
+var (
+	ErrOrderNotFound   = errors.New("payment order not found")
+	ErrInvalidCallback = errors.New("invalid payment callback")
+)
+
+// Service is a payment provider.
+//
+//go:generate mockgen -source=./types.go -package=paymentmocks -destination=./mocks/payment.mock.go
+type Service interface {
+	// Prepay creates the order at the provider and returns the URL where the
+	// user pays it. The result is notified later to the callback.
+	Prepay(ctx context.Context, p Payment) (string, error)
+	// Query asks the provider for the status of an order, in case the
+	// callback is lost.
+	Query(ctx context.Context, orderNo string) (Result, error)
+	// Close closes an order so that it can no longer be paid, and returns
+	// its final result, paid if the user paid it in the meantime.
+	Close(ctx context.Context, orderNo string) (Result, error)
+	// ParseCallback verifies a callback request of the provider.
+	ParseCallback(req *http.Request) (Result, error)
+}
+
+type Payment struct {
+	// unique on our side, the provider dedups the orders with it
+	OrderNo string
+	// in cents
+	Amount      int64
+	Description string
+}
+
+type Result struct {
+	OrderNo string
+	Status  Status
+	Amount  int64
+}
@@ -42,8 +42,10 @@ func (b *BatchRankingService) TopN(ctx context.Context) error {
 }
 
 func (b *BatchRankingService) topN(ctx context.Context) ([]domain.Article, error) {
-	offset := 0
 	start := time.Now()
+	// NOTE: keyset pagination, the articles updated during the scan are
+	// neither skipped nor repeated.
+	cursor := domain.Cursor{Time: start}
 
 	// NOTE: Improve: DDL, only the last days
 	ddl := start.Add(-7 * 24 * time.Hour)
@@ -58,7 +60,7 @@ func (b *BatchRankingService) topN(ctx context.Context) ([]domain.Article, error
 	})
 
 	for {
-		arts, err := b.artSvc.ListPub(ctx, start, offset, b.batchSize)
+		arts, err := b.artSvc.ListPub(ctx, cursor, b.batchSize)
 		if err != nil {
 			return []domain.Article{}, err
 		}
@@ -81,7 +83,7 @@ func (b *BatchRankingService) topN(ctx context.Context) ([]domain.Article, error
 				art:   art,
 			})
 		}
-		offset = offset + len(arts)
+		cursor = domain.ArticleCursor(arts[len(arts)-1])
 		// if len(arts) < b.batchSize {
 		// 	// no more data
 		// 	break
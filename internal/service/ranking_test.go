@@ -31,7 +31,7 @@ func TestBatchRankingService_TopN(t *testing.T) {
 				artSvc := svcmocks.NewMockArticleService(ctrl)
 
 				// 1st batch
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 0, 2).Return([]domain.Article{
+				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 2).Return([]domain.Article{
 					{ID: 1, Utime: now},
 					{ID: 2, Utime: now},
 				}, nil)
@@ -48,7 +48,7 @@ func TestBatchRankingService_TopN(t *testing.T) {
 					}, nil)
 
 				// 2nd batch
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 2, 2).Return([]domain.Article{
+				artSvc.EXPECT().ListPub(gomock.Any(), domain.Cursor{Time: now, ID: 2}, 2).Return([]domain.Article{
 					{ID: 3, Utime: now},
 					{ID: 4, Utime: now},
 				}, nil)
@@ -66,7 +66,7 @@ func TestBatchRankingService_TopN(t *testing.T) {
 
 				// 3rd batch
 				artSvc.EXPECT().
-					ListPub(gomock.Any(), gomock.Any(), 4, 2).
+					ListPub(gomock.Any(), domain.Cursor{Time: now, ID: 4}, 2).
 					Return([]domain.Article{}, nil)
 
 				return intrSvc, artSvc
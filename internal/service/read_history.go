@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"golang.org/x/sync/errgroup"
+)
+
+//go:generate mockgen -source=./read_history.go -package=svcmocks -destination=./mocks/read_history.mock.go
+type ReadHistoryService interface {
+	// List returns the reading history after the cursor, the latest read
+	// first. The articles no longer published only have their ID set.
+	List(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.ReadRecord, error)
+	Delete(ctx context.Context, uid int64, aid int64) error
+	Clear(ctx context.Context, uid int64) error
+}
+
+type readHistoryService struct {
+	repo    repository.ReadHistoryRepository
+	artRepo repository.ArticleRepository
+}
+
+// List implements ReadHistoryService.
+func (r *readHistoryService) List(
+	ctx context.Context,
+	uid int64,
+	cursor domain.Cursor,
+	limit int,
+) ([]domain.ReadRecord, error) {
+	records, err := r.repo.List(ctx, uid, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: one by one from the cache, BatchGetPubByIDs fails as soon as an
+	// article has been withdrawn or deleted.
+	var eg errgroup.Group
+	for i := range records {
+		eg.Go(func() error {
+			aid := records[i].Article.ID
+			art, err := r.artRepo.GetPubByID(ctx, aid)
+			switch {
+			case err == repository.ErrArticleNotFound:
+				return nil
+			case err != nil:
+				return err
+			case art.Status != domain.ArticleStatusPublished:
+				return nil
+			}
+			records[i].Article = art
+			return nil
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Delete implements ReadHistoryService.
+func (r *readHistoryService) Delete(ctx context.Context, uid int64, aid int64) error {
+	return r.repo.Delete(ctx, uid, aid)
+}
+
+// Clear implements ReadHistoryService.
+func (r *readHistoryService) Clear(ctx context.Context, uid int64) error {
+	return r.repo.Clear(ctx, uid)
+}
+
+func NewReadHistoryService(
+	repo repository.ReadHistoryRepository,
+	artRepo repository.ArticleRepository,
+) ReadHistoryService {
+	return &readHistoryService{
+		repo:    repo,
+		artRepo: artRepo,
+	}
+}
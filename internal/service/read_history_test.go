@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	repomocks "github.com/chenmuyao/go-bootcamp/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_readHistoryService_List(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	cursor := domain.Cursor{Time: now.Add(time.Minute), ID: 9}
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (
+			repository.ReadHistoryRepository,
+			repository.ArticleRepository,
+		)
+
+		wantRes []domain.ReadRecord
+		wantErr error
+	}{
+		{
+			name: "articles no longer published",
+			mock: func(ctrl *gomock.Controller) (
+				repository.ReadHistoryRepository,
+				repository.ArticleRepository,
+			) {
+				repo := repomocks.NewMockReadHistoryRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().List(gomock.Any(), int64(123), cursor, 3).Return([]domain.ReadRecord{
+					{UID: 123, Article: domain.Article{ID: 1}, ReadTime: now},
+					{UID: 123, Article: domain.Article{ID: 2}, ReadTime: now.Add(-time.Minute)},
+					{UID: 123, Article: domain.Article{ID: 3}, ReadTime: now.Add(-time.Hour)},
+				}, nil)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Title:  "my title",
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(2)).Return(domain.Article{
+					ID:     2,
+					Title:  "withdrawn",
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				artRepo.EXPECT().
+					GetPubByID(gomock.Any(), int64(3)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return repo, artRepo
+			},
+			wantRes: []domain.ReadRecord{
+				{
+					UID: 123,
+					Article: domain.Article{
+						ID:     1,
+						Title:  "my title",
+						Status: domain.ArticleStatusPublished,
+					},
+					ReadTime: now,
+				},
+				{UID: 123, Article: domain.Article{ID: 2}, ReadTime: now.Add(-time.Minute)},
+				{UID: 123, Article: domain.Article{ID: 3}, ReadTime: now.Add(-time.Hour)},
+			},
+		},
+		{
+			name: "history error",
+			mock: func(ctrl *gomock.Controller) (
+				repository.ReadHistoryRepository,
+				repository.ArticleRepository,
+			) {
+				repo := repomocks.NewMockReadHistoryRepository(ctrl)
+				repo.EXPECT().List(gomock.Any(), int64(123), cursor, 3).Return(nil, errors.New("redis error"))
+				return repo, nil
+			},
+			wantErr: errors.New("redis error"),
+		},
+		{
+			name: "article error",
+			mock: func(ctrl *gomock.Controller) (
+				repository.ReadHistoryRepository,
+				repository.ArticleRepository,
+			) {
+				repo := repomocks.NewMockReadHistoryRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().List(gomock.Any(), int64(123), cursor, 3).Return([]domain.ReadRecord{
+					{UID: 123, Article: domain.Article{ID: 1}, ReadTime: now},
+				}, nil)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(domain.Article{}, errors.New("db error"))
+				return repo, artRepo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewReadHistoryService(repo, artRepo)
+			res, err := svc.List(context.Background(), 123, cursor, 3)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,426 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"golang.org/x/sync/errgroup"
+)
+
+//go:generate mockgen -source=./recommend.go -package=svcmocks -destination=./mocks/recommend.mock.go
+type RecommendService interface {
+	// Compute rebuilds the related articles of the recent articles, and the
+	// recommendations of the users who liked, collected or read them.
+	Compute(ctx context.Context) error
+	// Related returns the articles related to the article, the hot ones
+	// until they are computed.
+	Related(ctx context.Context, aid int64, limit int) ([]domain.Article, error)
+	// ForUser returns the articles recommended to the user, the hot ones for
+	// the cold-start users.
+	ForUser(ctx context.Context, uid int64, limit int) ([]domain.Article, error)
+}
+
+type recommendService struct {
+	l           logger.Logger
+	repo        repository.RecommendRepository
+	artRepo     repository.ArticleRepository
+	historyRepo repository.ReadHistoryRepository
+	intrSvc     intrv1.InteractiveServiceClient
+	rankingSvc  RankingService
+	biz         string
+
+	// only the articles updated within the window are recommended
+	window    time.Duration
+	batchSize int
+	// max number of articles related to an article
+	relatedN int
+	// max number of articles recommended to a user
+	userN int
+	// number of the latest reads of a user taken into account
+	historySize int
+
+	likeWeight    float64
+	collectWeight float64
+	readWeight    float64
+	tagWeight     float64
+}
+
+type scoredArticle struct {
+	id    int64
+	score float64
+}
+
+// Compute implements RecommendService.
+func (r *recommendService) Compute(ctx context.Context) error {
+	since := time.Now().Add(-r.window)
+	tags, err := r.recentTags(ctx, since)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	// preferences of the users: uid -> aid -> weight
+	prefs := make(map[int64]map[int64]float64)
+	err = r.loadInteractions(ctx, tags, prefs)
+	if err != nil {
+		return err
+	}
+	// NOTE: the reads are too noisy to relate the articles, they only seed
+	// the recommendations of the readers.
+	related := r.related(tags, prefs)
+	err = r.repo.SetRelated(ctx, toIDLists(related))
+	if err != nil {
+		return err
+	}
+
+	err = r.loadHistories(ctx, since, tags, prefs)
+	if err != nil {
+		return err
+	}
+	return r.repo.SetForUsers(ctx, r.forUsers(prefs, related))
+}
+
+// recentTags returns the tags of the articles updated since the given time.
+func (r *recommendService) recentTags(
+	ctx context.Context,
+	since time.Time,
+) (map[int64][]string, error) {
+	res := make(map[int64][]string)
+	cursor := domain.Cursor{}
+	for {
+		arts, err := r.artRepo.ListPub(ctx, cursor, r.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range arts {
+			if art.Utime.Before(since) {
+				return res, nil
+			}
+			res[art.ID] = art.Tags
+		}
+		if len(arts) < r.batchSize {
+			return res, nil
+		}
+		cursor = domain.ArticleCursor(arts[len(arts)-1])
+	}
+}
+
+func (r *recommendService) loadInteractions(
+	ctx context.Context,
+	arts map[int64][]string,
+	prefs map[int64]map[int64]float64,
+) error {
+	err := r.scan(func(afterID int64) ([]*intrv1.UserBiz, error) {
+		resp, err := r.intrSvc.ListLikes(ctx, &intrv1.ListLikesRequest{
+			Biz:     r.biz,
+			AfterId: afterID,
+			Limit:   int32(r.batchSize),
+		})
+		return resp.GetLikes(), err
+	}, func(ub *intrv1.UserBiz) {
+		addPref(prefs, arts, ub.GetUid(), ub.GetBizId(), r.likeWeight)
+	})
+	if err != nil {
+		return err
+	}
+	// a resource is listed once per collection it is in
+	collected := make(map[[2]int64]struct{})
+	return r.scan(func(afterID int64) ([]*intrv1.UserBiz, error) {
+		resp, err := r.intrSvc.ListCollects(ctx, &intrv1.ListCollectsRequest{
+			Biz:     r.biz,
+			AfterId: afterID,
+			Limit:   int32(r.batchSize),
+		})
+		return resp.GetCollects(), err
+	}, func(ub *intrv1.UserBiz) {
+		key := [2]int64{ub.GetUid(), ub.GetBizId()}
+		if _, ok := collected[key]; ok {
+			return
+		}
+		collected[key] = struct{}{}
+		addPref(prefs, arts, ub.GetUid(), ub.GetBizId(), r.collectWeight)
+	})
+}
+
+func (r *recommendService) scan(
+	list func(afterID int64) ([]*intrv1.UserBiz, error),
+	fn func(ub *intrv1.UserBiz),
+) error {
+	var afterID int64
+	for {
+		ubs, err := list(afterID)
+		if err != nil {
+			return err
+		}
+		for _, ub := range ubs {
+			fn(ub)
+		}
+		if len(ubs) < r.batchSize {
+			return nil
+		}
+		afterID = ubs[len(ubs)-1].GetId()
+	}
+}
+
+func (r *recommendService) loadHistories(
+	ctx context.Context,
+	since time.Time,
+	arts map[int64][]string,
+	prefs map[int64]map[int64]float64,
+) error {
+	for offset := 0; ; offset += r.batchSize {
+		uids, err := r.historyRepo.Readers(ctx, since, offset, r.batchSize)
+		if err != nil {
+			return err
+		}
+		for _, uid := range uids {
+			records, err := r.historyRepo.List(ctx, uid, domain.Cursor{}, r.historySize)
+			if err != nil {
+				return err
+			}
+			for _, record := range records {
+				addPref(prefs, arts, uid, record.Article.ID, r.readWeight)
+			}
+		}
+		if len(uids) < r.batchSize {
+			return nil
+		}
+	}
+}
+
+// related scores the pairs of articles by the cosine similarity of their
+// likers and collectors, plus the Jaccard similarity of their tags.
+func (r *recommendService) related(
+	arts map[int64][]string,
+	prefs map[int64]map[int64]float64,
+) map[int64][]scoredArticle {
+	users := make(map[int64]map[int64]float64)
+	norms := make(map[int64]float64)
+	for uid, items := range prefs {
+		for aid, w := range items {
+			if users[aid] == nil {
+				users[aid] = make(map[int64]float64)
+			}
+			users[aid][uid] = w
+			norms[aid] += w * w
+		}
+	}
+	byTag := make(map[string][]int64)
+	for aid, tags := range arts {
+		for _, tag := range tags {
+			byTag[tag] = append(byTag[tag], aid)
+		}
+	}
+
+	res := make(map[int64][]scoredArticle, len(arts))
+	for aid, tags := range arts {
+		scores := make(map[int64]float64)
+		for uid, w := range users[aid] {
+			for other, ow := range prefs[uid] {
+				if other != aid {
+					scores[other] += w * ow
+				}
+			}
+		}
+		for other, dot := range scores {
+			scores[other] = dot / math.Sqrt(norms[aid]*norms[other])
+		}
+
+		common := make(map[int64]int)
+		for _, tag := range tags {
+			for _, other := range byTag[tag] {
+				if other != aid {
+					common[other]++
+				}
+			}
+		}
+		for other, n := range common {
+			union := len(tags) + len(arts[other]) - n
+			scores[other] += r.tagWeight * float64(n) / float64(union)
+		}
+
+		if len(scores) > 0 {
+			res[aid] = topScored(scores, r.relatedN)
+		}
+	}
+	return res
+}
+
+// forUsers recommends to each user the articles related to the ones the user
+// interacted with.
+func (r *recommendService) forUsers(
+	prefs map[int64]map[int64]float64,
+	related map[int64][]scoredArticle,
+) map[int64][]int64 {
+	res := make(map[int64][]int64, len(prefs))
+	for uid, items := range prefs {
+		scores := make(map[int64]float64)
+		for aid, w := range items {
+			for _, rel := range related[aid] {
+				if _, seen := items[rel.id]; !seen {
+					scores[rel.id] += w * rel.score
+				}
+			}
+		}
+		if len(scores) == 0 {
+			continue
+		}
+		res[uid] = gslice.Map(topScored(scores, r.userN), func(id int, src scoredArticle) int64 {
+			return src.id
+		})
+	}
+	return res
+}
+
+// Related implements RecommendService.
+func (r *recommendService) Related(
+	ctx context.Context,
+	aid int64,
+	limit int,
+) ([]domain.Article, error) {
+	ids, err := r.repo.GetRelated(ctx, aid)
+	if err != nil && err != repository.ErrRecommendationNotFound {
+		r.l.Warn("get related articles error", logger.Int64("aid", aid), logger.Error(err))
+	}
+	return r.getPubs(ctx, ids, aid, limit)
+}
+
+// ForUser implements RecommendService.
+func (r *recommendService) ForUser(
+	ctx context.Context,
+	uid int64,
+	limit int,
+) ([]domain.Article, error) {
+	ids, err := r.repo.GetForUser(ctx, uid)
+	if err != nil && err != repository.ErrRecommendationNotFound {
+		r.l.Warn("get recommendations error", logger.Int64("uid", uid), logger.Error(err))
+	}
+	return r.getPubs(ctx, ids, 0, limit)
+}
+
+// getPubs returns the published articles in the order of the IDs, or the hot
+// articles if none of them is still published.
+func (r *recommendService) getPubs(
+	ctx context.Context,
+	ids []int64,
+	exclude int64,
+	limit int,
+) ([]domain.Article, error) {
+	arts := make([]domain.Article, len(ids))
+	var eg errgroup.Group
+	for i, id := range ids {
+		eg.Go(func() error {
+			art, err := r.artRepo.GetPubByID(ctx, id)
+			switch {
+			case err == repository.ErrArticleNotFound:
+				return nil
+			case err != nil:
+				return err
+			}
+			arts[i] = art
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	arts = slices.DeleteFunc(arts, func(art domain.Article) bool {
+		return art.Status != domain.ArticleStatusPublished
+	})
+	if len(arts) == 0 {
+		hot, err := r.rankingSvc.GetTopN(ctx)
+		if err != nil {
+			return nil, err
+		}
+		arts = slices.DeleteFunc(hot, func(art domain.Article) bool {
+			return art.ID == exclude
+		})
+	}
+	if len(arts) > limit {
+		arts = arts[:limit]
+	}
+	return arts, nil
+}
+
+func addPref(
+	prefs map[int64]map[int64]float64,
+	arts map[int64][]string,
+	uid int64,
+	aid int64,
+	w float64,
+) {
+	if _, ok := arts[aid]; !ok {
+		return
+	}
+	if prefs[uid] == nil {
+		prefs[uid] = make(map[int64]float64)
+	}
+	prefs[uid][aid] += w
+}
+
+// topScored returns at most n articles, the best scored first.
+func topScored(scores map[int64]float64, n int) []scoredArticle {
+	res := make([]scoredArticle, 0, len(scores))
+	for id, score := range scores {
+		res = append(res, scoredArticle{id: id, score: score})
+	}
+	slices.SortFunc(res, func(a, b scoredArticle) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		// the latest first
+		return cmp.Compare(b.id, a.id)
+	})
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res
+}
+
+func toIDLists(scored map[int64][]scoredArticle) map[int64][]int64 {
+	res := make(map[int64][]int64, len(scored))
+	for aid, arts := range scored {
+		res[aid] = gslice.Map(arts, func(id int, src scoredArticle) int64 {
+			return src.id
+		})
+	}
+	return res
+}
+
+func NewRecommendService(
+	l logger.Logger,
+	repo repository.RecommendRepository,
+	artRepo repository.ArticleRepository,
+	historyRepo repository.ReadHistoryRepository,
+	intrSvc intrv1.InteractiveServiceClient,
+	rankingSvc RankingService,
+) RecommendService {
+	return &recommendService{
+		l:             l,
+		repo:          repo,
+		artRepo:       artRepo,
+		historyRepo:   historyRepo,
+		intrSvc:       intrSvc,
+		rankingSvc:    rankingSvc,
+		biz:           "article",
+		window:        30 * 24 * time.Hour,
+		batchSize:     500,
+		relatedN:      20,
+		userN:         50,
+		historySize:   50,
+		likeWeight:    1,
+		collectWeight: 2,
+		readWeight:    0.5,
+		tagWeight:     0.5,
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	intrv1mock "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1/mock"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	repomocks "github.com/chenmuyao/go-bootcamp/internal/repository/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_recommendService_Compute(t *testing.T) {
+	now := time.Now()
+	arts := []domain.Article{
+		{ID: 3, Tags: []string{"go"}, Utime: now},
+		{ID: 2, Tags: []string{"go", "web"}, Utime: now.Add(-time.Minute)},
+		{ID: 1, Tags: []string{"db"}, Utime: now.Add(-2 * time.Minute)},
+		{ID: 99, Utime: now.Add(-60 * 24 * time.Hour)},
+	}
+
+	ctrl := gomock.NewController(t)
+	repo := repomocks.NewMockRecommendRepository(ctrl)
+	artRepo := repomocks.NewMockArticleRepository(ctrl)
+	historyRepo := repomocks.NewMockReadHistoryRepository(ctrl)
+	intrSvc := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+
+	artRepo.EXPECT().ListPub(gomock.Any(), domain.Cursor{}, 2).Return(arts[:2], nil)
+	artRepo.EXPECT().ListPub(gomock.Any(), domain.ArticleCursor(arts[1]), 2).Return(arts[2:], nil)
+	intrSvc.EXPECT().
+		ListLikes(gomock.Any(), &intrv1.ListLikesRequest{Biz: "article", Limit: 2}).
+		Return(&intrv1.ListLikesResponse{Likes: []*intrv1.UserBiz{
+			{Id: 1, Uid: 10, BizId: 1},
+			{Id: 2, Uid: 10, BizId: 2},
+		}}, nil)
+	intrSvc.EXPECT().
+		ListLikes(gomock.Any(), &intrv1.ListLikesRequest{Biz: "article", AfterId: 2, Limit: 2}).
+		Return(&intrv1.ListLikesResponse{Likes: []*intrv1.UserBiz{
+			{Id: 3, Uid: 11, BizId: 2},
+		}}, nil)
+	intrSvc.EXPECT().
+		ListCollects(gomock.Any(), &intrv1.ListCollectsRequest{Biz: "article", Limit: 2}).
+		Return(&intrv1.ListCollectsResponse{Collects: []*intrv1.UserBiz{
+			{Id: 1, Uid: 11, BizId: 3},
+		}}, nil)
+	// co-likes and co-collects: 1-2 and 2-3; tags: 2-3
+	repo.EXPECT().SetRelated(gomock.Any(), map[int64][]int64{
+		1: {2},
+		2: {3, 1},
+		3: {2},
+	}).Return(nil)
+	historyRepo.EXPECT().Readers(gomock.Any(), gomock.Any(), 0, 2).Return([]int64{12}, nil)
+	historyRepo.EXPECT().List(gomock.Any(), int64(12), domain.Cursor{}, 50).Return([]domain.ReadRecord{
+		{UID: 12, Article: domain.Article{ID: 3}},
+		// too old to be recommended
+		{UID: 12, Article: domain.Article{ID: 99}},
+	}, nil)
+	// the articles already seen are not recommended
+	repo.EXPECT().SetForUsers(gomock.Any(), map[int64][]int64{
+		10: {3},
+		11: {1},
+		12: {2},
+	}).Return(nil)
+
+	svc := NewRecommendService(
+		logger.NewNopLogger(),
+		repo,
+		artRepo,
+		historyRepo,
+		intrSvc,
+		nil,
+	).(*recommendService)
+	svc.batchSize = 2
+	err := svc.Compute(context.Background())
+	assert.NoError(t, err)
+}
+
+func Test_recommendService_Related(t *testing.T) {
+	published := func(id int64) domain.Article {
+		return domain.Article{ID: id, Status: domain.ArticleStatusPublished}
+	}
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (
+			repository.RecommendRepository,
+			repository.ArticleRepository,
+			repository.RankingRepository,
+		)
+		aid   int64
+		limit int
+
+		wantErr  error
+		wantArts []domain.Article
+	}{
+		{
+			name: "computed",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RecommendRepository,
+				repository.ArticleRepository,
+				repository.RankingRepository,
+			) {
+				repo := repomocks.NewMockRecommendRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRelated(gomock.Any(), int64(1)).Return([]int64{4, 2, 3, 5}, nil)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(4)).Return(published(4), nil)
+				artRepo.EXPECT().
+					GetPubByID(gomock.Any(), int64(2)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				artRepo.EXPECT().
+					GetPubByID(gomock.Any(), int64(3)).
+					Return(domain.Article{ID: 3, Status: domain.ArticleStatusPrivate}, nil)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(5)).Return(published(5), nil)
+				return repo, artRepo, nil
+			},
+			aid:      1,
+			limit:    10,
+			wantArts: []domain.Article{published(4), published(5)},
+		},
+		{
+			name: "not computed yet",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RecommendRepository,
+				repository.ArticleRepository,
+				repository.RankingRepository,
+			) {
+				repo := repomocks.NewMockRecommendRepository(ctrl)
+				rankingRepo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().
+					GetRelated(gomock.Any(), int64(1)).
+					Return(nil, repository.ErrRecommendationNotFound)
+				rankingRepo.EXPECT().GetTopN(gomock.Any()).Return([]domain.Article{
+					published(1), published(6), published(7),
+				}, nil)
+				return repo, nil, rankingRepo
+			},
+			aid:      1,
+			limit:    1,
+			wantArts: []domain.Article{published(6)},
+		},
+		{
+			name: "all withdrawn",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RecommendRepository,
+				repository.ArticleRepository,
+				repository.RankingRepository,
+			) {
+				repo := repomocks.NewMockRecommendRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				rankingRepo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetRelated(gomock.Any(), int64(1)).Return([]int64{2}, nil)
+				artRepo.EXPECT().
+					GetPubByID(gomock.Any(), int64(2)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				rankingRepo.EXPECT().GetTopN(gomock.Any()).Return([]domain.Article{
+					published(6),
+				}, nil)
+				return repo, artRepo, rankingRepo
+			},
+			aid:      1,
+			limit:    10,
+			wantArts: []domain.Article{published(6)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, artRepo, rankingRepo := tc.mock(ctrl)
+			svc := NewRecommendService(
+				logger.NewNopLogger(),
+				repo,
+				artRepo,
+				nil,
+				nil,
+				NewBatchRankingService(nil, nil, rankingRepo),
+			)
+			arts, err := svc.Related(context.Background(), tc.aid, tc.limit)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArts, arts)
+		})
+	}
+}
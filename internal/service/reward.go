@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/events/reward"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"github.com/chenmuyao/go-bootcamp/internal/service/payment"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+)
+
+const (
+	bizArticle = "article"
+
+	// in cents
+	maxRewardAmount   = 100_000
+	rewardOrderPrefix = "reward-"
+	// the rewards not paid in time are closed
+	rewardPayDeadline = 30 * time.Minute
+)
+
+var (
+	ErrRewardNotFound      = repository.ErrRewardNotFound
+	ErrInvalidRewardAmount = errors.New("invalid reward amount")
+	ErrRewardSelf          = errors.New("cannot reward yourself")
+	ErrInvalidCallback     = payment.ErrInvalidCallback
+)
+
+//go:generate mockgen -source=./reward.go -package=svcmocks -destination=./mocks/reward.mock.go
+type RewardService interface {
+	// RewardArticle creates a reward of the author of a published article and
+	// returns the URL where the user pays it.
+	RewardArticle(ctx context.Context, aid, uid, amount int64) (domain.Reward, string, error)
+	// GetReward returns a reward of the user. A reward waiting for its
+	// payment is checked with the provider first.
+	GetReward(ctx context.Context, id, uid int64) (domain.Reward, error)
+	// HandleCallback applies the payment result notified by the provider.
+	// It can be called several times for the same reward.
+	HandleCallback(ctx context.Context, req *http.Request) error
+	// SyncPending checks with the provider at most limit rewards waiting for
+	// their payment since before, after the cursor, in case the callback is
+	// lost. It returns the cursor of the last listed reward, and how many are
+	// listed. A reward failing to sync is left to the next run.
+	SyncPending(
+		ctx context.Context,
+		before time.Time,
+		after domain.RewardCursor,
+		limit int,
+	) (domain.RewardCursor, int, error)
+	// NotifyPaid produces the paid events which failed, for at most limit
+	// rewards with an ID greater than afterID. It returns the ID of the last
+	// listed reward, and how many are listed.
+	NotifyPaid(ctx context.Context, afterID int64, limit int) (int64, int, error)
+}
+
+type rewardService struct {
+	l        logger.Logger
+	repo     repository.RewardRepository
+	artRepo  repository.ArticleRepository
+	pay      payment.Service
+	producer reward.Producer
+}
+
+// RewardArticle implements RewardService.
+func (r *rewardService) RewardArticle(
+	ctx context.Context,
+	aid, uid, amount int64,
+) (domain.Reward, string, error) {
+	if amount <= 0 || amount > maxRewardAmount {
+		return domain.Reward{}, "", ErrInvalidRewardAmount
+	}
+	// NOTE: from the repository, the service GetPubByID counts a read.
+	art, err := r.artRepo.GetPubByID(ctx, aid)
+	if err != nil {
+		return domain.Reward{}, "", err
+	}
+	if art.Status != domain.ArticleStatusPublished {
+		return domain.Reward{}, "", ErrArticleNotFound
+	}
+	if art.Author.ID == uid {
+		return domain.Reward{}, "", ErrRewardSelf
+	}
+
+	res := domain.Reward{
+		Biz:       bizArticle,
+		BizID:     aid,
+		UID:       uid,
+		TargetUID: art.Author.ID,
+		Amount:    amount,
+		Status:    domain.RewardStatusInit,
+	}
+	res.ID, err = r.repo.Create(ctx, res)
+	if err != nil {
+		return domain.Reward{}, "", err
+	}
+	// NOTE: if the prepay fails, the reward stays in init until SyncPending
+	// finds out that the provider does not know it.
+	payURL, err := r.pay.Prepay(ctx, payment.Payment{
+		OrderNo:     rewardOrderNo(res.ID),
+		Amount:      amount,
+		Description: fmt.Sprintf("reward of %s", art.Title),
+	})
+	if err != nil {
+		return domain.Reward{}, "", err
+	}
+	return res, payURL, nil
+}
+
+// GetReward implements RewardService.
+func (r *rewardService) GetReward(ctx context.Context, id, uid int64) (domain.Reward, error) {
+	res, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Reward{}, err
+	}
+	if res.UID != uid {
+		return domain.Reward{}, ErrRewardNotFound
+	}
+	if res.Status != domain.RewardStatusInit {
+		return res, nil
+	}
+	status, err := r.sync(ctx, res)
+	if err != nil {
+		// still waiting as far as we know
+		r.l.Warn("failed to query reward payment",
+			logger.Int64("id", id), logger.Error(err))
+		return res, nil
+	}
+	res.Status = status
+	return res, nil
+}
+
+// HandleCallback implements RewardService.
+func (r *rewardService) HandleCallback(ctx context.Context, req *http.Request) error {
+	result, err := r.pay.ParseCallback(req)
+	if err != nil {
+		return err
+	}
+	id, ok := parseRewardOrderNo(result.OrderNo)
+	if !ok {
+		return ErrRewardNotFound
+	}
+	rwd, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	_, err = r.apply(ctx, rwd, result)
+	return err
+}
+
+// SyncPending implements RewardService.
+func (r *rewardService) SyncPending(
+	ctx context.Context,
+	before time.Time,
+	after domain.RewardCursor,
+	limit int,
+) (domain.RewardCursor, int, error) {
+	pending, err := r.repo.ListPending(ctx, before, after, limit)
+	if err != nil {
+		return after, 0, err
+	}
+	for _, rwd := range pending {
+		_, err = r.sync(ctx, rwd)
+		if err != nil {
+			r.l.Error("failed to sync reward",
+				logger.Int64("id", rwd.ID), logger.Error(err))
+		}
+		after = domain.RewardCursor{Ctime: rwd.Ctime, ID: rwd.ID}
+	}
+	return after, len(pending), nil
+}
+
+// NotifyPaid implements RewardService.
+func (r *rewardService) NotifyPaid(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+) (int64, int, error) {
+	unnotified, err := r.repo.ListUnnotified(ctx, afterID, limit)
+	if err != nil {
+		return afterID, 0, err
+	}
+	for _, rwd := range unnotified {
+		err = r.notify(ctx, rwd)
+		if err != nil {
+			r.l.Error("failed to produce reward paid event",
+				logger.Int64("id", rwd.ID), logger.Error(err))
+		}
+		afterID = rwd.ID
+	}
+	return afterID, len(unnotified), nil
+}
+
+// sync queries the provider for a reward waiting for its payment and returns
+// its new status. The order is closed after the payment deadline.
+func (r *rewardService) sync(ctx context.Context, rwd domain.Reward) (domain.RewardStatus, error) {
+	orderNo := rewardOrderNo(rwd.ID)
+	result, err := r.pay.Query(ctx, orderNo)
+	if err == nil && result.Status == payment.StatusPending &&
+		time.Since(rwd.Ctime) > rewardPayDeadline {
+		// NOTE: closed at the provider first, the user may be paying it.
+		result, err = r.pay.Close(ctx, orderNo)
+	}
+	if err == payment.ErrOrderNotFound {
+		// the prepay failed, the user could never pay it
+		result = payment.Result{Status: payment.StatusFailed}
+	} else if err != nil {
+		return domain.RewardStatusUnknown, err
+	}
+	return r.apply(ctx, rwd, result)
+}
+
+// apply moves the reward to the status of the payment. The status only moves
+// once, the paid event is produced by whoever moved it.
+func (r *rewardService) apply(
+	ctx context.Context,
+	rwd domain.Reward,
+	result payment.Result,
+) (domain.RewardStatus, error) {
+	var status domain.RewardStatus
+	switch result.Status {
+	case payment.StatusPaid:
+		status = domain.RewardStatusPaid
+		if result.Amount != rwd.Amount {
+			return domain.RewardStatusUnknown, fmt.Errorf(
+				"reward %d paid %d instead of %d", rwd.ID, result.Amount, rwd.Amount)
+		}
+	case payment.StatusFailed:
+		status = domain.RewardStatusFailed
+	default:
+		return rwd.Status, nil
+	}
+	changed, err := r.repo.UpdateStatus(ctx, rwd.ID, status)
+	if err != nil {
+		return domain.RewardStatusUnknown, err
+	}
+	if !changed {
+		// settled by a concurrent callback or sync
+		res, err := r.repo.GetByID(ctx, rwd.ID)
+		return res.Status, err
+	}
+	if status == domain.RewardStatusPaid {
+		rwd.Status = status
+		err = r.notify(ctx, rwd)
+		if err != nil {
+			// the reward is paid, NotifyPaid produces the event again
+			r.l.Error("failed to produce reward paid event",
+				logger.Int64("id", rwd.ID), logger.Error(err))
+		}
+	}
+	return status, nil
+}
+
+func (r *rewardService) notify(ctx context.Context, rwd domain.Reward) error {
+	err := r.producer.ProducePaidEvent(reward.PaidEvent{
+		ID:        rwd.ID,
+		Biz:       rwd.Biz,
+		BizID:     rwd.BizID,
+		UID:       rwd.UID,
+		TargetUID: rwd.TargetUID,
+		Amount:    rwd.Amount,
+	})
+	if err != nil {
+		return err
+	}
+	// NOTE: the event is produced again if this fails, it is delivered at
+	// least once anyway.
+	return r.repo.SetNotified(ctx, rwd.ID)
+}
+
+func rewardOrderNo(id int64) string {
+	return rewardOrderPrefix + strconv.FormatInt(id, 10)
+}
+
+func parseRewardOrderNo(orderNo string) (int64, bool) {
+	s, ok := strings.CutPrefix(orderNo, rewardOrderPrefix)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	return id, err == nil && id > 0
+}
+
+func NewRewardService(
+	l logger.Logger,
+	repo repository.RewardRepository,
+	artRepo repository.ArticleRepository,
+	pay payment.Service,
+	producer reward.Producer,
+) RewardService {
+	return &rewardService{
+		l:        l,
+		repo:     repo,
+		artRepo:  artRepo,
+		pay:      pay,
+		producer: producer,
+	}
+}
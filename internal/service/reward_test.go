@@ -0,0 +1,545 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/events/reward"
+	rewardmocks "github.com/chenmuyao/go-bootcamp/internal/events/reward/mocks"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	repomocks "github.com/chenmuyao/go-bootcamp/internal/repository/mocks"
+	"github.com/chenmuyao/go-bootcamp/internal/service/payment"
+	paymentmocks "github.com/chenmuyao/go-bootcamp/internal/service/payment/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_rewardService_RewardArticle(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (
+			repository.RewardRepository,
+			repository.ArticleRepository,
+			payment.Service,
+		)
+		amount int64
+
+		wantRes    domain.Reward
+		wantPayURL string
+		wantErr    error
+	}{
+		{
+			name: "created",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				repository.ArticleRepository,
+				payment.Service,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Title:  "my title",
+					Author: domain.Author{ID: 456},
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Reward{
+					Biz:       "article",
+					BizID:     1,
+					UID:       123,
+					TargetUID: 456,
+					Amount:    500,
+					Status:    domain.RewardStatusInit,
+				}).Return(int64(7), nil)
+				pay.EXPECT().Prepay(gomock.Any(), payment.Payment{
+					OrderNo:     "reward-7",
+					Amount:      500,
+					Description: "reward of my title",
+				}).Return("http://pay/7", nil)
+				return repo, artRepo, pay
+			},
+			amount: 500,
+			wantRes: domain.Reward{
+				ID:        7,
+				Biz:       "article",
+				BizID:     1,
+				UID:       123,
+				TargetUID: 456,
+				Amount:    500,
+				Status:    domain.RewardStatusInit,
+			},
+			wantPayURL: "http://pay/7",
+		},
+		{
+			name: "invalid amount",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				repository.ArticleRepository,
+				payment.Service,
+			) {
+				return nil, nil, nil
+			},
+			amount:  0,
+			wantErr: ErrInvalidRewardAmount,
+		},
+		{
+			name: "article not published",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				repository.ArticleRepository,
+				payment.Service,
+			) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Author: domain.Author{ID: 456},
+					Status: domain.ArticleStatusPrivate,
+				}, nil)
+				return nil, artRepo, nil
+			},
+			amount:  500,
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "own article",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				repository.ArticleRepository,
+				payment.Service,
+			) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Author: domain.Author{ID: 123},
+					Status: domain.ArticleStatusPublished,
+				}, nil)
+				return nil, artRepo, nil
+			},
+			amount:  500,
+			wantErr: ErrRewardSelf,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, artRepo, pay := tc.mock(ctrl)
+			svc := NewRewardService(logger.NewNopLogger(), repo, artRepo, pay, nil)
+			res, payURL, err := svc.RewardArticle(context.Background(), 1, 123, tc.amount)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+			assert.Equal(t, tc.wantPayURL, payURL)
+		})
+	}
+}
+
+func Test_rewardService_HandleCallback(t *testing.T) {
+	rwd := domain.Reward{
+		ID:        7,
+		Biz:       "article",
+		BizID:     1,
+		UID:       123,
+		TargetUID: 456,
+		Amount:    500,
+		Status:    domain.RewardStatusInit,
+	}
+	paidEvent := reward.PaidEvent{
+		ID:        7,
+		Biz:       "article",
+		BizID:     1,
+		UID:       123,
+		TargetUID: 456,
+		Amount:    500,
+	}
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (
+			repository.RewardRepository,
+			payment.Service,
+			reward.Producer,
+		)
+
+		wantErr error
+	}{
+		{
+			name: "paid",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				producer := rewardmocks.NewMockProducer(ctrl)
+				pay.EXPECT().ParseCallback(gomock.Any()).Return(payment.Result{
+					OrderNo: "reward-7",
+					Status:  payment.StatusPaid,
+					Amount:  500,
+				}, nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(rwd, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), int64(7), domain.RewardStatusPaid).
+					Return(true, nil)
+				producer.EXPECT().ProducePaidEvent(paidEvent).Return(nil)
+				repo.EXPECT().SetNotified(gomock.Any(), int64(7)).Return(nil)
+				return repo, pay, producer
+			},
+		},
+		{
+			name: "paid again",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				pay.EXPECT().ParseCallback(gomock.Any()).Return(payment.Result{
+					OrderNo: "reward-7",
+					Status:  payment.StatusPaid,
+					Amount:  500,
+				}, nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(rwd, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), int64(7), domain.RewardStatusPaid).
+					Return(false, nil)
+				paid := rwd
+				paid.Status = domain.RewardStatusPaid
+				repo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(paid, nil)
+				// no event produced twice
+				return repo, pay, rewardmocks.NewMockProducer(ctrl)
+			},
+		},
+		{
+			name: "event failed",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				producer := rewardmocks.NewMockProducer(ctrl)
+				pay.EXPECT().ParseCallback(gomock.Any()).Return(payment.Result{
+					OrderNo: "reward-7",
+					Status:  payment.StatusPaid,
+					Amount:  500,
+				}, nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(rwd, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), int64(7), domain.RewardStatusPaid).
+					Return(true, nil)
+				// left to SyncPending
+				producer.EXPECT().ProducePaidEvent(paidEvent).Return(errors.New("kafka error"))
+				return repo, pay, producer
+			},
+		},
+		{
+			name: "failed",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				pay.EXPECT().ParseCallback(gomock.Any()).Return(payment.Result{
+					OrderNo: "reward-7",
+					Status:  payment.StatusFailed,
+				}, nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(rwd, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), int64(7), domain.RewardStatusFailed).
+					Return(true, nil)
+				return repo, pay, nil
+			},
+		},
+		{
+			name: "wrong amount",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				pay.EXPECT().ParseCallback(gomock.Any()).Return(payment.Result{
+					OrderNo: "reward-7",
+					Status:  payment.StatusPaid,
+					Amount:  1,
+				}, nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(rwd, nil)
+				return repo, pay, nil
+			},
+			wantErr: errors.New("reward 7 paid 1 instead of 500"),
+		},
+		{
+			name: "unknown order",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				pay := paymentmocks.NewMockService(ctrl)
+				pay.EXPECT().ParseCallback(gomock.Any()).Return(payment.Result{
+					OrderNo: "other-7",
+					Status:  payment.StatusPaid,
+				}, nil)
+				return nil, pay, nil
+			},
+			wantErr: ErrRewardNotFound,
+		},
+		{
+			name: "invalid signature",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				pay := paymentmocks.NewMockService(ctrl)
+				pay.EXPECT().
+					ParseCallback(gomock.Any()).
+					Return(payment.Result{}, payment.ErrInvalidCallback)
+				return nil, pay, nil
+			},
+			wantErr: ErrInvalidCallback,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, pay, producer := tc.mock(ctrl)
+			svc := NewRewardService(logger.NewNopLogger(), repo, nil, pay, producer)
+			req, err := http.NewRequest(http.MethodPost, "/rewards/callback", nil)
+			assert.NoError(t, err)
+			err = svc.HandleCallback(context.Background(), req)
+			if tc.wantErr != nil {
+				assert.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_rewardService_SyncPending(t *testing.T) {
+	before := time.UnixMilli(time.Now().UnixMilli())
+	ctime := before.Add(-time.Minute)
+	after := domain.RewardCursor{Ctime: ctime, ID: 1}
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (
+			repository.RewardRepository,
+			payment.Service,
+			reward.Producer,
+		)
+
+		wantCursor domain.RewardCursor
+		wantCnt    int
+		wantErr    error
+	}{
+		{
+			name: "settled",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				producer := rewardmocks.NewMockProducer(ctrl)
+				repo.EXPECT().ListPending(gomock.Any(), before, after, 10).Return([]domain.Reward{
+					{ID: 2, Amount: 500, Status: domain.RewardStatusInit, Ctime: ctime},
+					{ID: 3, Amount: 500, Status: domain.RewardStatusInit, Ctime: ctime},
+					{ID: 4, Amount: 500, Status: domain.RewardStatusInit, Ctime: ctime},
+				}, nil)
+				// still waiting
+				pay.EXPECT().Query(gomock.Any(), "reward-2").Return(payment.Result{
+					OrderNo: "reward-2",
+					Status:  payment.StatusPending,
+					Amount:  500,
+				}, nil)
+				// the prepay failed
+				pay.EXPECT().
+					Query(gomock.Any(), "reward-3").
+					Return(payment.Result{}, payment.ErrOrderNotFound)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), int64(3), domain.RewardStatusFailed).
+					Return(true, nil)
+				// the callback is lost
+				pay.EXPECT().Query(gomock.Any(), "reward-4").Return(payment.Result{
+					OrderNo: "reward-4",
+					Status:  payment.StatusPaid,
+					Amount:  500,
+				}, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), int64(4), domain.RewardStatusPaid).
+					Return(true, nil)
+				producer.EXPECT().ProducePaidEvent(reward.PaidEvent{ID: 4, Amount: 500}).Return(nil)
+				repo.EXPECT().SetNotified(gomock.Any(), int64(4)).Return(nil)
+				return repo, pay, producer
+			},
+			wantCursor: domain.RewardCursor{Ctime: ctime, ID: 4},
+			wantCnt:    3,
+		},
+		{
+			name: "closed after the deadline",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				old := before.Add(-time.Hour)
+				repo.EXPECT().ListPending(gomock.Any(), before, after, 10).Return([]domain.Reward{
+					{ID: 2, Amount: 500, Status: domain.RewardStatusInit, Ctime: old},
+				}, nil)
+				pay.EXPECT().Query(gomock.Any(), "reward-2").Return(payment.Result{
+					OrderNo: "reward-2",
+					Status:  payment.StatusPending,
+					Amount:  500,
+				}, nil)
+				pay.EXPECT().Close(gomock.Any(), "reward-2").Return(payment.Result{
+					OrderNo: "reward-2",
+					Status:  payment.StatusFailed,
+					Amount:  500,
+				}, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), int64(2), domain.RewardStatusFailed).
+					Return(true, nil)
+				return repo, pay, nil
+			},
+			wantCursor: domain.RewardCursor{Ctime: before.Add(-time.Hour), ID: 2},
+			wantCnt:    1,
+		},
+		{
+			name: "errors skipped",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				pay := paymentmocks.NewMockService(ctrl)
+				repo.EXPECT().ListPending(gomock.Any(), before, after, 10).Return([]domain.Reward{
+					{ID: 2, Amount: 500, Status: domain.RewardStatusInit, Ctime: ctime},
+					{ID: 3, Amount: 500, Status: domain.RewardStatusInit, Ctime: ctime},
+					{ID: 4, Amount: 500, Status: domain.RewardStatusInit, Ctime: ctime},
+				}, nil)
+				pay.EXPECT().
+					Query(gomock.Any(), "reward-2").
+					Return(payment.Result{}, errors.New("provider error"))
+				pay.EXPECT().Query(gomock.Any(), "reward-3").Return(payment.Result{
+					OrderNo: "reward-3",
+					Status:  payment.StatusPaid,
+					Amount:  1,
+				}, nil)
+				pay.EXPECT().Query(gomock.Any(), "reward-4").Return(payment.Result{
+					OrderNo: "reward-4",
+					Status:  payment.StatusFailed,
+				}, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), int64(4), domain.RewardStatusFailed).
+					Return(true, nil)
+				return repo, pay, nil
+			},
+			wantCursor: domain.RewardCursor{Ctime: ctime, ID: 4},
+			wantCnt:    3,
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (
+				repository.RewardRepository,
+				payment.Service,
+				reward.Producer,
+			) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				repo.EXPECT().
+					ListPending(gomock.Any(), before, after, 10).
+					Return(nil, errors.New("db error"))
+				return repo, nil, nil
+			},
+			wantCursor: after,
+			wantErr:    errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, pay, producer := tc.mock(ctrl)
+			svc := NewRewardService(logger.NewNopLogger(), repo, nil, pay, producer)
+			cursor, cnt, err := svc.SyncPending(context.Background(), before, after, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCursor, cursor)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func Test_rewardService_NotifyPaid(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.RewardRepository, reward.Producer)
+
+		wantAfterID int64
+		wantCnt     int
+		wantErr     error
+	}{
+		{
+			name: "errors skipped",
+			mock: func(ctrl *gomock.Controller) (repository.RewardRepository, reward.Producer) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				producer := rewardmocks.NewMockProducer(ctrl)
+				repo.EXPECT().ListUnnotified(gomock.Any(), int64(3), 10).Return([]domain.Reward{
+					{ID: 4, Amount: 100, Status: domain.RewardStatusPaid},
+					{ID: 5, Amount: 200, Status: domain.RewardStatusPaid},
+				}, nil)
+				producer.EXPECT().
+					ProducePaidEvent(reward.PaidEvent{ID: 4, Amount: 100}).
+					Return(errors.New("kafka error"))
+				producer.EXPECT().ProducePaidEvent(reward.PaidEvent{ID: 5, Amount: 200}).Return(nil)
+				repo.EXPECT().SetNotified(gomock.Any(), int64(5)).Return(nil)
+				return repo, producer
+			},
+			wantAfterID: 5,
+			wantCnt:     2,
+		},
+		{
+			name: "db error",
+			mock: func(ctrl *gomock.Controller) (repository.RewardRepository, reward.Producer) {
+				repo := repomocks.NewMockRewardRepository(ctrl)
+				repo.EXPECT().
+					ListUnnotified(gomock.Any(), int64(3), 10).
+					Return(nil, errors.New("db error"))
+				return repo, nil
+			},
+			wantAfterID: 3,
+			wantErr:     errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, producer := tc.mock(ctrl)
+			svc := NewRewardService(logger.NewNopLogger(), repo, nil, nil, producer)
+			afterID, cnt, err := svc.NotifyPaid(context.Background(), 3, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantAfterID, afterID)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
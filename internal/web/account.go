@@ -0,0 +1,234 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	ijwt "github.com/chenmuyao/go-bootcamp/internal/web/jwt"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// {{{ Consts
+
+// }}}
+// {{{ Global Varirables
+
+var withdrawalStatusNames = map[domain.WithdrawalStatus]string{
+	domain.WithdrawalStatusPending:  "pending",
+	domain.WithdrawalStatusApproved: "approved",
+	domain.WithdrawalStatusRejected: "rejected",
+}
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+// AccountHandler shows the revenue of the authors and their withdrawals.
+type AccountHandler struct {
+	l   logger.Logger
+	svc service.AccountService
+}
+
+func NewAccountHandler(l logger.Logger, svc service.AccountService) *AccountHandler {
+	return &AccountHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+// }}}
+// {{{ Struct Methods
+
+func (h *AccountHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/account")
+	g.GET("/balance", ginx.WrapClaims(h.l, h.Balance))
+	g.POST("/entries", ginx.WrapBodyAndClaims(h.l, h.Entries))
+	g.POST("/withdraw", ginx.WrapBodyAndClaims(h.l, h.Withdraw))
+	g.POST("/withdrawals", ginx.WrapBodyAndClaims(h.l, h.Withdrawals))
+
+	// admins only
+	g.POST("/withdrawals/pending", ginx.WrapBodyAndClaims(h.l, h.PendingWithdrawals))
+	g.POST("/withdrawals/review", ginx.WrapBodyAndClaims(h.l, h.ReviewWithdrawal))
+}
+
+func (h *AccountHandler) Balance(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	acc, err := h.svc.GetBalance(ctx, uc.UID)
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get balance",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: AccountVO{Balance: acc.Balance},
+	}, nil
+}
+
+func (h *AccountHandler) Entries(
+	ctx *gin.Context,
+	page Page,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	page = clampPage(page)
+	entries, err := h.svc.ListEntries(ctx, uc.UID, page.Offset, page.Limit)
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to list account entries",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: gslice.Map(entries, func(_ int, src domain.AccountEntry) AccountEntryVO {
+			return AccountEntryVO{
+				ID:     src.ID,
+				Biz:    src.Biz,
+				BizID:  src.BizID,
+				Amount: src.Amount,
+				Ctime:  src.Ctime.Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (h *AccountHandler) Withdraw(
+	ctx *gin.Context,
+	req WithdrawReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	w, err := h.svc.RequestWithdrawal(ctx, uc.UID, req.Amount)
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+			Data: toWithdrawalVO(w),
+		}, nil
+	case errors.Is(err, service.ErrInvalidWithdrawalAmount):
+		return ginx.Result{Code: ginx.CodeUserSide, Msg: "invalid amount"}, nil
+	case errors.Is(err, service.ErrInsufficientBalance):
+		return ginx.Result{Code: ginx.CodeUserSide, Msg: "insufficient balance"}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to request withdrawal",
+			logger.Int64("uid", uc.UID),
+			logger.Int64("amount", req.Amount),
+			logger.Error(err),
+		)
+	}
+}
+
+func (h *AccountHandler) Withdrawals(
+	ctx *gin.Context,
+	page Page,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	page = clampPage(page)
+	ws, err := h.svc.ListWithdrawals(ctx, uc.UID, page.Offset, page.Limit)
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to list withdrawals",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: gslice.Map(ws, func(_ int, src domain.Withdrawal) WithdrawalVO {
+			return toWithdrawalVO(src)
+		}),
+	}, nil
+}
+
+func (h *AccountHandler) PendingWithdrawals(
+	ctx *gin.Context,
+	page Page,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	page = clampPage(page)
+	ws, err := h.svc.ListPendingWithdrawals(ctx, uc.UID, page.Offset, page.Limit)
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+			Data: gslice.Map(ws, func(_ int, src domain.Withdrawal) WithdrawalVO {
+				return toWithdrawalVO(src)
+			}),
+		}, nil
+	case errors.Is(err, service.ErrNotAdmin):
+		return ginx.UnauthorizedResult, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to list pending withdrawals",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+}
+
+func (h *AccountHandler) ReviewWithdrawal(
+	ctx *gin.Context,
+	req WithdrawalReviewReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	err := h.svc.ReviewWithdrawal(ctx, req.ID, uc.UID, req.Approve)
+	switch {
+	case err == nil:
+		return ginx.Result{Code: ginx.CodeOK}, nil
+	case errors.Is(err, service.ErrNotAdmin):
+		return ginx.UnauthorizedResult, nil
+	case errors.Is(err, service.ErrWithdrawalNotFound):
+		return ginx.Result{Code: ginx.CodeNotFound, Msg: "withdrawal not found"}, nil
+	case errors.Is(err, service.ErrWithdrawalSettled):
+		return ginx.Result{Code: ginx.CodeConflict, Msg: "withdrawal already reviewed"}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to review withdrawal",
+			logger.Int64("uid", uc.UID),
+			logger.Int64("id", req.ID),
+			logger.Error(err),
+		)
+	}
+}
+
+// }}}
+// {{{ Private functions
+
+func clampPage(page Page) Page {
+	switch {
+	case page.Limit <= 0:
+		page.Limit = defaultListLimit
+	case page.Limit > maxListLimit:
+		page.Limit = maxListLimit
+	}
+	page.Offset = max(page.Offset, 0)
+	return page
+}
+
+func toWithdrawalVO(w domain.Withdrawal) WithdrawalVO {
+	return WithdrawalVO{
+		ID:     w.ID,
+		UID:    w.UID,
+		Amount: w.Amount,
+		Status: withdrawalStatusNames[w.Status],
+		Ctime:  w.Ctime.Format(time.DateTime),
+		Utime:  w.Utime.Format(time.DateTime),
+	}
+}
+
+// }}}
+// {{{ Package functions
+
+// }}}
@@ -0,0 +1,36 @@
+package web
+
+type WithdrawReq struct {
+	// in cents
+	Amount int64 `json:"amount"`
+}
+
+type WithdrawalReviewReq struct {
+	ID      int64 `json:"id"`
+	Approve bool  `json:"approve"`
+}
+
+type AccountVO struct {
+	// in cents
+	Balance int64 `json:"balance"`
+}
+
+type AccountEntryVO struct {
+	ID int64 `json:"id"`
+	// reward, withdrawal, withdrawal_approval or withdrawal_rejection
+	Biz   string `json:"biz"`
+	BizID int64  `json:"bizId"`
+	// signed, in cents
+	Amount int64  `json:"amount"`
+	Ctime  string `json:"ctime"`
+}
+
+type WithdrawalVO struct {
+	ID     int64 `json:"id"`
+	UID    int64 `json:"uid"`
+	Amount int64 `json:"amount"`
+	// pending, approved or rejected
+	Status string `json:"status"`
+	Ctime  string `json:"ctime"`
+	Utime  string `json:"utime"`
+}
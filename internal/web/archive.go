@@ -0,0 +1,305 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	ijwt "github.com/chenmuyao/go-bootcamp/internal/web/jwt"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// {{{ Consts
+
+const (
+	// the size of the uploaded zip, the uncompressed files are limited by
+	// the service
+	maxImportUploadSize = 50 << 20
+
+	contentTypeZip = "application/zip"
+)
+
+// }}}
+// {{{ Global Varirables
+
+var imageContentTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+// ArchiveHandler exports and imports the articles of the authors as zips of
+// Markdown files.
+type ArchiveHandler struct {
+	l   logger.Logger
+	svc service.ArchiveService
+}
+
+func NewArchiveHandler(l logger.Logger, svc service.ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+// attachmentWriter only sets the headers of the download when the archive
+// starts to be written, so that an error before can still be returned as
+// JSON.
+type attachmentWriter struct {
+	ctx      *gin.Context
+	filename string
+	started  bool
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		setAttachmentHeaders(w.ctx, w.filename)
+		w.ctx.Status(http.StatusOK)
+	}
+	return w.ctx.Writer.Write(p)
+}
+
+// }}}
+// {{{ Struct Methods
+
+func (h *ArchiveHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles")
+	g.GET("/export", ginx.WrapClaims(h.l, h.Export))
+	g.POST("/export/start", ginx.WrapClaims(h.l, h.StartExport))
+	g.GET("/export/status", ginx.WrapClaims(h.l, h.ExportStatus))
+	g.GET("/export/download", ginx.WrapClaims(h.l, h.Download))
+	g.POST("/import", ginx.WrapClaims(h.l, h.Import))
+
+	// public, the images are linked from the published articles
+	server.GET("/images/:uid/:name", ginx.WrapLog(h.l, h.Image))
+}
+
+// Export streams the archive, or tells to export in the background if the
+// author has too many articles.
+func (h *ArchiveHandler) Export(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	w := &attachmentWriter{ctx: ctx, filename: archiveFileName(uc.UID)}
+	err := h.svc.Export(ctx, uc.UID, w)
+	switch {
+	case err == nil:
+		return ginx.Result{}, nil
+	case err == service.ErrExportTooLarge:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "too many articles, please export in the background",
+		}, nil
+	case w.started:
+		// NOTE: too late to tell the client, the archive is truncated.
+		ctx.Abort()
+		return ginx.Result{}, logger.LError(
+			"failed to write the export",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to export articles",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+}
+
+func (h *ArchiveHandler) StartExport(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	export, err := h.svc.StartExport(ctx, uc.UID)
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to start export",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: toArticleExportVO(export),
+	}, nil
+}
+
+func (h *ArchiveHandler) ExportStatus(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	export, err := h.svc.GetExport(ctx, uc.UID)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+			Data: toArticleExportVO(export),
+		}, nil
+	case service.ErrArticleExportNotFound:
+		return ginx.Result{
+			Code: ginx.CodeNotFound,
+			Msg:  "no export",
+		}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get export",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+}
+
+func (h *ArchiveHandler) Download(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	data, err := h.svc.GetArchive(ctx, uc.UID)
+	switch err {
+	case nil:
+		setAttachmentHeaders(ctx, archiveFileName(uc.UID))
+		ctx.Data(http.StatusOK, contentTypeZip, data)
+		return ginx.Result{}, nil
+	case service.ErrArchiveNotFound:
+		return ginx.Result{
+			Code: ginx.CodeNotFound,
+			Msg:  "no export done",
+		}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get archive",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+}
+
+// Import takes the zip in the "file" field of a multipart form.
+func (h *ArchiveHandler) Import(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportUploadSize)
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return ginx.Result{
+				Code: ginx.CodeUserSide,
+				Msg:  fmt.Sprintf("archive larger than %d MB", maxImportUploadSize>>20),
+			}, nil
+		}
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "missing archive",
+		}, nil
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return ginx.InternalServerErrorResult, fmt.Errorf("open uploaded archive: %w", err)
+	}
+	defer f.Close()
+
+	results, err := h.svc.Import(ctx, uc.UID, f, fh.Size)
+	if errors.Is(err, service.ErrInvalidArchive) {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to import articles",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	res := ImportVO{
+		Results: gslice.Map(results, func(id int, src domain.ImportResult) ImportResultVO {
+			return ImportResultVO{
+				File:      src.File,
+				ArticleID: src.ArticleID,
+				Err:       src.Err,
+			}
+		}),
+	}
+	for _, r := range results {
+		if r.Err == "" {
+			res.Imported++
+		}
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: res,
+	}, nil
+}
+
+func (h *ArchiveHandler) Image(ctx *gin.Context) (ginx.Result, error) {
+	notFound := ginx.Result{
+		Code: ginx.CodeNotFound,
+		Msg:  "not found",
+	}
+	uid, err := strconv.ParseInt(ctx.Param("uid"), 10, 64)
+	if err != nil || uid <= 0 {
+		return notFound, nil
+	}
+	name := ctx.Param("name")
+	data, err := h.svc.GetImage(ctx, uid, name)
+	switch err {
+	case nil:
+		// NOTE: the images are named by their content, they never change.
+		ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+		ctx.Header("X-Content-Type-Options", "nosniff")
+		ctx.Data(http.StatusOK, imageContentTypes[path.Ext(name)], data)
+		return ginx.Result{}, nil
+	case service.ErrImageNotFound:
+		return notFound, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get image",
+			logger.Int64("uid", uid),
+			logger.String("name", name),
+			logger.Error(err),
+		)
+	}
+}
+
+// }}}
+// {{{ Private functions
+
+func archiveFileName(uid int64) string {
+	return fmt.Sprintf("articles-%d-%s.zip", uid, time.Now().Format("20060102"))
+}
+
+func setAttachmentHeaders(ctx *gin.Context, filename string) {
+	ctx.Header("Content-Type", contentTypeZip)
+	ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+}
+
+func toArticleExportVO(export domain.ArticleExport) ArticleExportVO {
+	var status string
+	switch export.Status {
+	case domain.ExportStatusPending:
+		status = "pending"
+	case domain.ExportStatusDone:
+		status = "done"
+	case domain.ExportStatusFailed:
+		status = "failed"
+	}
+	return ArticleExportVO{
+		Status: status,
+		Err:    export.Err,
+		Ctime:  export.Ctime.Format(time.DateTime),
+		Utime:  export.Utime.Format(time.DateTime),
+	}
+}
+
+// }}}
+// {{{ Package functions
+
+// }}}
@@ -0,0 +1,23 @@
+package web
+
+type ArticleExportVO struct {
+	// "pending", "done" or "failed"
+	Status string `json:"status"`
+	// why the export failed
+	Err   string `json:"err,omitempty"`
+	Ctime string `json:"ctime"`
+	Utime string `json:"utime"`
+}
+
+type ImportResultVO struct {
+	File string `json:"file"`
+	// ID of the draft created, absent if the file failed
+	ArticleID int64  `json:"articleId,omitempty"`
+	Err       string `json:"err,omitempty"`
+}
+
+type ImportVO struct {
+	Results []ImportResultVO `json:"results"`
+	// number of drafts created
+	Imported int `json:"imported"`
+}
@@ -2,8 +2,12 @@ package web
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/chenmuyao/generique/gslice"
@@ -19,9 +23,26 @@ import (
 
 // {{{ Consts
 
+const (
+	maxArticlePlaces = 20
+
+	defaultNearbyRadius = 5_000
+	maxNearbyRadius     = 50_000
+	defaultNearbyLimit  = 10
+	maxNearbyLimit      = 100
+
+	defaultListLimit = 10
+	maxListLimit     = 100
+)
+
 // }}}
 // {{{ Global Varirables
 
+var (
+	errInvalidPlaces = errors.New("invalid places")
+	errInvalidCursor = errors.New("invalid cursor")
+)
+
 // }}}
 // {{{ Interface
 
@@ -60,6 +81,14 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	g.POST("edit", ginx.WrapBodyAndClaims(h.l, h.Edit))
 	g.POST("publish", ginx.WrapBodyAndClaims(h.l, h.Publish))
 	g.POST("withdraw", ginx.WrapBodyAndClaims(h.l, h.Withdraw))
+	g.POST("delete", ginx.WrapBodyAndClaims(h.l, h.Delete))
+	g.POST("restore", ginx.WrapBodyAndClaims(h.l, h.Restore))
+	g.POST("trash", ginx.WrapBodyAndClaims(h.l, h.Trash))
+	g.POST("approve", ginx.WrapBodyAndClaims(h.l, h.Approve))
+
+	collab := g.Group("/collaborators")
+	collab.POST("/add", ginx.WrapBodyAndClaims(h.l, h.AddCollaborator))
+	collab.POST("/remove", ginx.WrapBodyAndClaims(h.l, h.RemoveCollaborator))
 
 	// author
 	g.GET("/detail/:id", ginx.WrapClaims(h.l, h.Detail))
@@ -70,9 +99,13 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	pub := g.Group("/pub")
 	pub.GET("/:id", ginx.WrapClaims(h.l, h.PubDetail))
 	pub.GET("/top_like", ginx.WrapLog(h.l, h.TopLike))
+	pub.GET("/nearby", ginx.WrapBodyAndClaims(h.l, h.Nearby))
 	// True: like; False: cancel like
 	pub.POST("/like", ginx.WrapBodyAndClaims(h.l, h.Like))
 	pub.POST("/collect", ginx.WrapBodyAndClaims(h.l, h.Collect))
+	// the articles liked or collected by the user
+	pub.POST("/liked", ginx.WrapBodyAndClaims(h.l, h.Liked))
+	pub.POST("/collected", ginx.WrapBodyAndClaims(h.l, h.Collected))
 }
 
 func (h *ArticleHandler) Edit(
@@ -80,6 +113,20 @@ func (h *ArticleHandler) Edit(
 	req ArticleEditReq,
 	uc ijwt.UserClaims,
 ) (ginx.Result, error) {
+	places, err := toDomainPlaces(req.Places)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+	tags, err := domain.NormalizeTags(req.Tags)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
 	aid, err := h.svc.Save(ctx, domain.Article{
 		ID:      req.ID,
 		Title:   req.Title,
@@ -87,7 +134,18 @@ func (h *ArticleHandler) Edit(
 		Author: domain.Author{
 			ID: uc.UID,
 		},
+		Places:  places,
+		Tags:    tags,
+		Version: req.Version,
 	})
+	var conflict service.VersionConflictError
+	if errors.As(err, &conflict) {
+		return ginx.Result{
+			Code: ginx.CodeConflict,
+			Msg:  "the article has been modified",
+			Data: VersionConflictVO{Version: conflict.Version},
+		}, nil
+	}
 	switch err {
 	case nil:
 		return ginx.Result{
@@ -99,6 +157,11 @@ func (h *ArticleHandler) Edit(
 			Code: ginx.CodeUserSide,
 			Msg:  "article not found",
 		}, nil
+	case service.ErrPermissionDenied:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "permission denied",
+		}, nil
 	default:
 		return ginx.InternalServerErrorResult, logger.LError(
 			"Save article failed: %w",
@@ -112,6 +175,20 @@ func (h *ArticleHandler) Publish(
 	req ArticlePublishReq,
 	uc ijwt.UserClaims,
 ) (ginx.Result, error) {
+	places, err := toDomainPlaces(req.Places)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+	tags, err := domain.NormalizeTags(req.Tags)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
 	aid, err := h.svc.Publish(ctx, domain.Article{
 		ID:      req.ID,
 		Title:   req.Title,
@@ -119,7 +196,18 @@ func (h *ArticleHandler) Publish(
 		Author: domain.Author{
 			ID: uc.UID,
 		},
+		Places:  places,
+		Tags:    tags,
+		Version: req.Version,
 	})
+	var conflict service.VersionConflictError
+	if errors.As(err, &conflict) {
+		return ginx.Result{
+			Code: ginx.CodeConflict,
+			Msg:  "the article has been modified",
+			Data: VersionConflictVO{Version: conflict.Version},
+		}, nil
+	}
 	switch err {
 	case nil:
 		return ginx.Result{
@@ -131,6 +219,16 @@ func (h *ArticleHandler) Publish(
 			Code: ginx.CodeUserSide,
 			Msg:  "article not found",
 		}, nil
+	case service.ErrPermissionDenied:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "permission denied",
+		}, nil
+	case service.ErrReviewRequired:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "the draft must be approved by a reviewer",
+		}, nil
 	default:
 		return ginx.InternalServerErrorResult, fmt.Errorf("Publish article failed: %w", err)
 	}
@@ -152,6 +250,11 @@ func (h *ArticleHandler) Withdraw(
 			Code: ginx.CodeUserSide,
 			Msg:  "article not found",
 		}, nil
+	case service.ErrPermissionDenied:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "permission denied",
+		}, nil
 	default:
 		return ginx.InternalServerErrorResult, fmt.Errorf(
 			"Withdraw article %d failed: %w",
@@ -161,6 +264,109 @@ func (h *ArticleHandler) Withdraw(
 	}
 }
 
+func (h *ArticleHandler) Delete(
+	ctx *gin.Context,
+	req ArticleDeleteReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	err := h.svc.Delete(ctx, uc.UID, req.ID)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+		}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "article not found",
+		}, nil
+	default:
+		return ginx.InternalServerErrorResult, fmt.Errorf(
+			"Delete article %d failed: %w",
+			req.ID,
+			err,
+		)
+	}
+}
+
+func (h *ArticleHandler) Restore(
+	ctx *gin.Context,
+	req ArticleRestoreReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	err := h.svc.Restore(ctx, uc.UID, req.ID)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+		}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "article not found",
+		}, nil
+	default:
+		return ginx.InternalServerErrorResult, fmt.Errorf(
+			"Restore article %d failed: %w",
+			req.ID,
+			err,
+		)
+	}
+}
+
+// Trash lists the deleted articles of the author, the latest deleted first.
+func (h *ArticleHandler) Trash(
+	ctx *gin.Context,
+	req ArticleListReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	switch {
+	case req.Limit <= 0:
+		req.Limit = defaultListLimit
+	case req.Limit > maxListLimit:
+		req.Limit = maxListLimit
+	}
+	cursor, err := decodeKeysetCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+
+	articles, err := h.svc.ListTrash(ctx, uc.UID, cursor, req.Limit)
+	if err != nil {
+		return ginx.InternalServerErrorResult,
+			logger.LError("Get the trash of author failed",
+				logger.Int64("uid", uc.UID),
+				logger.String("cursor", req.Cursor),
+				logger.Int("limit", req.Limit),
+				logger.Error(err),
+			)
+	}
+	res := ArticleListVO{
+		Articles: gslice.Map(articles, func(id int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				ID:       src.ID,
+				Title:    src.Title,
+				Abstract: src.Abstract(),
+				Status:   uint8(src.Status),
+				Ctime:    src.Ctime.Format(time.DateTime),
+				Utime:    src.Utime.Format(time.DateTime),
+				Dtime:    src.Dtime.Format(time.DateTime),
+			}
+		}),
+	}
+	if len(articles) == req.Limit {
+		last := articles[len(articles)-1]
+		res.NextCursor = encodeKeysetCursor(domain.Cursor{Time: last.Dtime, ID: last.ID})
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: res,
+	}, nil
+}
+
 func (h *ArticleHandler) Detail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -176,7 +382,7 @@ func (h *ArticleHandler) Detail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Resu
 			err,
 		)
 	}
-	if article.Author.ID != uc.UID {
+	if !article.CanView(uc.UID) {
 		return ginx.Result{
 				Code: ginx.CodeUserSide,
 				Msg:  "article not found",
@@ -195,31 +401,126 @@ func (h *ArticleHandler) Detail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Resu
 			Status:  uint8(article.Status),
 			Ctime:   article.Ctime.Format(time.DateTime),
 			Utime:   article.Ctime.Format(time.DateTime),
+			Places:  toPlaceVOs(article.Places),
+			Tags:    article.Tags,
+			Version: article.Version,
+			// the author may be a collaborator
+			AuthorID:      article.Author.ID,
+			Collaborators: toCollaboratorVOs(article),
 		},
 	}, nil
 }
 
-func (h *ArticleHandler) List(
+func (h *ArticleHandler) AddCollaborator(
+	ctx *gin.Context,
+	req AddCollaboratorReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	err := h.svc.AddCollaborator(ctx, uc.UID, req.ID, domain.Collaborator{
+		UID:  req.UID,
+		Role: domain.CollaboratorRole(req.Role),
+	})
+	return h.collaboratorResult(err, "Add collaborator", req.ID, req.UID)
+}
+
+func (h *ArticleHandler) RemoveCollaborator(
+	ctx *gin.Context,
+	req RemoveCollaboratorReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	err := h.svc.RemoveCollaborator(ctx, uc.UID, req.ID, req.UID)
+	return h.collaboratorResult(err, "Remove collaborator", req.ID, req.UID)
+}
+
+// Approve is called by a reviewer once the draft is ready to be published.
+func (h *ArticleHandler) Approve(
 	ctx *gin.Context,
-	page Page,
+	req ArticleApproveReq,
 	uc ijwt.UserClaims,
 ) (ginx.Result, error) {
-	articles, err := h.svc.GetByAuthor(ctx, uc.UID, page.Offset, page.Limit)
+	err := h.svc.Approve(ctx, uc.UID, req.ID)
+	return h.collaboratorResult(err, "Approve article", req.ID, uc.UID)
+}
+
+func (h *ArticleHandler) collaboratorResult(
+	err error,
+	action string,
+	aid int64,
+	uid int64,
+) (ginx.Result, error) {
 	switch err {
 	case nil:
 		return ginx.Result{
 			Code: ginx.CodeOK,
-			Data: gslice.Map(articles, func(id int, src domain.Article) ArticleVO {
+		}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "article not found",
+		}, nil
+	case service.ErrPermissionDenied:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "permission denied",
+		}, nil
+	case service.ErrInvalidCollaborator:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "invalid collaborator",
+		}, nil
+	default:
+		return ginx.InternalServerErrorResult, fmt.Errorf(
+			"%s failed, aid %d, uid %d: %w",
+			action,
+			aid,
+			uid,
+			err,
+		)
+	}
+}
+
+func (h *ArticleHandler) List(
+	ctx *gin.Context,
+	req ArticleListReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	switch {
+	case req.Limit <= 0:
+		req.Limit = defaultListLimit
+	case req.Limit > maxListLimit:
+		req.Limit = maxListLimit
+	}
+	cursor, err := decodeKeysetCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+
+	articles, err := h.svc.GetByAuthor(ctx, uc.UID, cursor, req.Limit)
+	switch err {
+	case nil:
+		res := ArticleListVO{
+			Articles: gslice.Map(articles, func(id int, src domain.Article) ArticleVO {
 				return ArticleVO{
 					ID:       src.ID,
 					Title:    src.Title,
 					Abstract: src.Abstract(),
 					// Content:  src.Content,
-					Status: uint8(src.Status),
-					Ctime:  src.Ctime.Format(time.DateTime),
-					Utime:  src.Ctime.Format(time.DateTime),
+					Status:  uint8(src.Status),
+					Ctime:   src.Ctime.Format(time.DateTime),
+					Utime:   src.Ctime.Format(time.DateTime),
+					Version: src.Version,
 				}
 			}),
+		}
+		if len(articles) == req.Limit {
+			res.NextCursor = encodeKeysetCursor(domain.ArticleCursor(articles[len(articles)-1]))
+		}
+		return ginx.Result{
+			Code: ginx.CodeOK,
+			Data: res,
 		}, nil
 	case service.ErrArticleNotFound:
 		return ginx.Result{
@@ -230,8 +531,8 @@ func (h *ArticleHandler) List(
 		return ginx.InternalServerErrorResult,
 			logger.LError("Get articles by author failed",
 				logger.Int64("uid", uc.UID),
-				logger.Int("offset", page.Offset),
-				logger.Int("limit", page.Limit),
+				logger.String("cursor", req.Cursor),
+				logger.Int("limit", req.Limit),
 			)
 	}
 }
@@ -305,12 +606,16 @@ func (h *ArticleHandler) PubDetail(
 			Status:     uint8(article.Status),
 			Ctime:      article.Ctime.Format(time.DateTime),
 			Utime:      article.Ctime.Format(time.DateTime),
+			Places:     toPlaceVOs(article.Places),
+			Tags:       article.Tags,
 
-			ReadCnt:    intr.Intr.ReadCnt,
-			LikeCnt:    intr.Intr.LikeCnt,
-			CollectCnt: intr.Intr.CollectCnt,
-			Liked:      intr.Intr.Liked,
-			Collected:  intr.Intr.Collected,
+			ReadCnt:       intr.Intr.ReadCnt,
+			UniqueReadCnt: intr.Intr.UniqueReadCnt,
+			LikeCnt:       intr.Intr.LikeCnt,
+			CollectCnt:    intr.Intr.CollectCnt,
+			TipCnt:        intr.Intr.TipCnt,
+			Liked:         intr.Intr.Liked,
+			Collected:     intr.Intr.Collected,
 		},
 	}, nil
 }
@@ -319,7 +624,7 @@ func (h *ArticleHandler) TopLike(ctx *gin.Context) (ginx.Result, error) {
 	// Get top limit
 	var limit int
 	limitStr := ctx.Query("limit")
-	if res, err := strconv.Atoi(limitStr); err != nil {
+	if res, err := strconv.Atoi(limitStr); err == nil {
 		limit = res
 	}
 
@@ -343,7 +648,7 @@ func (h *ArticleHandler) TopLike(ctx *gin.Context) (ginx.Result, error) {
 		)
 	}
 
-	intrs, err := h.intrSvc.MustBatchGet(ctx, &intrv1.MustBatchGetRequest{
+	intrs, err := h.intrSvc.BatchGet(ctx, &intrv1.BatchGetRequest{
 		Biz: h.biz,
 		Ids: articleIDs.Ids,
 	})
@@ -364,7 +669,7 @@ func (h *ArticleHandler) TopLike(ctx *gin.Context) (ginx.Result, error) {
 			AuthorName: src.Author.Name,
 			Ctime:      src.Ctime.Format(time.DateTime),
 			Utime:      src.Ctime.Format(time.DateTime),
-			LikeCnt:    intrs.Intrs[id].LikeCnt,
+			LikeCnt:    intrs.GetIntrs()[src.ID].GetLikeCnt(),
 		}
 	})
 	return ginx.Result{
@@ -373,6 +678,77 @@ func (h *ArticleHandler) TopLike(ctx *gin.Context) (ginx.Result, error) {
 	}, nil
 }
 
+func (h *ArticleHandler) Nearby(
+	ctx *gin.Context,
+	req NearbyReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	if !validCoordinates(req.Lat, req.Lng) {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "invalid coordinates",
+		}, nil
+	}
+	switch {
+	case req.Radius <= 0:
+		req.Radius = defaultNearbyRadius
+	case req.Radius > maxNearbyRadius:
+		req.Radius = maxNearbyRadius
+	}
+	switch {
+	case req.Limit <= 0:
+		req.Limit = defaultNearbyLimit
+	case req.Limit > maxNearbyLimit:
+		req.Limit = maxNearbyLimit
+	}
+	after, err := decodeNearbyCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+
+	nearby, err := h.svc.ListPubNearby(ctx, req.Lng, req.Lat, req.Radius, after, req.Limit)
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to list nearby articles",
+			logger.Int64("uid", uc.UID),
+			logger.Any("req", req),
+			logger.Error(err),
+		)
+	}
+
+	res := NearbyVO{
+		Articles: gslice.Map(nearby, func(id int, src domain.NearbyArticle) ArticleVO {
+			place := toPlaceVO(src.Place)
+			return ArticleVO{
+				ID:         src.Article.ID,
+				Title:      src.Article.Title,
+				Abstract:   src.Article.Abstract(),
+				AuthorID:   src.Article.Author.ID,
+				AuthorName: src.Article.Author.Name,
+				Ctime:      src.Article.Ctime.Format(time.DateTime),
+				Utime:      src.Article.Utime.Format(time.DateTime),
+				Places:     toPlaceVOs(src.Article.Places),
+				Place:      &place,
+				Distance:   src.Distance,
+			}
+		}),
+	}
+	if len(nearby) == req.Limit {
+		last := nearby[len(nearby)-1]
+		res.NextCursor = encodeNearbyCursor(domain.NearbyCursor{
+			Distance:  last.Distance,
+			ArticleID: last.Article.ID,
+		})
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: res,
+	}, nil
+}
+
 func (h *ArticleHandler) Like(ctx *gin.Context, req Like, uc ijwt.UserClaims) (ginx.Result, error) {
 	var err error
 	if req.Like {
@@ -422,6 +798,9 @@ func (h *ArticleHandler) Collect(
 			Uid: uc.UID,
 		})
 	}
+	if res, ok := collectionErrResult(err); ok {
+		return res, nil
+	}
 	if err != nil {
 		return ginx.InternalServerErrorResult, logger.LError(
 			"failed to collect article",
@@ -435,9 +814,276 @@ func (h *ArticleHandler) Collect(
 	}, nil
 }
 
+func (h *ArticleHandler) Liked(
+	ctx *gin.Context,
+	req ArticleListReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	return h.listInteracted(ctx, req, uc, func(cursor *intrv1.UserBizCursor) (
+		[]*intrv1.UserBiz, error,
+	) {
+		resp, err := h.intrSvc.ListUserLikes(ctx, &intrv1.ListUserLikesRequest{
+			Biz:    h.biz,
+			Uid:    uc.UID,
+			Cursor: cursor,
+			Limit:  int32(req.Limit),
+		})
+		return resp.GetLikes(), err
+	})
+}
+
+func (h *ArticleHandler) Collected(
+	ctx *gin.Context,
+	req ArticleListReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	return h.listInteracted(ctx, req, uc, func(cursor *intrv1.UserBizCursor) (
+		[]*intrv1.UserBiz, error,
+	) {
+		resp, err := h.intrSvc.ListUserCollects(ctx, &intrv1.ListUserCollectsRequest{
+			Biz:    h.biz,
+			Uid:    uc.UID,
+			Cursor: cursor,
+			Limit:  int32(req.Limit),
+		})
+		return resp.GetCollects(), err
+	})
+}
+
+// listInteracted pages the articles returned by list, the latest liked or
+// collected first.
+func (h *ArticleHandler) listInteracted(
+	ctx *gin.Context,
+	req ArticleListReq,
+	uc ijwt.UserClaims,
+	list func(cursor *intrv1.UserBizCursor) ([]*intrv1.UserBiz, error),
+) (ginx.Result, error) {
+	switch {
+	case req.Limit <= 0:
+		req.Limit = defaultListLimit
+	case req.Limit > maxListLimit:
+		req.Limit = maxListLimit
+	}
+	cursor, err := decodeKeysetCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+
+	var intrCursor *intrv1.UserBizCursor
+	if !cursor.IsZero() {
+		intrCursor = &intrv1.UserBizCursor{
+			Utime: cursor.Time.UnixMilli(),
+			BizId: cursor.ID,
+		}
+	}
+	items, err := list(intrCursor)
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to list the liked or collected articles",
+			logger.Int64("uid", uc.UID),
+			logger.String("cursor", req.Cursor),
+			logger.Error(err),
+		)
+	}
+	ids := gslice.Map(items, func(id int, src *intrv1.UserBiz) int64 { return src.GetBizId() })
+	arts, err := publishedArticleVOs(ctx, h.svc, ids)
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get the liked or collected articles",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	intrs, err := h.intrSvc.BatchGet(ctx, &intrv1.BatchGetRequest{
+		Biz: h.biz,
+		Ids: ids,
+		Uid: uc.UID,
+	})
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get interactives",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	res := InteractedArticleListVO{
+		Articles: gslice.Map(items, func(id int, src *intrv1.UserBiz) InteractedArticleVO {
+			return InteractedArticleVO{
+				Article: withIntr(arts[src.GetBizId()], intrs.GetIntrs()[src.GetBizId()]),
+				Time:    time.UnixMilli(src.GetUtime()).Format(time.DateTime),
+			}
+		}),
+	}
+	if len(items) == req.Limit {
+		last := items[len(items)-1]
+		res.NextCursor = encodeKeysetCursor(domain.Cursor{
+			Time: time.UnixMilli(last.GetUtime()),
+			ID:   last.GetBizId(),
+		})
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: res,
+	}, nil
+}
+
 // }}}
 // {{{ Private functions
 
+// withIntr sets the counters of the article, and whether the user liked or
+// collected it.
+func withIntr(art ArticleVO, intr *intrv1.Interactive) ArticleVO {
+	art.ReadCnt = intr.GetReadCnt()
+	art.UniqueReadCnt = intr.GetUniqueReadCnt()
+	art.LikeCnt = intr.GetLikeCnt()
+	art.CollectCnt = intr.GetCollectCnt()
+	art.TipCnt = intr.GetTipCnt()
+	art.Liked = intr.GetLiked()
+	art.Collected = intr.GetCollected()
+	return art
+}
+
+// publishedArticleVOs returns the abstracts of the articles by ID, only the ID
+// is set for those no longer published.
+func publishedArticleVOs(
+	ctx context.Context,
+	svc service.ArticleService,
+	ids []int64,
+) (map[int64]ArticleVO, error) {
+	res := make(map[int64]ArticleVO, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+	arts, err := svc.ListPubByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		res[id] = ArticleVO{ID: id}
+	}
+	for _, art := range arts {
+		res[art.ID] = ArticleVO{
+			ID:         art.ID,
+			Title:      art.Title,
+			Abstract:   art.Abstract(),
+			AuthorID:   art.Author.ID,
+			AuthorName: art.Author.Name,
+			Utime:      art.Utime.Format(time.DateTime),
+		}
+	}
+	return res, nil
+}
+
+func validCoordinates(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+func toDomainPlaces(places []PlaceVO) ([]domain.Place, error) {
+	if len(places) == 0 {
+		return nil, nil
+	}
+	if len(places) > maxArticlePlaces {
+		return nil, errInvalidPlaces
+	}
+	res := make([]domain.Place, 0, len(places))
+	for _, p := range places {
+		if !validCoordinates(p.Lat, p.Lng) {
+			return nil, errInvalidPlaces
+		}
+		res = append(res, domain.Place{
+			Name: p.Name,
+			Lat:  p.Lat,
+			Lng:  p.Lng,
+		})
+	}
+	return res, nil
+}
+
+func toCollaboratorVOs(draft domain.Article) []CollaboratorVO {
+	return gslice.Map(draft.Collaborators, func(id int, src domain.Collaborator) CollaboratorVO {
+		return CollaboratorVO{
+			UID:      src.UID,
+			Role:     uint8(src.Role),
+			Approved: src.ApprovedDraft(draft),
+		}
+	})
+}
+
+func toPlaceVO(place domain.Place) PlaceVO {
+	return PlaceVO{
+		Name: place.Name,
+		Lat:  place.Lat,
+		Lng:  place.Lng,
+	}
+}
+
+func toPlaceVOs(places []domain.Place) []PlaceVO {
+	return gslice.Map(places, func(id int, src domain.Place) PlaceVO {
+		return toPlaceVO(src)
+	})
+}
+
+// NOTE: The cursor is opaque to the clients, so that we can switch to another
+// paging method without breaking them.
+func encodeNearbyCursor(cursor domain.NearbyCursor) string {
+	raw := strconv.FormatFloat(cursor.Distance, 'g', -1, 64) + "_" +
+		strconv.FormatInt(cursor.ArticleID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeNearbyCursor(token string) (domain.NearbyCursor, error) {
+	if token == "" {
+		return domain.NearbyCursor{}, nil
+	}
+	val, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domain.NearbyCursor{}, errInvalidCursor
+	}
+	distance, id, ok := strings.Cut(string(val), "_")
+	if !ok {
+		return domain.NearbyCursor{}, errInvalidCursor
+	}
+	var res domain.NearbyCursor
+	res.Distance, err = strconv.ParseFloat(distance, 64)
+	if err != nil || res.Distance < 0 || math.IsNaN(res.Distance) {
+		return domain.NearbyCursor{}, errInvalidCursor
+	}
+	res.ArticleID, err = strconv.ParseInt(id, 10, 64)
+	if err != nil || res.ArticleID < 0 {
+		return domain.NearbyCursor{}, errInvalidCursor
+	}
+	return res, nil
+}
+
+// encodeKeysetCursor makes an opaque token of the cursor, the clients should
+// not rely on its format.
+func encodeKeysetCursor(cursor domain.Cursor) string {
+	raw := fmt.Sprintf("%d_%d", cursor.Time.UnixMilli(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeKeysetCursor(token string) (domain.Cursor, error) {
+	if token == "" {
+		return domain.Cursor{}, nil
+	}
+	val, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domain.Cursor{}, errInvalidCursor
+	}
+	var utime, id int64
+	n, err := fmt.Sscanf(string(val), "%d_%d", &utime, &id)
+	if err != nil || n != 2 || utime <= 0 {
+		return domain.Cursor{}, errInvalidCursor
+	}
+	return domain.Cursor{
+		Time: time.UnixMilli(utime),
+		ID:   id,
+	}, nil
+}
+
 // }}}
 // {{{ Package functions
 
@@ -2,12 +2,16 @@ package web
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	intrv1mock "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1/mock"
 	"github.com/chenmuyao/go-bootcamp/internal/domain"
 	"github.com/chenmuyao/go-bootcamp/internal/service"
 	svcmocks "github.com/chenmuyao/go-bootcamp/internal/service/mocks"
@@ -17,6 +21,7 @@ import (
 	"github.com/gin-gonic/gin"
 	prom "github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 )
@@ -166,3 +171,310 @@ func TestArticleHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleHandler_Liked(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	cursor := encodeKeysetCursor(domain.Cursor{Time: now, ID: 3})
+
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (
+			service.ArticleService,
+			intrv1.InteractiveServiceClient,
+		)
+		reqBody string
+
+		wantCode int
+		wantRes  ginx.Result
+	}{
+		{
+			name: "liked articles",
+			mock: func(ctrl *gomock.Controller) (
+				service.ArticleService,
+				intrv1.InteractiveServiceClient,
+			) {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				intrSvc := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().ListUserLikes(gomock.Any(), &intrv1.ListUserLikesRequest{
+					Biz:    "article",
+					Uid:    123,
+					Cursor: &intrv1.UserBizCursor{Utime: now.UnixMilli(), BizId: 3},
+					Limit:  2,
+				}).Return(&intrv1.ListUserLikesResponse{Likes: []*intrv1.UserBiz{
+					{Uid: 123, Biz: "article", BizId: 2, Utime: now.UnixMilli()},
+					{Uid: 123, Biz: "article", BizId: 1, Utime: now.UnixMilli() - 1000},
+				}}, nil)
+				// the second one is no longer published
+				svc.EXPECT().ListPubByIDs(gomock.Any(), []int64{2, 1}).Return([]domain.Article{
+					{ID: 2, Title: "my title", Author: domain.Author{ID: 7}, Utime: now},
+				}, nil)
+				intrSvc.EXPECT().BatchGet(gomock.Any(), &intrv1.BatchGetRequest{
+					Biz: "article",
+					Ids: []int64{2, 1},
+					Uid: 123,
+				}).Return(&intrv1.BatchGetResponse{Intrs: map[int64]*intrv1.Interactive{
+					2: {Biz: "article", BizId: 2, ReadCnt: 10, LikeCnt: 3, Liked: true},
+					1: {Biz: "article", BizId: 1, LikeCnt: 1, Liked: true, Collected: true},
+				}}, nil)
+				return svc, intrSvc
+			},
+			reqBody:  `{"cursor": "` + cursor + `", "limit": 2}`,
+			wantCode: http.StatusOK,
+			wantRes: ginx.Result{
+				Code: ginx.CodeOK,
+				Data: map[string]any{
+					"articles": []any{
+						map[string]any{
+							"article": map[string]any{
+								"id":        float64(2),
+								"title":     "my title",
+								"authorId":  float64(7),
+								"utime":     now.Format(time.DateTime),
+								"readCnt":   float64(10),
+								"likeCnt":   float64(3),
+								"liked":     true,
+								"collected": false,
+							},
+							"time": now.Format(time.DateTime),
+						},
+						map[string]any{
+							"article": map[string]any{
+								"id":        float64(1),
+								"likeCnt":   float64(1),
+								"liked":     true,
+								"collected": true,
+							},
+							"time": now.Add(-time.Second).Format(time.DateTime),
+						},
+					},
+					"nextCursor": encodeKeysetCursor(domain.Cursor{
+						Time: now.Add(-time.Second),
+						ID:   1,
+					}),
+				},
+			},
+		},
+		{
+			name: "invalid cursor",
+			mock: func(ctrl *gomock.Controller) (
+				service.ArticleService,
+				intrv1.InteractiveServiceClient,
+			) {
+				return nil, nil
+			},
+			reqBody:  `{"cursor": "invalid"}`,
+			wantCode: http.StatusBadRequest,
+			wantRes: ginx.Result{
+				Code: ginx.CodeUserSide,
+				Msg:  errInvalidCursor.Error(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc, intrSvc := tc.mock(ctrl)
+			hdl := NewArticleHandler(logger.NewNopLogger(), svc, intrSvc)
+			ginx.InitCounter(prom.CounterOpts{
+				Namespace: "my_company",
+				Subsystem: "wetravel",
+				Name:      "errcode",
+				Help:      "Error code data",
+				ConstLabels: prom.Labels{
+					"instance_id": "instance",
+				},
+			})
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", ijwt.UserClaims{
+					UID: 123,
+				})
+			})
+			hdl.RegisterRoutes(server)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/articles/pub/liked",
+				bytes.NewBufferString(tc.reqBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+
+			server.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.wantCode, recorder.Code)
+			var res ginx.Result
+			err = json.NewDecoder(recorder.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestArticleHandler_TopLike(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (
+			service.ArticleService,
+			intrv1.InteractiveServiceClient,
+		)
+
+		wantCode int
+		wantRes  ginx.Result
+	}{
+		{
+			name: "top liked articles",
+			mock: func(ctrl *gomock.Controller) (
+				service.ArticleService,
+				intrv1.InteractiveServiceClient,
+			) {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				intrSvc := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().GetTopLike(gomock.Any(), &intrv1.GetTopLikeRequest{
+					Biz:   "article",
+					Limit: 2,
+				}).Return(&intrv1.GetTopLikeResponse{Ids: []int64{2, 1}}, nil)
+				svc.EXPECT().BatchGetPubByIDs(gomock.Any(), []int64{2, 1}).Return([]domain.Article{
+					{ID: 2, Title: "second", Ctime: now},
+					{ID: 1, Title: "first", Ctime: now},
+				}, nil)
+				intrSvc.EXPECT().BatchGet(gomock.Any(), &intrv1.BatchGetRequest{
+					Biz: "article",
+					Ids: []int64{2, 1},
+				}).Return(&intrv1.BatchGetResponse{Intrs: map[int64]*intrv1.Interactive{
+					1: {Biz: "article", BizId: 1, LikeCnt: 5},
+					2: {Biz: "article", BizId: 2, LikeCnt: 8},
+				}}, nil)
+				return svc, intrSvc
+			},
+			wantCode: http.StatusOK,
+			wantRes: ginx.Result{
+				Code: ginx.CodeOK,
+				Data: []any{
+					map[string]any{
+						"id":        float64(2),
+						"title":     "second",
+						"ctime":     now.Format(time.DateTime),
+						"utime":     now.Format(time.DateTime),
+						"likeCnt":   float64(8),
+						"liked":     false,
+						"collected": false,
+					},
+					map[string]any{
+						"id":        float64(1),
+						"title":     "first",
+						"ctime":     now.Format(time.DateTime),
+						"utime":     now.Format(time.DateTime),
+						"likeCnt":   float64(5),
+						"liked":     false,
+						"collected": false,
+					},
+				},
+			},
+		},
+		{
+			name: "interactive service error",
+			mock: func(ctrl *gomock.Controller) (
+				service.ArticleService,
+				intrv1.InteractiveServiceClient,
+			) {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				intrSvc := intrv1mock.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().GetTopLike(gomock.Any(), gomock.Any()).
+					Return(&intrv1.GetTopLikeResponse{Ids: []int64{1}}, nil)
+				svc.EXPECT().BatchGetPubByIDs(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{ID: 1}}, nil)
+				intrSvc.EXPECT().BatchGet(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return svc, intrSvc
+			},
+			wantCode: http.StatusInternalServerError,
+			wantRes:  ginx.InternalServerErrorResult,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc, intrSvc := tc.mock(ctrl)
+			hdl := NewArticleHandler(logger.NewNopLogger(), svc, intrSvc)
+			ginx.InitCounter(prom.CounterOpts{
+				Namespace: "my_company",
+				Subsystem: "wetravel",
+				Name:      "errcode",
+				Help:      "Error code data",
+				ConstLabels: prom.Labels{
+					"instance_id": "instance",
+				},
+			})
+
+			server := gin.Default()
+			hdl.RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, "/articles/pub/top_like?limit=2", nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			server.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.wantCode, recorder.Code)
+			var res ginx.Result
+			err = json.NewDecoder(recorder.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func Test_decodeNearbyCursor(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	testCases := []struct {
+		name  string
+		token string
+
+		wantRes domain.NearbyCursor
+		wantErr error
+	}{
+		{
+			name:    "round trip",
+			token:   encodeNearbyCursor(domain.NearbyCursor{Distance: 123.4567, ArticleID: 42}),
+			wantRes: domain.NearbyCursor{Distance: 123.4567, ArticleID: 42},
+		},
+		{
+			name: "first page",
+		},
+		{
+			name:    "not base64",
+			token:   "!",
+			wantErr: errInvalidCursor,
+		},
+		{
+			name:    "no article id",
+			token:   encode("123.4"),
+			wantErr: errInvalidCursor,
+		},
+		{
+			name:    "negative distance",
+			token:   encode("-1_42"),
+			wantErr: errInvalidCursor,
+		},
+		{
+			name:    "not a number",
+			token:   encode("NaN_42"),
+			wantErr: errInvalidCursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := decodeNearbyCursor(tc.token)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package web
 
 type ArticleEditReq struct {
-	ID      int64  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	ID      int64     `json:"id"`
+	Title   string    `json:"title"`
+	Content string    `json:"content"`
+	Places  []PlaceVO `json:"places"`
+	Tags    []string  `json:"tags"`
+	// version of the draft the edit is based on, it is increased by 1 by a
+	// successful save.
+	Version int64 `json:"version"`
 }
 
 type ArticlePublishReq ArticleEditReq
@@ -12,6 +17,37 @@ type ArticleWithdrawReq struct {
 	ID int64 `json:"id"`
 }
 
+type ArticleDeleteReq ArticleWithdrawReq
+
+type ArticleRestoreReq ArticleWithdrawReq
+
+type ArticleApproveReq ArticleWithdrawReq
+
+type AddCollaboratorReq struct {
+	// article ID
+	ID  int64 `json:"id"`
+	UID int64 `json:"uid"`
+	// 1: editor, 2: reviewer
+	Role uint8 `json:"role"`
+}
+
+type RemoveCollaboratorReq struct {
+	ID  int64 `json:"id"`
+	UID int64 `json:"uid"`
+}
+
+// VersionConflictVO is returned with ginx.CodeConflict when the draft has been
+// saved since the version of the edit.
+type VersionConflictVO struct {
+	Version int64 `json:"version"`
+}
+
+type CollaboratorVO struct {
+	UID      int64 `json:"uid"`
+	Role     uint8 `json:"role"`
+	Approved bool  `json:"approved,omitempty"`
+}
+
 type ArticleVO struct {
 	ID         int64  `json:"id,omitempty"`
 	Title      string `json:"title,omitempty"`
@@ -22,12 +58,59 @@ type ArticleVO struct {
 	Status     uint8  `json:"status,omitempty"`
 	Ctime      string `json:"ctime,omitempty"`
 	Utime      string `json:"utime,omitempty"`
+	// when it was moved to the trash
+	Dtime string `json:"dtime,omitempty"`
+	// version of the draft
+	Version int64 `json:"version,omitempty"`
 
-	ReadCnt    int64 `json:"readCnt,omitempty"`
-	LikeCnt    int64 `json:"likeCnt,omitempty"`
-	CollectCnt int64 `json:"collectCnt,omitempty"`
-	Liked      bool  `json:"liked"`
-	Collected  bool  `json:"collected"`
+	Places []PlaceVO `json:"places,omitempty"`
+	Tags   []string  `json:"tags,omitempty"`
+	// nearest place and its distance in meters, for nearby search
+	Place    *PlaceVO `json:"place,omitempty"`
+	Distance float64  `json:"distance,omitempty"`
+
+	Collaborators []CollaboratorVO `json:"collaborators,omitempty"`
+
+	ReadCnt       int64 `json:"readCnt,omitempty"`
+	UniqueReadCnt int64 `json:"uniqueReadCnt,omitempty"`
+	LikeCnt       int64 `json:"likeCnt,omitempty"`
+	CollectCnt    int64 `json:"collectCnt,omitempty"`
+	TipCnt        int64 `json:"tipCnt,omitempty"`
+	Liked         bool  `json:"liked"`
+	Collected     bool  `json:"collected"`
+}
+
+type PlaceVO struct {
+	Name string  `json:"name"`
+	Lat  float64 `json:"lat"`
+	Lng  float64 `json:"lng"`
+}
+
+type ArticleListReq struct {
+	// empty for the first page
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type ArticleListVO struct {
+	Articles []ArticleVO `json:"articles"`
+	// empty if there is no more articles
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type NearbyReq struct {
+	Lat float64 `form:"lat"    json:"lat"`
+	Lng float64 `form:"lng"    json:"lng"`
+	// in meters
+	Radius float64 `form:"radius" json:"radius"`
+	Cursor string  `form:"cursor" json:"cursor"`
+	Limit  int     `form:"limit"  json:"limit"`
+}
+
+type NearbyVO struct {
+	Articles []ArticleVO `json:"articles"`
+	// empty if there is no more articles
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type Like struct {
@@ -41,3 +124,16 @@ type Collect struct {
 	CID       int64 `json:"cid"`
 	Collected bool  `json:"collected"`
 }
+
+type InteractedArticleVO struct {
+	// only the ID is set if the article is no longer published
+	Article ArticleVO `json:"article"`
+	// when the article was liked or collected
+	Time string `json:"time"`
+}
+
+type InteractedArticleListVO struct {
+	Articles []InteractedArticleVO `json:"articles"`
+	// empty if there is no more articles
+	NextCursor string `json:"nextCursor,omitempty"`
+}
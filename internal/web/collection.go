@@ -0,0 +1,293 @@
+package web
+
+import (
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	ijwt "github.com/chenmuyao/go-bootcamp/internal/web/jwt"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// {{{ Consts
+
+// }}}
+// {{{ Global Varirables
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+type CollectionHandler struct {
+	l       logger.Logger
+	svc     service.ArticleService
+	intrSvc intrv1.InteractiveServiceClient
+	biz     string
+}
+
+func NewCollectionHandler(
+	l logger.Logger,
+	svc service.ArticleService,
+	intrSvc intrv1.InteractiveServiceClient,
+) *CollectionHandler {
+	return &CollectionHandler{
+		l:       l,
+		svc:     svc,
+		intrSvc: intrSvc,
+		biz:     "article",
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+// }}}
+// {{{ Struct Methods
+
+func (h *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/collections")
+	g.POST("/create", ginx.WrapBodyAndClaims(h.l, h.Create))
+	g.POST("/edit", ginx.WrapBodyAndClaims(h.l, h.Edit))
+	g.POST("/delete", ginx.WrapBodyAndClaims(h.l, h.Delete))
+	g.POST("/list", ginx.WrapBodyAndClaims(h.l, h.List))
+	g.POST("/items", ginx.WrapBodyAndClaims(h.l, h.Items))
+	g.POST("/move", ginx.WrapBodyAndClaims(h.l, h.Move))
+}
+
+func (h *CollectionHandler) Create(
+	ctx *gin.Context,
+	req CollectionCreateReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	resp, err := h.intrSvc.CreateCollection(ctx, &intrv1.CreateCollectionRequest{
+		Uid:    uc.UID,
+		Name:   req.Name,
+		Public: req.Public,
+	})
+	if res, ok := collectionErrResult(err); ok {
+		return res, nil
+	}
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to create collection",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: toCollectionVO(0, resp.GetCollection()),
+	}, nil
+}
+
+func (h *CollectionHandler) Edit(
+	ctx *gin.Context,
+	req CollectionEditReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	_, err := h.intrSvc.UpdateCollection(ctx, &intrv1.UpdateCollectionRequest{
+		Id:     req.ID,
+		Uid:    uc.UID,
+		Name:   req.Name,
+		Public: req.Public,
+	})
+	if res, ok := collectionErrResult(err); ok {
+		return res, nil
+	}
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to update collection",
+			logger.Int64("uid", uc.UID),
+			logger.Int64("cid", req.ID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+	}, nil
+}
+
+func (h *CollectionHandler) Delete(
+	ctx *gin.Context,
+	req CollectionDeleteReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	_, err := h.intrSvc.DeleteCollection(ctx, &intrv1.DeleteCollectionRequest{
+		Id:  req.ID,
+		Uid: uc.UID,
+	})
+	if res, ok := collectionErrResult(err); ok {
+		return res, nil
+	}
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to delete collection",
+			logger.Int64("uid", uc.UID),
+			logger.Int64("cid", req.ID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+	}, nil
+}
+
+// List lists the collections of the current user, or the public ones of
+// another user.
+func (h *CollectionHandler) List(
+	ctx *gin.Context,
+	req CollectionListReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	if req.UID == 0 {
+		req.UID = uc.UID
+	}
+	resp, err := h.intrSvc.ListCollections(ctx, &intrv1.ListCollectionsRequest{
+		Uid:       req.UID,
+		ViewerUid: uc.UID,
+	})
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to list collections",
+			logger.Int64("uid", req.UID),
+			logger.Int64("viewer", uc.UID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: gslice.Map(resp.GetCollections(), toCollectionVO),
+	}, nil
+}
+
+func (h *CollectionHandler) Items(
+	ctx *gin.Context,
+	req CollectionItemsReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	switch {
+	case req.Limit <= 0:
+		req.Limit = defaultListLimit
+	case req.Limit > maxListLimit:
+		req.Limit = maxListLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	resp, err := h.intrSvc.ListCollectionItems(ctx, &intrv1.ListCollectionItemsRequest{
+		Cid:       req.CID,
+		ViewerUid: uc.UID,
+		Offset:    int32(req.Offset),
+		Limit:     int32(req.Limit),
+	})
+	if res, ok := collectionErrResult(err); ok {
+		return res, nil
+	}
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to list collection items",
+			logger.Int64("cid", req.CID),
+			logger.Int64("viewer", uc.UID),
+			logger.Error(err),
+		)
+	}
+
+	items := make([]*intrv1.UserBiz, 0, len(resp.GetItems()))
+	ids := make([]int64, 0, len(resp.GetItems()))
+	for _, item := range resp.GetItems() {
+		if item.GetBiz() == h.biz {
+			items = append(items, item)
+			ids = append(ids, item.GetBizId())
+		}
+	}
+	arts, err := publishedArticleVOs(ctx, h.svc, ids)
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get collected articles",
+			logger.Int64("cid", req.CID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: gslice.Map(items, func(id int, src *intrv1.UserBiz) CollectionItemVO {
+			return CollectionItemVO{
+				Article:     arts[src.GetBizId()],
+				CollectTime: time.UnixMilli(src.GetUtime()).Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (h *CollectionHandler) Move(
+	ctx *gin.Context,
+	req CollectionMoveReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	_, err := h.intrSvc.MoveCollectionItem(ctx, &intrv1.MoveCollectionItemRequest{
+		Biz:     h.biz,
+		Id:      req.ID,
+		Uid:     uc.UID,
+		FromCid: req.FromCID,
+		ToCid:   req.ToCID,
+	})
+	if res, ok := collectionErrResult(err); ok {
+		return res, nil
+	}
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to move collected article",
+			logger.Int64("uid", uc.UID),
+			logger.Int64("aid", req.ID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+	}, nil
+}
+
+// }}}
+// {{{ Private functions
+
+// collectionErrResult maps the user side errors of the interactive service.
+func collectionErrResult(err error) (ginx.Result, bool) {
+	if err == nil {
+		return ginx.Result{}, false
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return ginx.Result{}, false
+	}
+	switch s.Code() {
+	case codes.NotFound:
+		return ginx.Result{Code: ginx.CodeNotFound, Msg: s.Message()}, true
+	case codes.AlreadyExists:
+		return ginx.Result{Code: ginx.CodeConflict, Msg: s.Message()}, true
+	case codes.InvalidArgument:
+		return ginx.Result{Code: ginx.CodeUserSide, Msg: s.Message()}, true
+	}
+	return ginx.Result{}, false
+}
+
+func toCollectionVO(id int, src *intrv1.Collection) CollectionVO {
+	return CollectionVO{
+		ID:     src.GetId(),
+		Name:   src.GetName(),
+		Public: src.GetPublic(),
+		Ctime:  time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+		Utime:  time.UnixMilli(src.GetUtime()).Format(time.DateTime),
+	}
+}
+
+// }}}
+// {{{ Package functions
+
+// }}}
@@ -0,0 +1,49 @@
+package web
+
+type CollectionCreateReq struct {
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+}
+
+type CollectionEditReq struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+}
+
+type CollectionDeleteReq struct {
+	ID int64 `json:"id"`
+}
+
+type CollectionListReq struct {
+	// 0 to list the collections of the current user
+	UID int64 `json:"uid"`
+}
+
+type CollectionItemsReq struct {
+	// 0 for the default collection of the current user
+	CID    int64 `json:"cid"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type CollectionMoveReq struct {
+	// article ID
+	ID      int64 `json:"id"`
+	FromCID int64 `json:"fromCid"`
+	ToCID   int64 `json:"toCid"`
+}
+
+type CollectionVO struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+	Ctime  string `json:"ctime"`
+	Utime  string `json:"utime"`
+}
+
+type CollectionItemVO struct {
+	// only the ID is set if the article is no longer published
+	Article     ArticleVO `json:"article"`
+	CollectTime string    `json:"collectTime"`
+}
@@ -0,0 +1,147 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// {{{ Consts
+
+const (
+	contentTypeAtom = "application/atom+xml; charset=utf-8"
+	contentTypeRSS  = "application/rss+xml; charset=utf-8"
+	contentTypeXML  = "application/xml; charset=utf-8"
+)
+
+// }}}
+// {{{ Global Varirables
+
+var feedNotFoundResult = ginx.Result{
+	Code: ginx.CodeNotFound,
+	Msg:  "not found",
+}
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+// FeedHandler serves the public feeds and sitemaps, no login required.
+type FeedHandler struct {
+	l   logger.Logger
+	svc service.FeedService
+}
+
+func NewFeedHandler(l logger.Logger, svc service.FeedService) *FeedHandler {
+	return &FeedHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+// }}}
+// {{{ Struct Methods
+
+func (h *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/feed")
+	g.GET("/atom", ginx.WrapLog(h.l, h.feed(domain.FeedFormatAtom)))
+	g.GET("/rss", ginx.WrapLog(h.l, h.feed(domain.FeedFormatRSS)))
+	g.GET("/authors/:uid/atom", ginx.WrapLog(h.l, h.feed(domain.FeedFormatAtom)))
+	g.GET("/authors/:uid/rss", ginx.WrapLog(h.l, h.feed(domain.FeedFormatRSS)))
+
+	server.GET("/sitemap.xml", ginx.WrapLog(h.l, h.SitemapIndex))
+	// /sitemaps/1.xml
+	server.GET("/sitemaps/:page", ginx.WrapLog(h.l, h.Sitemap))
+}
+
+// feed serves the site-wide feed, or the feed of the author in the path.
+func (h *FeedHandler) feed(format domain.FeedFormat) func(ctx *gin.Context) (ginx.Result, error) {
+	contentType := contentTypeAtom
+	if format == domain.FeedFormatRSS {
+		contentType = contentTypeRSS
+	}
+	return func(ctx *gin.Context) (ginx.Result, error) {
+		var uid int64
+		if param := ctx.Param("uid"); param != "" {
+			var err error
+			uid, err = strconv.ParseInt(param, 10, 64)
+			if err != nil || uid <= 0 {
+				return feedNotFoundResult, nil
+			}
+		}
+
+		doc, err := h.svc.Feed(ctx, uid, format)
+		switch err {
+		case nil:
+			h.serve(ctx, contentType, doc)
+			return ginx.Result{}, nil
+		case service.ErrFeedNotFound:
+			return feedNotFoundResult, nil
+		default:
+			return ginx.InternalServerErrorResult, logger.LError(
+				"failed to get feed",
+				logger.Int64("uid", uid),
+				logger.String("format", string(format)),
+				logger.Error(err),
+			)
+		}
+	}
+}
+
+func (h *FeedHandler) SitemapIndex(ctx *gin.Context) (ginx.Result, error) {
+	return h.sitemap(ctx, 0)
+}
+
+func (h *FeedHandler) Sitemap(ctx *gin.Context) (ginx.Result, error) {
+	page, err := strconv.Atoi(strings.TrimSuffix(ctx.Param("page"), ".xml"))
+	if err != nil || page <= 0 {
+		return feedNotFoundResult, nil
+	}
+	return h.sitemap(ctx, page)
+}
+
+func (h *FeedHandler) sitemap(ctx *gin.Context, page int) (ginx.Result, error) {
+	doc, err := h.svc.Sitemap(ctx, page)
+	switch err {
+	case nil:
+		h.serve(ctx, contentTypeXML, doc)
+		return ginx.Result{}, nil
+	case service.ErrSitemapNotFound:
+		return feedNotFoundResult, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get sitemap",
+			logger.Int("page", page),
+			logger.Error(err),
+		)
+	}
+}
+
+// serve writes the document with its validators, http.ServeContent answers
+// 304 to the conditional requests.
+func (h *FeedHandler) serve(ctx *gin.Context, contentType string, doc domain.FeedDoc) {
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("ETag", doc.ETag)
+	ctx.Header("Cache-Control", "public, max-age=300")
+	http.ServeContent(ctx.Writer, ctx.Request, "", doc.Modified, bytes.NewReader(doc.Body))
+}
+
+// }}}
+// {{{ Private functions
+
+// }}}
+// {{{ Package functions
+
+// }}}
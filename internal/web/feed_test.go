@@ -0,0 +1,204 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	svcmocks "github.com/chenmuyao/go-bootcamp/internal/service/mocks"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestFeedHandler(t *testing.T) {
+	modified := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc := domain.FeedDoc{
+		Body:     []byte("<feed></feed>"),
+		ETag:     `"abc"`,
+		Modified: modified,
+	}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.FeedService
+		path    string
+		headers map[string]string
+
+		wantCode        int
+		wantBody        string
+		wantContentType string
+	}{
+		{
+			name: "site-wide atom",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Feed(gomock.Any(), int64(0), domain.FeedFormatAtom).Return(doc, nil)
+				return svc
+			},
+			path:            "/feed/atom",
+			wantCode:        http.StatusOK,
+			wantBody:        "<feed></feed>",
+			wantContentType: contentTypeAtom,
+		},
+		{
+			name: "author rss",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Feed(gomock.Any(), int64(12), domain.FeedFormatRSS).Return(doc, nil)
+				return svc
+			},
+			path:            "/feed/authors/12/rss",
+			wantCode:        http.StatusOK,
+			wantBody:        "<feed></feed>",
+			wantContentType: contentTypeRSS,
+		},
+		{
+			name: "etag matched",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Feed(gomock.Any(), int64(0), domain.FeedFormatRSS).Return(doc, nil)
+				return svc
+			},
+			path:     "/feed/rss",
+			headers:  map[string]string{"If-None-Match": `"abc"`},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name: "not modified since",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Feed(gomock.Any(), int64(0), domain.FeedFormatAtom).Return(doc, nil)
+				return svc
+			},
+			path:     "/feed/atom",
+			headers:  map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			wantCode: http.StatusNotModified,
+		},
+		{
+			name: "etag changed",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Feed(gomock.Any(), int64(0), domain.FeedFormatAtom).Return(doc, nil)
+				return svc
+			},
+			path: "/feed/atom",
+			headers: map[string]string{
+				"If-None-Match":     `"old"`,
+				"If-Modified-Since": modified.Format(http.TimeFormat),
+			},
+			wantCode:        http.StatusOK,
+			wantBody:        "<feed></feed>",
+			wantContentType: contentTypeAtom,
+		},
+		{
+			name: "unknown author",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().
+					Feed(gomock.Any(), int64(12), domain.FeedFormatAtom).
+					Return(domain.FeedDoc{}, service.ErrFeedNotFound)
+				return svc
+			},
+			path:     "/feed/authors/12/atom",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "invalid author",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				return svcmocks.NewMockFeedService(ctrl)
+			},
+			path:     "/feed/authors/abc/atom",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "sitemap index",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Sitemap(gomock.Any(), 0).Return(doc, nil)
+				return svc
+			},
+			path:            "/sitemap.xml",
+			wantCode:        http.StatusOK,
+			wantBody:        "<feed></feed>",
+			wantContentType: contentTypeXML,
+		},
+		{
+			name: "sitemap page",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Sitemap(gomock.Any(), 2).Return(doc, nil)
+				return svc
+			},
+			path:            "/sitemaps/2.xml",
+			wantCode:        http.StatusOK,
+			wantBody:        "<feed></feed>",
+			wantContentType: contentTypeXML,
+		},
+		{
+			name: "sitemap page not found",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Sitemap(gomock.Any(), 3).Return(domain.FeedDoc{}, service.ErrSitemapNotFound)
+				return svc
+			},
+			path:     "/sitemaps/3.xml",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "system error",
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Sitemap(gomock.Any(), 0).Return(domain.FeedDoc{}, errors.New("db error"))
+				return svc
+			},
+			path:     "/sitemap.xml",
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			hdl := NewFeedHandler(logger.NewZapLogger(zap.L()), tc.mock(ctrl))
+			ginx.InitCounter(prom.CounterOpts{
+				Namespace: "my_company",
+				Subsystem: "wetravel",
+				Name:      "errcode",
+				Help:      "Error code data",
+				ConstLabels: prom.Labels{
+					"instance_id": "instance",
+				},
+			})
+			server := gin.Default()
+			hdl.RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			recorder := httptest.NewRecorder()
+
+			server.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.wantCode, recorder.Code)
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+			assert.Equal(t, tc.wantBody, recorder.Body.String())
+			assert.Equal(t, tc.wantContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, doc.ETag, recorder.Header().Get("ETag"))
+			assert.Equal(t, modified.Format(http.TimeFormat), recorder.Header().Get("Last-Modified"))
+		})
+	}
+}
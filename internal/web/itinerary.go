@@ -0,0 +1,507 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	ijwt "github.com/chenmuyao/go-bootcamp/internal/web/jwt"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// {{{ Consts
+
+const (
+	maxItineraryDays  = 90
+	maxItineraryStops = 50
+)
+
+// }}}
+// {{{ Global Varirables
+
+var errInvalidItinerary = errors.New("invalid itinerary")
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+type ItineraryHandler struct {
+	l       logger.Logger
+	svc     service.ItineraryService
+	intrSvc intrv1.InteractiveServiceClient
+	biz     string
+}
+
+func NewItineraryHandler(
+	l logger.Logger,
+	svc service.ItineraryService,
+	intrSvc intrv1.InteractiveServiceClient,
+) *ItineraryHandler {
+	return &ItineraryHandler{
+		l:       l,
+		svc:     svc,
+		intrSvc: intrSvc,
+		biz:     "itinerary",
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+// }}}
+// {{{ Struct Methods
+
+func (h *ItineraryHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/itineraries")
+
+	g.POST("/edit", ginx.WrapBodyAndClaims(h.l, h.Edit))
+	g.POST("/publish", ginx.WrapBodyAndClaims(h.l, h.Publish))
+	g.POST("/withdraw", ginx.WrapBodyAndClaims(h.l, h.Withdraw))
+
+	// author
+	g.GET("/detail/:id", ginx.WrapClaims(h.l, h.Detail))
+	g.POST("/list", ginx.WrapBodyAndClaims(h.l, h.List))
+
+	// reader
+	pub := g.Group("/pub")
+	pub.GET("/:id", ginx.WrapClaims(h.l, h.PubDetail))
+	pub.GET("/article/:aid", ginx.WrapClaims(h.l, h.PubByArticle))
+	pub.POST("/clone", ginx.WrapBodyAndClaims(h.l, h.Clone))
+	// True: like; False: cancel like
+	pub.POST("/like", ginx.WrapBodyAndClaims(h.l, h.Like))
+	pub.POST("/collect", ginx.WrapBodyAndClaims(h.l, h.Collect))
+}
+
+func (h *ItineraryHandler) Edit(
+	ctx *gin.Context,
+	req ItineraryEditReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	itinerary, err := toDomainItinerary(req, uc.UID)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+	id, err := h.svc.Save(ctx, itinerary)
+	return h.saveResult(id, err, "Save itinerary failed")
+}
+
+func (h *ItineraryHandler) Publish(
+	ctx *gin.Context,
+	req ItineraryPublishReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	itinerary, err := toDomainItinerary(ItineraryEditReq(req), uc.UID)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+	id, err := h.svc.Publish(ctx, itinerary)
+	return h.saveResult(id, err, "Publish itinerary failed")
+}
+
+func (h *ItineraryHandler) Withdraw(
+	ctx *gin.Context,
+	req ItineraryWithdrawReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	err := h.svc.Withdraw(ctx, uc.UID, req.ID)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+		}, nil
+	case service.ErrItineraryNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "itinerary not found",
+		}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"Withdraw itinerary failed",
+			logger.Int64("id", req.ID),
+			logger.Error(err),
+		)
+	}
+}
+
+func (h *ItineraryHandler) Detail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	idStr := ctx.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.l.Warn("wrong id", logger.String("id", idStr), logger.Error(err))
+		return ginx.InternalServerErrorResult, nil
+	}
+	itinerary, err := h.svc.GetByID(ctx, id)
+	switch {
+	case err == service.ErrItineraryNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "itinerary not found",
+		}, nil
+	case err != nil:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"Get itinerary detail failed",
+			logger.Int64("id", id),
+			logger.Error(err),
+		)
+	}
+	if itinerary.Author.ID != uc.UID {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "itinerary not found",
+		}, logger.LError("invalid itinerary query",
+			logger.Int64("id", id),
+			logger.Int64("uid", uc.UID),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: toItineraryVO(itinerary),
+	}, nil
+}
+
+func (h *ItineraryHandler) List(
+	ctx *gin.Context,
+	page Page,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	itineraries, err := h.svc.GetByAuthor(ctx, uc.UID, page.Offset, page.Limit)
+	if err != nil {
+		return ginx.InternalServerErrorResult,
+			logger.LError("Get itineraries by author failed",
+				logger.Int64("uid", uc.UID),
+				logger.Int("offset", page.Offset),
+				logger.Int("limit", page.Limit),
+				logger.Error(err),
+			)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: gslice.Map(itineraries, func(id int, src domain.Itinerary) ItineraryVO {
+			res := toItineraryVO(src)
+			// the list only shows the summary
+			res.Days = nil
+			return res
+		}),
+	}, nil
+}
+
+func (h *ItineraryHandler) PubDetail(
+	ctx *gin.Context,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	idStr := ctx.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.l.Warn("wrong id", logger.String("id", idStr), logger.Error(err))
+		return ginx.InternalServerErrorResult, nil
+	}
+	return h.pubDetail(ctx, uc, func() (domain.Itinerary, error) {
+		return h.svc.GetPubByID(ctx, id)
+	})
+}
+
+func (h *ItineraryHandler) PubByArticle(
+	ctx *gin.Context,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	aidStr := ctx.Param("aid")
+	aid, err := strconv.ParseInt(aidStr, 10, 64)
+	if err != nil {
+		h.l.Warn("wrong article id", logger.String("aid", aidStr), logger.Error(err))
+		return ginx.InternalServerErrorResult, nil
+	}
+	return h.pubDetail(ctx, uc, func() (domain.Itinerary, error) {
+		return h.svc.GetPubByArticleID(ctx, aid)
+	})
+}
+
+func (h *ItineraryHandler) Clone(
+	ctx *gin.Context,
+	req ItineraryCloneReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	id, err := h.svc.Clone(ctx, req.ID, uc.UID)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+			Data: id,
+		}, nil
+	case service.ErrItineraryNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "itinerary not found",
+		}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"Clone itinerary failed",
+			logger.Int64("id", req.ID),
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+}
+
+func (h *ItineraryHandler) Like(
+	ctx *gin.Context,
+	req Like,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	var err error
+	if req.Like {
+		_, err = h.intrSvc.Like(ctx, &intrv1.LikeRequest{
+			Biz:   h.biz,
+			BizId: req.ID,
+			Uid:   uc.UID,
+		})
+	} else {
+		_, err = h.intrSvc.CancelLike(ctx, &intrv1.CancelLikeRequest{
+			Biz:   h.biz,
+			BizId: req.ID,
+			Uid:   uc.UID,
+		})
+	}
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to like or cancel like",
+			logger.Error(err),
+			logger.Int64("uid", uc.UID),
+			logger.Int64("id", req.ID),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+	}, nil
+}
+
+func (h *ItineraryHandler) Collect(
+	ctx *gin.Context,
+	req Collect,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	var err error
+	if req.Collected {
+		_, err = h.intrSvc.Collect(ctx, &intrv1.CollectRequest{
+			Biz: h.biz,
+			Id:  req.ID,
+			Cid: req.CID,
+			Uid: uc.UID,
+		})
+	} else {
+		_, err = h.intrSvc.CancelCollect(ctx, &intrv1.CancelCollectRequest{
+			Biz: h.biz,
+			Id:  req.ID,
+			Cid: req.CID,
+			Uid: uc.UID,
+		})
+	}
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to collect itinerary",
+			logger.Error(err),
+			logger.Int64("uid", uc.UID),
+			logger.Int64("id", req.ID),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+	}, nil
+}
+
+func (h *ItineraryHandler) pubDetail(
+	ctx *gin.Context,
+	uc ijwt.UserClaims,
+	get func() (domain.Itinerary, error),
+) (ginx.Result, error) {
+	itinerary, err := get()
+	switch {
+	case err == service.ErrItineraryNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "itinerary not found",
+		}, nil
+	case err != nil:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"Get published itinerary failed",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+
+	intr, err := h.intrSvc.Get(ctx, &intrv1.GetRequest{Biz: h.biz, Id: itinerary.ID, Uid: uc.UID})
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"Get itinerary interactive failed",
+			logger.Int64("id", itinerary.ID),
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, er := h.intrSvc.IncrReadCnt(ctx, &intrv1.IncrReadCntRequest{
+			Biz:   h.biz,
+			BizId: itinerary.ID,
+		})
+		if er != nil {
+			h.l.Error(
+				"failed to update read count",
+				logger.String("biz", h.biz),
+				logger.Int64("bizID", itinerary.ID),
+				logger.Error(er),
+			)
+		}
+	}()
+
+	res := toItineraryVO(itinerary)
+	res.ReadCnt = intr.Intr.ReadCnt
+	res.LikeCnt = intr.Intr.LikeCnt
+	res.CollectCnt = intr.Intr.CollectCnt
+	res.Liked = intr.Intr.Liked
+	res.Collected = intr.Intr.Collected
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: res,
+	}, nil
+}
+
+func (h *ItineraryHandler) saveResult(id int64, err error, msg string) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+			Data: id,
+		}, nil
+	case service.ErrItineraryNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "itinerary not found",
+		}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "article not found",
+		}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			msg,
+			logger.Int64("id", id),
+			logger.Error(err),
+		)
+	}
+}
+
+// }}}
+// {{{ Private functions
+
+func toDomainItinerary(req ItineraryEditReq, uid int64) (domain.Itinerary, error) {
+	if len(req.Days) > maxItineraryDays {
+		return domain.Itinerary{}, errInvalidItinerary
+	}
+	days := make([]domain.ItineraryDay, 0, len(req.Days))
+	for _, d := range req.Days {
+		if len(d.Stops) > maxItineraryStops {
+			return domain.Itinerary{}, errInvalidItinerary
+		}
+		date, err := time.Parse(time.DateOnly, d.Date)
+		if err != nil {
+			return domain.Itinerary{}, errInvalidItinerary
+		}
+		stops := make([]domain.ItineraryStop, 0, len(d.Stops))
+		for _, s := range d.Stops {
+			if !validCoordinates(s.Lat, s.Lng) || s.Cost < 0 {
+				return domain.Itinerary{}, errInvalidItinerary
+			}
+			var arrival time.Time
+			if s.Arrival != "" {
+				arrival, err = time.Parse(time.DateTime, s.Arrival)
+				if err != nil {
+					return domain.Itinerary{}, errInvalidItinerary
+				}
+			}
+			stops = append(stops, domain.ItineraryStop{
+				Place: domain.Place{
+					Name: s.Name,
+					Lat:  s.Lat,
+					Lng:  s.Lng,
+				},
+				Arrival: arrival,
+				Cost:    s.Cost,
+				Notes:   s.Notes,
+			})
+		}
+		days = append(days, domain.ItineraryDay{
+			Date:  date,
+			Notes: d.Notes,
+			Stops: stops,
+		})
+	}
+	return domain.Itinerary{
+		ID:    req.ID,
+		Title: req.Title,
+		Author: domain.Author{
+			ID: uid,
+		},
+		ArticleID: req.ArticleID,
+		Currency:  req.Currency,
+		Days:      days,
+	}, nil
+}
+
+func toItineraryVO(itinerary domain.Itinerary) ItineraryVO {
+	return ItineraryVO{
+		ID:         itinerary.ID,
+		Title:      itinerary.Title,
+		AuthorID:   itinerary.Author.ID,
+		AuthorName: itinerary.Author.Name,
+		ArticleID:  itinerary.ArticleID,
+		SourceID:   itinerary.SourceID,
+		Currency:   itinerary.Currency,
+		TotalCost:  itinerary.TotalCost(),
+		Days: gslice.Map(itinerary.Days, func(id int, d domain.ItineraryDay) ItineraryDayVO {
+			return ItineraryDayVO{
+				Date:  d.Date.Format(time.DateOnly),
+				Notes: d.Notes,
+				Cost:  d.Cost(),
+				Stops: gslice.Map(d.Stops, func(id int, s domain.ItineraryStop) ItineraryStopVO {
+					var arrival string
+					if !s.Arrival.IsZero() {
+						arrival = s.Arrival.Format(time.DateTime)
+					}
+					return ItineraryStopVO{
+						Name:    s.Place.Name,
+						Lat:     s.Place.Lat,
+						Lng:     s.Place.Lng,
+						Arrival: arrival,
+						Cost:    s.Cost,
+						Notes:   s.Notes,
+					}
+				}),
+			}
+		}),
+		Status: uint8(itinerary.Status),
+		Ctime:  itinerary.Ctime.Format(time.DateTime),
+		Utime:  itinerary.Utime.Format(time.DateTime),
+	}
+}
+
+// }}}
+// {{{ Package functions
+
+// }}}
@@ -0,0 +1,60 @@
+package web
+
+type ItineraryEditReq struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	// attached article, 0 if none
+	ArticleID int64            `json:"articleId"`
+	Currency  string           `json:"currency"`
+	Days      []ItineraryDayVO `json:"days"`
+}
+
+type ItineraryPublishReq ItineraryEditReq
+
+type ItineraryWithdrawReq struct {
+	ID int64 `json:"id"`
+}
+
+type ItineraryCloneReq struct {
+	ID int64 `json:"id"`
+}
+
+type ItineraryDayVO struct {
+	// 2006-01-02
+	Date  string            `json:"date"`
+	Notes string            `json:"notes,omitempty"`
+	Stops []ItineraryStopVO `json:"stops"`
+	Cost  int64             `json:"cost,omitempty"`
+}
+
+type ItineraryStopVO struct {
+	Name string  `json:"name"`
+	Lat  float64 `json:"lat"`
+	Lng  float64 `json:"lng"`
+	// 2006-01-02 15:04:05, optional
+	Arrival string `json:"arrival,omitempty"`
+	// in the minor unit of the currency
+	Cost  int64  `json:"cost"`
+	Notes string `json:"notes,omitempty"`
+}
+
+type ItineraryVO struct {
+	ID         int64            `json:"id,omitempty"`
+	Title      string           `json:"title,omitempty"`
+	AuthorID   int64            `json:"authorId,omitempty"`
+	AuthorName string           `json:"authorName,omitempty"`
+	ArticleID  int64            `json:"articleId,omitempty"`
+	SourceID   int64            `json:"sourceId,omitempty"`
+	Currency   string           `json:"currency,omitempty"`
+	TotalCost  int64            `json:"totalCost"`
+	Days       []ItineraryDayVO `json:"days,omitempty"`
+	Status     uint8            `json:"status,omitempty"`
+	Ctime      string           `json:"ctime,omitempty"`
+	Utime      string           `json:"utime,omitempty"`
+
+	ReadCnt    int64 `json:"readCnt,omitempty"`
+	LikeCnt    int64 `json:"likeCnt,omitempty"`
+	CollectCnt int64 `json:"collectCnt,omitempty"`
+	Liked      bool  `json:"liked"`
+	Collected  bool  `json:"collected"`
+}
@@ -49,6 +49,10 @@ func (m *LoginJWT) Build() gin.HandlerFunc {
 			// Do not check
 			return
 		}
+		// the route pattern, e.g. /feed/authors/:uid/atom
+		if _, ok := m.ignorePaths[ctx.FullPath()]; ok {
+			return
+		}
 		// Authorization: Bearer XXXX
 		tokenStr := m.ExtractToken(ctx)
 
@@ -0,0 +1,148 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	ijwt "github.com/chenmuyao/go-bootcamp/internal/web/jwt"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// {{{ Consts
+
+// }}}
+// {{{ Global Varirables
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+type ReadHistoryHandler struct {
+	l   logger.Logger
+	svc service.ReadHistoryService
+}
+
+func NewReadHistoryHandler(l logger.Logger, svc service.ReadHistoryService) *ReadHistoryHandler {
+	return &ReadHistoryHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+// }}}
+// {{{ Struct Methods
+
+func (h *ReadHistoryHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/history")
+	g.POST("/list", ginx.WrapBodyAndClaims(h.l, h.List))
+	g.POST("/delete", ginx.WrapBodyAndClaims(h.l, h.Delete))
+	g.POST("/clear", ginx.WrapClaims(h.l, h.Clear))
+}
+
+func (h *ReadHistoryHandler) List(
+	ctx *gin.Context,
+	req ArticleListReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	switch {
+	case req.Limit <= 0:
+		req.Limit = defaultListLimit
+	case req.Limit > maxListLimit:
+		req.Limit = maxListLimit
+	}
+	cursor, err := decodeKeysetCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  err.Error(),
+		}, nil
+	}
+
+	records, err := h.svc.List(ctx, uc.UID, cursor, req.Limit)
+	if err != nil {
+		return ginx.InternalServerErrorResult,
+			logger.LError("Get the reading history failed",
+				logger.Int64("uid", uc.UID),
+				logger.String("cursor", req.Cursor),
+				logger.Int("limit", req.Limit),
+				logger.Error(err),
+			)
+	}
+	res := ReadHistoryVO{
+		Records: gslice.Map(records, func(id int, src domain.ReadRecord) ReadRecordVO {
+			art := ArticleVO{ID: src.Article.ID}
+			if src.Article.Status == domain.ArticleStatusPublished {
+				art = ArticleVO{
+					ID:         src.Article.ID,
+					Title:      src.Article.Title,
+					Abstract:   src.Article.Abstract(),
+					AuthorID:   src.Article.Author.ID,
+					AuthorName: src.Article.Author.Name,
+					Utime:      src.Article.Utime.Format(time.DateTime),
+				}
+			}
+			return ReadRecordVO{
+				Article:  art,
+				ReadTime: src.ReadTime.Format(time.DateTime),
+			}
+		}),
+	}
+	if len(records) == req.Limit {
+		res.NextCursor = encodeKeysetCursor(domain.ReadRecordCursor(records[len(records)-1]))
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: res,
+	}, nil
+}
+
+func (h *ReadHistoryHandler) Delete(
+	ctx *gin.Context,
+	req ReadHistoryDeleteReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	err := h.svc.Delete(ctx, uc.UID, req.ID)
+	if err != nil {
+		return ginx.InternalServerErrorResult, fmt.Errorf(
+			"Delete article %d from the reading history of %d failed: %w",
+			req.ID,
+			uc.UID,
+			err,
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+	}, nil
+}
+
+func (h *ReadHistoryHandler) Clear(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	err := h.svc.Clear(ctx, uc.UID)
+	if err != nil {
+		return ginx.InternalServerErrorResult, fmt.Errorf(
+			"Clear the reading history of %d failed: %w",
+			uc.UID,
+			err,
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+	}, nil
+}
+
+// }}}
+// {{{ Private functions
+
+// }}}
+// {{{ Package functions
+
+// }}}
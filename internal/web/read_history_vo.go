@@ -0,0 +1,18 @@
+package web
+
+type ReadHistoryDeleteReq struct {
+	// article ID
+	ID int64 `json:"id"`
+}
+
+type ReadRecordVO struct {
+	// only the ID is set if the article is no longer published
+	Article  ArticleVO `json:"article"`
+	ReadTime string    `json:"readTime"`
+}
+
+type ReadHistoryVO struct {
+	Records []ReadRecordVO `json:"records"`
+	// empty if there is no more records
+	NextCursor string `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,119 @@
+package web
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/chenmuyao/generique/gslice"
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	ijwt "github.com/chenmuyao/go-bootcamp/internal/web/jwt"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// {{{ Consts
+
+// }}}
+// {{{ Global Varirables
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+type RecommendHandler struct {
+	l   logger.Logger
+	svc service.RecommendService
+}
+
+func NewRecommendHandler(l logger.Logger, svc service.RecommendService) *RecommendHandler {
+	return &RecommendHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+// }}}
+// {{{ Struct Methods
+
+func (h *RecommendHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles")
+	g.GET("/recommend", ginx.WrapClaims(h.l, h.ForUser))
+	g.GET("/pub/:id/related", ginx.WrapClaims(h.l, h.Related))
+}
+
+func (h *RecommendHandler) Related(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: ginx.CodeUserSide,
+			Msg:  "invalid article id",
+		}, nil
+	}
+	arts, err := h.svc.Related(ctx, id, queryLimit(ctx))
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"Get related articles failed",
+			logger.Int64("aid", id),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: toRecommendedVOs(arts),
+	}, nil
+}
+
+func (h *RecommendHandler) ForUser(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	arts, err := h.svc.ForUser(ctx, uc.UID, queryLimit(ctx))
+	if err != nil {
+		return ginx.InternalServerErrorResult, logger.LError(
+			"Get recommended articles failed",
+			logger.Int64("uid", uc.UID),
+			logger.Error(err),
+		)
+	}
+	return ginx.Result{
+		Code: ginx.CodeOK,
+		Data: toRecommendedVOs(arts),
+	}, nil
+}
+
+// }}}
+// {{{ Private functions
+
+func queryLimit(ctx *gin.Context) int {
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	switch {
+	case err != nil || limit <= 0:
+		return defaultListLimit
+	case limit > maxListLimit:
+		return maxListLimit
+	}
+	return limit
+}
+
+func toRecommendedVOs(arts []domain.Article) []ArticleVO {
+	return gslice.Map(arts, func(id int, src domain.Article) ArticleVO {
+		return ArticleVO{
+			ID:         src.ID,
+			Title:      src.Title,
+			Abstract:   src.Abstract(),
+			AuthorID:   src.Author.ID,
+			AuthorName: src.Author.Name,
+			Utime:      src.Utime.Format(time.DateTime),
+			Tags:       src.Tags,
+		}
+	})
+}
+
+// }}}
+// {{{ Package functions
+
+// }}}
@@ -0,0 +1,147 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/internal/domain"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	ijwt "github.com/chenmuyao/go-bootcamp/internal/web/jwt"
+	"github.com/chenmuyao/go-bootcamp/pkg/ginx"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// {{{ Consts
+
+// }}}
+// {{{ Global Varirables
+
+var rewardStatusNames = map[domain.RewardStatus]string{
+	domain.RewardStatusInit:   "init",
+	domain.RewardStatusPaid:   "paid",
+	domain.RewardStatusFailed: "failed",
+}
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+// RewardHandler lets the readers tip the authors.
+type RewardHandler struct {
+	l   logger.Logger
+	svc service.RewardService
+}
+
+func NewRewardHandler(l logger.Logger, svc service.RewardService) *RewardHandler {
+	return &RewardHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+// }}}
+// {{{ Struct Methods
+
+func (h *RewardHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/rewards")
+	g.POST("/article", ginx.WrapBodyAndClaims(h.l, h.RewardArticle))
+	g.POST("/detail", ginx.WrapBodyAndClaims(h.l, h.Detail))
+	// called by the payment provider, the request is signed
+	g.POST("/callback", ginx.WrapLog(h.l, h.Callback))
+}
+
+func (h *RewardHandler) RewardArticle(
+	ctx *gin.Context,
+	req RewardArticleReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	rwd, payURL, err := h.svc.RewardArticle(ctx, req.ID, uc.UID, req.Amount)
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+			Data: RewardArticleVO{ID: rwd.ID, PayURL: payURL},
+		}, nil
+	case errors.Is(err, service.ErrInvalidRewardAmount):
+		return ginx.Result{Code: ginx.CodeUserSide, Msg: "invalid amount"}, nil
+	case errors.Is(err, service.ErrRewardSelf):
+		return ginx.Result{Code: ginx.CodeUserSide, Msg: "cannot reward your own article"}, nil
+	case errors.Is(err, service.ErrArticleNotFound):
+		return ginx.Result{Code: ginx.CodeNotFound, Msg: "article not found"}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to reward article",
+			logger.Int64("uid", uc.UID),
+			logger.Int64("aid", req.ID),
+			logger.Error(err),
+		)
+	}
+}
+
+func (h *RewardHandler) Detail(
+	ctx *gin.Context,
+	req RewardDetailReq,
+	uc ijwt.UserClaims,
+) (ginx.Result, error) {
+	rwd, err := h.svc.GetReward(ctx, req.ID, uc.UID)
+	switch {
+	case err == nil:
+		return ginx.Result{
+			Code: ginx.CodeOK,
+			Data: toRewardVO(rwd),
+		}, nil
+	case errors.Is(err, service.ErrRewardNotFound):
+		return ginx.Result{Code: ginx.CodeNotFound, Msg: "reward not found"}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to get reward",
+			logger.Int64("uid", uc.UID),
+			logger.Int64("id", req.ID),
+			logger.Error(err),
+		)
+	}
+}
+
+// Callback applies the payment result. Any error makes the provider retry.
+func (h *RewardHandler) Callback(ctx *gin.Context) (ginx.Result, error) {
+	err := h.svc.HandleCallback(ctx, ctx.Request)
+	switch {
+	case err == nil:
+		return ginx.Result{Code: ginx.CodeOK}, nil
+	case errors.Is(err, service.ErrInvalidCallback):
+		return ginx.Result{Code: ginx.CodeUserSide, Msg: "invalid callback"}, nil
+	case errors.Is(err, service.ErrRewardNotFound):
+		return ginx.Result{Code: ginx.CodeNotFound, Msg: "reward not found"}, nil
+	default:
+		return ginx.InternalServerErrorResult, logger.LError(
+			"failed to handle reward callback",
+			logger.Error(err),
+		)
+	}
+}
+
+// }}}
+// {{{ Private functions
+
+func toRewardVO(rwd domain.Reward) RewardVO {
+	return RewardVO{
+		ID:     rwd.ID,
+		Biz:    rwd.Biz,
+		BizID:  rwd.BizID,
+		Amount: rwd.Amount,
+		Status: rewardStatusNames[rwd.Status],
+		Ctime:  rwd.Ctime.Format(time.DateTime),
+		Utime:  rwd.Utime.Format(time.DateTime),
+	}
+}
+
+// }}}
+// {{{ Package functions
+
+// }}}
@@ -0,0 +1,29 @@
+package web
+
+type RewardArticleReq struct {
+	// article ID
+	ID int64 `json:"id"`
+	// in cents
+	Amount int64 `json:"amount"`
+}
+
+type RewardDetailReq struct {
+	ID int64 `json:"id"`
+}
+
+type RewardArticleVO struct {
+	ID int64 `json:"id"`
+	// where the user pays the reward
+	PayURL string `json:"payURL"`
+}
+
+type RewardVO struct {
+	ID     int64  `json:"id"`
+	Biz    string `json:"biz"`
+	BizID  int64  `json:"bizId"`
+	Amount int64  `json:"amount"`
+	// init, paid or failed
+	Status string `json:"status"`
+	Ctime  string `json:"ctime"`
+	Utime  string `json:"utime"`
+}
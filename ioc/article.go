@@ -0,0 +1,101 @@
+package ioc
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/bwmarrin/snowflake"
+	"github.com/chenmuyao/go-bootcamp/config"
+	"github.com/chenmuyao/go-bootcamp/internal/job"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/chenmuyao/go-bootcamp/pkg/objstore"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	ArticleStorageMySQL   = "mysql"
+	ArticleStorageMongoDB = "mongodb"
+	// metadata in MySQL, contents in the object store
+	ArticleStorageObjStore = "objstore"
+
+	defaultTrashRetentionDays = 30
+)
+
+// InitObjStore stores the article contents, the images and the exports.
+func InitObjStore() objstore.Store {
+	store, err := objstore.NewLocalStore(config.Cfg.Article.ObjStore.Dir)
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
+
+// InitArticleDAO switches the article storage with the config, MongoDB is
+// only connected when it is used.
+func InitArticleDAO(l logger.Logger, db *gorm.DB, store objstore.Store) dao.ArticleDAO {
+	switch config.Cfg.Article.Storage {
+	case ArticleStorageMongoDB:
+		l.Info("articles are stored in MongoDB")
+		node, err := snowflake.NewNode(config.Cfg.Mongo.NodeID)
+		if err != nil {
+			panic(err)
+		}
+		return dao.NewMongoDBArticleDAO(InitMongoDB(), node)
+	case ArticleStorageObjStore:
+		l.Info("article contents are stored in the object store")
+		return dao.NewObjStoreArticleDAO(db, store)
+	case ArticleStorageMySQL, "":
+		return dao.NewArticleDAO(db)
+	default:
+		panic("unknown article storage: " + config.Cfg.Article.Storage)
+	}
+}
+
+// InitArticleContentMigrationJob returns nil if the storage has nothing to
+// migrate.
+func InitArticleContentMigrationJob(
+	l logger.Logger,
+	artDAO dao.ArticleDAO,
+	redis redis.Cmdable,
+) *job.ArticleContentMigrationJob {
+	migrator, ok := artDAO.(job.ContentMigrator)
+	if !ok {
+		return nil
+	}
+	return job.NewArticleContentMigrationJob(migrator, redislock.New(redis), 100, time.Second*50, l)
+}
+
+func InitArticlePurgeJob(
+	l logger.Logger,
+	svc service.ArticleService,
+	redis redis.Cmdable,
+) *job.ArticlePurgeJob {
+	days := config.Cfg.Article.TrashRetentionDays
+	if days <= 0 {
+		days = defaultTrashRetentionDays
+	}
+	retention := time.Duration(days) * 24 * time.Hour
+	return job.NewArticlePurgeJob(svc, redislock.New(redis), retention, 100, time.Second*50, l)
+}
+
+func InitFeedService(
+	l logger.Logger,
+	repo repository.FeedRepository,
+	artRepo repository.ArticleRepository,
+	userRepo repository.UserRepository,
+) service.FeedService {
+	cfg := config.Cfg.Feed
+	return service.NewFeedService(
+		l,
+		repo,
+		artRepo,
+		userRepo,
+		strings.TrimSuffix(cfg.BaseURL, "/"),
+		cfg.Title,
+	)
+}
@@ -2,11 +2,14 @@ package ioc
 
 import (
 	"log/slog"
+	"time"
 
 	intrv1 "github.com/chenmuyao/go-bootcamp/api/proto/gen/intr/v1"
 	"github.com/chenmuyao/go-bootcamp/config"
 	"github.com/chenmuyao/go-bootcamp/interactive/service"
 	"github.com/chenmuyao/go-bootcamp/internal/client"
+	"github.com/chenmuyao/go-bootcamp/pkg/breaker"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -15,7 +18,10 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func InitIntrClient(intrSvc service.InteractiveService) intrv1.InteractiveServiceClient {
+func InitIntrClient(
+	intrSvc service.InteractiveService,
+	l logger.Logger,
+) intrv1.InteractiveServiceClient {
 	var opts []grpc.DialOption
 	if !config.Cfg.GRPC.Secure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -26,7 +32,16 @@ func InitIntrClient(intrSvc service.InteractiveService) intrv1.InteractiveServic
 	}
 	remote := intrv1.NewInteractiveServiceClient(cc)
 	local := client.NewLocalInteractiveAdapter(intrSvc)
-	res := client.NewInteractiveClient(remote, local)
+	res := client.NewInteractiveClient(remote, local, l, breaker.Options{
+		Window:         10 * time.Second,
+		Buckets:        10,
+		MinCalls:       20,
+		ErrorRate:      0.5,
+		SlowCall:       500 * time.Millisecond,
+		SlowRate:       0.8,
+		OpenTimeout:    5 * time.Second,
+		HalfOpenProbes: 3,
+	})
 	viper.OnConfigChange(func(in fsnotify.Event) {
 		th := config.Cfg.GRPC.Intr.Threshold
 		slog.Info("change threshold", "th", th)
@@ -17,7 +17,22 @@ func InitRankingJob(svc service.RankingService, l logger.Logger, redis redis.Cmd
 	return job.NewRankingJob(svc, lock, time.Second*30, l)
 }
 
-func InitJobs(l logger.Logger, j job.Job) *cron.Cron {
+func InitRecommendJob(
+	svc service.RecommendService,
+	l logger.Logger,
+	redis redis.Cmdable,
+) *job.RecommendJob {
+	return job.NewRecommendJob(svc, redislock.New(redis), time.Minute*10, l)
+}
+
+func InitJobs(
+	l logger.Logger,
+	j job.Job,
+	contentMigration *job.ArticleContentMigrationJob,
+	articlePurge *job.ArticlePurgeJob,
+	recommend *job.RecommendJob,
+	rewardSync *job.RewardSyncJob,
+) *cron.Cron {
 	builder := job.NewCronJobBuilder(l, prometheus.SummaryOpts{
 		Namespace: "my_company",
 		Subsystem: "wetravel",
@@ -36,5 +51,23 @@ func InitJobs(l logger.Logger, j job.Job) *cron.Cron {
 	if err != nil {
 		panic(err)
 	}
+	if contentMigration != nil {
+		_, err = expr.AddJob("@every 1m", builder.Build(contentMigration))
+		if err != nil {
+			panic(err)
+		}
+	}
+	_, err = expr.AddJob("@every 1h", builder.Build(articlePurge))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 1h", builder.Build(recommend))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 1m", builder.Build(rewardSync))
+	if err != nil {
+		panic(err)
+	}
 	return expr
 }
@@ -4,6 +4,10 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/chenmuyao/go-bootcamp/config"
 	"github.com/chenmuyao/go-bootcamp/internal/events"
+	"github.com/chenmuyao/go-bootcamp/internal/events/article"
+	"github.com/chenmuyao/go-bootcamp/internal/events/reward"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
 )
 
 func InitSaramaClient() sarama.Client {
@@ -27,6 +31,26 @@ func InitSyncProducer(c sarama.Client) sarama.SyncProducer {
 //	func InitConsumers(c1 *intrEvents.InteractiveReadEventConsumer) []events.Consumer {
 //		return []events.Consumer{c1}
 //	}
-func InitConsumers() []events.Consumer {
-	return []events.Consumer{}
+func InitConsumers(
+	history *article.ReadHistoryConsumer,
+	export *article.ExportConsumer,
+	account *reward.AccountConsumer,
+) []events.Consumer {
+	return []events.Consumer{history, export, account}
+}
+
+func InitExportConsumer(
+	l logger.Logger,
+	svc service.ArchiveService,
+	client sarama.Client,
+) *article.ExportConsumer {
+	return article.NewExportConsumer(l, svc, client)
+}
+
+func InitAccountConsumer(
+	l logger.Logger,
+	svc service.AccountService,
+	client sarama.Client,
+) *reward.AccountConsumer {
+	return reward.NewAccountConsumer(l, svc, client)
 }
@@ -0,0 +1,29 @@
+package ioc
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/config"
+	"github.com/chenmuyao/go-bootcamp/internal/repository/dao"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func InitMongoDB() *mongo.Client {
+	client, err := mongo.Connect(options.Client().ApplyURI(config.Cfg.Mongo.URI))
+	if err != nil {
+		panic(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		panic(err)
+	}
+	err = dao.InitCollection(client.Database(dao.DatabaseName))
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
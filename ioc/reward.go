@@ -0,0 +1,41 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/bsm/redislock"
+	"github.com/chenmuyao/go-bootcamp/config"
+	"github.com/chenmuyao/go-bootcamp/internal/job"
+	"github.com/chenmuyao/go-bootcamp/internal/repository"
+	"github.com/chenmuyao/go-bootcamp/internal/service"
+	"github.com/chenmuyao/go-bootcamp/internal/service/payment"
+	"github.com/chenmuyao/go-bootcamp/internal/service/payment/localpay"
+	"github.com/chenmuyao/go-bootcamp/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// 10% of the rewards
+const defaultFeeRate = 1000
+
+func InitPaymentService() payment.Service {
+	cfg := config.Cfg.Payment.Local
+	return localpay.NewService(cfg.Secret, cfg.NotifyURL, time.Duration(cfg.PayDelay)*time.Second)
+}
+
+func InitRewardSyncJob(
+	l logger.Logger,
+	svc service.RewardService,
+	redis redis.Cmdable,
+) *job.RewardSyncJob {
+	// NOTE: leaves a minute to the callbacks
+	return job.NewRewardSyncJob(svc, redislock.New(redis), time.Minute, 100, time.Second*50, l)
+}
+
+func InitAccountService(repo repository.AccountRepository) service.AccountService {
+	cfg := config.Cfg.Account
+	feeRate := cfg.FeeRate
+	if feeRate <= 0 {
+		feeRate = defaultFeeRate
+	}
+	return service.NewAccountService(repo, feeRate, cfg.Admins)
+}
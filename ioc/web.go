@@ -21,12 +21,28 @@ func InitWebServer(middlewares []gin.HandlerFunc,
 	userHandlers *web.UserHandler,
 	giteaHandlers *web.OAuth2GiteaHandler,
 	articleHandlers *web.ArticleHandler,
+	itineraryHandlers *web.ItineraryHandler,
+	feedHandlers *web.FeedHandler,
+	historyHandlers *web.ReadHistoryHandler,
+	recommendHandlers *web.RecommendHandler,
+	archiveHandlers *web.ArchiveHandler,
+	collectionHandlers *web.CollectionHandler,
+	rewardHandlers *web.RewardHandler,
+	accountHandlers *web.AccountHandler,
 ) *gin.Engine {
 	server := gin.Default()
 	server.Use(middlewares...)
 	userHandlers.RegisterRoutes(server)
 	giteaHandlers.RegisterRoutes(server)
 	articleHandlers.RegisterRoutes(server)
+	itineraryHandlers.RegisterRoutes(server)
+	feedHandlers.RegisterRoutes(server)
+	historyHandlers.RegisterRoutes(server)
+	recommendHandlers.RegisterRoutes(server)
+	archiveHandlers.RegisterRoutes(server)
+	collectionHandlers.RegisterRoutes(server)
+	rewardHandlers.RegisterRoutes(server)
+	accountHandlers.RegisterRoutes(server)
 	return server
 }
 
@@ -95,6 +111,14 @@ func useJWT(jwtHdl ijwt.Handler) gin.HandlerFunc {
 		"/user/refresh_token",
 		"/oauth2/gitea/authurl",
 		"/oauth2/gitea/callback",
+		"/feed/atom",
+		"/feed/rss",
+		"/feed/authors/:uid/atom",
+		"/feed/authors/:uid/rss",
+		"/sitemap.xml",
+		"/sitemaps/:page",
+		"/images/:uid/:name",
+		"/rewards/callback",
 	})
 	return loginJWT.Build()
 }
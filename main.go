@@ -61,9 +61,11 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	intrRepo := InitInteractiveRepo()
-	err := intrRepo.BatchSetTopLike(ctx, "article", 1000)
-	if err != nil {
-		panic(err)
+	for _, biz := range app.bizs.List() {
+		err := intrRepo.BatchSetTopLike(ctx, biz.Name, 1000)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	app.server.Run(":8081")
@@ -0,0 +1,237 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// {{{ Consts
+
+type State int32
+
+const (
+	StateClosed State = iota
+	StateOpen
+	// a few probes are let through to test the recovery
+	StateHalfOpen
+)
+
+// }}}
+// {{{ Global Varirables
+
+// }}}
+// {{{ Interface
+
+// }}}
+// {{{ Struct
+
+// Breaker is a circuit breaker driven by the error rate and the slow call
+// rate over a sliding window. It opens when one of the rates is reached,
+// rejects the calls for OpenTimeout, then lets HalfOpenProbes calls through:
+// it closes if they all succeed and opens again at the first failure.
+type Breaker struct {
+	opts Options
+
+	mu       sync.Mutex
+	state    State
+	openedAt time.Time
+	// the probes let through and succeeded in half-open
+	probes   int
+	probesOK int
+	buckets  []bucket
+}
+
+func NewBreaker(options *Options) *Breaker {
+	opts := *options
+	if opts.Window <= 0 {
+		opts.Window = 10 * time.Second
+	}
+	if opts.Buckets <= 0 {
+		opts.Buckets = 10
+	}
+	if opts.MinCalls <= 0 {
+		opts.MinCalls = 20
+	}
+	if opts.ErrorRate <= 0 {
+		opts.ErrorRate = 0.5
+	}
+	if opts.SlowRate <= 0 {
+		opts.SlowRate = 1
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = 5 * time.Second
+	}
+	if opts.HalfOpenProbes <= 0 {
+		opts.HalfOpenProbes = 3
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Breaker{
+		opts:    opts,
+		buckets: make([]bucket, opts.Buckets),
+	}
+}
+
+// }}}
+// {{{ Other structs
+
+type Options struct {
+	// the rates are computed over the window, split in buckets
+	Window  time.Duration
+	Buckets int
+	// the calls needed in the window before the rates count
+	MinCalls  int
+	ErrorRate float64
+	// a call is slow above SlowCall, never if 0
+	SlowCall time.Duration
+	SlowRate float64
+
+	OpenTimeout    time.Duration
+	HalfOpenProbes int
+
+	// called on each change, under the lock of the breaker
+	OnStateChange func(from, to State)
+	// for the tests
+	Now func() time.Time
+}
+
+type bucket struct {
+	// the window the bucket is for, it is stale otherwise
+	epoch    int64
+	calls    int
+	failures int
+	slow     int
+}
+
+// }}}
+// {{{ Struct Methods
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow tells whether a call can go through. The result of an allowed call
+// must be recorded with Record.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateClosed:
+		return true
+	case StateOpen:
+		if b.opts.Now().Sub(b.openedAt) < b.opts.OpenTimeout {
+			return false
+		}
+		b.setState(StateHalfOpen)
+	}
+	if b.probes >= b.opts.HalfOpenProbes {
+		return false
+	}
+	b.probes++
+	return true
+}
+
+// Record records the result of an allowed call.
+func (b *Breaker) Record(failed bool, latency time.Duration) {
+	slow := b.opts.SlowCall > 0 && latency > b.opts.SlowCall
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateClosed:
+		bkt := b.current()
+		bkt.calls++
+		if failed {
+			bkt.failures++
+		}
+		if slow {
+			bkt.slow++
+		}
+		if b.tripped() {
+			b.setState(StateOpen)
+		}
+	case StateHalfOpen:
+		if failed || slow {
+			b.setState(StateOpen)
+			return
+		}
+		b.probesOK++
+		if b.probesOK >= b.opts.HalfOpenProbes {
+			b.setState(StateClosed)
+		}
+	default:
+		// NOTE: a call allowed before the circuit opened
+	}
+}
+
+func (b *Breaker) current() *bucket {
+	epoch := b.opts.Now().UnixNano() / int64(b.bucketSize())
+	bkt := &b.buckets[epoch%int64(len(b.buckets))]
+	if bkt.epoch != epoch {
+		*bkt = bucket{epoch: epoch}
+	}
+	return bkt
+}
+
+func (b *Breaker) tripped() bool {
+	oldest := b.opts.Now().UnixNano()/int64(b.bucketSize()) - int64(len(b.buckets)) + 1
+	var calls, failures, slow int
+	for _, bkt := range b.buckets {
+		if bkt.epoch < oldest {
+			continue
+		}
+		calls += bkt.calls
+		failures += bkt.failures
+		slow += bkt.slow
+	}
+	if calls < b.opts.MinCalls {
+		return false
+	}
+	return float64(failures) >= b.opts.ErrorRate*float64(calls) ||
+		(slow > 0 && float64(slow) >= b.opts.SlowRate*float64(calls))
+}
+
+func (b *Breaker) bucketSize() time.Duration {
+	return max(b.opts.Window/time.Duration(len(b.buckets)), time.Millisecond)
+}
+
+func (b *Breaker) setState(to State) {
+	from := b.state
+	b.state = to
+	b.probes = 0
+	b.probesOK = 0
+	switch to {
+	case StateOpen:
+		b.openedAt = b.opts.Now()
+	case StateClosed:
+		// start again from a clean window
+		clear(b.buckets)
+	}
+	if b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(from, to)
+	}
+}
+
+// }}}
+// {{{ Private functions
+
+// }}}
+// {{{ Package functions
+
+// }}}
@@ -0,0 +1,145 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestBreaker(clock *fakeClock, changes *[]State) *Breaker {
+	return NewBreaker(&Options{
+		Window:         10 * time.Second,
+		Buckets:        10,
+		MinCalls:       4,
+		ErrorRate:      0.5,
+		SlowCall:       100 * time.Millisecond,
+		SlowRate:       0.5,
+		OpenTimeout:    5 * time.Second,
+		HalfOpenProbes: 2,
+		OnStateChange: func(from, to State) {
+			*changes = append(*changes, to)
+		},
+		Now: clock.Now,
+	})
+}
+
+func TestBreaker(t *testing.T) {
+	ms := time.Millisecond
+	testCases := []struct {
+		name string
+		// run the calls and check the breaker
+		run func(t *testing.T, b *Breaker, clock *fakeClock)
+
+		wantChanges []State
+	}{
+		{
+			name: "too few calls",
+			run: func(t *testing.T, b *Breaker, clock *fakeClock) {
+				for range 3 {
+					assert.True(t, b.Allow())
+					b.Record(true, ms)
+				}
+				assert.Equal(t, StateClosed, b.State())
+			},
+		},
+		{
+			name: "error rate",
+			run: func(t *testing.T, b *Breaker, clock *fakeClock) {
+				for i := range 4 {
+					assert.True(t, b.Allow())
+					b.Record(i%2 == 0, ms)
+				}
+				assert.Equal(t, StateOpen, b.State())
+				assert.False(t, b.Allow())
+			},
+			wantChanges: []State{StateOpen},
+		},
+		{
+			name: "slow rate",
+			run: func(t *testing.T, b *Breaker, clock *fakeClock) {
+				for i := range 4 {
+					assert.True(t, b.Allow())
+					b.Record(false, time.Duration(i%2)*time.Second)
+				}
+				assert.Equal(t, StateOpen, b.State())
+			},
+			wantChanges: []State{StateOpen},
+		},
+		{
+			name: "old failures out of the window",
+			run: func(t *testing.T, b *Breaker, clock *fakeClock) {
+				for range 3 {
+					b.Allow()
+					b.Record(true, ms)
+				}
+				clock.now = clock.now.Add(11 * time.Second)
+				for range 3 {
+					b.Allow()
+					b.Record(false, ms)
+				}
+				b.Allow()
+				b.Record(true, ms)
+				assert.Equal(t, StateClosed, b.State())
+			},
+		},
+		{
+			name: "recovered",
+			run: func(t *testing.T, b *Breaker, clock *fakeClock) {
+				for range 4 {
+					b.Allow()
+					b.Record(true, ms)
+				}
+				clock.now = clock.now.Add(5 * time.Second)
+				// only the probes go through
+				assert.True(t, b.Allow())
+				assert.True(t, b.Allow())
+				assert.False(t, b.Allow())
+				assert.Equal(t, StateHalfOpen, b.State())
+				b.Record(false, ms)
+				b.Record(false, ms)
+				assert.Equal(t, StateClosed, b.State())
+				// with a clean window
+				b.Allow()
+				b.Record(true, ms)
+				assert.Equal(t, StateClosed, b.State())
+			},
+			wantChanges: []State{StateOpen, StateHalfOpen, StateClosed},
+		},
+		{
+			name: "probe failed",
+			run: func(t *testing.T, b *Breaker, clock *fakeClock) {
+				for range 4 {
+					b.Allow()
+					b.Record(true, ms)
+				}
+				clock.now = clock.now.Add(5 * time.Second)
+				assert.True(t, b.Allow())
+				b.Record(false, time.Second)
+				assert.Equal(t, StateOpen, b.State())
+				assert.False(t, b.Allow())
+				clock.now = clock.now.Add(5 * time.Second)
+				assert.True(t, b.Allow())
+			},
+			wantChanges: []State{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+			var changes []State
+			b := newTestBreaker(clock, &changes)
+			tc.run(t, b, clock)
+			assert.Equal(t, tc.wantChanges, changes)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFeed = Feed{
+	ID:      "https://example.com/feed/atom",
+	Title:   "my site",
+	Link:    "https://example.com",
+	Author:  "my site",
+	Updated: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	Items: []Item{
+		{
+			Link:      "https://example.com/articles/1",
+			Title:     "title & co",
+			Summary:   "my <summary>",
+			Author:    "Tom",
+			Published: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Updated:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	},
+}
+
+func TestAtom(t *testing.T) {
+	body, err := Atom(testFeed)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">`+
+		`<id>https://example.com/feed/atom</id>`+
+		`<title>my site</title>`+
+		`<updated>2024-01-02T03:04:05Z</updated>`+
+		`<link href="https://example.com/feed/atom" rel="self" type="application/atom+xml"></link>`+
+		`<link href="https://example.com" rel="alternate" type="text/html"></link>`+
+		`<author><name>my site</name></author>`+
+		`<entry>`+
+		`<id>https://example.com/articles/1</id>`+
+		`<title>title &amp; co</title>`+
+		`<updated>2024-01-02T03:04:05Z</updated>`+
+		`<published>2024-01-01T00:00:00Z</published>`+
+		`<link href="https://example.com/articles/1" rel="alternate"></link>`+
+		`<author><name>Tom</name></author>`+
+		`<summary type="text">my &lt;summary&gt;</summary>`+
+		`</entry></feed>`, string(body))
+}
+
+func TestRSS(t *testing.T) {
+	body, err := RSS(testFeed)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel>`+
+		`<title>my site</title>`+
+		`<link>https://example.com</link>`+
+		`<description>my site</description>`+
+		`<lastBuildDate>Tue, 02 Jan 2024 03:04:05 +0000</lastBuildDate>`+
+		`<item>`+
+		`<title>title &amp; co</title>`+
+		`<link>https://example.com/articles/1</link>`+
+		`<description>my &lt;summary&gt;</description>`+
+		`<guid isPermaLink="true">https://example.com/articles/1</guid>`+
+		`<pubDate>Mon, 01 Jan 2024 00:00:00 +0000</pubDate>`+
+		`</item></channel></rss>`, string(body))
+}
+
+func TestSitemap(t *testing.T) {
+	body, err := Sitemap([]URL{
+		{Loc: "https://example.com/articles/1", LastMod: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Loc: "https://example.com/articles/2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+		`<url><loc>https://example.com/articles/1</loc><lastmod>2024-01-01T00:00:00Z</lastmod></url>`+
+		`<url><loc>https://example.com/articles/2</loc></url>`+
+		`</urlset>`, string(body))
+
+	body, err = SitemapIndex([]URL{{Loc: "https://example.com/sitemaps/1.xml"}})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+		`<sitemap><loc>https://example.com/sitemaps/1.xml</loc></sitemap>`+
+		`</sitemapindex>`, string(body))
+
+	_, err = Sitemap(make([]URL, MaxSitemapURLs+1))
+	assert.Equal(t, ErrTooManyURLs, err)
+}
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, ETag([]byte("a")), ETag([]byte("a")))
+	assert.NotEqual(t, ETag([]byte("a")), ETag([]byte("b")))
+}
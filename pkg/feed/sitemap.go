@@ -0,0 +1,62 @@
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+var ErrTooManyURLs = errors.New("too many urls for a sitemap")
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	NS      string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	NS       string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Sitemap renders a sitemap of at most MaxSitemapURLs URLs.
+func Sitemap(urls []URL) ([]byte, error) {
+	if len(urls) > MaxSitemapURLs {
+		return nil, ErrTooManyURLs
+	}
+	return marshal(sitemapURLSet{
+		NS:   sitemapNS,
+		URLs: toSitemapURLs(urls),
+	})
+}
+
+// SitemapIndex renders the index of at most MaxSitemapURLs sitemaps.
+func SitemapIndex(sitemaps []URL) ([]byte, error) {
+	if len(sitemaps) > MaxSitemapURLs {
+		return nil, ErrTooManyURLs
+	}
+	return marshal(sitemapIndex{
+		NS:       sitemapNS,
+		Sitemaps: toSitemapURLs(sitemaps),
+	})
+}
+
+func toSitemapURLs(urls []URL) []sitemapURL {
+	res := make([]sitemapURL, 0, len(urls))
+	for _, u := range urls {
+		su := sitemapURL{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			su.LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+		res = append(res, su)
+	}
+	return res
+}
@@ -0,0 +1,59 @@
+// Package feed renders the Atom and RSS 2.0 feeds, and the sitemaps.
+package feed
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// MaxSitemapURLs is the max number of URLs in a sitemap, and of sitemaps in a
+// sitemap index.
+const MaxSitemapURLs = 50000
+
+// Feed is the content of a feed, independent of the format.
+type Feed struct {
+	// the URL of the feed itself, used as the Atom ID
+	ID          string
+	Title       string
+	Description string
+	// the HTML page of the feed
+	Link    string
+	Author  string
+	Updated time.Time
+	Items   []Item
+}
+
+type Item struct {
+	// the URL of the item, used as the Atom ID and the RSS guid
+	Link      string
+	Title     string
+	Summary   string
+	Author    string
+	Published time.Time
+	Updated   time.Time
+}
+
+// URL is an entry of a sitemap or a sitemap index.
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// ETag returns a strong entity tag of the body.
+func ETag(body []byte) string {
+	return fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+}
+
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	err := enc.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
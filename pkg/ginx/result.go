@@ -9,6 +9,8 @@ const (
 	CodeRedirect   = 3
 	CodeUserSide   = 4
 	CodeUnauth     = 401
+	CodeNotFound   = 404
+	CodeConflict   = 409
 	CodeServerSide = 5
 )
 
@@ -38,6 +40,10 @@ func ResultToStatus(res Result) int {
 		return http.StatusBadRequest
 	case 401:
 		return http.StatusUnauthorized
+	case 404:
+		return http.StatusNotFound
+	case 409:
+		return http.StatusConflict
 	case 5:
 		return http.StatusInternalServerError
 	default:
@@ -0,0 +1,135 @@
+package chash
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/chenmuyao/go-bootcamp/pkg/grpcx/balancer/internal/cfgbalancer"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/serviceconfig"
+)
+
+const Name = "custom_consistent_hash"
+
+// Config is the config of the balancer in the service config, e.g.
+//
+//	{"loadBalancingConfig": [{"custom_consistent_hash": {"key": "uid"}}]}
+type Config struct {
+	serviceconfig.LoadBalancingConfig `json:"-"`
+
+	// the metadata the requests are hashed by, the requests without it are
+	// balanced in round robin
+	Key string `json:"key"`
+	// the virtual nodes of a node on the ring
+	Replicas int `json:"replicas"`
+}
+
+func (c *Config) withDefaults() *Config {
+	res := *c
+	if res.Key == "" {
+		res.Key = "uid"
+	}
+	if res.Replicas <= 0 {
+		res.Replicas = 100
+	}
+	return &res
+}
+
+type ringNode struct {
+	hash uint32
+	conn balancer.SubConn
+}
+
+type Picker struct {
+	key string
+	// sorted by hash
+	ring  []ringNode
+	conns []balancer.SubConn
+	next  atomic.Uint32
+}
+
+// Pick implements balancer.Picker.
+func (p *Picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	if len(p.conns) == 0 {
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+	md, _ := metadata.FromOutgoingContext(info.Ctx)
+	vals := md.Get(p.key)
+	if len(vals) == 0 {
+		idx := p.next.Add(1) % uint32(len(p.conns))
+		return balancer.PickResult{SubConn: p.conns[idx]}, nil
+	}
+	h := crc32.ChecksumIEEE([]byte(vals[0]))
+	// the first node clockwise
+	idx := sort.Search(len(p.ring), func(i int) bool {
+		return p.ring[i].hash >= h
+	})
+	if idx == len(p.ring) {
+		idx = 0
+	}
+	return balancer.PickResult{SubConn: p.ring[idx].conn}, nil
+}
+
+// PickerBuilder builds the pickers of one ClientConn.
+type PickerBuilder struct {
+	mu  sync.Mutex
+	cfg *Config
+}
+
+// Build implements base.PickerBuilder.
+func (p *PickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ring := make([]ringNode, 0, len(info.ReadySCs)*p.cfg.Replicas)
+	conns := make([]balancer.SubConn, 0, len(info.ReadySCs))
+	for sc, sci := range info.ReadySCs {
+		conns = append(conns, sc)
+		// NOTE: hashed by the address, a node keeps its place on the ring
+		// whatever the other nodes.
+		for i := range p.cfg.Replicas {
+			ring = append(ring, ringNode{
+				hash: crc32.ChecksumIEEE([]byte(sci.Address.Addr + "#" + strconv.Itoa(i))),
+				conn: sc,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		return ring[i].hash < ring[j].hash
+	})
+	return &Picker{
+		key:   p.cfg.Key,
+		ring:  ring,
+		conns: conns,
+	}
+}
+
+// SetConfig implements cfgbalancer.PickerBuilder.
+func (p *PickerBuilder) SetConfig(cfg *Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg.withDefaults()
+}
+
+func newBuilder() balancer.Builder {
+	return cfgbalancer.NewBuilder[Config](Name, func() cfgbalancer.PickerBuilder[*Config] {
+		return &PickerBuilder{
+			cfg: (&Config{}).withDefaults(),
+		}
+	})
+}
+
+func init() {
+	balancer.Register(newBuilder())
+}
+
+var (
+	_ base.PickerBuilder                 = &PickerBuilder{}
+	_ balancer.Picker                    = &Picker{}
+	_ cfgbalancer.PickerBuilder[*Config] = &PickerBuilder{}
+	_ serviceconfig.LoadBalancingConfig  = &Config{}
+)
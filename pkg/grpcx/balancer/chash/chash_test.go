@@ -0,0 +1,121 @@
+package chash
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/pkg/grpcx/balancer/internal/balancertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+)
+
+// startServers starts n servers and returns a client hashing between them.
+func startServers(t *testing.T, cfg string, key string, n int) *balancertest.Cluster {
+	servers := make([]*balancertest.Server, 0, n)
+	for range n {
+		servers = append(servers, &balancertest.Server{Key: key})
+	}
+	return balancertest.Start(t, Name, cfg, servers...)
+}
+
+// callKeys makes a call per key, without a key if key is empty.
+func callKeys(c *balancertest.Cluster, key string, n int) {
+	for i := range n {
+		if key == "" {
+			_ = c.Call(time.Second)
+			continue
+		}
+		_ = c.Call(time.Second, key, strconv.Itoa(i))
+	}
+}
+
+// owners returns the server of each key, failing if a key went to several
+// servers.
+func owners(t *testing.T, servers []*balancertest.Server) map[string]int {
+	res := make(map[string]int)
+	for i, srv := range servers {
+		for key := range srv.ResetKeys() {
+			_, ok := res[key]
+			assert.False(t, ok, "key %s on several servers", key)
+			res[key] = i
+		}
+	}
+	return res
+}
+
+func TestConsistentHash(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  string
+		key  string
+	}{
+		{
+			name: "default key",
+			cfg:  `{}`,
+			key:  "uid",
+		},
+		{
+			name: "configured key",
+			cfg:  `{"key": "biz_id", "replicas": 50}`,
+			key:  "biz_id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := startServers(t, tc.cfg, tc.key, 3)
+
+			// the same key always goes to the same server
+			for range 3 {
+				callKeys(c, tc.key, 100)
+			}
+			owned := owners(t, c.Servers)
+			assert.Len(t, owned, 100)
+			cnts := make([]int, len(c.Servers))
+			for _, i := range owned {
+				cnts[i]++
+			}
+			for i, cnt := range cnts {
+				assert.Positive(t, cnt, "no key on server %d", i)
+			}
+		})
+	}
+}
+
+func TestConsistentHashNoKey(t *testing.T) {
+	c := startServers(t, `{}`, "uid", 3)
+
+	callKeys(c, "", 300)
+	for _, srv := range c.Servers {
+		assert.Equal(t, 100, srv.ResetKeys()[""])
+	}
+}
+
+func TestConsistentHashNodeRemoved(t *testing.T) {
+	c := startServers(t, `{}`, "uid", 3)
+
+	callKeys(c, "uid", 100)
+	before := owners(t, c.Servers)
+
+	c.Resolver.UpdateState(resolver.State{Addresses: c.Addrs[1:]})
+	require.Eventually(t, func() bool {
+		callKeys(c, "", 10)
+		return len(c.Servers[0].ResetKeys()) == 0
+	}, 5*time.Second, time.Millisecond)
+	for _, srv := range c.Servers {
+		srv.ResetKeys()
+	}
+
+	// only the keys of the removed server move
+	callKeys(c, "uid", 100)
+	after := owners(t, c.Servers)
+	for key, i := range before {
+		if i != 0 {
+			assert.Equal(t, i, after[key], "key %s moved", key)
+		} else {
+			assert.NotEqual(t, 0, after[key])
+		}
+	}
+}
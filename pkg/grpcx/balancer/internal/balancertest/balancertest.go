@@ -0,0 +1,151 @@
+// Package balancertest runs the balancers against in-process servers.
+package balancertest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+)
+
+// Server counts the calls it got, and the calls per value of the metadata
+// Key.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+	// the metadata of its address
+	Metadata any
+	Key      string
+
+	Calls atomic.Int64
+	Fail  atomic.Bool
+	Delay atomic.Int64
+
+	mu   sync.Mutex
+	keys map[string]int
+}
+
+func (s *Server) Check(
+	ctx context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	s.Calls.Add(1)
+	if s.Key != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		key := ""
+		if vals := md.Get(s.Key); len(vals) > 0 {
+			key = vals[0]
+		}
+		s.mu.Lock()
+		s.keys[key]++
+		s.mu.Unlock()
+	}
+	select {
+	case <-time.After(time.Duration(s.Delay.Load())):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if s.Fail.Load() {
+		return nil, status.Error(codes.Unavailable, "mock error")
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// ResetKeys returns the calls per key since the last reset.
+func (s *Server) ResetKeys() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := s.keys
+	s.keys = make(map[string]int)
+	return res
+}
+
+// Cluster is a client balancing between in-process servers.
+type Cluster struct {
+	Client   healthpb.HealthClient
+	Resolver *manual.Resolver
+	Servers  []*Server
+	Addrs    []resolver.Address
+}
+
+// Call makes a call, adding the metadata kv if any.
+func (c *Cluster) Call(timeout time.Duration, kv ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if len(kv) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, kv...)
+	}
+	_, err := c.Client.Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+// Start starts the servers and returns a client balancing between them with
+// the policy name configured by cfg. The calls and the keys are reset once
+// all the servers have been picked.
+func Start(t *testing.T, name string, cfg string, servers ...*Server) *Cluster {
+	addrs := make([]resolver.Address, 0, len(servers))
+	for _, srv := range servers {
+		srv.keys = make(map[string]int)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		gs := grpc.NewServer()
+		healthpb.RegisterHealthServer(gs, srv)
+		go func() {
+			_ = gs.Serve(l)
+		}()
+		t.Cleanup(gs.Stop)
+		addrs = append(addrs, resolver.Address{
+			Addr:     l.Addr().String(),
+			Metadata: srv.Metadata,
+		})
+	}
+
+	r := manual.NewBuilderWithScheme("balancertest")
+	r.InitialState(resolver.State{Addresses: addrs})
+	cc, err := grpc.NewClient(
+		r.Scheme()+":///test",
+		grpc.WithResolvers(r),
+		grpc.WithDefaultServiceConfig(
+			fmt.Sprintf(`{"loadBalancingConfig": [{%q: %s}]}`, name, cfg),
+		),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+	c := &Cluster{
+		Client:   healthpb.NewHealthClient(cc),
+		Resolver: r,
+		Servers:  servers,
+		Addrs:    addrs,
+	}
+
+	// wait for all the servers to be picked
+	require.Eventually(t, func() bool {
+		_ = c.Call(time.Second)
+		for _, srv := range servers {
+			if srv.Calls.Load() == 0 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, time.Millisecond)
+	for _, srv := range servers {
+		srv.Calls.Store(0)
+		srv.ResetKeys()
+	}
+	return c
+}
@@ -0,0 +1,101 @@
+// Package cfgbalancer builds the balancers whose pickers are configured by
+// the service config.
+package cfgbalancer
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/serviceconfig"
+)
+
+// PickerBuilder is a picker builder taking the config of the balancer.
+type PickerBuilder[PC serviceconfig.LoadBalancingConfig] interface {
+	base.PickerBuilder
+	SetConfig(cfg PC)
+}
+
+// Builder builds the balancers named name, with a picker builder per
+// ClientConn. C is the JSON config of the balancer.
+type Builder[C any, PC interface {
+	*C
+	serviceconfig.LoadBalancingConfig
+}] struct {
+	name             string
+	newPickerBuilder func() PickerBuilder[PC]
+}
+
+// Name implements balancer.Builder.
+func (b *Builder[C, PC]) Name() string {
+	return b.name
+}
+
+// Build implements balancer.Builder.
+func (b *Builder[C, PC]) Build(
+	cc balancer.ClientConn,
+	opts balancer.BuildOptions,
+) balancer.Balancer {
+	pb := b.newPickerBuilder()
+	bb := base.NewBalancerBuilder(b.name, pb, base.Config{HealthCheck: true})
+	return &cfgBalancer[PC]{
+		Balancer: bb.Build(cc, opts),
+		pb:       pb,
+	}
+}
+
+// ParseConfig implements balancer.ConfigParser.
+func (b *Builder[C, PC]) ParseConfig(
+	js json.RawMessage,
+) (serviceconfig.LoadBalancingConfig, error) {
+	var cfg C
+	if len(js) > 0 {
+		err := json.Unmarshal(js, &cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return PC(&cfg), nil
+}
+
+// cfgBalancer gives the config to the picker builder.
+type cfgBalancer[PC serviceconfig.LoadBalancingConfig] struct {
+	balancer.Balancer
+	pb PickerBuilder[PC]
+}
+
+func (b *cfgBalancer[PC]) UpdateClientConnState(s balancer.ClientConnState) error {
+	if cfg, ok := s.BalancerConfig.(PC); ok {
+		b.pb.SetConfig(cfg)
+	}
+	return b.Balancer.UpdateClientConnState(s)
+}
+
+// ExitIdle implements balancer.ExitIdler.
+func (b *cfgBalancer[PC]) ExitIdle() {
+	if ei, ok := b.Balancer.(balancer.ExitIdler); ok {
+		ei.ExitIdle()
+	}
+}
+
+// NewBuilder returns the builder of the balancers named name.
+// NOTE: newPickerBuilder is called once per ClientConn, what the picker
+// builder learns is per node of the ClientConn.
+func NewBuilder[C any, PC interface {
+	*C
+	serviceconfig.LoadBalancingConfig
+}](name string, newPickerBuilder func() PickerBuilder[PC]) *Builder[C, PC] {
+	return &Builder[C, PC]{
+		name:             name,
+		newPickerBuilder: newPickerBuilder,
+	}
+}
+
+var (
+	_ balancer.ConfigParser = &Builder[emptyConfig, *emptyConfig]{}
+	_ balancer.ExitIdler    = &cfgBalancer[*emptyConfig]{}
+)
+
+type emptyConfig struct {
+	serviceconfig.LoadBalancingConfig
+}
@@ -0,0 +1,108 @@
+package p2c
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+const Name = "custom_p2c"
+
+// loadConn counts the requests in flight on a node, it outlives the pickers.
+type loadConn struct {
+	balancer.SubConn
+	inflight atomic.Int64
+}
+
+// Picker picks the least loaded of two random nodes.
+type Picker struct {
+	conns []*loadConn
+}
+
+// Pick implements balancer.Picker.
+func (p *Picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	var c *loadConn
+	switch len(p.conns) {
+	case 0:
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	case 1:
+		c = p.conns[0]
+	default:
+		i := rand.IntN(len(p.conns))
+		// another one than i
+		j := (i + 1 + rand.IntN(len(p.conns)-1)) % len(p.conns)
+		c = p.conns[i]
+		if p.conns[j].inflight.Load() < c.inflight.Load() {
+			c = p.conns[j]
+		}
+	}
+
+	c.inflight.Add(1)
+	return balancer.PickResult{
+		SubConn: c.SubConn,
+		Done: func(di balancer.DoneInfo) {
+			c.inflight.Add(-1)
+		},
+	}, nil
+}
+
+// PickerBuilder builds the pickers of one ClientConn.
+type PickerBuilder struct {
+	mu    sync.Mutex
+	conns map[balancer.SubConn]*loadConn
+}
+
+// Build implements base.PickerBuilder.
+func (p *PickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	conns := make([]*loadConn, 0, len(info.ReadySCs))
+	loads := make(map[balancer.SubConn]*loadConn, len(info.ReadySCs))
+	for sc := range info.ReadySCs {
+		// NOTE: the requests picked by the previous picker are still in
+		// flight.
+		c, ok := p.conns[sc]
+		if !ok {
+			c = &loadConn{SubConn: sc}
+		}
+		loads[sc] = c
+		conns = append(conns, c)
+	}
+	p.conns = loads
+	return &Picker{
+		conns: conns,
+	}
+}
+
+type builder struct{}
+
+// Name implements balancer.Builder.
+func (b builder) Name() string {
+	return Name
+}
+
+// Build implements balancer.Builder.
+func (b builder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	// NOTE: one picker builder per ClientConn, the loads are per node of
+	// the ClientConn.
+	pb := &PickerBuilder{
+		conns: make(map[balancer.SubConn]*loadConn),
+	}
+	return base.NewBalancerBuilder(Name, pb, base.Config{HealthCheck: true}).Build(cc, opts)
+}
+
+func newBuilder() balancer.Builder {
+	return builder{}
+}
+
+func init() {
+	balancer.Register(newBuilder())
+}
+
+var (
+	_ base.PickerBuilder = &PickerBuilder{}
+	_ balancer.Picker    = &Picker{}
+)
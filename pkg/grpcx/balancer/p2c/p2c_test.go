@@ -0,0 +1,76 @@
+package p2c
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/pkg/grpcx/balancer/internal/balancertest"
+	"github.com/stretchr/testify/assert"
+)
+
+// startServers starts a server per delay and returns a client balancing
+// between them.
+func startServers(t *testing.T, delays ...time.Duration) *balancertest.Cluster {
+	servers := make([]*balancertest.Server, 0, len(delays))
+	for _, delay := range delays {
+		srv := &balancertest.Server{}
+		srv.Delay.Store(int64(delay))
+		servers = append(servers, srv)
+	}
+	return balancertest.Start(t, Name, `{}`, servers...)
+}
+
+func TestP2C(t *testing.T) {
+	ms := time.Millisecond
+	testCases := []struct {
+		name   string
+		delays []time.Duration
+		// the concurrent callers, each making the calls one by one
+		callers int
+		calls   int
+
+		// the expected share of each server
+		wantShares []float64
+		delta      float64
+	}{
+		{
+			name:       "same load",
+			delays:     []time.Duration{ms, ms, ms},
+			callers:    6,
+			calls:      50,
+			wantShares: []float64{0.33, 0.33, 0.33},
+			delta:      0.1,
+		},
+		{
+			name:    "slow server",
+			delays:  []time.Duration{20 * ms, ms},
+			callers: 8,
+			calls:   25,
+			// the requests pile up on the slow server
+			wantShares: []float64{0.1, 0.9},
+			delta:      0.1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := startServers(t, tc.delays...)
+			var wg sync.WaitGroup
+			for range tc.callers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range tc.calls {
+						assert.NoError(t, c.Call(time.Second))
+					}
+				}()
+			}
+			wg.Wait()
+			total := float64(tc.callers * tc.calls)
+			for i, srv := range c.Servers {
+				assert.InDelta(t, tc.wantShares[i], float64(srv.Calls.Load())/total, tc.delta)
+			}
+		})
+	}
+}
@@ -2,21 +2,103 @@ package wrr
 
 import (
 	"sync"
+	"time"
 
+	"github.com/chenmuyao/go-bootcamp/pkg/grpcx"
+	"github.com/chenmuyao/go-bootcamp/pkg/grpcx/balancer/internal/cfgbalancer"
 	"google.golang.org/grpc/balancer"
 	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/serviceconfig"
+	"google.golang.org/grpc/status"
 )
 
 const Name = "custom_weighted_round_robin"
 
+// the weights are scaled to keep the precision of the adjustments
+const weightScale = 100
+
+// Config is the config of the balancer in the service config, e.g.
+//
+//	{"loadBalancingConfig": [{"custom_weighted_round_robin": {"floor": 0.1}}]}
+type Config struct {
+	serviceconfig.LoadBalancingConfig `json:"-"`
+
+	// the lowest share of its weight a node keeps, so that a bad node is
+	// still probed
+	Floor float64 `json:"floor"`
+	// the share of the health lost on an error
+	ErrorPenalty float64 `json:"errorPenalty"`
+	// the share of the health restored on a success
+	Recovery float64 `json:"recovery"`
+	// the weight of the last call in the latency EWMA
+	LatencyDecay float64 `json:"latencyDecay"`
+}
+
+func (c *Config) withDefaults() *Config {
+	res := *c
+	if res.Floor <= 0 || res.Floor > 1 {
+		res.Floor = 0.1
+	}
+	if res.ErrorPenalty <= 0 || res.ErrorPenalty > 1 {
+		res.ErrorPenalty = 0.5
+	}
+	if res.Recovery <= 0 || res.Recovery > 1 {
+		res.Recovery = 0.05
+	}
+	if res.LatencyDecay <= 0 || res.LatencyDecay > 1 {
+		res.LatencyDecay = 0.2
+	}
+	return &res
+}
+
+// connStats is what the balancer learnt of a node, it outlives the pickers.
+type connStats struct {
+	mu sync.Mutex
+	// in [Floor, 1], lowered on the errors and restored on the successes
+	health float64
+	// EWMA of the latency, 0 until the first call
+	latency time.Duration
+}
+
+func (s *connStats) record(err error, latency time.Duration, cfg *Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if grpcx.IsServerFailure(err) {
+		s.health = max(s.health*(1-cfg.ErrorPenalty), cfg.Floor)
+	} else {
+		s.health = min(s.health+cfg.Recovery, 1)
+	}
+	if err != nil && status.Code(err) != codes.DeadlineExceeded {
+		// NOTE: the latency of a failed call says nothing, but the one of a
+		// call timing out says the node is slow.
+		return
+	}
+	if s.latency == 0 {
+		s.latency = latency
+		return
+	}
+	s.latency = time.Duration(
+		cfg.LatencyDecay*float64(latency) + (1-cfg.LatencyDecay)*float64(s.latency),
+	)
+}
+
+func (s *connStats) get() (float64, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.health, s.latency
+}
+
 type weightConn struct {
 	balancer.SubConn
 	weight        int
 	currentWeight int
+	stats         *connStats
 }
 
 type Picker struct {
 	conns []*weightConn
+	cfg   *Config
 	lock  sync.Mutex
 }
 
@@ -28,12 +110,13 @@ func (p *Picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
 		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
 	}
 
+	weights := p.effectiveWeights()
 	var total int
 	var maxCC *weightConn
 
-	for _, c := range p.conns {
-		total += c.weight
-		c.currentWeight = c.currentWeight + c.weight
+	for i, c := range p.conns {
+		total += weights[i]
+		c.currentWeight = c.currentWeight + weights[i]
 		if maxCC == nil || maxCC.currentWeight < c.currentWeight {
 			maxCC = c
 		}
@@ -41,39 +124,97 @@ func (p *Picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
 
 	maxCC.currentWeight = maxCC.currentWeight - total
 
+	start := time.Now()
 	return balancer.PickResult{
 		SubConn: maxCC.SubConn,
 		Done: func(di balancer.DoneInfo) {
-			// info.Err -> change weight
+			maxCC.stats.record(di.Err, time.Since(start), p.cfg)
 		},
 	}, nil
 }
 
-type PickerBuilder struct{}
+// effectiveWeights scales the weights by the health of the nodes and by how
+// slow they are compared to the average, never under the floor.
+func (p *Picker) effectiveWeights() []int {
+	healths := make([]float64, len(p.conns))
+	latencies := make([]time.Duration, len(p.conns))
+	var sum time.Duration
+	var cnt int
+	for i, c := range p.conns {
+		healths[i], latencies[i] = c.stats.get()
+		if latencies[i] > 0 {
+			sum += latencies[i]
+			cnt++
+		}
+	}
+	res := make([]int, len(p.conns))
+	for i, c := range p.conns {
+		factor := healths[i]
+		if latencies[i] > 0 {
+			avg := sum / time.Duration(cnt)
+			factor *= min(float64(avg)/float64(latencies[i]), 1)
+		}
+		factor = max(factor, p.cfg.Floor)
+		res[i] = max(int(float64(c.weight*weightScale)*factor), 1)
+	}
+	return res
+}
+
+// PickerBuilder builds the pickers of one ClientConn.
+type PickerBuilder struct {
+	mu    sync.Mutex
+	cfg   *Config
+	stats map[balancer.SubConn]*connStats
+}
 
 // Build implements base.PickerBuilder.
 func (p *PickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	conns := make([]*weightConn, 0, len(info.ReadySCs))
+	stats := make(map[balancer.SubConn]*connStats, len(info.ReadySCs))
 	for sc, sci := range info.ReadySCs {
 		md, _ := sci.Address.Metadata.(map[string]any)
 		weightVal, _ := md["weight"]
 		weight, _ := weightVal.(float64)
-		// NOTE: can give a default value
-		// if weight == 0 {
-		// }
+		if weight <= 0 {
+			weight = 1
+		}
+		// NOTE: a node keeps what was learnt while it stays ready, it starts
+		// again from a full health when it comes back.
+		st, ok := p.stats[sc]
+		if !ok {
+			st = &connStats{health: 1}
+		}
+		stats[sc] = st
 		conns = append(conns, &weightConn{
 			SubConn:       sc,
 			weight:        int(weight),
 			currentWeight: int(weight),
+			stats:         st,
 		})
 	}
+	p.stats = stats
 	return &Picker{
 		conns: conns,
+		cfg:   p.cfg,
 	}
 }
 
+// SetConfig implements cfgbalancer.PickerBuilder.
+func (p *PickerBuilder) SetConfig(cfg *Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg.withDefaults()
+}
+
 func newBuilder() balancer.Builder {
-	return base.NewBalancerBuilder(Name, &PickerBuilder{}, base.Config{HealthCheck: true})
+	return cfgbalancer.NewBuilder[Config](Name, func() cfgbalancer.PickerBuilder[*Config] {
+		return &PickerBuilder{
+			cfg:   (&Config{}).withDefaults(),
+			stats: make(map[balancer.SubConn]*connStats),
+		}
+	})
 }
 
 func init() {
@@ -81,6 +222,8 @@ func init() {
 }
 
 var (
-	_ base.PickerBuilder = &PickerBuilder{}
-	_ balancer.Picker    = &Picker{}
+	_ base.PickerBuilder                 = &PickerBuilder{}
+	_ balancer.Picker                    = &Picker{}
+	_ cfgbalancer.PickerBuilder[*Config] = &PickerBuilder{}
+	_ serviceconfig.LoadBalancingConfig  = &Config{}
 )
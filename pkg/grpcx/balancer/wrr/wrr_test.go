@@ -0,0 +1,137 @@
+package wrr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chenmuyao/go-bootcamp/pkg/grpcx/balancer/internal/balancertest"
+	"github.com/stretchr/testify/assert"
+)
+
+// startServers starts a server per weight and returns a client balancing
+// between them.
+func startServers(t *testing.T, cfg string, weights ...int) *balancertest.Cluster {
+	servers := make([]*balancertest.Server, 0, len(weights))
+	for _, w := range weights {
+		servers = append(servers, &balancertest.Server{
+			Metadata: map[string]any{"weight": float64(w)},
+		})
+	}
+	return balancertest.Start(t, Name, cfg, servers...)
+}
+
+// callN makes n calls and returns the share of each server.
+func callN(c *balancertest.Cluster, n int, timeout time.Duration) []float64 {
+	before := make([]int64, len(c.Servers))
+	for i, srv := range c.Servers {
+		before[i] = srv.Calls.Load()
+	}
+	for range n {
+		_ = c.Call(timeout)
+	}
+	res := make([]float64, len(c.Servers))
+	for i, srv := range c.Servers {
+		res[i] = float64(srv.Calls.Load()-before[i]) / float64(n)
+	}
+	return res
+}
+
+func TestWRR(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     string
+		weights []int
+		// breaks the servers
+		before  func(servers []*balancertest.Server)
+		calls   int
+		timeout time.Duration
+
+		// the expected share of the first server
+		wantShare float64
+		delta     float64
+	}{
+		{
+			name:      "static weights",
+			cfg:       `{}`,
+			weights:   []int{1, 3},
+			before:    func(servers []*balancertest.Server) {},
+			calls:     400,
+			timeout:   time.Second,
+			wantShare: 0.25,
+			delta:     0.1,
+		},
+		{
+			name:    "errors",
+			cfg:     `{}`,
+			weights: []int{1, 1},
+			before: func(servers []*balancertest.Server) {
+				servers[0].Fail.Store(true)
+			},
+			calls:   400,
+			timeout: time.Second,
+			// the floor is 0.1
+			wantShare: 0.1,
+			delta:     0.07,
+		},
+		{
+			name:    "deadline exceeded",
+			cfg:     `{}`,
+			weights: []int{1, 1},
+			before: func(servers []*balancertest.Server) {
+				servers[0].Delay.Store(int64(100 * time.Millisecond))
+			},
+			calls:     200,
+			timeout:   10 * time.Millisecond,
+			wantShare: 0.1,
+			delta:     0.07,
+		},
+		{
+			name:    "slow",
+			cfg:     `{}`,
+			weights: []int{1, 1, 1},
+			before: func(servers []*balancertest.Server) {
+				servers[0].Delay.Store(int64(10 * time.Millisecond))
+			},
+			calls:   200,
+			timeout: time.Second,
+			// about the third of the weight of the others
+			wantShare: 0.15,
+			delta:     0.08,
+		},
+		{
+			name:    "configured floor",
+			cfg:     `{"floor": 0.5}`,
+			weights: []int{1, 1},
+			before: func(servers []*balancertest.Server) {
+				servers[0].Fail.Store(true)
+			},
+			calls:     400,
+			timeout:   time.Second,
+			wantShare: 0.33,
+			delta:     0.07,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := startServers(t, tc.cfg, tc.weights...)
+			tc.before(c.Servers)
+			shares := callN(c, tc.calls, tc.timeout)
+			assert.InDelta(t, tc.wantShare, shares[0], tc.delta)
+		})
+	}
+}
+
+func TestWRRRecovery(t *testing.T) {
+	c := startServers(t, `{"recovery": 0.2}`, 1, 1)
+
+	c.Servers[0].Fail.Store(true)
+	shares := callN(c, 200, time.Second)
+	assert.Less(t, shares[0], 0.2)
+
+	// the weight comes back with the successes
+	c.Servers[0].Fail.Store(false)
+	callN(c, 200, time.Second)
+	shares = callN(c, 200, time.Second)
+	assert.InDelta(t, 0.5, shares[0], 0.1)
+}
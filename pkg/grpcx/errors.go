@@ -0,0 +1,25 @@
+package grpcx
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsServerFailure tells whether the error is the server failing rather than
+// the request being refused, the business errors are not failures.
+func IsServerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable,
+		codes.DeadlineExceeded,
+		codes.Internal,
+		codes.Unknown,
+		codes.ResourceExhausted,
+		codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
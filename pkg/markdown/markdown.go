@@ -0,0 +1,88 @@
+// Package markdown reads and writes Markdown documents with a YAML front
+// matter.
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+var ErrInvalidFrontMatter = errors.New("invalid front matter")
+
+// imageRegexp matches ![alt](src) and ![alt](src "title"), the first group is
+// the src.
+var imageRegexp = regexp.MustCompile(`!\[[^\]]*\]\(\s*([^)\s]+)(?:\s+"[^"]*")?\s*\)`)
+
+type FrontMatter struct {
+	Title   string    `yaml:"title"`
+	Status  string    `yaml:"status,omitempty"`
+	Tags    []string  `yaml:"tags,omitempty,flow"`
+	Created time.Time `yaml:"created,omitempty"`
+	Updated time.Time `yaml:"updated,omitempty"`
+}
+
+type Document struct {
+	FrontMatter FrontMatter
+	Content     string
+}
+
+// Marshal writes the front matter between "---" lines, followed by the
+// content.
+func Marshal(doc Document) ([]byte, error) {
+	meta, err := yaml.Marshal(doc.FrontMatter)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(delimiter + "\n")
+	buf.Write(meta)
+	buf.WriteString(delimiter + "\n\n")
+	buf.WriteString(doc.Content)
+	return buf.Bytes(), nil
+}
+
+// Unmarshal parses a document, the whole data is the content if it has no
+// front matter.
+func Unmarshal(data []byte) (Document, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(data, []byte(delimiter+"\n")) {
+		return Document{Content: string(data)}, nil
+	}
+	rest := data[len(delimiter)+1:]
+	var meta []byte
+	switch {
+	case bytes.HasPrefix(rest, []byte(delimiter+"\n")):
+		// empty front matter
+		rest = rest[len(delimiter)+1:]
+	default:
+		end := bytes.Index(rest, []byte("\n"+delimiter+"\n"))
+		if end < 0 {
+			return Document{}, ErrInvalidFrontMatter
+		}
+		meta, rest = rest[:end+1], rest[end+len(delimiter)+2:]
+	}
+	var doc Document
+	err := yaml.Unmarshal(meta, &doc.FrontMatter)
+	if err != nil {
+		return Document{}, errors.Join(ErrInvalidFrontMatter, err)
+	}
+	doc.Content = string(bytes.TrimPrefix(rest, []byte("\n")))
+	return doc, nil
+}
+
+// ReplaceImages replaces the src of each image of the content with the result
+// of fn.
+func ReplaceImages(content string, fn func(src string) string) string {
+	return imageRegexp.ReplaceAllStringFunc(content, func(image string) string {
+		loc := imageRegexp.FindStringSubmatchIndex(image)
+		start, end := loc[2], loc[3]
+		return image[:start] + fn(image[start:end]) + image[end:]
+	})
+}
@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	doc := Document{
+		FrontMatter: FrontMatter{
+			Title:   "title: with a colon",
+			Status:  "published",
+			Tags:    []string{"go", "travel"},
+			Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Updated: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		Content: "# Hello\n\n---\n\nworld\n",
+	}
+	data, err := Marshal(doc)
+	require.NoError(t, err)
+	assert.Equal(t, `---
+title: 'title: with a colon'
+status: published
+tags: [go, travel]
+created: 2024-01-01T00:00:00Z
+updated: 2024-01-02T03:04:05Z
+---
+
+# Hello
+
+---
+
+world
+`, string(data))
+
+	res, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, doc, res)
+}
+
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+
+		wantErr error
+		wantDoc Document
+	}{
+		{
+			name:    "no front matter",
+			data:    "# Hello\n",
+			wantDoc: Document{Content: "# Hello\n"},
+		},
+		{
+			name:    "empty front matter",
+			data:    "---\n---\nHello",
+			wantDoc: Document{Content: "Hello"},
+		},
+		{
+			name: "CRLF",
+			data: "---\r\ntitle: Hello\r\n---\r\n\r\nworld\r\n",
+			wantDoc: Document{
+				FrontMatter: FrontMatter{Title: "Hello"},
+				Content:     "world\n",
+			},
+		},
+		{
+			name:    "unclosed",
+			data:    "---\ntitle: Hello\n",
+			wantErr: ErrInvalidFrontMatter,
+		},
+		{
+			name:    "invalid YAML",
+			data:    "---\ntags: {\n---\n",
+			wantErr: ErrInvalidFrontMatter,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := Unmarshal([]byte(tc.data))
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantDoc, doc)
+		})
+	}
+}
+
+func TestReplaceImages(t *testing.T) {
+	content := `![a](images/1.png) text [link](a.png)
+![b]( https://example.com/b.jpg "title")`
+	var srcs []string
+	res := ReplaceImages(content, func(src string) string {
+		srcs = append(srcs, src)
+		return "/x/" + src
+	})
+	assert.Equal(t, []string{"images/1.png", "https://example.com/b.jpg"}, srcs)
+	assert.Equal(t, `![a](/x/images/1.png) text [link](a.png)
+![b]( /x/https://example.com/b.jpg "title")`, res)
+}
@@ -0,0 +1,39 @@
+package objstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = s.Get(ctx, "articles/1/1")
+	assert.Equal(t, ErrObjectNotFound, err)
+
+	require.NoError(t, s.Put(ctx, "articles/1/1", []byte("v1")))
+	require.NoError(t, s.Put(ctx, "articles/1/2", []byte("v2")))
+	// overwrite
+	require.NoError(t, s.Put(ctx, "articles/1/2", []byte("v2 bis")))
+
+	res, err := BatchGet(ctx, s, []string{"articles/1/2", "articles/1/1"}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("v2 bis"), []byte("v1")}, res)
+
+	_, err = BatchGet(ctx, s, []string{"articles/1/1", "articles/1/3"}, 2)
+	assert.Equal(t, ErrObjectNotFound, err)
+
+	require.NoError(t, s.Delete(ctx, "articles/1/1"))
+	require.NoError(t, s.Delete(ctx, "articles/1/1"))
+	_, err = s.Get(ctx, "articles/1/1")
+	assert.Equal(t, ErrObjectNotFound, err)
+
+	for _, key := range []string{"", "../escape", "/abs", "a/./b"} {
+		assert.Error(t, s.Put(ctx, key, []byte("x")), key)
+	}
+}
@@ -0,0 +1,34 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/sync/errgroup"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// Store is a minimal object storage, like S3 or OSS, objects are addressed by
+// keys like "articles/123/456".
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// BatchGet gets the objects concurrently, the results are in the order of the
+// keys.
+func BatchGet(ctx context.Context, s Store, keys []string, concurrency int) ([][]byte, error) {
+	res := make([][]byte, len(keys))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	for i, key := range keys {
+		eg.Go(func() error {
+			data, err := s.Get(ctx, key)
+			res[i] = data
+			return err
+		})
+	}
+	return res, eg.Wait()
+}
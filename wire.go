@@ -4,11 +4,13 @@
 package main
 
 import (
+	intrIoc "github.com/chenmuyao/go-bootcamp/interactive/ioc"
 	intrRepository "github.com/chenmuyao/go-bootcamp/interactive/repository"
 	intrRediscache "github.com/chenmuyao/go-bootcamp/interactive/repository/cache/rediscache"
 	intrDao "github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
 	intrService "github.com/chenmuyao/go-bootcamp/interactive/service"
 	"github.com/chenmuyao/go-bootcamp/internal/events/article"
+	"github.com/chenmuyao/go-bootcamp/internal/events/reward"
 	"github.com/chenmuyao/go-bootcamp/internal/job"
 	"github.com/chenmuyao/go-bootcamp/internal/repository"
 	"github.com/chenmuyao/go-bootcamp/internal/repository/cache/rediscache"
@@ -31,9 +33,15 @@ var thirdPartySet = wire.NewSet(
 
 var interactiveSvcSet = wire.NewSet(
 	intrDao.NewGORMInteractiveDAO,
+	intrDao.NewGORMCollectionDAO,
+	intrDao.NewGORMPublishedDAO,
 	intrRediscache.NewInteractiveRedisCache,
+	intrRepository.NewWriteThroughCntWriter,
 	intrRepository.NewCachedInteractiveRepository,
+	intrRepository.NewGORMCollectionRepository,
+	intrRepository.NewGORMPublishedRepository,
 	intrService.NewInteractiveService,
+	intrIoc.InitBizRegistry,
 )
 
 var rankingSvcSet = wire.NewSet(
@@ -56,6 +64,7 @@ func InitInteractiveRepo() intrRepository.InteractiveRepository {
 		ioc.InitTopArticlesCache,
 
 		intrDao.NewGORMInteractiveDAO,
+		intrRepository.NewWriteThroughCntWriter,
 
 		intrRediscache.NewInteractiveRedisCache,
 
@@ -75,20 +84,39 @@ func InitWebServer() *App {
 		rankingSvcSet,
 		ioc.InitJobs,
 		ioc.InitRankingJob,
+		ioc.InitArticleContentMigrationJob,
+		ioc.InitArticlePurgeJob,
+		ioc.InitRecommendJob,
+		ioc.InitRewardSyncJob,
 
 		article.NewSaramaSyncProducer,
+		reward.NewSaramaSyncProducer,
 		// intrEvents.NewInteractiveReadEventConsumer,
+		article.NewReadHistoryConsumer,
+		ioc.InitExportConsumer,
+		ioc.InitAccountConsumer,
 		ioc.InitConsumers,
 
 		// DAO
 		dao.NewUserDAO,
 		dao.NewAsyncSMSDAO,
-		dao.NewArticleDAO,
+		ioc.InitObjStore,
+		ioc.InitArticleDAO,
+		dao.NewGORMArticleExportDAO,
+		dao.NewGORMArticleCollaboratorDAO,
+		dao.NewItineraryGORMAuthorDAO,
+		dao.NewItineraryGORMReaderDAO,
+		dao.NewGORMRewardDAO,
+		dao.NewGORMAccountDAO,
 
 		// Cache
 		rediscache.NewCodeRedisCache,
 		rediscache.NewUserRedisCache,
 		rediscache.NewArticleRedisCache,
+		rediscache.NewArticleGeoRedisCache,
+		rediscache.NewFeedRedisCache,
+		rediscache.NewReadHistoryRedisCache,
+		rediscache.NewRecommendRedisCache,
 		// ioc.InitCodeLocalCache,
 		// ioc.InitUserLocalCache,
 		// ioc.InitTopArticlesCache,
@@ -98,6 +126,14 @@ func InitWebServer() *App {
 		repository.NewCodeRepository,
 		repository.NewAsyncSMSRepository,
 		repository.NewArticleRepository,
+		repository.NewArticleCollaboratorRepository,
+		repository.NewCachedFeedRepository,
+		repository.NewCachedReadHistoryRepository,
+		repository.NewCachedRecommendRepository,
+		repository.NewItineraryRepository,
+		repository.NewObjStoreArticleArchiveRepository,
+		repository.NewRewardRepository,
+		repository.NewAccountRepository,
 
 		// Services
 		ioc.InitSMSService,
@@ -105,15 +141,34 @@ func InitWebServer() *App {
 		service.NewUserService,
 		ioc.InitGiteaService,
 		service.NewArticleService,
+		service.NewItineraryService,
+		ioc.InitFeedService,
+		service.NewReadHistoryService,
+		service.NewRecommendService,
+		service.NewArchiveService,
+		ioc.InitPaymentService,
+		service.NewRewardService,
+		ioc.InitAccountService,
 
 		// handler
 		web.NewUserHandler,
 		web.NewOAuth2GiteaHandler,
 		ijwt.NewRedisJWTHandler,
 		web.NewArticleHandler,
+		web.NewItineraryHandler,
+		web.NewFeedHandler,
+		web.NewReadHistoryHandler,
+		web.NewRecommendHandler,
+		web.NewArchiveHandler,
+		web.NewCollectionHandler,
+		web.NewRewardHandler,
+		web.NewAccountHandler,
 
 		ioc.InitGinMiddlewares,
 		ioc.InitWebServer,
+		intrDao.NewGORMPublishedDAO,
+		intrRepository.NewGORMPublishedRepository,
+		intrIoc.InitBizRegistry,
 
 		wire.Struct(new(App), "*"),
 	)
@@ -7,11 +7,13 @@
 package main
 
 import (
+	ioc2 "github.com/chenmuyao/go-bootcamp/interactive/ioc"
 	"github.com/chenmuyao/go-bootcamp/interactive/repository"
 	"github.com/chenmuyao/go-bootcamp/interactive/repository/cache/rediscache"
 	"github.com/chenmuyao/go-bootcamp/interactive/repository/dao"
 	service2 "github.com/chenmuyao/go-bootcamp/interactive/service"
 	"github.com/chenmuyao/go-bootcamp/internal/events/article"
+	"github.com/chenmuyao/go-bootcamp/internal/events/reward"
 	"github.com/chenmuyao/go-bootcamp/internal/job"
 	repository2 "github.com/chenmuyao/go-bootcamp/internal/repository"
 	rediscache2 "github.com/chenmuyao/go-bootcamp/internal/repository/cache/rediscache"
@@ -29,10 +31,11 @@ func InitInteractiveRepo() repository.InteractiveRepository {
 	logger := ioc.InitLogger()
 	db := ioc.InitDB(logger)
 	interactiveDAO := dao.NewGORMInteractiveDAO(db)
+	cntWriter := repository.NewWriteThroughCntWriter(interactiveDAO)
 	cmdable := ioc.InitRedis()
 	interactiveCache := rediscache.NewInteractiveRedisCache(cmdable)
 	topArticlesCache := ioc.InitTopArticlesCache()
-	interactiveRepository := repository.NewCachedInteractiveRepository(logger, interactiveDAO, interactiveCache, topArticlesCache)
+	interactiveRepository := repository.NewCachedInteractiveRepository(logger, interactiveDAO, cntWriter, interactiveCache, topArticlesCache)
 	return interactiveRepository
 }
 
@@ -55,28 +58,75 @@ func InitWebServer() *App {
 	userHandler := web.NewUserHandler(logger, userService, codeService, handler)
 	giteaService := ioc.InitGiteaService(logger)
 	oAuth2GiteaHandler := web.NewOAuth2GiteaHandler(logger, giteaService, userService, handler)
-	articleDAO := dao2.NewArticleDAO(db)
+	store := ioc.InitObjStore()
+	articleDAO := ioc.InitArticleDAO(logger, db, store)
 	articleCache := rediscache2.NewArticleRedisCache(cmdable)
-	articleRepository := repository2.NewArticleRepository(logger, articleDAO, articleCache, userRepository)
+	articleGeoCache := rediscache2.NewArticleGeoRedisCache(cmdable)
+	feedCache := rediscache2.NewFeedRedisCache(cmdable)
+	articleRepository := repository2.NewArticleRepository(logger, articleDAO, articleCache, articleGeoCache, feedCache, userRepository)
+	articleCollaboratorDAO := dao2.NewGORMArticleCollaboratorDAO(db)
+	articleCollaboratorRepository := repository2.NewArticleCollaboratorRepository(articleCollaboratorDAO)
 	client := ioc.InitSaramaClient()
 	syncProducer := ioc.InitSyncProducer(client)
 	producer := article.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer)
 	clientv3Client := ioc.InitEtcd()
 	interactiveServiceClient := ioc.InitIntrClientEtcd(clientv3Client)
+	articleService := service.NewArticleService(logger, articleRepository, articleCollaboratorRepository, producer, interactiveServiceClient)
 	articleHandler := web.NewArticleHandler(logger, articleService, interactiveServiceClient)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2GiteaHandler, articleHandler)
-	v2 := ioc.InitConsumers()
+	itineraryAuthorDAO := dao2.NewItineraryGORMAuthorDAO(db)
+	itineraryReaderDAO := dao2.NewItineraryGORMReaderDAO(db)
+	itineraryRepository := repository2.NewItineraryRepository(logger, itineraryAuthorDAO, itineraryReaderDAO, userRepository)
+	itineraryService := service.NewItineraryService(logger, itineraryRepository, articleRepository)
+	itineraryHandler := web.NewItineraryHandler(logger, itineraryService, interactiveServiceClient)
+	feedRepository := repository2.NewCachedFeedRepository(feedCache)
+	feedService := ioc.InitFeedService(logger, feedRepository, articleRepository, userRepository)
+	feedHandler := web.NewFeedHandler(logger, feedService)
+	readHistoryCache := rediscache2.NewReadHistoryRedisCache(cmdable)
+	readHistoryRepository := repository2.NewCachedReadHistoryRepository(readHistoryCache)
+	readHistoryService := service.NewReadHistoryService(readHistoryRepository, articleRepository)
+	readHistoryHandler := web.NewReadHistoryHandler(logger, readHistoryService)
+	recommendCache := rediscache2.NewRecommendRedisCache(cmdable)
+	recommendRepository := repository2.NewCachedRecommendRepository(recommendCache)
 	rankingCache := rediscache2.NewRankingRedisCache(cmdable)
 	rankingLocalCache := ioc.InitRankingLocalCache()
 	rankingRepository := repository2.NewCachedRankingRepository(rankingCache, rankingLocalCache)
 	rankingService := service.NewBatchRankingService(interactiveServiceClient, articleService, rankingRepository)
+	recommendService := service.NewRecommendService(logger, recommendRepository, articleRepository, readHistoryRepository, interactiveServiceClient, rankingService)
+	recommendHandler := web.NewRecommendHandler(logger, recommendService)
+	articleExportDAO := dao2.NewGORMArticleExportDAO(db)
+	articleArchiveRepository := repository2.NewObjStoreArticleArchiveRepository(articleExportDAO, store)
+	archiveService := service.NewArchiveService(logger, articleArchiveRepository, articleRepository, articleService, producer)
+	archiveHandler := web.NewArchiveHandler(logger, archiveService)
+	collectionHandler := web.NewCollectionHandler(logger, articleService, interactiveServiceClient)
+	rewardDAO := dao2.NewGORMRewardDAO(db)
+	rewardRepository := repository2.NewRewardRepository(rewardDAO)
+	paymentService := ioc.InitPaymentService()
+	rewardProducer := reward.NewSaramaSyncProducer(syncProducer)
+	rewardService := service.NewRewardService(logger, rewardRepository, articleRepository, paymentService, rewardProducer)
+	rewardHandler := web.NewRewardHandler(logger, rewardService)
+	accountDAO := dao2.NewGORMAccountDAO(db)
+	accountRepository := repository2.NewAccountRepository(accountDAO)
+	accountService := ioc.InitAccountService(accountRepository)
+	accountHandler := web.NewAccountHandler(logger, accountService)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2GiteaHandler, articleHandler, itineraryHandler, feedHandler, readHistoryHandler, recommendHandler, archiveHandler, collectionHandler, rewardHandler, accountHandler)
+	readHistoryConsumer := article.NewReadHistoryConsumer(logger, readHistoryRepository, client)
+	exportConsumer := ioc.InitExportConsumer(logger, archiveService, client)
+	accountConsumer := ioc.InitAccountConsumer(logger, accountService, client)
+	v2 := ioc.InitConsumers(readHistoryConsumer, exportConsumer, accountConsumer)
 	job := ioc.InitRankingJob(rankingService, logger, cmdable)
-	cron := ioc.InitJobs(logger, job)
+	articleContentMigrationJob := ioc.InitArticleContentMigrationJob(logger, articleDAO, cmdable)
+	articlePurgeJob := ioc.InitArticlePurgeJob(logger, articleService, cmdable)
+	recommendJob := ioc.InitRecommendJob(recommendService, logger, cmdable)
+	rewardSyncJob := ioc.InitRewardSyncJob(logger, rewardService, cmdable)
+	cron := ioc.InitJobs(logger, job, articleContentMigrationJob, articlePurgeJob, recommendJob, rewardSyncJob)
+	publishedDAO := dao.NewGORMPublishedDAO(db)
+	publishedRepository := repository.NewGORMPublishedRepository(publishedDAO)
+	bizRegistry := ioc2.InitBizRegistry(publishedRepository)
 	app := &App{
 		server:    engine,
 		consumers: v2,
 		cron:      cron,
+		bizs:      bizRegistry,
 	}
 	return app
 }
@@ -95,7 +145,7 @@ func InitJobScheduler() *job.Scheduler {
 
 var thirdPartySet = wire.NewSet(ioc.InitRedis, ioc.InitDB, ioc.InitLogger, ioc.InitSaramaClient, ioc.InitSyncProducer, ioc.InitEtcd)
 
-var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, rediscache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, service2.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, dao.NewGORMCollectionDAO, dao.NewGORMPublishedDAO, rediscache.NewInteractiveRedisCache, repository.NewWriteThroughCntWriter, repository.NewCachedInteractiveRepository, repository.NewGORMCollectionRepository, repository.NewGORMPublishedRepository, service2.NewInteractiveService, ioc2.InitBizRegistry)
 
 var rankingSvcSet = wire.NewSet(ioc.InitRankingLocalCache, rediscache2.NewRankingRedisCache, repository2.NewCachedRankingRepository, service.NewBatchRankingService)
 